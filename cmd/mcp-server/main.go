@@ -17,6 +17,9 @@ import (
 const (
 	ExitCodeOK    = 0
 	ExitCodeError = 1
+
+	// ShutdownTimeout bounds stopping the servers once in-flight work drained
+	ShutdownTimeout = 5 * time.Second
 )
 
 func main() {
@@ -43,7 +46,7 @@ func main() {
 		os.Exit(ExitCodeError)
 	}
 
-	gracefulShutdown(srv, log)
+	gracefulShutdown(srv, cfg, log)
 
 	os.Exit(ExitCodeOK)
 }
@@ -68,7 +71,7 @@ func setupServers(cfg *config.Config, log *logger.Logger) (*server.Server, error
 
 	srv := server.New(cfg, log)
 
-	if err := registerAllTools(srv.ToolRegistry(), log); err != nil {
+	if err := registerAllTools(srv.ToolRegistry(), cfg, log); err != nil {
 		return nil, fmt.Errorf("failed to register tools: %w", err)
 	}
 
@@ -112,8 +115,17 @@ func runServers(srv *server.Server, log *logger.Logger) error {
 	}
 }
 
-func gracefulShutdown(srv *server.Server, log *logger.Logger) {
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+func gracefulShutdown(srv *server.Server, cfg *config.Config, log *logger.Logger) {
+	log.Info("Draining in-flight work...", "drain_timeout", cfg.Server.DrainTimeout)
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), cfg.Server.DrainTimeout)
+	if err := srv.Drain(drainCtx); err != nil {
+		log.Warn("In-flight work did not drain in time", "error", err)
+	} else {
+		log.Info("In-flight work drained")
+	}
+	drainCancel()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), ShutdownTimeout)
 	defer shutdownCancel()
 
 	log.Info("Shutting down MCP server...")
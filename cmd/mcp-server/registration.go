@@ -9,6 +9,8 @@ import (
 	"mcp-server/internal/resources/files"
 	"mcp-server/internal/tools"
 	"mcp-server/internal/tools/echo"
+	"mcp-server/internal/tools/exec"
+	"mcp-server/internal/tools/http"
 )
 
 func registerEchoTool(registry tools.ToolRegistry, log *logger.Logger) error {
@@ -24,12 +26,64 @@ func registerEchoTool(registry tools.ToolRegistry, log *logger.Logger) error {
 	return nil
 }
 
-func registerAllTools(registry tools.ToolRegistry, log *logger.Logger) error {
+func registerExecTools(registry tools.ToolRegistry, cfg *config.Config, log *logger.Logger) error {
+	for _, toolConfig := range cfg.Tools.Exec {
+		log.Info("Registering exec tool", "name", toolConfig.Name, "command", toolConfig.Command)
+
+		factory, err := exec.NewExecFactory(toolConfig)
+		if err != nil {
+			log.Error("Failed to create exec tool factory", "name", toolConfig.Name, "error", err)
+			return err
+		}
+
+		if err := registry.Register(toolConfig.Name, factory); err != nil {
+			log.Error("Failed to register exec tool", "name", toolConfig.Name, "error", err)
+			return err
+		}
+	}
+
+	if len(cfg.Tools.Exec) > 0 {
+		log.Info("Successfully registered exec tools", "count", len(cfg.Tools.Exec))
+	}
+	return nil
+}
+
+func registerHTTPTools(registry tools.ToolRegistry, cfg *config.Config, log *logger.Logger) error {
+	for _, toolConfig := range cfg.Tools.HTTP {
+		log.Info("Registering http tool", "name", toolConfig.Name, "method", toolConfig.Method)
+
+		factory, err := http.NewHTTPFactory(toolConfig)
+		if err != nil {
+			log.Error("Failed to create http tool factory", "name", toolConfig.Name, "error", err)
+			return err
+		}
+
+		if err := registry.Register(toolConfig.Name, factory); err != nil {
+			log.Error("Failed to register http tool", "name", toolConfig.Name, "error", err)
+			return err
+		}
+	}
+
+	if len(cfg.Tools.HTTP) > 0 {
+		log.Info("Successfully registered http tools", "count", len(cfg.Tools.HTTP))
+	}
+	return nil
+}
+
+func registerAllTools(registry tools.ToolRegistry, cfg *config.Config, log *logger.Logger) error {
 	log.Info("Registering all available tools")
 	
 	if err := registerEchoTool(registry, log); err != nil {
 		return err
 	}
+
+	if err := registerExecTools(registry, cfg, log); err != nil {
+		return err
+	}
+
+	if err := registerHTTPTools(registry, cfg, log); err != nil {
+		return err
+	}
 	
 	log.Info("Successfully registered all tools")
 	return nil
@@ -56,6 +110,7 @@ func registerFileSystemResource(registry resources.ResourceRegistry, cfg *config
 		MaxFileSize:        cfg.FileResource.MaxFileSize,
 		AllowedExtensions:  cfg.FileResource.AllowedExtensions,
 		BlockedPatterns:    cfg.FileResource.BlockedPatterns,
+		CacheTimeout:       cfg.FileResource.CacheTimeout,
 		Logger:            log,
 	}
 
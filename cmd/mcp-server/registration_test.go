@@ -107,7 +107,7 @@ func TestRegisterAllTools(t *testing.T) {
 	
 	registry := tools.NewDefaultToolRegistry(cfg, log)
 	
-	err = registerAllTools(registry, log)
+	err = registerAllTools(registry, cfg, log)
 	if err != nil {
 		t.Fatalf("Expected successful registration of all tools, got error: %v", err)
 	}
@@ -160,4 +160,79 @@ func TestEchoFactoryDirectly(t *testing.T) {
 	if factory.GetVersion() != "1.0.0" {
 		t.Errorf("Expected factory version '1.0.0', got '%s'", factory.GetVersion())
 	}
-}
\ No newline at end of file
+}
+func TestRegisterExecTools(t *testing.T) {
+	cfg := &config.Config{
+		Tools: config.ToolsConfig{
+			Exec: []config.ExecToolConfig{
+				{
+					Name:        "list_files",
+					Description: "List files in a directory",
+					Command:     "ls",
+					Args:        []string{"{{.path}}"},
+				},
+			},
+		},
+	}
+	log, err := logger.NewDefault()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	registry := tools.NewDefaultToolRegistry(cfg, log)
+
+	err = registerExecTools(registry, cfg, log)
+	if err != nil {
+		t.Fatalf("Expected successful registration, got error: %v", err)
+	}
+
+	factory, err := registry.GetFactory("list_files")
+	if err != nil {
+		t.Fatalf("Expected to get list_files factory, got error: %v", err)
+	}
+
+	if factory.GetVersion() != config.DefaultExecToolVersion {
+		t.Errorf("Expected default version '%s', got '%s'", config.DefaultExecToolVersion, factory.GetVersion())
+	}
+
+	cfg.Tools.Exec[0].Args = []string{"{{.path"}
+	err = registerExecTools(tools.NewDefaultToolRegistry(cfg, log), cfg, log)
+	if err == nil {
+		t.Error("Expected invalid argument template to be rejected")
+	}
+}
+
+func TestRegisterHTTPTools(t *testing.T) {
+	cfg := &config.Config{
+		Tools: config.ToolsConfig{
+			HTTP: []config.HTTPToolConfig{
+				{
+					Name:        "get_user",
+					Description: "Look up a user",
+					URL:         "https://users.internal/v1/users/{{.id}}",
+				},
+			},
+		},
+	}
+	log, err := logger.NewDefault()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	registry := tools.NewDefaultToolRegistry(cfg, log)
+
+	err = registerHTTPTools(registry, cfg, log)
+	if err != nil {
+		t.Fatalf("Expected successful registration, got error: %v", err)
+	}
+
+	if _, err := registry.GetFactory("get_user"); err != nil {
+		t.Fatalf("Expected to get get_user factory, got error: %v", err)
+	}
+
+	cfg.Tools.HTTP[0].URL = "https://{{.host}}/v1/users"
+	err = registerHTTPTools(tools.NewDefaultToolRegistry(cfg, log), cfg, log)
+	if err == nil {
+		t.Error("Expected templated host to be rejected")
+	}
+}
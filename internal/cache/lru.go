@@ -0,0 +1,171 @@
+// Package cache provides a bounded, expiring LRU cache shared by the tool
+// result and resource content caches.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Options bounds a cache. Zero values disable the corresponding bound.
+type Options struct {
+	TTL        time.Duration
+	MaxEntries int
+	MaxBytes   int64
+}
+
+// Stats is a snapshot of cache occupancy and effectiveness
+type Stats struct {
+	Entries     int     `json:"entries"`
+	Bytes       int64   `json:"bytes"`
+	Hits        int64   `json:"hits"`
+	Misses      int64   `json:"misses"`
+	Evictions   int64   `json:"evictions"`
+	Expirations int64   `json:"expirations"`
+	HitRate     float64 `json:"hit_rate"`
+}
+
+type entry[V any] struct {
+	key     string
+	value   V
+	size    int64
+	expires time.Time
+}
+
+// LRU is a thread-safe least-recently-used cache whose entries also expire
+type LRU[V any] struct {
+	mu      sync.Mutex
+	options Options
+	order   *list.List
+	items   map[string]*list.Element
+	bytes   int64
+	stats   Stats
+	now     func() time.Time
+}
+
+// New creates an empty cache
+func New[V any](options Options) *LRU[V] {
+	return &LRU[V]{
+		options: options,
+		order:   list.New(),
+		items:   make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// Get returns the live value stored under key and marks it recently used
+func (c *LRU[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	element, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return zero, false
+	}
+	e := element.Value.(*entry[V])
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		c.remove(element)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	c.stats.Hits++
+	return e.value, true
+}
+
+// Set stores value under key with the cache's TTL. size counts against
+// MaxBytes; values larger than the whole budget are not stored.
+func (c *LRU[V]) Set(key string, value V, size int64) {
+	c.SetWithTTL(key, value, size, c.options.TTL)
+}
+
+// SetWithTTL stores value under key with its own time to live; zero keeps the
+// entry until it is evicted
+func (c *LRU[V]) SetWithTTL(key string, value V, size int64, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		c.remove(element)
+	}
+	if c.options.MaxBytes > 0 && size > c.options.MaxBytes {
+		return
+	}
+
+	e := &entry[V]{key: key, value: value, size: size}
+	if ttl > 0 {
+		e.expires = c.now().Add(ttl)
+	}
+	c.items[key] = c.order.PushFront(e)
+	c.bytes += size
+
+	for c.overBudget() {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Delete removes key and reports whether it was present
+func (c *LRU[V]) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if ok {
+		c.remove(element)
+	}
+	return ok
+}
+
+// Purge removes every entry; counters are kept
+func (c *LRU[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	c.bytes = 0
+}
+
+// Len returns the number of stored entries, including expired ones not yet
+// reclaimed
+func (c *LRU[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns a snapshot of the cache counters
+func (c *LRU[V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	stats.Bytes = c.bytes
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(lookups) * 100
+	}
+	return stats
+}
+
+func (c *LRU[V]) overBudget() bool {
+	if c.order.Len() == 0 {
+		return false
+	}
+	if c.options.MaxEntries > 0 && c.order.Len() > c.options.MaxEntries {
+		return true
+	}
+	return c.options.MaxBytes > 0 && c.bytes > c.options.MaxBytes
+}
+
+func (c *LRU[V]) remove(element *list.Element) {
+	e := c.order.Remove(element).(*entry[V])
+	delete(c.items, e.key)
+	c.bytes -= e.size
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := New[string](Options{MaxEntries: 2})
+	c.Set("a", "1", 0)
+	c.Set("b", "2", 0)
+
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	c.Set("c", "3", 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted as least recently used")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+
+	stats := c.Stats()
+	if stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.Hits != 3 || stats.Misses != 1 {
+		t.Errorf("expected 3 hits and 1 miss, got %+v", stats)
+	}
+	if stats.HitRate != 75 {
+		t.Errorf("expected hit rate 75, got %v", stats.HitRate)
+	}
+}
+
+func TestLRU_Expiry(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := New[int](Options{TTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	c.Set("short", 1, 0)
+	c.SetWithTTL("long", 2, 0, time.Hour)
+
+	now = now.Add(time.Minute)
+	if _, ok := c.Get("short"); ok {
+		t.Error("expected short to expire after the TTL")
+	}
+	if value, ok := c.Get("long"); !ok || value != 2 {
+		t.Errorf("expected long to outlive the default TTL, got %v %v", value, ok)
+	}
+
+	stats := c.Stats()
+	if stats.Expirations != 1 || stats.Entries != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestLRU_ByteBudget(t *testing.T) {
+	c := New[string](Options{MaxBytes: 10})
+	c.Set("a", "aaaa", 4)
+	c.Set("b", "bbbb", 4)
+	c.Set("c", "cccc", 4)
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("expected a to be evicted to stay within the byte budget")
+	}
+	if stats := c.Stats(); stats.Bytes != 8 {
+		t.Errorf("expected 8 bytes cached, got %d", stats.Bytes)
+	}
+
+	c.Set("huge", "x", 11)
+	if _, ok := c.Get("huge"); ok {
+		t.Error("expected a value larger than the budget not to be stored")
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected oversized value not to evict others, got %d entries", c.Len())
+	}
+}
+
+func TestLRU_DeleteAndPurge(t *testing.T) {
+	c := New[string](Options{})
+	c.Set("a", "1", 1)
+	c.Set("b", "2", 1)
+	c.Set("a", "3", 1)
+
+	if value, _ := c.Get("a"); value != "3" {
+		t.Errorf("expected replaced value, got %q", value)
+	}
+	if !c.Delete("a") || c.Delete("a") {
+		t.Error("expected delete to report presence once")
+	}
+
+	c.Purge()
+	stats := c.Stats()
+	if stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("expected empty cache after purge, got %+v", stats)
+	}
+	if stats.Hits != 1 {
+		t.Errorf("expected counters to survive purge, got %+v", stats)
+	}
+}
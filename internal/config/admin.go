@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// MinAdminTokenLength keeps admin tokens out of guessing range
+const MinAdminTokenLength = 16
+
+// AdminConfig protects the /admin endpoints. The token is only read from
+// MCP_ADMIN_TOKEN so it never lands in a config file; without one the admin
+// API is refused. Admin actions are appended to AuditLog as JSON lines, and
+// always logged.
+type AdminConfig struct {
+	Token    string `json:"-"`
+	AuditLog string `json:"audit_log,omitempty"`
+}
+
+type FileAdminConfig struct {
+	AuditLog string `yaml:"audit_log"`
+}
+
+func loadAdminFromEnvironment() AdminConfig {
+	return AdminConfig{
+		Token:    getEnv("MCP_ADMIN_TOKEN", ""),
+		AuditLog: getEnv("MCP_ADMIN_AUDIT_LOG", ""),
+	}
+}
+
+func mergeAdminConfig(base *AdminConfig, file *FileAdminConfig) {
+	if file.AuditLog != "" && os.Getenv("MCP_ADMIN_AUDIT_LOG") == "" {
+		base.AuditLog = file.AuditLog
+	}
+}
+
+func validateAdminConfig(cfg *AdminConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if cfg.Token != "" && len(cfg.Token) < MinAdminTokenLength {
+		errors = append(errors, fmt.Sprintf("admin token must be at least %d characters, got %d (hint: generate one with openssl rand -hex 32)", MinAdminTokenLength, len(cfg.Token)))
+	}
+
+	return errors
+}
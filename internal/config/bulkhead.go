@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	DefaultToolBulkheadEnabled       = true
+	DefaultToolBulkheadMaxConcurrent = 16
+	DefaultToolBulkheadMaxQueue      = 64
+	DefaultToolBulkheadQueueTimeout  = 10 * time.Second
+
+	DefaultGlobalBulkheadEnabled       = true
+	DefaultGlobalBulkheadMaxConcurrent = 64
+	DefaultGlobalBulkheadMaxQueue      = 256
+	DefaultGlobalBulkheadQueueTimeout  = 10 * time.Second
+)
+
+// ToolBulkheadConfig bounds concurrent executions and the wait queue in front of them
+type ToolBulkheadConfig struct {
+	Enabled       bool          `json:"enabled"`
+	MaxConcurrent int           `json:"max_concurrent"`
+	MaxQueue      int           `json:"max_queue"`
+	QueueTimeout  time.Duration `json:"queue_timeout"`
+}
+
+type FileToolBulkheadConfig struct {
+	Enabled       *bool  `yaml:"enabled"`
+	MaxConcurrent int    `yaml:"max_concurrent"`
+	MaxQueue      int    `yaml:"max_queue"`
+	QueueTimeout  string `yaml:"queue_timeout"`
+}
+
+// bulkheadEnv names the environment variables of one bulkhead scope
+type bulkheadEnv struct {
+	enabled, maxConcurrent, maxQueue, queueTimeout string
+}
+
+var (
+	toolBulkheadEnv = bulkheadEnv{
+		enabled:       "MCP_TOOL_BULKHEAD_ENABLED",
+		maxConcurrent: "MCP_TOOL_BULKHEAD_MAX_CONCURRENT",
+		maxQueue:      "MCP_TOOL_BULKHEAD_MAX_QUEUE",
+		queueTimeout:  "MCP_TOOL_BULKHEAD_QUEUE_TIMEOUT",
+	}
+	globalBulkheadEnv = bulkheadEnv{
+		enabled:       "MCP_GLOBAL_BULKHEAD_ENABLED",
+		maxConcurrent: "MCP_GLOBAL_BULKHEAD_MAX_CONCURRENT",
+		maxQueue:      "MCP_GLOBAL_BULKHEAD_MAX_QUEUE",
+		queueTimeout:  "MCP_GLOBAL_BULKHEAD_QUEUE_TIMEOUT",
+	}
+)
+
+func loadToolBulkheadFromEnvironment() ToolBulkheadConfig {
+	return ToolBulkheadConfig{
+		Enabled:       getEnvBool(toolBulkheadEnv.enabled, DefaultToolBulkheadEnabled),
+		MaxConcurrent: getEnvInt(toolBulkheadEnv.maxConcurrent, DefaultToolBulkheadMaxConcurrent),
+		MaxQueue:      getEnvInt(toolBulkheadEnv.maxQueue, DefaultToolBulkheadMaxQueue),
+		QueueTimeout:  getEnvDuration(toolBulkheadEnv.queueTimeout, DefaultToolBulkheadQueueTimeout),
+	}
+}
+
+func loadGlobalBulkheadFromEnvironment() ToolBulkheadConfig {
+	return ToolBulkheadConfig{
+		Enabled:       getEnvBool(globalBulkheadEnv.enabled, DefaultGlobalBulkheadEnabled),
+		MaxConcurrent: getEnvInt(globalBulkheadEnv.maxConcurrent, DefaultGlobalBulkheadMaxConcurrent),
+		MaxQueue:      getEnvInt(globalBulkheadEnv.maxQueue, DefaultGlobalBulkheadMaxQueue),
+		QueueTimeout:  getEnvDuration(globalBulkheadEnv.queueTimeout, DefaultGlobalBulkheadQueueTimeout),
+	}
+}
+
+func mergeToolBulkheadConfig(base *ToolBulkheadConfig, file *FileToolBulkheadConfig, env bulkheadEnv, useEnv bool) {
+	envUnset := func(key string) bool {
+		return !useEnv || os.Getenv(key) == ""
+	}
+
+	if file.Enabled != nil && envUnset(env.enabled) {
+		base.Enabled = *file.Enabled
+	}
+	if file.MaxConcurrent != 0 && envUnset(env.maxConcurrent) {
+		base.MaxConcurrent = file.MaxConcurrent
+	}
+	if file.MaxQueue != 0 && envUnset(env.maxQueue) {
+		base.MaxQueue = file.MaxQueue
+	}
+	if file.QueueTimeout != "" && envUnset(env.queueTimeout) {
+		if duration, err := time.ParseDuration(file.QueueTimeout); err == nil {
+			base.QueueTimeout = duration
+		}
+	}
+}
+
+func validateToolBulkheadConfig(scope string, cfg *ToolBulkheadConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if !cfg.Enabled {
+		return errors
+	}
+
+	if cfg.MaxConcurrent < 1 {
+		errors = append(errors, fmt.Sprintf("%s bulkhead max concurrent must be positive, got %d (hint: use 4-64)", scope, cfg.MaxConcurrent))
+	}
+	if cfg.MaxQueue < 0 {
+		errors = append(errors, fmt.Sprintf("%s bulkhead max queue cannot be negative, got %d (hint: use 0 to reject instead of queueing)", scope, cfg.MaxQueue))
+	}
+	if cfg.QueueTimeout <= 0 {
+		errors = append(errors, fmt.Sprintf("%s bulkhead queue timeout must be positive, got %v (hint: use 5s-30s)", scope, cfg.QueueTimeout))
+	}
+
+	return errors
+}
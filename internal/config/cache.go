@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	DefaultToolCacheEnabled    = false
+	DefaultToolCacheTTL        = 5 * time.Minute
+	DefaultToolCacheMaxEntries = 256
+)
+
+// ToolCacheConfig controls caching of successful tool results. Only enable it
+// for tools whose results depend on nothing but their arguments.
+type ToolCacheConfig struct {
+	Enabled    bool          `json:"enabled"`
+	TTL        time.Duration `json:"ttl"`
+	MaxEntries int           `json:"max_entries"`
+}
+
+type FileToolCacheConfig struct {
+	Enabled    *bool  `yaml:"enabled"`
+	TTL        string `yaml:"ttl"`
+	MaxEntries int    `yaml:"max_entries"`
+}
+
+func loadToolCacheFromEnvironment() ToolCacheConfig {
+	return ToolCacheConfig{
+		Enabled:    getEnvBool("MCP_TOOL_CACHE_ENABLED", DefaultToolCacheEnabled),
+		TTL:        getEnvDuration("MCP_TOOL_CACHE_TTL", DefaultToolCacheTTL),
+		MaxEntries: getEnvInt("MCP_TOOL_CACHE_MAX_ENTRIES", DefaultToolCacheMaxEntries),
+	}
+}
+
+func mergeToolCacheConfig(base *ToolCacheConfig, file *FileToolCacheConfig, useEnv bool) {
+	envUnset := func(key string) bool {
+		return !useEnv || os.Getenv(key) == ""
+	}
+
+	if file.Enabled != nil && envUnset("MCP_TOOL_CACHE_ENABLED") {
+		base.Enabled = *file.Enabled
+	}
+	if file.TTL != "" && envUnset("MCP_TOOL_CACHE_TTL") {
+		if duration, err := time.ParseDuration(file.TTL); err == nil {
+			base.TTL = duration
+		}
+	}
+	if file.MaxEntries != 0 && envUnset("MCP_TOOL_CACHE_MAX_ENTRIES") {
+		base.MaxEntries = file.MaxEntries
+	}
+}
+
+func validateToolCacheConfig(scope string, cfg *ToolCacheConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if !cfg.Enabled {
+		return errors
+	}
+
+	if cfg.TTL <= 0 {
+		errors = append(errors, fmt.Sprintf("%s cache TTL must be positive, got %v (hint: use 1m-15m)", scope, cfg.TTL))
+	}
+	if cfg.MaxEntries < 1 {
+		errors = append(errors, fmt.Sprintf("%s cache max entries must be positive, got %d (hint: use 100-1000)", scope, cfg.MaxEntries))
+	}
+
+	return errors
+}
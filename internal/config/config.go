@@ -19,6 +19,7 @@ const (
 	DefaultWriteTimeout   = 15 * time.Second
 	DefaultIdleTimeout    = 60 * time.Second
 	DefaultMaxHeaderBytes = 1 << 20 // 1MB
+	DefaultDrainTimeout   = 30 * time.Second
 	
 	DefaultProtocolTimeout = 30 * time.Second
 	DefaultMaxTools        = 100
@@ -31,6 +32,8 @@ const (
 	DefaultFileResourceBaseDir     = "/tmp/mcp-files"
 	DefaultFileResourceMaxSize     = 10 * 1024 * 1024 // 10MB
 	DefaultFileResourceCacheTimeout = 5 * time.Minute
+
+	DefaultResourceCacheMaxBytes = 64 * 1024 * 1024 // 64MB
 )
 
 type Config struct {
@@ -38,6 +41,14 @@ type Config struct {
 	Logger       LoggerConfig
 	MCP          MCPConfig
 	FileResource FileResourceConfig
+	Tools        ToolsConfig
+	Tracing      TracingConfig
+	Supervisor   SupervisorConfig
+	Events       EventsConfig
+	State        StateConfig
+	Startup      StartupConfig
+	Readiness    ReadinessConfig
+	Upstreams    []UpstreamConfig
 }
 
 type ServerConfig struct {
@@ -47,6 +58,12 @@ type ServerConfig struct {
 	WriteTimeout   time.Duration
 	IdleTimeout    time.Duration
 	MaxHeaderBytes int
+	RateLimit      HTTPRateLimitConfig
+	Admin          AdminConfig
+
+	// DrainTimeout is how long shutdown waits for in-flight tool calls and
+	// resource reads before cancelling them
+	DrainTimeout time.Duration
 }
 
 type LoggerConfig struct {
@@ -68,9 +85,10 @@ type MCPConfig struct {
 }
 
 type ResourceCacheConfig struct {
-	DefaultTimeout int  `json:"default_timeout_seconds"`
-	MaxSize        int  `json:"max_size"`
-	Enabled        bool `json:"enabled"`
+	DefaultTimeout int   `json:"default_timeout_seconds"`
+	MaxSize        int   `json:"max_size"`
+	MaxBytes       int64 `json:"max_bytes"`
+	Enabled        bool  `json:"enabled"`
 }
 
 type FileResourceConfig struct {
@@ -100,15 +118,26 @@ type FileConfig struct {
 	Logger       FileLoggerConfig       `yaml:"logger"`
 	MCP          FileMCPConfig          `yaml:"mcp"`
 	FileResource FileFileResourceConfig `yaml:"file_resource"`
+	Tools        FileToolsConfig        `yaml:"tools"`
+	Tracing      FileTracingConfig      `yaml:"tracing"`
+	Supervisor   FileSupervisorConfig   `yaml:"supervisor"`
+	Events       FileEventsConfig       `yaml:"events"`
+	State        FileStateConfig        `yaml:"state"`
+	Startup      FileStartupConfig      `yaml:"startup"`
+	Readiness    FileReadinessConfig    `yaml:"readiness"`
+	Upstreams    []FileUpstreamConfig   `yaml:"upstreams"`
 }
 
 type FileServerConfig struct {
-	Host           string `yaml:"host"`
-	Port           int    `yaml:"port"`
-	ReadTimeout    string `yaml:"read_timeout"`
-	WriteTimeout   string `yaml:"write_timeout"`
-	IdleTimeout    string `yaml:"idle_timeout"`
-	MaxHeaderBytes int    `yaml:"max_header_bytes"`
+	Host           string                  `yaml:"host"`
+	Port           int                     `yaml:"port"`
+	ReadTimeout    string                  `yaml:"read_timeout"`
+	WriteTimeout   string                  `yaml:"write_timeout"`
+	IdleTimeout    string                  `yaml:"idle_timeout"`
+	MaxHeaderBytes int                     `yaml:"max_header_bytes"`
+	RateLimit      FileHTTPRateLimitConfig `yaml:"rate_limit"`
+	Admin          FileAdminConfig         `yaml:"admin"`
+	DrainTimeout   string                  `yaml:"drain_timeout"`
 }
 
 type FileLoggerConfig struct {
@@ -130,9 +159,10 @@ type FileMCPConfig struct {
 }
 
 type FileResourceCacheConfig struct {
-	DefaultTimeout int  `yaml:"default_timeout_seconds"`
-	MaxSize        int  `yaml:"max_size"`
-	Enabled        bool `yaml:"enabled"`
+	DefaultTimeout int   `yaml:"default_timeout_seconds"`
+	MaxSize        int   `yaml:"max_size"`
+	MaxBytes       int64 `yaml:"max_bytes"`
+	Enabled        bool  `yaml:"enabled"`
 }
 
 type FileFileResourceConfig struct {
@@ -244,6 +274,9 @@ func loadFromEnvironment() *Config {
 			WriteTimeout:   getEnvDuration("MCP_SERVER_WRITE_TIMEOUT", DefaultWriteTimeout),
 			IdleTimeout:    getEnvDuration("MCP_SERVER_IDLE_TIMEOUT", DefaultIdleTimeout),
 			MaxHeaderBytes: getEnvInt("MCP_SERVER_MAX_HEADER_BYTES", DefaultMaxHeaderBytes),
+			RateLimit:      loadHTTPRateLimitFromEnvironment(),
+			Admin:          loadAdminFromEnvironment(),
+			DrainTimeout:   getEnvDuration("MCP_SERVER_DRAIN_TIMEOUT", DefaultDrainTimeout),
 		},
 		Logger: LoggerConfig{
 			Level:     getEnv("MCP_LOG_LEVEL", "info"),
@@ -262,6 +295,7 @@ func loadFromEnvironment() *Config {
 			ResourceCache: ResourceCacheConfig{
 				DefaultTimeout: getEnvInt("MCP_RESOURCE_CACHE_TIMEOUT", 300),
 				MaxSize:        getEnvInt("MCP_RESOURCE_CACHE_MAX_SIZE", 1000),
+				MaxBytes:       getEnvInt64("MCP_RESOURCE_CACHE_MAX_BYTES", DefaultResourceCacheMaxBytes),
 				Enabled:        getEnvBool("MCP_RESOURCE_CACHE_ENABLED", true),
 			},
 		},
@@ -274,6 +308,13 @@ func loadFromEnvironment() *Config {
 			BlockedPatterns:    getEnvStringSlice("MCP_FILE_RESOURCE_BLOCKED_PATTERNS", []string{".*", "~*", "*.tmp"}),
 			CacheTimeout:       getEnvDuration("MCP_FILE_RESOURCE_CACHE_TIMEOUT", DefaultFileResourceCacheTimeout),
 		},
+		Tools:      loadToolsFromEnvironment(),
+		Tracing:    loadTracingFromEnvironment(),
+		Supervisor: loadSupervisorFromEnvironment(),
+		Events:     loadEventsFromEnvironment(),
+		State:      loadStateFromEnvironment(),
+		Startup:    loadStartupFromEnvironment(),
+		Readiness:  loadReadinessFromEnvironment(),
 	}
 }
 
@@ -302,6 +343,13 @@ func mergeServerConfig(base *ServerConfig, file *FileServerConfig) {
 	if file.MaxHeaderBytes != 0 && os.Getenv("MCP_SERVER_MAX_HEADER_BYTES") == "" {
 		base.MaxHeaderBytes = file.MaxHeaderBytes
 	}
+	if file.DrainTimeout != "" && os.Getenv("MCP_SERVER_DRAIN_TIMEOUT") == "" {
+		if duration, err := time.ParseDuration(file.DrainTimeout); err == nil {
+			base.DrainTimeout = duration
+		}
+	}
+	mergeHTTPRateLimitConfig(&base.RateLimit, &file.RateLimit)
+	mergeAdminConfig(&base.Admin, &file.Admin)
 }
 
 func mergeLoggerConfig(base *LoggerConfig, file *FileLoggerConfig) {
@@ -352,6 +400,9 @@ func mergeResourceCacheConfig(base *ResourceCacheConfig, file *FileResourceCache
 	if file.MaxSize != 0 && os.Getenv("MCP_RESOURCE_CACHE_MAX_SIZE") == "" {
 		base.MaxSize = file.MaxSize
 	}
+	if file.MaxBytes != 0 && os.Getenv("MCP_RESOURCE_CACHE_MAX_BYTES") == "" {
+		base.MaxBytes = file.MaxBytes
+	}
 	if os.Getenv("MCP_RESOURCE_CACHE_ENABLED") == "" {
 		base.Enabled = file.Enabled
 	}
@@ -395,6 +446,14 @@ func mergeConfigs(base *Config, file *FileConfig) *Config {
 	mergeMCPConfig(&result.MCP, &file.MCP)
 	mergeResourceCacheConfig(&result.MCP.ResourceCache, &file.MCP.ResourceCache)
 	mergeFileResourceConfig(&result.FileResource, &file.FileResource)
+	mergeToolsConfig(&result.Tools, &file.Tools)
+	mergeTracingConfig(&result.Tracing, &file.Tracing)
+	mergeSupervisorConfig(&result.Supervisor, &file.Supervisor)
+	mergeEventsConfig(&result.Events, &file.Events)
+	mergeStateConfig(&result.State, &file.State)
+	mergeStartupConfig(&result.Startup, &file.Startup)
+	mergeReadinessConfig(&result.Readiness, &file.Readiness)
+	mergeUpstreamsConfig(&result, file.Upstreams)
 	
 	return &result
 }
@@ -434,12 +493,21 @@ func validateServerConfig(cfg *ServerConfig) ValidationErrors {
 		errors = append(errors, fmt.Sprintf("write timeout (%v) should be less than idle timeout (%v)", cfg.WriteTimeout, cfg.IdleTimeout))
 	}
 	
+	if cfg.DrainTimeout < 0 {
+		errors = append(errors, fmt.Sprintf("server drain timeout cannot be negative, got %v (hint: use 30s, or 0 to cancel in-flight work at once)", cfg.DrainTimeout))
+	} else if cfg.DrainTimeout > 10*time.Minute {
+		errors = append(errors, fmt.Sprintf("server drain timeout is very large: %v (hint: keep it below your orchestrator's termination grace period)", cfg.DrainTimeout))
+	}
+	
 	if cfg.MaxHeaderBytes < 1 {
 		errors = append(errors, fmt.Sprintf("server max header bytes must be positive, got %d (hint: use 1048576 for 1MB)", cfg.MaxHeaderBytes))
 	} else if cfg.MaxHeaderBytes > 10*1024*1024 {
 		errors = append(errors, fmt.Sprintf("server max header bytes is very large: %d (hint: typically 1MB-8MB)", cfg.MaxHeaderBytes))
 	}
 	
+	errors = append(errors, validateHTTPRateLimitConfig(&cfg.RateLimit)...)
+	errors = append(errors, validateAdminConfig(&cfg.Admin)...)
+	
 	return errors
 }
 
@@ -504,6 +572,12 @@ func validateResourceCacheConfig(cfg *ResourceCacheConfig) ValidationErrors {
 	} else if cfg.MaxSize > 100000 {
 		errors = append(errors, fmt.Sprintf("resource cache max size very large: %d (hint: typically 100-10000)", cfg.MaxSize))
 	}
+
+	if cfg.MaxBytes < 0 {
+		errors = append(errors, fmt.Sprintf("resource cache max bytes cannot be negative: %d", cfg.MaxBytes))
+	} else if cfg.MaxBytes > 0 && cfg.MaxBytes < 1024 {
+		errors = append(errors, fmt.Sprintf("resource cache max bytes too small: %d (hint: typically 16MB-256MB, or 0 for no byte limit)", cfg.MaxBytes))
+	}
 	
 	return errors
 }
@@ -546,6 +620,14 @@ func validateConfig(cfg *Config) error {
 	allErrors = append(allErrors, validateMCPConfig(&cfg.MCP)...)
 	allErrors = append(allErrors, validateResourceCacheConfig(&cfg.MCP.ResourceCache)...)
 	allErrors = append(allErrors, validateFileResourceConfig(&cfg.FileResource)...)
+	allErrors = append(allErrors, validateToolsConfig(&cfg.Tools)...)
+	allErrors = append(allErrors, validateTracingConfig(&cfg.Tracing)...)
+	allErrors = append(allErrors, validateSupervisorConfig(&cfg.Supervisor)...)
+	allErrors = append(allErrors, validateEventsConfig(&cfg.Events)...)
+	allErrors = append(allErrors, validateStateConfig(&cfg.State)...)
+	allErrors = append(allErrors, validateStartupConfig(&cfg.Startup)...)
+	allErrors = append(allErrors, validateReadinessConfig(&cfg.Readiness)...)
+	allErrors = append(allErrors, validateUpstreamsConfig(cfg.Upstreams)...)
 	
 	if len(allErrors) > 0 {
 		return allErrors
@@ -579,13 +661,13 @@ func (c *Config) String() string {
 Server: %s:%d (timeouts: read=%v, write=%v, idle=%v)
 Logger: level=%s, format=%s, service=%s
 MCP: timeout=%v, tools=%d, resources=%d, debug=%v
-Resource Cache: enabled=%v, timeout=%ds, max_size=%d
+Resource Cache: enabled=%v, timeout=%ds, max_size=%d, max_bytes=%d
 File Resource: enabled=%v, base_dir=%s, max_size=%d, cache_timeout=%v`,
 		c.Server.Host, c.Server.Port,
 		c.Server.ReadTimeout, c.Server.WriteTimeout, c.Server.IdleTimeout,
 		c.Logger.Level, c.Logger.Format, c.Logger.Service,
 		c.MCP.ProtocolTimeout, c.MCP.MaxTools, c.MCP.MaxResources, c.MCP.DebugMode,
-		c.MCP.ResourceCache.Enabled, c.MCP.ResourceCache.DefaultTimeout, c.MCP.ResourceCache.MaxSize,
+		c.MCP.ResourceCache.Enabled, c.MCP.ResourceCache.DefaultTimeout, c.MCP.ResourceCache.MaxSize, c.MCP.ResourceCache.MaxBytes,
 		c.FileResource.Enabled, c.FileResource.BaseDirectory, c.FileResource.MaxFileSize, c.FileResource.CacheTimeout)
 }
 
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	DefaultEventsHistory       = 256
+	DefaultWebhookTimeout      = 5 * time.Second
+	DefaultWebhookMaxRetries   = 3
+	DefaultWebhookRetryBackoff = time.Second
+)
+
+// EventsConfig controls the lifecycle event feed. History events are kept
+// so subscribers that reconnect can resume where they left off.
+type EventsConfig struct {
+	History  int             `json:"history"`
+	Webhooks []WebhookConfig `json:"webhooks,omitempty"`
+}
+
+// WebhookConfig declares an endpoint that lifecycle events are POSTed to,
+// signed with an HMAC of the secret. ${VAR} references in the secret are
+// expanded from the environment. An empty Events list subscribes to all
+// event types; failed deliveries are retried with exponential backoff.
+type WebhookConfig struct {
+	Name         string        `json:"name"`
+	URL          string        `json:"url"`
+	Secret       string        `json:"-"`
+	Events       []string      `json:"events,omitempty"`
+	Timeout      time.Duration `json:"timeout"`
+	MaxRetries   int           `json:"max_retries"`
+	RetryBackoff time.Duration `json:"retry_backoff"`
+}
+
+type FileEventsConfig struct {
+	History  int                 `yaml:"history"`
+	Webhooks []FileWebhookConfig `yaml:"webhooks"`
+}
+
+type FileWebhookConfig struct {
+	Name         string   `yaml:"name"`
+	URL          string   `yaml:"url"`
+	Secret       string   `yaml:"secret"`
+	Events       []string `yaml:"events"`
+	Timeout      string   `yaml:"timeout"`
+	MaxRetries   *int     `yaml:"max_retries"`
+	RetryBackoff string   `yaml:"retry_backoff"`
+}
+
+func loadEventsFromEnvironment() EventsConfig {
+	return EventsConfig{
+		History: getEnvInt("MCP_EVENTS_HISTORY", DefaultEventsHistory),
+	}
+}
+
+func mergeEventsConfig(base *EventsConfig, file *FileEventsConfig) {
+	if file.History != 0 && os.Getenv("MCP_EVENTS_HISTORY") == "" {
+		base.History = file.History
+	}
+	if len(file.Webhooks) == 0 {
+		return
+	}
+
+	base.Webhooks = make([]WebhookConfig, 0, len(file.Webhooks))
+	for _, fileWebhook := range file.Webhooks {
+		webhook := WebhookConfig{
+			Name:         fileWebhook.Name,
+			URL:          fileWebhook.URL,
+			Secret:       fileWebhook.Secret,
+			Events:       fileWebhook.Events,
+			Timeout:      DefaultWebhookTimeout,
+			MaxRetries:   DefaultWebhookMaxRetries,
+			RetryBackoff: DefaultWebhookRetryBackoff,
+		}
+		if webhook.Name == "" {
+			webhook.Name = webhook.URL
+		}
+		// Unparsable durations become zero and are reported by validation
+		if fileWebhook.Timeout != "" {
+			webhook.Timeout, _ = time.ParseDuration(fileWebhook.Timeout)
+		}
+		if fileWebhook.MaxRetries != nil {
+			webhook.MaxRetries = *fileWebhook.MaxRetries
+		}
+		if fileWebhook.RetryBackoff != "" {
+			webhook.RetryBackoff, _ = time.ParseDuration(fileWebhook.RetryBackoff)
+		}
+		base.Webhooks = append(base.Webhooks, webhook)
+	}
+}
+
+func validateEventsConfig(cfg *EventsConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if cfg.History < 1 {
+		errors = append(errors, fmt.Sprintf("events history must be positive, got %d (hint: use %d)", cfg.History, DefaultEventsHistory))
+	}
+
+	names := make(map[string]bool, len(cfg.Webhooks))
+	for i, webhook := range cfg.Webhooks {
+		scope := fmt.Sprintf("webhook %q", webhook.Name)
+		if webhook.Name == "" {
+			scope = fmt.Sprintf("webhook #%d", i+1)
+		} else if names[webhook.Name] {
+			errors = append(errors, fmt.Sprintf("%s is declared more than once", scope))
+		}
+		names[webhook.Name] = true
+
+		if parsed, err := url.Parse(webhook.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			errors = append(errors, fmt.Sprintf("%s URL must be an absolute http(s) URL, got %q", scope, webhook.URL))
+		}
+		if webhook.Secret == "" {
+			errors = append(errors, fmt.Sprintf("%s secret cannot be empty (hint: use secret: ${WEBHOOK_SECRET})", scope))
+		}
+		for _, eventType := range webhook.Events {
+			if eventType == "" {
+				errors = append(errors, fmt.Sprintf("%s event types cannot be empty", scope))
+			}
+		}
+		if webhook.Timeout <= 0 {
+			errors = append(errors, fmt.Sprintf("%s timeout must be positive, got %v (hint: use 5s)", scope, webhook.Timeout))
+		}
+		if webhook.MaxRetries < 0 {
+			errors = append(errors, fmt.Sprintf("%s max retries cannot be negative, got %d", scope, webhook.MaxRetries))
+		}
+		if webhook.RetryBackoff <= 0 {
+			errors = append(errors, fmt.Sprintf("%s retry backoff must be positive, got %v (hint: use 1s)", scope, webhook.RetryBackoff))
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultExecToolVersion = "1.0.0"
+	DefaultExecToolTimeout = 30 * time.Second
+	DefaultExecToolOutput  = ExecOutputText
+
+	// ExecOutputText returns stdout as a single text content item
+	ExecOutputText = "text"
+	// ExecOutputJSON parses stdout as a JSON array of content items
+	ExecOutputJSON = "json"
+)
+
+// ExecToolConfig declares a tool backed by an external executable. Args are
+// text/template strings rendered with the call arguments; the command runs
+// directly, without a shell, with only the allowlisted environment variables.
+type ExecToolConfig struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Version     string          `json:"version"`
+	InputSchema json.RawMessage `json:"input_schema,omitempty"`
+	Command     string          `json:"command"`
+	Args        []string        `json:"args,omitempty"`
+	WorkingDir  string          `json:"working_dir,omitempty"`
+	Env         []string        `json:"env,omitempty"`
+	Timeout     time.Duration   `json:"timeout"`
+	Output      string          `json:"output"`
+}
+
+type FileExecToolConfig struct {
+	Name        string                 `yaml:"name"`
+	Description string                 `yaml:"description"`
+	Version     string                 `yaml:"version"`
+	InputSchema map[string]interface{} `yaml:"input_schema"`
+	Command     string                 `yaml:"command"`
+	Args        []string               `yaml:"args"`
+	WorkingDir  string                 `yaml:"working_dir"`
+	Env         []string               `yaml:"env"`
+	Timeout     string                 `yaml:"timeout"`
+	Output      string                 `yaml:"output"`
+}
+
+func mergeExecToolsConfig(base *ToolsConfig, file []FileExecToolConfig) {
+	if len(file) == 0 {
+		return
+	}
+
+	base.Exec = make([]ExecToolConfig, 0, len(file))
+	for _, fileTool := range file {
+		tool := ExecToolConfig{
+			Name:        fileTool.Name,
+			Description: fileTool.Description,
+			Version:     DefaultExecToolVersion,
+			Command:     fileTool.Command,
+			Args:        fileTool.Args,
+			WorkingDir:  fileTool.WorkingDir,
+			Env:         fileTool.Env,
+			Timeout:     DefaultExecToolTimeout,
+			Output:      DefaultExecToolOutput,
+		}
+		if fileTool.Version != "" {
+			tool.Version = fileTool.Version
+		}
+		if len(fileTool.InputSchema) > 0 {
+			// YAML maps always marshal, so the error is unreachable
+			tool.InputSchema, _ = json.Marshal(fileTool.InputSchema)
+		}
+		if fileTool.Timeout != "" {
+			// An unparsable timeout becomes zero and is reported by validation
+			tool.Timeout, _ = time.ParseDuration(fileTool.Timeout)
+		}
+		if fileTool.Output != "" {
+			tool.Output = fileTool.Output
+		}
+		base.Exec = append(base.Exec, tool)
+	}
+}
+
+func validateExecToolsConfig(tools []ExecToolConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	seen := make(map[string]bool, len(tools))
+	for i, tool := range tools {
+		scope := fmt.Sprintf("exec tool %q", tool.Name)
+		if tool.Name == "" {
+			scope = fmt.Sprintf("exec tool #%d", i+1)
+			errors = append(errors, fmt.Sprintf("%s name cannot be empty", scope))
+		}
+
+		ref := tool.Name + "@" + tool.Version
+		if seen[ref] {
+			errors = append(errors, fmt.Sprintf("%s version %s is declared more than once", scope, tool.Version))
+		}
+		seen[ref] = true
+
+		if tool.Description == "" {
+			errors = append(errors, fmt.Sprintf("%s description cannot be empty", scope))
+		}
+		if tool.Command == "" {
+			errors = append(errors, fmt.Sprintf("%s command cannot be empty (hint: use an executable on PATH or an absolute path)", scope))
+		}
+		if tool.Timeout <= 0 {
+			errors = append(errors, fmt.Sprintf("%s timeout must be positive, got %v (hint: use 5s-5m)", scope, tool.Timeout))
+		}
+		if tool.Output != ExecOutputText && tool.Output != ExecOutputJSON {
+			errors = append(errors, fmt.Sprintf("%s output must be %q or %q, got %q", scope, ExecOutputText, ExecOutputJSON, tool.Output))
+		}
+	}
+
+	return errors
+}
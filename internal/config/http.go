@@ -0,0 +1,133 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultHTTPToolVersion = "1.0.0"
+	DefaultHTTPToolMethod  = http.MethodGet
+	DefaultHTTPToolTimeout = 30 * time.Second
+)
+
+// httpStatusPattern matches the keys of HTTPToolConfig.StatusErrors: an
+// exact status code such as 404 or a class such as 4xx
+var httpStatusPattern = regexp.MustCompile(`^[1-5]([0-9]{2}|xx)$`)
+
+// HTTPToolConfig declares a tool that calls a REST endpoint. The URL, header
+// values and body are text/template strings rendered with the call
+// arguments; header values may also reference secrets from the environment
+// with {{secret "NAME"}}.
+type HTTPToolConfig struct {
+	Name            string            `json:"name"`
+	Description     string            `json:"description"`
+	Version         string            `json:"version"`
+	InputSchema     json.RawMessage   `json:"input_schema,omitempty"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	Body            string            `json:"body,omitempty"`
+	ResponsePointer string            `json:"response_pointer,omitempty"`
+	StatusErrors    map[string]string `json:"status_errors,omitempty"`
+	Timeout         time.Duration     `json:"timeout"`
+}
+
+type FileHTTPToolConfig struct {
+	Name            string                 `yaml:"name"`
+	Description     string                 `yaml:"description"`
+	Version         string                 `yaml:"version"`
+	InputSchema     map[string]interface{} `yaml:"input_schema"`
+	Method          string                 `yaml:"method"`
+	URL             string                 `yaml:"url"`
+	Headers         map[string]string      `yaml:"headers"`
+	Body            string                 `yaml:"body"`
+	ResponsePointer string                 `yaml:"response_pointer"`
+	StatusErrors    map[string]string      `yaml:"status_errors"`
+	Timeout         string                 `yaml:"timeout"`
+}
+
+func mergeHTTPToolsConfig(base *ToolsConfig, file []FileHTTPToolConfig) {
+	if len(file) == 0 {
+		return
+	}
+
+	base.HTTP = make([]HTTPToolConfig, 0, len(file))
+	for _, fileTool := range file {
+		tool := HTTPToolConfig{
+			Name:            fileTool.Name,
+			Description:     fileTool.Description,
+			Version:         DefaultHTTPToolVersion,
+			Method:          DefaultHTTPToolMethod,
+			URL:             fileTool.URL,
+			Headers:         fileTool.Headers,
+			Body:            fileTool.Body,
+			ResponsePointer: fileTool.ResponsePointer,
+			StatusErrors:    fileTool.StatusErrors,
+			Timeout:         DefaultHTTPToolTimeout,
+		}
+		if fileTool.Version != "" {
+			tool.Version = fileTool.Version
+		}
+		if fileTool.Method != "" {
+			tool.Method = strings.ToUpper(fileTool.Method)
+		}
+		if len(fileTool.InputSchema) > 0 {
+			// YAML maps always marshal, so the error is unreachable
+			tool.InputSchema, _ = json.Marshal(fileTool.InputSchema)
+		}
+		if fileTool.Timeout != "" {
+			// An unparsable timeout becomes zero and is reported by validation
+			tool.Timeout, _ = time.ParseDuration(fileTool.Timeout)
+		}
+		base.HTTP = append(base.HTTP, tool)
+	}
+}
+
+func validateHTTPToolsConfig(tools []HTTPToolConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	seen := make(map[string]bool, len(tools))
+	for i, tool := range tools {
+		scope := fmt.Sprintf("http tool %q", tool.Name)
+		if tool.Name == "" {
+			scope = fmt.Sprintf("http tool #%d", i+1)
+			errors = append(errors, fmt.Sprintf("%s name cannot be empty", scope))
+		}
+
+		ref := tool.Name + "@" + tool.Version
+		if seen[ref] {
+			errors = append(errors, fmt.Sprintf("%s version %s is declared more than once", scope, tool.Version))
+		}
+		seen[ref] = true
+
+		if tool.Description == "" {
+			errors = append(errors, fmt.Sprintf("%s description cannot be empty", scope))
+		}
+		switch tool.Method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead:
+		default:
+			errors = append(errors, fmt.Sprintf("%s method %q is not supported (hint: use GET, POST, PUT, PATCH, DELETE or HEAD)", scope, tool.Method))
+		}
+		if !strings.HasPrefix(tool.URL, "http://") && !strings.HasPrefix(tool.URL, "https://") {
+			errors = append(errors, fmt.Sprintf("%s URL must start with http:// or https://, got %q", scope, tool.URL))
+		}
+		if tool.ResponsePointer != "" && !strings.HasPrefix(tool.ResponsePointer, "/") {
+			errors = append(errors, fmt.Sprintf("%s response pointer must be a JSON pointer starting with /, got %q", scope, tool.ResponsePointer))
+		}
+		for status := range tool.StatusErrors {
+			if !httpStatusPattern.MatchString(status) {
+				errors = append(errors, fmt.Sprintf("%s status error key %q must be a status code or class (hint: use 404 or 5xx)", scope, status))
+			}
+		}
+		if tool.Timeout <= 0 {
+			errors = append(errors, fmt.Sprintf("%s timeout must be positive, got %v (hint: use 5s-60s)", scope, tool.Timeout))
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	DefaultToolRateLimitEnabled      = false
+	DefaultToolRateLimitSessionRate  = 10.0
+	DefaultToolRateLimitSessionBurst = 20
+	DefaultToolRateLimitToolRate     = 0.0 // unlimited
+	DefaultToolRateLimitToolBurst    = 0
+
+	DefaultHTTPRateLimitEnabled = false
+	DefaultHTTPRateLimitRate    = 10.0
+	DefaultHTTPRateLimitBurst   = 20
+)
+
+// RateLimit is a token bucket: a sustained rate plus a burst allowance.
+// A zero rate disables the limit.
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+}
+
+// ToolRateLimitConfig limits tool calls per session and in total.
+// PerSession is a budget shared by all tool calls and resource reads of a
+// session, so it is taken from the global configuration only. PerTool gives
+// each tool its own bucket and can be overridden per tool. There is no
+// per-client budget: over stdio, the only transport served, clients have no
+// network address to tell them apart.
+type ToolRateLimitConfig struct {
+	Enabled    bool      `json:"enabled"`
+	PerSession RateLimit `json:"per_session"`
+	PerTool    RateLimit `json:"per_tool"`
+
+	// perClient records a per_client section in the file, which is rejected
+	perClient bool
+}
+
+// HTTPRateLimitConfig limits discovery endpoint requests per client address
+type HTTPRateLimitConfig struct {
+	Enabled bool `json:"enabled"`
+	RateLimit
+}
+
+type FileRateLimit struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+type FileToolRateLimitConfig struct {
+	Enabled    *bool          `yaml:"enabled"`
+	PerSession FileRateLimit  `yaml:"per_session"`
+	PerClient  *FileRateLimit `yaml:"per_client"`
+	PerTool    FileRateLimit  `yaml:"per_tool"`
+}
+
+type FileHTTPRateLimitConfig struct {
+	Enabled           *bool   `yaml:"enabled"`
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+func loadToolRateLimitFromEnvironment() ToolRateLimitConfig {
+	return ToolRateLimitConfig{
+		Enabled: getEnvBool("MCP_TOOL_RATE_LIMIT_ENABLED", DefaultToolRateLimitEnabled),
+		PerSession: RateLimit{
+			RequestsPerSecond: getEnvFloat("MCP_TOOL_RATE_LIMIT_SESSION_RPS", DefaultToolRateLimitSessionRate),
+			Burst:             getEnvInt("MCP_TOOL_RATE_LIMIT_SESSION_BURST", DefaultToolRateLimitSessionBurst),
+		},
+		PerTool: RateLimit{
+			RequestsPerSecond: getEnvFloat("MCP_TOOL_RATE_LIMIT_TOOL_RPS", DefaultToolRateLimitToolRate),
+			Burst:             getEnvInt("MCP_TOOL_RATE_LIMIT_TOOL_BURST", DefaultToolRateLimitToolBurst),
+		},
+	}
+}
+
+func loadHTTPRateLimitFromEnvironment() HTTPRateLimitConfig {
+	return HTTPRateLimitConfig{
+		Enabled: getEnvBool("MCP_HTTP_RATE_LIMIT_ENABLED", DefaultHTTPRateLimitEnabled),
+		RateLimit: RateLimit{
+			RequestsPerSecond: getEnvFloat("MCP_HTTP_RATE_LIMIT_RPS", DefaultHTTPRateLimitRate),
+			Burst:             getEnvInt("MCP_HTTP_RATE_LIMIT_BURST", DefaultHTTPRateLimitBurst),
+		},
+	}
+}
+
+func mergeRateLimit(base *RateLimit, file *FileRateLimit, envUnset func(string) bool, rateKey, burstKey string) {
+	if file.RequestsPerSecond != 0 && envUnset(rateKey) {
+		base.RequestsPerSecond = file.RequestsPerSecond
+	}
+	if file.Burst != 0 && envUnset(burstKey) {
+		base.Burst = file.Burst
+	}
+}
+
+func mergeToolRateLimitConfig(base *ToolRateLimitConfig, file *FileToolRateLimitConfig, useEnv bool) {
+	envUnset := func(key string) bool {
+		return !useEnv || os.Getenv(key) == ""
+	}
+
+	if file.Enabled != nil && envUnset("MCP_TOOL_RATE_LIMIT_ENABLED") {
+		base.Enabled = *file.Enabled
+	}
+	mergeRateLimit(&base.PerSession, &file.PerSession, envUnset, "MCP_TOOL_RATE_LIMIT_SESSION_RPS", "MCP_TOOL_RATE_LIMIT_SESSION_BURST")
+	base.perClient = file.PerClient != nil
+	mergeRateLimit(&base.PerTool, &file.PerTool, envUnset, "MCP_TOOL_RATE_LIMIT_TOOL_RPS", "MCP_TOOL_RATE_LIMIT_TOOL_BURST")
+}
+
+func mergeHTTPRateLimitConfig(base *HTTPRateLimitConfig, file *FileHTTPRateLimitConfig) {
+	if file.Enabled != nil && os.Getenv("MCP_HTTP_RATE_LIMIT_ENABLED") == "" {
+		base.Enabled = *file.Enabled
+	}
+	if file.RequestsPerSecond != 0 && os.Getenv("MCP_HTTP_RATE_LIMIT_RPS") == "" {
+		base.RequestsPerSecond = file.RequestsPerSecond
+	}
+	if file.Burst != 0 && os.Getenv("MCP_HTTP_RATE_LIMIT_BURST") == "" {
+		base.Burst = file.Burst
+	}
+}
+
+func validateRateLimit(scope string, limit RateLimit) ValidationErrors {
+	var errors ValidationErrors
+
+	if limit.RequestsPerSecond < 0 {
+		errors = append(errors, fmt.Sprintf("%s rate limit cannot be negative, got %v (hint: use 0 to disable)", scope, limit.RequestsPerSecond))
+	}
+	if limit.RequestsPerSecond > 0 && limit.Burst < 1 {
+		errors = append(errors, fmt.Sprintf("%s rate limit burst must be positive, got %d (hint: use about twice the rate)", scope, limit.Burst))
+	}
+
+	return errors
+}
+
+func validateToolRateLimitConfig(scope string, cfg *ToolRateLimitConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if cfg.perClient {
+		errors = append(errors, fmt.Sprintf("%s per-client rate limit is not supported: stdio clients have no network address (hint: use per_session)", scope))
+	}
+	if !cfg.Enabled {
+		return errors
+	}
+
+	errors = append(errors, validateRateLimit(scope+" per-session", cfg.PerSession)...)
+	errors = append(errors, validateRateLimit(scope+" per-tool", cfg.PerTool)...)
+
+	return errors
+}
+
+func validateHTTPRateLimitConfig(cfg *HTTPRateLimitConfig) ValidationErrors {
+	if !cfg.Enabled {
+		return nil
+	}
+	return validateRateLimit("HTTP discovery", cfg.RateLimit)
+}
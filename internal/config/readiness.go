@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const DefaultReadinessTimeout = 5 * time.Second
+
+// Built-in readiness checks
+const (
+	ReadinessCheckTransport     = "transport"
+	ReadinessCheckRegistries    = "registries"
+	ReadinessCheckRequiredTools = "required_tools"
+	ReadinessCheckFileResources = "file_resources"
+	ReadinessCheckUpstreams     = "upstreams"
+)
+
+// Modes a readiness check can be set to through MCP_READINESS_CHECKS
+const (
+	ReadinessModeCritical    = "critical"
+	ReadinessModeNonCritical = "non-critical"
+	ReadinessModeDisabled    = "disabled"
+)
+
+// ReadinessConfig controls the checks behind /ready. Each check gets at most
+// Timeout. A failing critical check makes the server not ready; a failing
+// non-critical check only reports it as degraded.
+type ReadinessConfig struct {
+	Timeout time.Duration `json:"timeout"`
+	// RequiredTools are tool references that must be loaded or active, like
+	// name or name@version
+	RequiredTools []string                        `json:"required_tools"`
+	Checks        map[string]ReadinessCheckConfig `json:"checks"`
+}
+
+// ReadinessCheckConfig sets whether a check runs and whether it is critical
+type ReadinessCheckConfig struct {
+	Enabled  bool `json:"enabled"`
+	Critical bool `json:"critical"`
+}
+
+type FileReadinessConfig struct {
+	Timeout       string                              `yaml:"timeout"`
+	RequiredTools []string                            `yaml:"required_tools"`
+	Checks        map[string]FileReadinessCheckConfig `yaml:"checks"`
+}
+
+type FileReadinessCheckConfig struct {
+	Enabled  *bool `yaml:"enabled"`
+	Critical *bool `yaml:"critical"`
+}
+
+// defaultReadinessChecks returns the built-in checks. Upstreams connect in
+// the background and can be down without the server being unusable, so
+// they are not critical.
+func defaultReadinessChecks() map[string]ReadinessCheckConfig {
+	return map[string]ReadinessCheckConfig{
+		ReadinessCheckTransport:     {Enabled: true, Critical: true},
+		ReadinessCheckRegistries:    {Enabled: true, Critical: true},
+		ReadinessCheckRequiredTools: {Enabled: true, Critical: true},
+		ReadinessCheckFileResources: {Enabled: true, Critical: true},
+		ReadinessCheckUpstreams:     {Enabled: true, Critical: false},
+	}
+}
+
+// readinessModeConfig turns a mode from MCP_READINESS_CHECKS into a check
+// configuration
+func readinessModeConfig(mode string) (ReadinessCheckConfig, bool) {
+	switch mode {
+	case ReadinessModeCritical:
+		return ReadinessCheckConfig{Enabled: true, Critical: true}, true
+	case ReadinessModeNonCritical:
+		return ReadinessCheckConfig{Enabled: true, Critical: false}, true
+	case ReadinessModeDisabled:
+		return ReadinessCheckConfig{Enabled: false}, true
+	default:
+		return ReadinessCheckConfig{}, false
+	}
+}
+
+func loadReadinessFromEnvironment() ReadinessConfig {
+	cfg := ReadinessConfig{
+		Timeout:       getEnvDuration("MCP_READINESS_TIMEOUT", DefaultReadinessTimeout),
+		RequiredTools: getEnvStringSlice("MCP_READINESS_REQUIRED_TOOLS", []string{}),
+		Checks:        defaultReadinessChecks(),
+	}
+	for i, ref := range cfg.RequiredTools {
+		cfg.RequiredTools[i] = strings.TrimSpace(ref)
+	}
+
+	// MCP_READINESS_CHECKS takes name=mode pairs, like upstreams=critical
+	for name, mode := range getEnvStringMap("MCP_READINESS_CHECKS") {
+		if check, valid := readinessModeConfig(mode); valid {
+			cfg.Checks[name] = check
+		}
+	}
+	return cfg
+}
+
+func mergeReadinessConfig(base *ReadinessConfig, file *FileReadinessConfig) {
+	if file.Timeout != "" && os.Getenv("MCP_READINESS_TIMEOUT") == "" {
+		if duration, err := time.ParseDuration(file.Timeout); err == nil {
+			base.Timeout = duration
+		}
+	}
+	if len(file.RequiredTools) > 0 && os.Getenv("MCP_READINESS_REQUIRED_TOOLS") == "" {
+		base.RequiredTools = file.RequiredTools
+	}
+
+	fromEnvironment := getEnvStringMap("MCP_READINESS_CHECKS")
+	for name, fileCheck := range file.Checks {
+		if _, set := fromEnvironment[name]; set {
+			continue
+		}
+		// Checks that are not built in are critical unless configured otherwise
+		check, exists := base.Checks[name]
+		if !exists {
+			check = ReadinessCheckConfig{Enabled: true, Critical: true}
+		}
+		if fileCheck.Enabled != nil {
+			check.Enabled = *fileCheck.Enabled
+		}
+		if fileCheck.Critical != nil {
+			check.Critical = *fileCheck.Critical
+		}
+		base.Checks[name] = check
+	}
+}
+
+func validateReadinessConfig(cfg *ReadinessConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if cfg.Timeout <= 0 {
+		errors = append(errors, fmt.Sprintf("readiness timeout must be positive, got %v (hint: use 5s)", cfg.Timeout))
+	}
+	for _, ref := range cfg.RequiredTools {
+		if ref == "" {
+			errors = append(errors, "readiness required tools must not contain empty names (hint: check for stray commas)")
+			break
+		}
+	}
+
+	var invalid []string
+	for name, mode := range getEnvStringMap("MCP_READINESS_CHECKS") {
+		if _, valid := readinessModeConfig(mode); !valid {
+			invalid = append(invalid, fmt.Sprintf("%s=%s", name, mode))
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		errors = append(errors, fmt.Sprintf("invalid readiness check modes %s (hint: use %s, %s or %s)",
+			strings.Join(invalid, ", "), ReadinessModeCritical, ReadinessModeNonCritical, ReadinessModeDisabled))
+	}
+
+	return errors
+}
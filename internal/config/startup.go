@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	DefaultStartupWorkers        = 8
+	DefaultStartupFactoryTimeout = 30 * time.Second
+	DefaultStartupDeadline       = 60 * time.Second
+)
+
+// StartupConfig bounds the loading of tools and resources at startup. Up to
+// Workers entities load at once, each for at most FactoryTimeout. The server
+// becomes ready once every entity loaded or Deadline passed; entities still
+// loading then finish in the background.
+type StartupConfig struct {
+	Workers        int           `json:"workers"`
+	FactoryTimeout time.Duration `json:"factory_timeout"`
+	Deadline       time.Duration `json:"deadline"`
+}
+
+type FileStartupConfig struct {
+	Workers        int    `yaml:"workers"`
+	FactoryTimeout string `yaml:"factory_timeout"`
+	Deadline       string `yaml:"deadline"`
+}
+
+func loadStartupFromEnvironment() StartupConfig {
+	return StartupConfig{
+		Workers:        getEnvInt("MCP_STARTUP_WORKERS", DefaultStartupWorkers),
+		FactoryTimeout: getEnvDuration("MCP_STARTUP_FACTORY_TIMEOUT", DefaultStartupFactoryTimeout),
+		Deadline:       getEnvDuration("MCP_STARTUP_DEADLINE", DefaultStartupDeadline),
+	}
+}
+
+func mergeStartupConfig(base *StartupConfig, file *FileStartupConfig) {
+	if file.Workers != 0 && os.Getenv("MCP_STARTUP_WORKERS") == "" {
+		base.Workers = file.Workers
+	}
+	if file.FactoryTimeout != "" && os.Getenv("MCP_STARTUP_FACTORY_TIMEOUT") == "" {
+		if duration, err := time.ParseDuration(file.FactoryTimeout); err == nil {
+			base.FactoryTimeout = duration
+		}
+	}
+	if file.Deadline != "" && os.Getenv("MCP_STARTUP_DEADLINE") == "" {
+		if duration, err := time.ParseDuration(file.Deadline); err == nil {
+			base.Deadline = duration
+		}
+	}
+}
+
+func validateStartupConfig(cfg *StartupConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if cfg.Workers < 1 {
+		errors = append(errors, fmt.Sprintf("startup workers must be positive, got %d (hint: use 8)", cfg.Workers))
+	}
+	if cfg.FactoryTimeout <= 0 {
+		errors = append(errors, fmt.Sprintf("startup factory timeout must be positive, got %v (hint: use 30s)", cfg.FactoryTimeout))
+	}
+	if cfg.Deadline <= 0 {
+		errors = append(errors, fmt.Sprintf("startup deadline must be positive, got %v (hint: use 60s)", cfg.Deadline))
+	}
+
+	return errors
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// StateConfig locates the file where operator decisions about tools and
+// resources, such as a disabled tool, are kept across restarts. Without a
+// path they last only as long as the process.
+type StateConfig struct {
+	Path string `json:"path,omitempty"`
+}
+
+type FileStateConfig struct {
+	Path string `yaml:"path"`
+}
+
+func loadStateFromEnvironment() StateConfig {
+	return StateConfig{
+		Path: getEnv("MCP_STATE_PATH", ""),
+	}
+}
+
+func mergeStateConfig(base *StateConfig, file *FileStateConfig) {
+	if file.Path != "" && os.Getenv("MCP_STATE_PATH") == "" {
+		base.Path = file.Path
+	}
+}
+
+func validateStateConfig(cfg *StateConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if cfg.Path != "" {
+		if info, err := os.Stat(cfg.Path); err == nil && info.IsDir() {
+			errors = append(errors, fmt.Sprintf("state path %s is a directory (hint: point it at a file such as %s/state.json)", cfg.Path, cfg.Path))
+		}
+	}
+
+	return errors
+}
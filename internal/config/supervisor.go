@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	DefaultSupervisorEnabled        = true
+	DefaultSupervisorInterval       = 30 * time.Second
+	DefaultSupervisorProbeTimeout   = 5 * time.Second
+	DefaultSupervisorInitialBackoff = 1 * time.Second
+	DefaultSupervisorMaxBackoff     = 5 * time.Minute
+	DefaultSupervisorMaxAttempts    = 5
+)
+
+// SupervisorConfig controls the background supervisor that probes tools and
+// resources and restarts the ones in error. Restarts back off exponentially
+// from InitialBackoff to MaxBackoff; after MaxAttempts failed restarts the
+// entity is disabled and left for an operator.
+type SupervisorConfig struct {
+	Enabled        bool          `json:"enabled"`
+	Interval       time.Duration `json:"interval"`
+	ProbeTimeout   time.Duration `json:"probe_timeout"`
+	InitialBackoff time.Duration `json:"initial_backoff"`
+	MaxBackoff     time.Duration `json:"max_backoff"`
+	MaxAttempts    int           `json:"max_attempts"`
+}
+
+type FileSupervisorConfig struct {
+	Enabled        *bool  `yaml:"enabled"`
+	Interval       string `yaml:"interval"`
+	ProbeTimeout   string `yaml:"probe_timeout"`
+	InitialBackoff string `yaml:"initial_backoff"`
+	MaxBackoff     string `yaml:"max_backoff"`
+	MaxAttempts    int    `yaml:"max_attempts"`
+}
+
+func loadSupervisorFromEnvironment() SupervisorConfig {
+	return SupervisorConfig{
+		Enabled:        getEnvBool("MCP_SUPERVISOR_ENABLED", DefaultSupervisorEnabled),
+		Interval:       getEnvDuration("MCP_SUPERVISOR_INTERVAL", DefaultSupervisorInterval),
+		ProbeTimeout:   getEnvDuration("MCP_SUPERVISOR_PROBE_TIMEOUT", DefaultSupervisorProbeTimeout),
+		InitialBackoff: getEnvDuration("MCP_SUPERVISOR_INITIAL_BACKOFF", DefaultSupervisorInitialBackoff),
+		MaxBackoff:     getEnvDuration("MCP_SUPERVISOR_MAX_BACKOFF", DefaultSupervisorMaxBackoff),
+		MaxAttempts:    getEnvInt("MCP_SUPERVISOR_MAX_ATTEMPTS", DefaultSupervisorMaxAttempts),
+	}
+}
+
+func mergeSupervisorConfig(base *SupervisorConfig, file *FileSupervisorConfig) {
+	if file.Enabled != nil && os.Getenv("MCP_SUPERVISOR_ENABLED") == "" {
+		base.Enabled = *file.Enabled
+	}
+	if file.Interval != "" && os.Getenv("MCP_SUPERVISOR_INTERVAL") == "" {
+		if duration, err := time.ParseDuration(file.Interval); err == nil {
+			base.Interval = duration
+		}
+	}
+	if file.ProbeTimeout != "" && os.Getenv("MCP_SUPERVISOR_PROBE_TIMEOUT") == "" {
+		if duration, err := time.ParseDuration(file.ProbeTimeout); err == nil {
+			base.ProbeTimeout = duration
+		}
+	}
+	if file.InitialBackoff != "" && os.Getenv("MCP_SUPERVISOR_INITIAL_BACKOFF") == "" {
+		if duration, err := time.ParseDuration(file.InitialBackoff); err == nil {
+			base.InitialBackoff = duration
+		}
+	}
+	if file.MaxBackoff != "" && os.Getenv("MCP_SUPERVISOR_MAX_BACKOFF") == "" {
+		if duration, err := time.ParseDuration(file.MaxBackoff); err == nil {
+			base.MaxBackoff = duration
+		}
+	}
+	if file.MaxAttempts != 0 && os.Getenv("MCP_SUPERVISOR_MAX_ATTEMPTS") == "" {
+		base.MaxAttempts = file.MaxAttempts
+	}
+}
+
+func validateSupervisorConfig(cfg *SupervisorConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if !cfg.Enabled {
+		return errors
+	}
+
+	if cfg.Interval <= 0 {
+		errors = append(errors, fmt.Sprintf("supervisor interval must be positive, got %v (hint: use 30s)", cfg.Interval))
+	}
+	if cfg.ProbeTimeout <= 0 {
+		errors = append(errors, fmt.Sprintf("supervisor probe timeout must be positive, got %v (hint: use 5s)", cfg.ProbeTimeout))
+	}
+	if cfg.InitialBackoff <= 0 {
+		errors = append(errors, fmt.Sprintf("supervisor initial backoff must be positive, got %v (hint: use 1s)", cfg.InitialBackoff))
+	}
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		errors = append(errors, fmt.Sprintf("supervisor max backoff %v cannot be shorter than the initial backoff %v", cfg.MaxBackoff, cfg.InitialBackoff))
+	}
+	if cfg.MaxAttempts < 1 {
+		errors = append(errors, fmt.Sprintf("supervisor max attempts must be positive, got %d (hint: use 5)", cfg.MaxAttempts))
+	}
+
+	return errors
+}
@@ -0,0 +1,268 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultToolBreakerEnabled          = true
+	DefaultToolBreakerMinRequests      = 5
+	DefaultToolBreakerFailureRatio     = 0.5
+	DefaultToolBreakerOpenTimeout      = 30 * time.Second
+	DefaultToolBreakerInterval         = 60 * time.Second
+	DefaultToolBreakerHalfOpenRequests = 1
+)
+
+// ToolsConfig holds execution policies applied to tools, with optional per-tool overrides
+type ToolsConfig struct {
+	CircuitBreaker ToolCircuitBreakerConfig
+	RateLimit      ToolRateLimitConfig
+	Bulkhead       ToolBulkheadConfig
+	GlobalBulkhead ToolBulkheadConfig
+	Cache          ToolCacheConfig
+	Overrides      map[string]ToolOverrideConfig
+	Exec           []ExecToolConfig
+	HTTP           []HTTPToolConfig
+}
+
+// ToolCircuitBreakerConfig controls the breaker guarding tool execution
+type ToolCircuitBreakerConfig struct {
+	Enabled          bool          `json:"enabled"`
+	MinRequests      uint32        `json:"min_requests"`
+	FailureRatio     float64       `json:"failure_ratio"`
+	OpenTimeout      time.Duration `json:"open_timeout"`
+	Interval         time.Duration `json:"interval"`
+	HalfOpenRequests uint32        `json:"half_open_requests"`
+}
+
+// ToolOverrideConfig replaces the global policies for a single tool and
+// manages the versions registered under its name
+type ToolOverrideConfig struct {
+	CircuitBreaker *ToolCircuitBreakerConfig        `json:"circuit_breaker,omitempty"`
+	RateLimit      *ToolRateLimitConfig             `json:"rate_limit,omitempty"`
+	Bulkhead       *ToolBulkheadConfig              `json:"bulkhead,omitempty"`
+	Cache          *ToolCacheConfig                 `json:"cache,omitempty"`
+	DefaultVersion string                           `json:"default_version,omitempty"`
+	Deprecations   map[string]ToolDeprecationConfig `json:"deprecated_versions,omitempty"`
+}
+
+type FileToolsConfig struct {
+	CircuitBreaker FileToolCircuitBreakerConfig      `yaml:"circuit_breaker"`
+	RateLimit      FileToolRateLimitConfig           `yaml:"rate_limit"`
+	Bulkhead       FileToolBulkheadConfig            `yaml:"bulkhead"`
+	GlobalBulkhead FileToolBulkheadConfig            `yaml:"global_bulkhead"`
+	Cache          FileToolCacheConfig               `yaml:"cache"`
+	Overrides      map[string]FileToolOverrideConfig `yaml:"overrides"`
+	Exec           []FileExecToolConfig              `yaml:"exec"`
+	HTTP           []FileHTTPToolConfig              `yaml:"http"`
+}
+
+type FileToolCircuitBreakerConfig struct {
+	Enabled          *bool   `yaml:"enabled"`
+	MinRequests      uint32  `yaml:"min_requests"`
+	FailureRatio     float64 `yaml:"failure_ratio"`
+	OpenTimeout      string  `yaml:"open_timeout"`
+	Interval         string  `yaml:"interval"`
+	HalfOpenRequests uint32  `yaml:"half_open_requests"`
+}
+
+type FileToolOverrideConfig struct {
+	CircuitBreaker *FileToolCircuitBreakerConfig        `yaml:"circuit_breaker"`
+	RateLimit      *FileToolRateLimitConfig             `yaml:"rate_limit"`
+	Bulkhead       *FileToolBulkheadConfig              `yaml:"bulkhead"`
+	Cache          *FileToolCacheConfig                 `yaml:"cache"`
+	DefaultVersion string                               `yaml:"default_version"`
+	Deprecations   map[string]FileToolDeprecationConfig `yaml:"deprecated_versions"`
+}
+
+// CircuitBreakerFor returns the effective breaker configuration for a tool
+func (c ToolsConfig) CircuitBreakerFor(name string) ToolCircuitBreakerConfig {
+	if override, exists := c.Overrides[name]; exists && override.CircuitBreaker != nil {
+		return *override.CircuitBreaker
+	}
+	return c.CircuitBreaker
+}
+
+// RateLimitFor returns the effective rate limits for a tool
+func (c ToolsConfig) RateLimitFor(name string) ToolRateLimitConfig {
+	if override, exists := c.Overrides[name]; exists && override.RateLimit != nil {
+		return *override.RateLimit
+	}
+	return c.RateLimit
+}
+
+// BulkheadFor returns the effective concurrency limits for a tool
+func (c ToolsConfig) BulkheadFor(name string) ToolBulkheadConfig {
+	if override, exists := c.Overrides[name]; exists && override.Bulkhead != nil {
+		return *override.Bulkhead
+	}
+	return c.Bulkhead
+}
+
+// CacheFor returns the effective result cache configuration for a tool
+func (c ToolsConfig) CacheFor(name string) ToolCacheConfig {
+	if override, exists := c.Overrides[name]; exists && override.Cache != nil {
+		return *override.Cache
+	}
+	return c.Cache
+}
+
+func getEnvUint32(key string, defaultValue uint32) uint32 {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.ParseUint(value, 10, 32); err == nil {
+			return uint32(intValue)
+		}
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func loadToolsFromEnvironment() ToolsConfig {
+	return ToolsConfig{
+		CircuitBreaker: ToolCircuitBreakerConfig{
+			Enabled:          getEnvBool("MCP_TOOL_BREAKER_ENABLED", DefaultToolBreakerEnabled),
+			MinRequests:      getEnvUint32("MCP_TOOL_BREAKER_MIN_REQUESTS", DefaultToolBreakerMinRequests),
+			FailureRatio:     getEnvFloat("MCP_TOOL_BREAKER_FAILURE_RATIO", DefaultToolBreakerFailureRatio),
+			OpenTimeout:      getEnvDuration("MCP_TOOL_BREAKER_OPEN_TIMEOUT", DefaultToolBreakerOpenTimeout),
+			Interval:         getEnvDuration("MCP_TOOL_BREAKER_INTERVAL", DefaultToolBreakerInterval),
+			HalfOpenRequests: getEnvUint32("MCP_TOOL_BREAKER_HALF_OPEN_REQUESTS", DefaultToolBreakerHalfOpenRequests),
+		},
+		RateLimit:      loadToolRateLimitFromEnvironment(),
+		Bulkhead:       loadToolBulkheadFromEnvironment(),
+		GlobalBulkhead: loadGlobalBulkheadFromEnvironment(),
+		Cache:          loadToolCacheFromEnvironment(),
+		Overrides:      make(map[string]ToolOverrideConfig),
+	}
+}
+
+func mergeToolCircuitBreakerConfig(base *ToolCircuitBreakerConfig, file *FileToolCircuitBreakerConfig, useEnv bool) {
+	envUnset := func(key string) bool {
+		return !useEnv || os.Getenv(key) == ""
+	}
+
+	if file.Enabled != nil && envUnset("MCP_TOOL_BREAKER_ENABLED") {
+		base.Enabled = *file.Enabled
+	}
+	if file.MinRequests != 0 && envUnset("MCP_TOOL_BREAKER_MIN_REQUESTS") {
+		base.MinRequests = file.MinRequests
+	}
+	if file.FailureRatio != 0 && envUnset("MCP_TOOL_BREAKER_FAILURE_RATIO") {
+		base.FailureRatio = file.FailureRatio
+	}
+	if file.OpenTimeout != "" && envUnset("MCP_TOOL_BREAKER_OPEN_TIMEOUT") {
+		if duration, err := time.ParseDuration(file.OpenTimeout); err == nil {
+			base.OpenTimeout = duration
+		}
+	}
+	if file.Interval != "" && envUnset("MCP_TOOL_BREAKER_INTERVAL") {
+		if duration, err := time.ParseDuration(file.Interval); err == nil {
+			base.Interval = duration
+		}
+	}
+	if file.HalfOpenRequests != 0 && envUnset("MCP_TOOL_BREAKER_HALF_OPEN_REQUESTS") {
+		base.HalfOpenRequests = file.HalfOpenRequests
+	}
+}
+
+func mergeToolsConfig(base *ToolsConfig, file *FileToolsConfig) {
+	mergeToolCircuitBreakerConfig(&base.CircuitBreaker, &file.CircuitBreaker, true)
+	mergeToolRateLimitConfig(&base.RateLimit, &file.RateLimit, true)
+	mergeToolBulkheadConfig(&base.Bulkhead, &file.Bulkhead, toolBulkheadEnv, true)
+	mergeToolBulkheadConfig(&base.GlobalBulkhead, &file.GlobalBulkhead, globalBulkheadEnv, true)
+	mergeToolCacheConfig(&base.Cache, &file.Cache, true)
+
+	overrides := make(map[string]ToolOverrideConfig, len(file.Overrides))
+	for name, fileOverride := range file.Overrides {
+		override := ToolOverrideConfig{}
+		if fileOverride.CircuitBreaker != nil {
+			// Per-tool overrides start from the global settings
+			breaker := base.CircuitBreaker
+			mergeToolCircuitBreakerConfig(&breaker, fileOverride.CircuitBreaker, false)
+			override.CircuitBreaker = &breaker
+		}
+		if fileOverride.RateLimit != nil {
+			rateLimit := base.RateLimit
+			mergeToolRateLimitConfig(&rateLimit, fileOverride.RateLimit, false)
+			override.RateLimit = &rateLimit
+		}
+		if fileOverride.Bulkhead != nil {
+			bulkhead := base.Bulkhead
+			mergeToolBulkheadConfig(&bulkhead, fileOverride.Bulkhead, toolBulkheadEnv, false)
+			override.Bulkhead = &bulkhead
+		}
+		if fileOverride.Cache != nil {
+			cache := base.Cache
+			mergeToolCacheConfig(&cache, fileOverride.Cache, false)
+			override.Cache = &cache
+		}
+		mergeToolVersionsConfig(&override, &fileOverride)
+		overrides[name] = override
+	}
+	base.Overrides = overrides
+
+	mergeExecToolsConfig(base, file.Exec)
+	mergeHTTPToolsConfig(base, file.HTTP)
+}
+
+func validateToolCircuitBreakerConfig(scope string, cfg *ToolCircuitBreakerConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if !cfg.Enabled {
+		return errors
+	}
+
+	if cfg.MinRequests < 1 {
+		errors = append(errors, fmt.Sprintf("%s circuit breaker min requests must be positive, got %d (hint: use 5-20)", scope, cfg.MinRequests))
+	}
+	if cfg.FailureRatio <= 0 || cfg.FailureRatio > 1 {
+		errors = append(errors, fmt.Sprintf("%s circuit breaker failure ratio must be in (0, 1], got %v (hint: use 0.5)", scope, cfg.FailureRatio))
+	}
+	if cfg.OpenTimeout < 0 {
+		errors = append(errors, fmt.Sprintf("%s circuit breaker open timeout cannot be negative, got %v", scope, cfg.OpenTimeout))
+	}
+	if cfg.Interval < 0 {
+		errors = append(errors, fmt.Sprintf("%s circuit breaker interval cannot be negative, got %v", scope, cfg.Interval))
+	}
+
+	return errors
+}
+
+func validateToolsConfig(cfg *ToolsConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	errors = append(errors, validateToolCircuitBreakerConfig("tool", &cfg.CircuitBreaker)...)
+	errors = append(errors, validateToolRateLimitConfig("tool", &cfg.RateLimit)...)
+	errors = append(errors, validateToolBulkheadConfig("tool", &cfg.Bulkhead)...)
+	errors = append(errors, validateToolBulkheadConfig("global tool", &cfg.GlobalBulkhead)...)
+	errors = append(errors, validateToolCacheConfig("tool", &cfg.Cache)...)
+	for name, override := range cfg.Overrides {
+		if override.CircuitBreaker != nil {
+			errors = append(errors, validateToolCircuitBreakerConfig(fmt.Sprintf("tool %q", name), override.CircuitBreaker)...)
+		}
+		if override.RateLimit != nil {
+			errors = append(errors, validateToolRateLimitConfig(fmt.Sprintf("tool %q", name), override.RateLimit)...)
+		}
+		if override.Bulkhead != nil {
+			errors = append(errors, validateToolBulkheadConfig(fmt.Sprintf("tool %q", name), override.Bulkhead)...)
+		}
+		if override.Cache != nil {
+			errors = append(errors, validateToolCacheConfig(fmt.Sprintf("tool %q", name), override.Cache)...)
+		}
+		errors = append(errors, validateToolVersionsConfig(name, &override)...)
+	}
+	errors = append(errors, validateExecToolsConfig(cfg.Exec)...)
+	errors = append(errors, validateHTTPToolsConfig(cfg.HTTP)...)
+
+	return errors
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	TracingExporterOTLP  = "otlp"
+	TracingExporterJSONL = "jsonl"
+
+	DefaultTracingEnabled       = false
+	DefaultTracingExporter      = TracingExporterOTLP
+	DefaultTracingOTLPEndpoint  = "http://localhost:4318/v1/traces"
+	DefaultTracingFilePath      = "traces.jsonl"
+	DefaultTracingBatchSize     = 128
+	DefaultTracingFlushInterval = 5 * time.Second
+)
+
+// TracingConfig controls span collection and export
+type TracingConfig struct {
+	Enabled       bool              `json:"enabled"`
+	Exporter      string            `json:"exporter"`
+	OTLPEndpoint  string            `json:"otlp_endpoint"`
+	OTLPHeaders   map[string]string `json:"-"`
+	FilePath      string            `json:"file_path"`
+	BatchSize     int               `json:"batch_size"`
+	FlushInterval time.Duration     `json:"flush_interval"`
+}
+
+type FileTracingConfig struct {
+	Enabled       *bool             `yaml:"enabled"`
+	Exporter      string            `yaml:"exporter"`
+	OTLPEndpoint  string            `yaml:"otlp_endpoint"`
+	OTLPHeaders   map[string]string `yaml:"otlp_headers"`
+	FilePath      string            `yaml:"file_path"`
+	BatchSize     int               `yaml:"batch_size"`
+	FlushInterval string            `yaml:"flush_interval"`
+}
+
+// getEnvStringMap parses comma-separated key=value pairs
+func getEnvStringMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		name, value, found := strings.Cut(pair, "=")
+		if found && strings.TrimSpace(name) != "" {
+			result[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	return result
+}
+
+func loadTracingFromEnvironment() TracingConfig {
+	return TracingConfig{
+		Enabled:       getEnvBool("MCP_TRACING_ENABLED", DefaultTracingEnabled),
+		Exporter:      getEnv("MCP_TRACING_EXPORTER", DefaultTracingExporter),
+		OTLPEndpoint:  getEnv("MCP_TRACING_OTLP_ENDPOINT", DefaultTracingOTLPEndpoint),
+		OTLPHeaders:   getEnvStringMap("MCP_TRACING_OTLP_HEADERS"),
+		FilePath:      getEnv("MCP_TRACING_FILE", DefaultTracingFilePath),
+		BatchSize:     getEnvInt("MCP_TRACING_BATCH_SIZE", DefaultTracingBatchSize),
+		FlushInterval: getEnvDuration("MCP_TRACING_FLUSH_INTERVAL", DefaultTracingFlushInterval),
+	}
+}
+
+func mergeTracingConfig(base *TracingConfig, file *FileTracingConfig) {
+	if file.Enabled != nil && os.Getenv("MCP_TRACING_ENABLED") == "" {
+		base.Enabled = *file.Enabled
+	}
+	if file.Exporter != "" && os.Getenv("MCP_TRACING_EXPORTER") == "" {
+		base.Exporter = file.Exporter
+	}
+	if file.OTLPEndpoint != "" && os.Getenv("MCP_TRACING_OTLP_ENDPOINT") == "" {
+		base.OTLPEndpoint = file.OTLPEndpoint
+	}
+	if len(file.OTLPHeaders) > 0 && os.Getenv("MCP_TRACING_OTLP_HEADERS") == "" {
+		base.OTLPHeaders = file.OTLPHeaders
+	}
+	if file.FilePath != "" && os.Getenv("MCP_TRACING_FILE") == "" {
+		base.FilePath = file.FilePath
+	}
+	if file.BatchSize != 0 && os.Getenv("MCP_TRACING_BATCH_SIZE") == "" {
+		base.BatchSize = file.BatchSize
+	}
+	if file.FlushInterval != "" && os.Getenv("MCP_TRACING_FLUSH_INTERVAL") == "" {
+		if duration, err := time.ParseDuration(file.FlushInterval); err == nil {
+			base.FlushInterval = duration
+		}
+	}
+}
+
+func validateTracingConfig(cfg *TracingConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	if !cfg.Enabled {
+		return errors
+	}
+
+	switch cfg.Exporter {
+	case TracingExporterOTLP:
+		if !strings.HasPrefix(cfg.OTLPEndpoint, "http://") && !strings.HasPrefix(cfg.OTLPEndpoint, "https://") {
+			errors = append(errors, fmt.Sprintf("tracing OTLP endpoint must be an http(s) URL, got %q (hint: use %s)", cfg.OTLPEndpoint, DefaultTracingOTLPEndpoint))
+		}
+	case TracingExporterJSONL:
+		if cfg.FilePath == "" {
+			errors = append(errors, "tracing file path cannot be empty for the jsonl exporter")
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("tracing exporter must be %q or %q, got %q", TracingExporterOTLP, TracingExporterJSONL, cfg.Exporter))
+	}
+
+	if cfg.BatchSize < 1 {
+		errors = append(errors, fmt.Sprintf("tracing batch size must be positive, got %d (hint: use 128)", cfg.BatchSize))
+	}
+	if cfg.FlushInterval <= 0 {
+		errors = append(errors, fmt.Sprintf("tracing flush interval must be positive, got %v (hint: use 5s)", cfg.FlushInterval))
+	}
+
+	return errors
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	UpstreamTransportStdio = "stdio"
+	UpstreamTransportHTTP  = "http"
+
+	DefaultUpstreamHealthInterval      = 30 * time.Second
+	DefaultUpstreamRequestTimeout      = 30 * time.Second
+	DefaultUpstreamReconnectBackoff    = time.Second
+	DefaultUpstreamMaxReconnectBackoff = time.Minute
+)
+
+// upstreamNamespacePattern keeps prefixed tool names valid tool names
+var upstreamNamespacePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,31}$`)
+
+// UpstreamConfig declares another MCP server whose tools and resources are
+// mounted into this server's registries under a namespace prefix. Stdio
+// upstreams are launched as child processes with only the allowlisted
+// environment variables; HTTP upstreams are reached over streamable HTTP,
+// with ${VAR} references in header values expanded from the environment.
+type UpstreamConfig struct {
+	Name                string            `json:"name"`
+	Namespace           string            `json:"namespace"`
+	Transport           string            `json:"transport"`
+	Command             string            `json:"command,omitempty"`
+	Args                []string          `json:"args,omitempty"`
+	Env                 []string          `json:"env,omitempty"`
+	URL                 string            `json:"url,omitempty"`
+	Headers             map[string]string `json:"-"`
+	HealthInterval      time.Duration     `json:"health_interval"`
+	RequestTimeout      time.Duration     `json:"request_timeout"`
+	ReconnectBackoff    time.Duration     `json:"reconnect_backoff"`
+	MaxReconnectBackoff time.Duration     `json:"max_reconnect_backoff"`
+}
+
+type FileUpstreamConfig struct {
+	Name                string            `yaml:"name"`
+	Namespace           string            `yaml:"namespace"`
+	Transport           string            `yaml:"transport"`
+	Command             string            `yaml:"command"`
+	Args                []string          `yaml:"args"`
+	Env                 []string          `yaml:"env"`
+	URL                 string            `yaml:"url"`
+	Headers             map[string]string `yaml:"headers"`
+	HealthInterval      string            `yaml:"health_interval"`
+	RequestTimeout      string            `yaml:"request_timeout"`
+	ReconnectBackoff    string            `yaml:"reconnect_backoff"`
+	MaxReconnectBackoff string            `yaml:"max_reconnect_backoff"`
+}
+
+func mergeUpstreamsConfig(base *Config, file []FileUpstreamConfig) {
+	if len(file) == 0 {
+		return
+	}
+
+	base.Upstreams = make([]UpstreamConfig, 0, len(file))
+	for _, fileUpstream := range file {
+		upstream := UpstreamConfig{
+			Name:                fileUpstream.Name,
+			Namespace:           fileUpstream.Namespace,
+			Transport:           fileUpstream.Transport,
+			Command:             fileUpstream.Command,
+			Args:                fileUpstream.Args,
+			Env:                 fileUpstream.Env,
+			URL:                 fileUpstream.URL,
+			Headers:             fileUpstream.Headers,
+			HealthInterval:      DefaultUpstreamHealthInterval,
+			RequestTimeout:      DefaultUpstreamRequestTimeout,
+			ReconnectBackoff:    DefaultUpstreamReconnectBackoff,
+			MaxReconnectBackoff: DefaultUpstreamMaxReconnectBackoff,
+		}
+		if upstream.Namespace == "" {
+			upstream.Namespace = upstream.Name
+		}
+		if upstream.Transport == "" {
+			upstream.Transport = UpstreamTransportStdio
+			if upstream.URL != "" {
+				upstream.Transport = UpstreamTransportHTTP
+			}
+		}
+		// Unparsable durations become zero and are reported by validation
+		if fileUpstream.HealthInterval != "" {
+			upstream.HealthInterval, _ = time.ParseDuration(fileUpstream.HealthInterval)
+		}
+		if fileUpstream.RequestTimeout != "" {
+			upstream.RequestTimeout, _ = time.ParseDuration(fileUpstream.RequestTimeout)
+		}
+		if fileUpstream.ReconnectBackoff != "" {
+			upstream.ReconnectBackoff, _ = time.ParseDuration(fileUpstream.ReconnectBackoff)
+		}
+		if fileUpstream.MaxReconnectBackoff != "" {
+			upstream.MaxReconnectBackoff, _ = time.ParseDuration(fileUpstream.MaxReconnectBackoff)
+		}
+		base.Upstreams = append(base.Upstreams, upstream)
+	}
+}
+
+func validateUpstreamsConfig(upstreams []UpstreamConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	names := make(map[string]bool, len(upstreams))
+	namespaces := make(map[string]bool, len(upstreams))
+	for i, upstream := range upstreams {
+		scope := fmt.Sprintf("upstream %q", upstream.Name)
+		if upstream.Name == "" {
+			scope = fmt.Sprintf("upstream #%d", i+1)
+			errors = append(errors, fmt.Sprintf("%s name cannot be empty", scope))
+		} else if names[upstream.Name] {
+			errors = append(errors, fmt.Sprintf("%s is declared more than once", scope))
+		}
+		names[upstream.Name] = true
+
+		if !upstreamNamespacePattern.MatchString(upstream.Namespace) {
+			errors = append(errors, fmt.Sprintf("%s namespace %q must start with a letter and contain only letters, digits and underscores (max 32)", scope, upstream.Namespace))
+		} else if namespaces[upstream.Namespace] {
+			errors = append(errors, fmt.Sprintf("%s namespace %q is already used by another upstream", scope, upstream.Namespace))
+		}
+		namespaces[upstream.Namespace] = true
+
+		switch upstream.Transport {
+		case UpstreamTransportStdio:
+			if upstream.Command == "" {
+				errors = append(errors, fmt.Sprintf("%s command cannot be empty for the stdio transport", scope))
+			}
+		case UpstreamTransportHTTP:
+			if upstream.URL == "" {
+				errors = append(errors, fmt.Sprintf("%s URL cannot be empty for the http transport", scope))
+			}
+		default:
+			errors = append(errors, fmt.Sprintf("%s transport must be %q or %q, got %q", scope, UpstreamTransportStdio, UpstreamTransportHTTP, upstream.Transport))
+		}
+
+		if upstream.HealthInterval <= 0 {
+			errors = append(errors, fmt.Sprintf("%s health interval must be positive, got %v (hint: use 10s-60s)", scope, upstream.HealthInterval))
+		}
+		if upstream.RequestTimeout <= 0 {
+			errors = append(errors, fmt.Sprintf("%s request timeout must be positive, got %v (hint: use 10s-60s)", scope, upstream.RequestTimeout))
+		}
+		if upstream.ReconnectBackoff <= 0 {
+			errors = append(errors, fmt.Sprintf("%s reconnect backoff must be positive, got %v (hint: use 1s)", scope, upstream.ReconnectBackoff))
+		}
+		if upstream.MaxReconnectBackoff < upstream.ReconnectBackoff {
+			errors = append(errors, fmt.Sprintf("%s max reconnect backoff %v cannot be less than the reconnect backoff %v", scope, upstream.MaxReconnectBackoff, upstream.ReconnectBackoff))
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ToolDeprecationConfig marks one version of a tool as deprecated. Calls to
+// that version keep working but their results carry a warning naming the
+// sunset date.
+type ToolDeprecationConfig struct {
+	Sunset  time.Time `json:"sunset"`
+	Message string    `json:"message,omitempty"`
+}
+
+type FileToolDeprecationConfig struct {
+	Sunset  string `yaml:"sunset"`
+	Message string `yaml:"message"`
+}
+
+// DefaultVersionFor returns the version a bare tool name resolves to, or ""
+// to keep the first registered version
+func (c ToolsConfig) DefaultVersionFor(name string) string {
+	return c.Overrides[name].DefaultVersion
+}
+
+// DeprecationFor returns the deprecation of one version of a tool, if any
+func (c ToolsConfig) DeprecationFor(name, version string) (ToolDeprecationConfig, bool) {
+	deprecation, exists := c.Overrides[name].Deprecations[version]
+	return deprecation, exists
+}
+
+func mergeToolVersionsConfig(base *ToolOverrideConfig, file *FileToolOverrideConfig) {
+	base.DefaultVersion = file.DefaultVersion
+	if len(file.Deprecations) == 0 {
+		return
+	}
+
+	base.Deprecations = make(map[string]ToolDeprecationConfig, len(file.Deprecations))
+	for version, fileDeprecation := range file.Deprecations {
+		// An unparsable sunset stays zero and is reported by validation
+		sunset, _ := parseSunset(fileDeprecation.Sunset)
+		base.Deprecations[version] = ToolDeprecationConfig{
+			Sunset:  sunset,
+			Message: fileDeprecation.Message,
+		}
+	}
+}
+
+// parseSunset accepts a calendar date, taken as midnight UTC, or an RFC 3339
+// timestamp
+func parseSunset(value string) (time.Time, error) {
+	if sunset, err := time.Parse(time.DateOnly, value); err == nil {
+		return sunset, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func validateToolVersionsConfig(name string, cfg *ToolOverrideConfig) ValidationErrors {
+	var errors ValidationErrors
+
+	for version, deprecation := range cfg.Deprecations {
+		if version == "" {
+			errors = append(errors, fmt.Sprintf("tool %q deprecated version cannot be empty", name))
+		}
+		if deprecation.Sunset.IsZero() {
+			errors = append(errors, fmt.Sprintf("tool %q version %q deprecation needs a valid sunset date (hint: use YYYY-MM-DD)", name, version))
+		}
+	}
+
+	return errors
+}
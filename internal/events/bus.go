@@ -0,0 +1,160 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Type classifies a lifecycle event
+type Type string
+
+const (
+	// TypeRegistered means a tool or resource factory was registered
+	TypeRegistered Type = "registered"
+	// TypeUnregistered means a tool or resource was removed
+	TypeUnregistered Type = "unregistered"
+	// TypeStatusChanged means an entity moved between lifecycle statuses
+	TypeStatusChanged Type = "status_changed"
+	// TypeCircuitBreaker means a circuit breaker changed state
+	TypeCircuitBreaker Type = "circuit_breaker"
+	// TypeRestartAttempt means the supervisor tried to restart an entity
+	TypeRestartAttempt Type = "restart_attempt"
+	// TypeCacheInvalidated means cached results or content were dropped
+	TypeCacheInvalidated Type = "cache_invalidated"
+)
+
+const (
+	KindTool     = "tool"
+	KindResource = "resource"
+	KindPrompt   = "prompt"
+)
+
+// DefaultHistory is how many events are kept for subscribers resuming a feed
+const DefaultHistory = 256
+
+// Event is one lifecycle change of a tool, resource or prompt. From and To
+// hold statuses, or breaker states for circuit breaker events.
+type Event struct {
+	ID        uint64    `json:"id"`
+	Type      Type      `json:"type"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	Attempt   int       `json:"attempt,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Bus numbers events and fans them out to subscribers. Publishing never
+// blocks: a subscriber that falls behind has its channel closed and can
+// resubscribe from the last event it saw, which is replayed from history.
+type Bus struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []Event // ring buffer of the most recent events
+	next        int
+	subscribers map[chan Event]struct{}
+}
+
+// NewBus creates a bus keeping the given number of events for replay
+func NewBus(history int) *Bus {
+	if history < 1 {
+		history = DefaultHistory
+	}
+	return &Bus{
+		history:     make([]Event, 0, history),
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish is a no-op on a nil bus, so registries need not check for one
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	if len(b.history) < cap(b.history) {
+		b.history = append(b.history, event)
+	} else {
+		b.history[b.next] = event
+		b.next = (b.next + 1) % len(b.history)
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe returns the retained events after lastID, followed on the
+// channel by every event published from now on. The channel is closed when
+// the subscriber falls more than buffer events behind or cancels. A lastID
+// of 0 replays nothing.
+func (b *Bus) Subscribe(lastID uint64, buffer int) ([]Event, <-chan Event, func()) {
+	if lastID == 0 {
+		_, ch, cancel := b.SubscribeLatest(buffer)
+		return nil, ch, cancel
+	}
+	return b.resume(lastID, buffer)
+}
+
+// resume is Subscribe replaying every retained event after lastID, for
+// subscribers that started out at 0
+func (b *Bus) resume(lastID uint64, buffer int) ([]Event, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var backlog []Event
+	for i := range b.history {
+		event := b.history[(b.next+i)%len(b.history)]
+		if event.ID > lastID {
+			backlog = append(backlog, event)
+		}
+	}
+
+	ch, cancel := b.subscribe(buffer)
+	return backlog, ch, cancel
+}
+
+// SubscribeLatest is Subscribe without a backlog. It also returns the ID of
+// the last event published before the subscription, so a subscriber can
+// tell from the first event whether it missed any.
+func (b *Bus) SubscribeLatest(buffer int) (uint64, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch, cancel := b.subscribe(buffer)
+	return b.lastID, ch, cancel
+}
+
+// subscribe adds a subscriber channel; the caller holds b.mu
+func (b *Bus) subscribe(buffer int) (chan Event, func()) {
+	ch := make(chan Event, buffer)
+	b.subscribers[ch] = struct{}{}
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, exists := b.subscribers[ch]; exists {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, cancel
+}
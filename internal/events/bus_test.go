@@ -0,0 +1,75 @@
+package events
+
+import "testing"
+
+func TestBus_PublishSubscribe(t *testing.T) {
+	bus := NewBus(4)
+
+	backlog, events, cancel := bus.Subscribe(0, 8)
+	defer cancel()
+	if len(backlog) != 0 {
+		t.Fatalf("expected no backlog for a new subscriber, got %d events", len(backlog))
+	}
+
+	bus.Publish(Event{Type: TypeStatusChanged, Kind: KindTool, Name: "echo", From: "active", To: "error", Reason: "probe failed"})
+
+	event := <-events
+	if event.ID != 1 {
+		t.Errorf("expected event ID 1, got %d", event.ID)
+	}
+	if event.Timestamp.IsZero() {
+		t.Error("expected the bus to timestamp the event")
+	}
+	if event.From != "active" || event.To != "error" || event.Reason != "probe failed" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestBus_ReplaysHistoryAfterLastID(t *testing.T) {
+	bus := NewBus(3)
+	for i := 0; i < 5; i++ {
+		bus.Publish(Event{Type: TypeRegistered, Kind: KindResource, Name: "file:///data.txt"})
+	}
+
+	// Only the last three events are retained
+	backlog, _, cancel := bus.Subscribe(1, 1)
+	defer cancel()
+	if len(backlog) != 3 {
+		t.Fatalf("expected 3 retained events, got %d", len(backlog))
+	}
+	for i, event := range backlog {
+		if want := uint64(i + 3); event.ID != want {
+			t.Errorf("backlog[%d]: expected ID %d, got %d", i, want, event.ID)
+		}
+	}
+
+	backlog, _, cancel = bus.Subscribe(4, 1)
+	defer cancel()
+	if len(backlog) != 1 || backlog[0].ID != 5 {
+		t.Errorf("expected only event 5 after ID 4, got %+v", backlog)
+	}
+}
+
+func TestBus_ClosesLaggingSubscriber(t *testing.T) {
+	bus := NewBus(DefaultHistory)
+	_, events, cancel := bus.Subscribe(0, 1)
+	defer cancel()
+
+	bus.Publish(Event{Type: TypeRegistered, Kind: KindTool, Name: "a"})
+	bus.Publish(Event{Type: TypeRegistered, Kind: KindTool, Name: "b"})
+
+	if event := <-events; event.Name != "a" {
+		t.Errorf("expected the buffered event first, got %+v", event)
+	}
+	if _, ok := <-events; ok {
+		t.Error("expected the lagging subscriber's channel to be closed")
+	}
+
+	// Cancelling a closed subscription is harmless
+	cancel()
+}
+
+func TestBus_NilPublish(t *testing.T) {
+	var bus *Bus
+	bus.Publish(Event{Type: TypeRegistered, Kind: KindTool, Name: "echo"})
+}
@@ -0,0 +1,301 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+)
+
+const (
+	// webhookQueueSize bounds the events waiting for delivery to one webhook
+	webhookQueueSize = 256
+	// dispatcherBuffer is the dispatcher's bus subscription buffer
+	dispatcherBuffer = 1024
+)
+
+// Headers set on webhook deliveries. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the webhook secret, so receivers can
+// reject both forged and replayed deliveries.
+const (
+	HeaderEvent     = "X-MCP-Event"
+	HeaderDelivery  = "X-MCP-Delivery"
+	HeaderTimestamp = "X-MCP-Timestamp"
+	HeaderSignature = "X-MCP-Signature"
+)
+
+// Sign computes the signature header value for a webhook delivery
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher delivers bus events to the configured webhooks. Each webhook
+// has its own queue so a slow or failing endpoint does not hold up others.
+type Dispatcher struct {
+	bus      *Bus
+	webhooks []*webhook
+	logger   *logger.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// missed counts events that left the bus history before the dispatcher
+	// could copy them, and events dropped from a full webhook queue
+	missed atomic.Uint64
+}
+
+type webhook struct {
+	config config.WebhookConfig
+	secret string
+	types  map[Type]bool // nil delivers every type
+	queue  chan Event
+	client *http.Client
+}
+
+// NewDispatcher creates a dispatcher for the webhooks; it returns nil when
+// none are configured
+func NewDispatcher(webhooks []config.WebhookConfig, bus *Bus, log *logger.Logger) *Dispatcher {
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	d := &Dispatcher{bus: bus, logger: log}
+	for _, cfg := range webhooks {
+		hook := &webhook{
+			config: cfg,
+			secret: os.ExpandEnv(cfg.Secret),
+			queue:  make(chan Event, webhookQueueSize),
+			client: &http.Client{Timeout: cfg.Timeout},
+		}
+		if len(cfg.Events) > 0 {
+			hook.types = make(map[Type]bool, len(cfg.Events))
+			for _, eventType := range cfg.Events {
+				hook.types[Type(eventType)] = true
+			}
+		}
+		d.webhooks = append(d.webhooks, hook)
+	}
+	return d
+}
+
+// Start begins delivering events published from now on
+func (d *Dispatcher) Start() {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+
+	// Subscribe before returning so no event published after Start is missed
+	lastID, events, unsubscribe := d.bus.SubscribeLatest(dispatcherBuffer)
+
+	d.wg.Add(1 + len(d.webhooks))
+	go d.fanOut(ctx, lastID, events, unsubscribe)
+	for _, hook := range d.webhooks {
+		go d.deliverAll(ctx, hook)
+	}
+
+	d.logger.Info("webhook dispatcher started", "webhooks", len(d.webhooks))
+}
+
+// Stop abandons queued deliveries and waits for the dispatcher to exit
+func (d *Dispatcher) Stop() {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	cancel := d.cancel
+	d.cancel = nil
+	d.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	d.wg.Wait()
+
+	d.logger.Info("webhook dispatcher stopped")
+}
+
+// fanOut copies bus events onto the webhook queues, starting after lastID.
+// When the dispatcher falls behind the bus it resubscribes from the last
+// event it saw; events the history no longer holds are reported as missed.
+func (d *Dispatcher) fanOut(ctx context.Context, lastID uint64, events <-chan Event, cancel func()) {
+	defer d.wg.Done()
+	defer func() { cancel() }()
+
+	var backlog []Event
+	for {
+		for _, event := range backlog {
+			if event.ID > lastID+1 {
+				d.reportGap(lastID+1, event.ID-1)
+			}
+			lastID = event.ID
+			d.enqueue(event)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				d.logger.Warn("webhook dispatcher fell behind, resuming from history", "last_event_id", lastID)
+				backlog, events, cancel = d.bus.resume(lastID, dispatcherBuffer)
+				continue
+			}
+			backlog = []Event{event}
+		}
+	}
+}
+
+// reportGap records events from first to last that were never delivered
+func (d *Dispatcher) reportGap(first, last uint64) {
+	count := last - first + 1
+	d.missed.Add(count)
+	d.logger.Error("webhook dispatcher missed events",
+		"first_event_id", first,
+		"last_event_id", last,
+		"missed", count,
+	)
+}
+
+// Missed returns how many events were never delivered to the webhooks,
+// because the dispatcher fell further behind than the bus history reaches
+// or a webhook's queue was full. A drop from one queue counts once per
+// webhook.
+func (d *Dispatcher) Missed() uint64 {
+	if d == nil {
+		return 0
+	}
+	return d.missed.Load()
+}
+
+func (d *Dispatcher) enqueue(event Event) {
+	for _, hook := range d.webhooks {
+		if hook.types != nil && !hook.types[event.Type] {
+			continue
+		}
+		select {
+		case hook.queue <- event:
+		default:
+			d.missed.Add(1)
+			d.logger.Error("webhook queue full, event missed",
+				"webhook", hook.config.Name,
+				"event_id", event.ID,
+				"event_type", string(event.Type),
+			)
+		}
+	}
+}
+
+func (d *Dispatcher) deliverAll(ctx context.Context, hook *webhook) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-hook.queue:
+			d.deliver(ctx, hook, event)
+		}
+	}
+}
+
+// deliver posts an event, retrying failed attempts with exponential backoff.
+// Client errors other than 408 and 429 are not retried.
+func (d *Dispatcher) deliver(ctx context.Context, hook *webhook, event Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.logger.Error("failed to encode webhook event", "event_id", event.ID, "error", err)
+		return
+	}
+
+	backoff := hook.config.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := hook.post(ctx, event, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= hook.config.MaxRetries || ctx.Err() != nil {
+			d.logger.Error("webhook delivery failed",
+				"webhook", hook.config.Name,
+				"event_id", event.ID,
+				"event_type", string(event.Type),
+				"attempts", attempt+1,
+				"error", err,
+			)
+			return
+		}
+
+		d.logger.Warn("webhook delivery failed, retrying",
+			"webhook", hook.config.Name,
+			"event_id", event.ID,
+			"attempt", attempt+1,
+			"retry_in", backoff,
+			"error", err,
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends one delivery attempt and reports whether a failure is worth
+// retrying
+func (h *webhook) post(ctx context.Context, event Event, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(event.Type))
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(event.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(h.secret, timestamp, body))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded %s", resp.Status)
+	default:
+		return false, fmt.Errorf("webhook responded %s", resp.Status)
+	}
+}
@@ -0,0 +1,201 @@
+package events
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+)
+
+func createTestLogger() *logger.Logger {
+	log, _ := logger.New(logger.Config{Level: "error", Format: "console", Service: "test", Version: "test"})
+	return log
+}
+
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookReceiver records deliveries, failing the first failures of them
+type webhookReceiver struct {
+	mu         sync.Mutex
+	failures   int
+	deliveries []delivery
+	received   chan struct{}
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	if rcv.failures > 0 {
+		rcv.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	rcv.deliveries = append(rcv.deliveries, delivery{header: r.Header.Clone(), body: body})
+	rcv.received <- struct{}{}
+}
+
+func webhookConfig(url string, eventTypes ...string) config.WebhookConfig {
+	return config.WebhookConfig{
+		Name:         "test",
+		URL:          url,
+		Secret:       "s3cret",
+		Events:       eventTypes,
+		Timeout:      time.Second,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	}
+}
+
+func TestDispatcher_DeliversSignedEvents(t *testing.T) {
+	receiver := &webhookReceiver{failures: 1, received: make(chan struct{}, 4)}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	bus := NewBus(DefaultHistory)
+	dispatcher := NewDispatcher([]config.WebhookConfig{webhookConfig(server.URL)}, bus, createTestLogger())
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	bus.Publish(Event{Type: TypeStatusChanged, Kind: KindTool, Name: "echo", From: "active", To: "error"})
+
+	select {
+	case <-receiver.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered after a failed attempt")
+	}
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	got := receiver.deliveries[0]
+
+	if got.header.Get(HeaderEvent) != string(TypeStatusChanged) {
+		t.Errorf("expected event header %q, got %q", TypeStatusChanged, got.header.Get(HeaderEvent))
+	}
+	if got.header.Get(HeaderDelivery) != "1" {
+		t.Errorf("expected delivery header 1, got %q", got.header.Get(HeaderDelivery))
+	}
+	timestamp, err := strconv.ParseInt(got.header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid timestamp header: %v", err)
+	}
+	if want := Sign("s3cret", timestamp, got.body); got.header.Get(HeaderSignature) != want {
+		t.Errorf("signature mismatch: got %q, want %q", got.header.Get(HeaderSignature), want)
+	}
+}
+
+func TestDispatcher_FiltersEventTypes(t *testing.T) {
+	receiver := &webhookReceiver{received: make(chan struct{}, 4)}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	bus := NewBus(DefaultHistory)
+	dispatcher := NewDispatcher([]config.WebhookConfig{webhookConfig(server.URL, string(TypeCircuitBreaker))}, bus, createTestLogger())
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	bus.Publish(Event{Type: TypeRegistered, Kind: KindTool, Name: "echo"})
+	bus.Publish(Event{Type: TypeCircuitBreaker, Kind: KindTool, Name: "echo", From: "closed", To: "open"})
+
+	select {
+	case <-receiver.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	if len(receiver.deliveries) != 1 || receiver.deliveries[0].header.Get(HeaderEvent) != string(TypeCircuitBreaker) {
+		t.Errorf("expected only the circuit breaker event, got %d deliveries", len(receiver.deliveries))
+	}
+}
+
+func TestDispatcher_ReportsMissedEvents(t *testing.T) {
+	bus := NewBus(4)
+	bus.Publish(Event{Type: TypeRegistered, Kind: KindTool, Name: "before"})
+	bus.Publish(Event{Type: TypeRegistered, Kind: KindTool, Name: "before"})
+	dispatcher := NewDispatcher([]config.WebhookConfig{webhookConfig("http://127.0.0.1:1")}, bus, createTestLogger())
+
+	// A one event buffer overflows at event 4; the history of four then
+	// only holds events 9 to 12
+	lastID, events, unsubscribe := bus.SubscribeLatest(1)
+	for i := 0; i < 10; i++ {
+		bus.Publish(Event{Type: TypeRegistered, Kind: KindTool, Name: "echo"})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dispatcher.wg.Add(1)
+	go dispatcher.fanOut(ctx, lastID, events, unsubscribe)
+
+	var delivered []uint64
+	for len(delivered) < 5 {
+		select {
+		case event := <-dispatcher.webhooks[0].queue:
+			delivered = append(delivered, event.ID)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after events %v", delivered)
+		}
+	}
+	cancel()
+	dispatcher.wg.Wait()
+
+	if want := []uint64{3, 9, 10, 11, 12}; !slices.Equal(delivered, want) {
+		t.Errorf("expected events %v, got %v", want, delivered)
+	}
+	if missed := dispatcher.Missed(); missed != 5 {
+		t.Errorf("expected events 4 to 8 to be reported missed, got %d", missed)
+	}
+}
+
+func TestDispatcher_ReportsQueueOverflow(t *testing.T) {
+	bus := NewBus(DefaultHistory)
+	dispatcher := NewDispatcher([]config.WebhookConfig{webhookConfig("http://127.0.0.1:1")}, bus, createTestLogger())
+
+	// Nothing drains the queue while the dispatcher is not started
+	for id := uint64(1); id <= webhookQueueSize+3; id++ {
+		dispatcher.enqueue(Event{ID: id, Type: TypeRegistered, Kind: KindTool, Name: "echo"})
+	}
+
+	if queued := len(dispatcher.webhooks[0].queue); queued != webhookQueueSize {
+		t.Errorf("expected a full queue of %d events, got %d", webhookQueueSize, queued)
+	}
+	if missed := dispatcher.Missed(); missed != 3 {
+		t.Errorf("expected the 3 events beyond the queue reported missed, got %d", missed)
+	}
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	signature := Sign("secret", 1700000000, body)
+
+	if signature != Sign("secret", 1700000000, body) {
+		t.Error("expected signatures to be deterministic")
+	}
+	if signature == Sign("other", 1700000000, body) {
+		t.Error("expected the secret to change the signature")
+	}
+	if signature == Sign("secret", 1700000001, body) {
+		t.Error("expected the timestamp to change the signature")
+	}
+}
+
+func TestNewDispatcher_NoWebhooks(t *testing.T) {
+	dispatcher := NewDispatcher(nil, NewBus(DefaultHistory), createTestLogger())
+	if dispatcher != nil {
+		t.Fatal("expected no dispatcher without webhooks")
+	}
+	dispatcher.Start()
+	dispatcher.Stop()
+}
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-server/internal/ratelimit"
+)
+
+// Caller identifies the client issuing a request, for per-caller policies
+// such as rate limiting. The session comes from the transport, never from
+// what the client says about itself.
+type Caller struct {
+	SessionID string
+}
+
+type callerKey struct{}
+
+// WithCaller stores the caller in the context, overriding the session
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller stored by WithCaller, or else the
+// mcp-go client session handling the request. Fields are empty when unknown.
+func CallerFromContext(ctx context.Context) Caller {
+	if caller, ok := ctx.Value(callerKey{}).(Caller); ok {
+		return caller
+	}
+
+	var caller Caller
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		caller.SessionID = session.SessionID()
+	}
+	return caller
+}
+
+// CallerLimiter holds the per-session budget that every tool call and
+// resource read of a caller draws from, whichever tool or resource it
+// targets. A nil CallerLimiter limits nothing.
+type CallerLimiter struct {
+	session *ratelimit.Limiter
+}
+
+// NewCallerLimiter creates the session budget
+func NewCallerLimiter(session ratelimit.Limit) *CallerLimiter {
+	return &CallerLimiter{
+		session: ratelimit.NewLimiter("session", session),
+	}
+}
+
+// Checks returns the buckets a call from caller draws from, to be passed to
+// ratelimit.AllowAll ahead of any bucket of the target. Unknown identities
+// skip their scope.
+func (l *CallerLimiter) Checks(caller Caller) []ratelimit.Check {
+	if l == nil {
+		return nil
+	}
+	var checks []ratelimit.Check
+	if caller.SessionID != "" {
+		checks = append(checks, ratelimit.Check{Limiter: l.session, Key: caller.SessionID})
+	}
+	return checks
+}
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass marks the call as one that must neither be served from nor
+// stored in a result cache
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+// CacheBypassFromContext reports whether the caller asked to skip caching
+func CacheBypassFromContext(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
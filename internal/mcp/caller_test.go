@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-server/internal/ratelimit"
+)
+
+type testSession struct {
+	id   string
+	info mcp.Implementation
+}
+
+func (s *testSession) Initialize()                                         {}
+func (s *testSession) Initialized() bool                                   { return true }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s *testSession) SessionID() string                                   { return s.id }
+func (s *testSession) GetClientInfo() mcp.Implementation                   { return s.info }
+func (s *testSession) SetClientInfo(info mcp.Implementation)               { s.info = info }
+
+func TestCallerFromContext(t *testing.T) {
+	if caller := CallerFromContext(context.Background()); caller != (Caller{}) {
+		t.Errorf("CallerFromContext() without session = %+v, want empty", caller)
+	}
+
+	session := &testSession{id: "session-1", info: mcp.Implementation{Name: "agent"}}
+	ctx := server.NewMCPServer("test", "1.0.0").WithContext(context.Background(), session)
+
+	// The self-reported client name is not an identity
+	caller := CallerFromContext(ctx)
+	if caller != (Caller{SessionID: "session-1"}) {
+		t.Errorf("CallerFromContext() = %+v, want session-1 without client", caller)
+	}
+
+	explicit := Caller{SessionID: "override"}
+	if caller := CallerFromContext(WithCaller(ctx, explicit)); caller != explicit {
+		t.Errorf("CallerFromContext() = %+v, want explicit caller", caller)
+	}
+}
+
+func TestNewToolErrorResult_RetryAfter(t *testing.T) {
+	throttled := fmt.Errorf("tool echo: %w", &ratelimit.LimitError{Scope: "session", RetryAfter: 1500 * time.Millisecond})
+	result := NewToolErrorResult(throttled.Error(), throttled)
+	if !result.IsError {
+		t.Fatal("expected error result")
+	}
+	if got := result.Meta[RetryAfterMetaKey]; got != int64(1500) {
+		t.Errorf("expected retry hint 1500, got %v", got)
+	}
+
+	plain := NewToolErrorResult("boom", errors.New("boom"))
+	if plain.Meta != nil {
+		t.Errorf("expected no meta for ordinary errors, got %v", plain.Meta)
+	}
+}
+
+func TestWithCallMeta_CacheBypass(t *testing.T) {
+	ctx := context.Background()
+	if CacheBypassFromContext(WithCallMeta(ctx, nil)) {
+		t.Error("expected no bypass without _meta")
+	}
+
+	meta := &mcp.Meta{AdditionalFields: map[string]any{NoCacheMetaKey: false}}
+	if CacheBypassFromContext(WithCallMeta(ctx, meta)) {
+		t.Error("expected no bypass when noCache is false")
+	}
+
+	meta.AdditionalFields[NoCacheMetaKey] = true
+	if !CacheBypassFromContext(WithCallMeta(ctx, meta)) {
+		t.Error("expected bypass when noCache is true")
+	}
+}
@@ -67,3 +67,30 @@ func (r *ResourceContentImpl) GetContent() []Content {
 func (r *ResourceContentImpl) GetMimeType() string {
 	return r.MimeType
 }
+
+// warnedResult carries advisory warnings alongside a tool result
+type warnedResult struct {
+	ToolResult
+	warnings []string
+}
+
+// WithWarnings attaches advisory warnings, such as deprecation notices, to a
+// result. They reach the client in the _meta of the tool call response.
+func WithWarnings(result ToolResult, warnings ...string) ToolResult {
+	if result == nil || len(warnings) == 0 {
+		return result
+	}
+	if warned, ok := result.(*warnedResult); ok {
+		combined := append(append([]string(nil), warned.warnings...), warnings...)
+		return &warnedResult{ToolResult: warned.ToolResult, warnings: combined}
+	}
+	return &warnedResult{ToolResult: result, warnings: warnings}
+}
+
+// ResultWarnings returns the warnings attached to a result by WithWarnings
+func ResultWarnings(result ToolResult) []string {
+	if warned, ok := result.(*warnedResult); ok {
+		return warned.warnings
+	}
+	return nil
+}
@@ -276,3 +276,23 @@ func BenchmarkToolResultCreation(b *testing.B) {
 		_ = &ToolResultImpl{Content: []Content{content}, IsErrorFlag: false}
 	}
 }
+
+func TestWithWarnings(t *testing.T) {
+	result := &ToolResultImpl{Content: []Content{&TextContent{Text: "ok"}}}
+
+	if got := WithWarnings(result); got != result {
+		t.Error("expected result without warnings to be returned unchanged")
+	}
+	if warnings := ResultWarnings(result); warnings != nil {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+
+	warned := WithWarnings(WithWarnings(result, "first"), "second")
+	warnings := ResultWarnings(warned)
+	if len(warnings) != 2 || warnings[0] != "first" || warnings[1] != "second" {
+		t.Errorf("expected warnings to accumulate, got %v", warnings)
+	}
+	if warned.IsError() || len(warned.GetContent()) != 1 || warned.GetContent()[0].GetText() != "ok" {
+		t.Error("expected warned result to keep the original content")
+	}
+}
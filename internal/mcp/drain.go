@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrServerDraining is the error of tool calls and resource reads refused or
+// cancelled because the server is shutting down
+var ErrServerDraining = errors.New("server is shutting down")
+
+const (
+	// drainProgressInterval is how often a drain logs the work still running
+	drainProgressInterval = time.Second
+	// drainCancelGrace lets cancelled calls send their error responses before
+	// the transport is closed
+	drainCancelGrace = time.Second
+)
+
+// inflight tracks the running tool calls and resource reads so shutdown can
+// wait for them, and cancel them once it runs out of patience
+type inflight struct {
+	mu       sync.Mutex
+	draining bool
+	nextID   uint64
+	calls    map[uint64]context.CancelCauseFunc
+	idle     chan struct{} // closed once draining with no calls left
+	drained  bool
+}
+
+// begin admits a call, returning its context and the function to call when
+// it completes. Calls are refused once draining has started.
+func (f *inflight) begin(ctx context.Context) (context.Context, func(), error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.draining {
+		return ctx, nil, ErrServerDraining
+	}
+	if f.calls == nil {
+		f.calls = make(map[uint64]context.CancelCauseFunc)
+	}
+
+	f.nextID++
+	id := f.nextID
+	ctx, cancel := context.WithCancelCause(ctx)
+	f.calls[id] = cancel
+
+	return ctx, func() {
+		cancel(nil)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.calls, id)
+		f.closeIfDrained()
+	}, nil
+}
+
+// startDrain refuses new calls and returns a channel closed once the calls
+// in flight complete
+func (f *inflight) startDrain() (<-chan struct{}, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.draining {
+		f.draining = true
+		f.idle = make(chan struct{})
+		f.closeIfDrained()
+	}
+	return f.idle, len(f.calls)
+}
+
+// closeIfDrained signals a drain once no calls are left; the caller must
+// hold f.mu
+func (f *inflight) closeIfDrained() {
+	if f.draining && !f.drained && len(f.calls) == 0 {
+		f.drained = true
+		close(f.idle)
+	}
+}
+
+func (f *inflight) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+// cancelAll cancels the calls in flight and returns how many there were
+func (f *inflight) cancelAll() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, cancel := range f.calls {
+		cancel(ErrServerDraining)
+	}
+	return len(f.calls)
+}
+
+// drainError explains a call failure caused by the drain cancelling it, and
+// returns other errors unchanged
+func drainError(ctx context.Context, operation string, err error) error {
+	if cause := context.Cause(ctx); errors.Is(cause, ErrServerDraining) {
+		return fmt.Errorf("%s cancelled: %w", operation, cause)
+	}
+	return err
+}
+
+// Drain stops admitting tool calls and resource reads, and waits for those
+// in flight until ctx is done. The calls still running then are cancelled
+// and answered with ErrServerDraining.
+func (s *Server) Drain(ctx context.Context) error {
+	return s.pipeline.Drain(ctx)
+}
+
+// Drain implements Server.Drain for every call served through the pipeline
+func (p *Pipeline) Drain(ctx context.Context) error {
+	idle, running := p.inflight.startDrain()
+	p.logger.Info("draining in-flight MCP requests", "in_flight", running)
+
+	ticker := time.NewTicker(drainProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-idle:
+			p.logger.Info("MCP requests drained")
+			return nil
+		case <-ticker.C:
+			p.logger.Info("waiting for in-flight MCP requests", "in_flight", p.inflight.count())
+		case <-ctx.Done():
+			cancelled := p.inflight.cancelAll()
+			p.logger.Warn("drain timed out, cancelling in-flight MCP requests", "cancelled", cancelled)
+
+			select {
+			case <-idle:
+			case <-time.After(drainCancelGrace):
+				p.logger.Warn("in-flight MCP requests ignored cancellation", "in_flight", p.inflight.count())
+			}
+			return fmt.Errorf("drain timed out, cancelled %d in-flight requests: %w", cancelled, ctx.Err())
+		}
+	}
+}
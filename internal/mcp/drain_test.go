@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func newDrainTestServer(t *testing.T) *Server {
+	return NewServer(Implementation{Name: "test-server", Version: "1.0.0"}, nil, createTestLogger(t)).(*Server)
+}
+
+func callTool(adapter func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)) *mcp.CallToolResult {
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "slow"
+	result, _ := adapter(context.Background(), req)
+	return result
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	if result == nil || len(result.Content) == 0 {
+		return ""
+	}
+	if text, ok := result.Content[0].(mcp.TextContent); ok {
+		return text.Text
+	}
+	return ""
+}
+
+func waitForInFlight(t *testing.T, server *Server, want int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for server.pipeline.inflight.count() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d calls in flight, got %d", want, server.pipeline.inflight.count())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestServer_DrainWaitsForInFlightCalls(t *testing.T) {
+	server := newDrainTestServer(t)
+	release := make(chan struct{})
+	adapter := server.pipeline.ToolHandler("slow", &mockToolHandler{
+		handleFunc: func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+			<-release
+			return &ToolResultImpl{Content: []Content{&TextContent{Text: "done"}}}, nil
+		},
+	})
+
+	results := make(chan *mcp.CallToolResult, 1)
+	go func() { results <- callTool(adapter) }()
+	waitForInFlight(t, server, 1)
+
+	drained := make(chan error, 1)
+	go func() { drained <- server.Drain(context.Background()) }()
+
+	// New calls are refused once the drain starts
+	waitForDraining(t, server)
+	refused := callTool(adapter)
+	if !refused.IsError || !strings.Contains(resultText(refused), ErrServerDraining.Error()) {
+		t.Errorf("expected the new call to be refused, got %+v", refused)
+	}
+
+	select {
+	case err := <-drained:
+		t.Fatalf("drain returned before the call completed: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	if result := <-results; result.IsError || resultText(result) != "done" {
+		t.Errorf("expected the in-flight call to complete, got %+v", result)
+	}
+	if err := <-drained; err != nil {
+		t.Errorf("expected a clean drain, got %v", err)
+	}
+}
+
+func TestServer_DrainCancelsCallsAfterTimeout(t *testing.T) {
+	server := newDrainTestServer(t)
+	adapter := server.pipeline.ToolHandler("slow", &mockToolHandler{
+		handleFunc: func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+
+	results := make(chan *mcp.CallToolResult, 1)
+	go func() { results <- callTool(adapter) }()
+	waitForInFlight(t, server, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := server.Drain(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the drain to time out, got %v", err)
+	}
+
+	result := <-results
+	if !result.IsError || resultText(result) != "tool call cancelled: server is shutting down" {
+		t.Errorf("expected a cancellation error result, got %q", resultText(result))
+	}
+}
+
+func TestServer_DrainRefusesResourceReads(t *testing.T) {
+	server := newDrainTestServer(t)
+	adapter := server.pipeline.ResourceHandler(&mockResourceHandler{})
+
+	if err := server.Drain(context.Background()); err != nil {
+		t.Fatalf("expected an idle server to drain at once, got %v", err)
+	}
+
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = "file://test.txt"
+	if _, err := adapter(context.Background(), req); !errors.Is(err, ErrServerDraining) {
+		t.Errorf("expected ErrServerDraining, got %v", err)
+	}
+}
+
+func waitForDraining(t *testing.T, server *Server) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		server.pipeline.inflight.mu.Lock()
+		draining := server.pipeline.inflight.draining
+		server.pipeline.inflight.mu.Unlock()
+		if draining {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("drain did not start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -14,8 +14,20 @@ type MCPServer interface {
 	AddTool(tool Tool) error
 	AddResource(resource Resource) error
 
+	// Tool middleware
+	Use(middlewares ...ToolMiddleware)
+	UseForTool(name string, middlewares ...ToolMiddleware)
+
 	// Server information
 	GetImplementation() Implementation
+
+	// Drain refuses new tool calls and resource reads and waits for those in
+	// flight, cancelling the rest once ctx is done
+	Drain(ctx context.Context) error
+
+	// Pipeline serves the tool calls and resource reads of the server; the
+	// library adapters publishing tools share it
+	Pipeline() *Pipeline
 }
 
 type Tool interface {
@@ -52,6 +64,37 @@ type ResourceContent interface {
 	GetMimeType() string
 }
 
+// Prompt is a prompt template clients can list and render with arguments
+type Prompt interface {
+	Name() string
+	Description() string
+	Arguments() []PromptArgument
+	Handler() PromptHandler
+}
+
+type PromptArgument struct {
+	Name        string
+	Description string
+	Required    bool
+}
+
+type PromptHandler interface {
+	Get(ctx context.Context, arguments map[string]string) (*PromptResult, error)
+}
+
+type PromptResult struct {
+	Description string
+	Messages    []PromptMessage
+}
+
+// PromptMessage is one message of a rendered prompt. Role is "user" or
+// "assistant"; blob content is sent as an image or audio by its detected
+// media type.
+type PromptMessage struct {
+	Role    string
+	Content Content
+}
+
 type Transport interface {
 	Read() ([]byte, error)
 	Write(data []byte) error
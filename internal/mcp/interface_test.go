@@ -43,6 +43,18 @@ func (m *MockMCPServer) GetImplementation() Implementation {
 	return m.impl
 }
 
+func (m *MockMCPServer) Use(middlewares ...ToolMiddleware) {}
+
+func (m *MockMCPServer) UseForTool(name string, middlewares ...ToolMiddleware) {}
+
+func (m *MockMCPServer) Drain(ctx context.Context) error {
+	return nil
+}
+
+func (m *MockMCPServer) Pipeline() *Pipeline {
+	return nil
+}
+
 type MockTool struct {
 	name        string
 	description string
@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"mcp-server/internal/logger"
+)
+
+// ToolMiddleware wraps a tool handler with additional behavior
+type ToolMiddleware func(next ToolHandler) ToolHandler
+
+// ToolHandlerFunc adapts a plain function to the ToolHandler interface
+type ToolHandlerFunc func(ctx context.Context, params json.RawMessage) (ToolResult, error)
+
+func (f ToolHandlerFunc) Handle(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+	return f(ctx, params)
+}
+
+// ChainToolMiddleware applies middlewares to a handler; the first middleware is the outermost
+func ChainToolMiddleware(handler ToolHandler, middlewares ...ToolMiddleware) ToolHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			handler = middlewares[i](handler)
+		}
+	}
+	return handler
+}
+
+type toolNameKey struct{}
+type redactedArgumentsKey struct{}
+
+// WithToolName stores the name of the executing tool in the context
+func WithToolName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, toolNameKey{}, name)
+}
+
+// ToolNameFromContext returns the name of the executing tool, if known
+func ToolNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(toolNameKey{}).(string)
+	return name
+}
+
+// RedactedArgumentsFromContext returns the redacted view of the tool arguments
+// stored by RedactionMiddleware, if any
+func RedactedArgumentsFromContext(ctx context.Context) (json.RawMessage, bool) {
+	args, ok := ctx.Value(redactedArgumentsKey{}).(json.RawMessage)
+	return args, ok
+}
+
+// NewErrorResult builds an error tool result from an error
+func NewErrorResult(err error) ToolResult {
+	return &ToolResultImpl{Error: err, IsErrorFlag: true}
+}
+
+// LoggingMiddleware logs the start, outcome and duration of every tool call.
+// Arguments are only logged when RedactionMiddleware runs before it.
+func LoggingMiddleware(log *logger.Logger) ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		return ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+			name := ToolNameFromContext(ctx)
+			start := time.Now()
+
+			if args, ok := RedactedArgumentsFromContext(ctx); ok {
+				log.Debug("tool call started", "tool", name, "arguments", string(args))
+			} else {
+				log.Debug("tool call started", "tool", name)
+			}
+
+			result, err := next.Handle(ctx, params)
+			duration := time.Since(start)
+
+			switch {
+			case err != nil:
+				log.Error("tool call failed", "tool", name, "duration_ms", duration.Milliseconds(), "error", err)
+			case result != nil && result.IsError():
+				log.Warn("tool call returned error result", "tool", name, "duration_ms", duration.Milliseconds(), "error", result.GetError())
+			default:
+				log.Info("tool call completed", "tool", name, "duration_ms", duration.Milliseconds())
+			}
+
+			return result, err
+		})
+	}
+}
+
+// RecoveryMiddleware converts a panicking handler into an error result
+func RecoveryMiddleware(log *logger.Logger) ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		return ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (result ToolResult, err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					log.Error("tool handler panicked",
+						"tool", ToolNameFromContext(ctx),
+						"panic", fmt.Sprintf("%v", recovered),
+						"stack", string(debug.Stack()),
+					)
+					result = NewErrorResult(fmt.Errorf("tool execution panicked: %v", recovered))
+					err = nil
+				}
+			}()
+			return next.Handle(ctx, params)
+		})
+	}
+}
+
+// TimeoutMiddleware bounds the execution time of a tool call. Handlers that
+// ignore context cancellation keep running in the background, but the caller
+// receives an error result once the timeout elapses. The handler runs on its
+// own goroutine, out of reach of RecoveryMiddleware, so a panic is recovered
+// there and returned as an error result.
+func TimeoutMiddleware(timeout time.Duration) ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		if timeout <= 0 {
+			return next
+		}
+		return ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			type outcome struct {
+				result ToolResult
+				err    error
+			}
+			done := make(chan outcome, 1)
+
+			go func() {
+				defer func() {
+					if recovered := recover(); recovered != nil {
+						done <- outcome{result: NewErrorResult(fmt.Errorf("tool execution panicked: %v", recovered))}
+					}
+				}()
+				result, err := next.Handle(ctx, params)
+				done <- outcome{result: result, err: err}
+			}()
+
+			select {
+			case out := <-done:
+				return out.result, out.err
+			case <-ctx.Done():
+				return NewErrorResult(fmt.Errorf("tool %s timed out after %v", ToolNameFromContext(ctx), timeout)), nil
+			}
+		})
+	}
+}
+
+// RedactionPlaceholder replaces the value of redacted arguments
+const RedactionPlaceholder = "[REDACTED]"
+
+// RedactionMiddleware stores a copy of the arguments with the given keys
+// replaced by RedactionPlaceholder in the context, for use by downstream
+// logging and auditing middlewares. The handler still receives the original
+// arguments.
+func RedactionMiddleware(keys ...string) ToolMiddleware {
+	redacted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		redacted[key] = true
+	}
+
+	return func(next ToolHandler) ToolHandler {
+		return ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+			ctx = context.WithValue(ctx, redactedArgumentsKey{}, redactArguments(params, redacted))
+			return next.Handle(ctx, params)
+		})
+	}
+}
+
+func redactArguments(params json.RawMessage, keys map[string]bool) json.RawMessage {
+	if len(params) == 0 {
+		return json.RawMessage("{}")
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(params, &value); err != nil {
+		return json.RawMessage(fmt.Sprintf("%q", RedactionPlaceholder))
+	}
+
+	data, err := json.Marshal(redactValue(value, keys))
+	if err != nil {
+		return json.RawMessage(fmt.Sprintf("%q", RedactionPlaceholder))
+	}
+	return data
+}
+
+func redactValue(value interface{}, keys map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if keys[key] {
+				v[key] = RedactionPlaceholder
+			} else {
+				v[key] = redactValue(nested, keys)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested, keys)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,197 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-server/internal/config"
+)
+
+func TestChainToolMiddleware_Order(t *testing.T) {
+	var order []string
+	tag := func(name string) ToolMiddleware {
+		return func(next ToolHandler) ToolHandler {
+			return ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+				order = append(order, name)
+				return next.Handle(ctx, params)
+			})
+		}
+	}
+
+	handler := ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+		order = append(order, "handler")
+		return &ToolResultImpl{}, nil
+	})
+
+	chained := ChainToolMiddleware(handler, tag("first"), nil, tag("second"))
+	if _, err := chained.Handle(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "first,second,handler"
+	if got := strings.Join(order, ","); got != expected {
+		t.Errorf("expected order %s, got %s", expected, got)
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	log := createTestLogger(t)
+	handler := ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+		panic("boom")
+	})
+
+	result, err := RecoveryMiddleware(log)(handler).Handle(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected panic to be converted to a result, got error: %v", err)
+	}
+	if result == nil || !result.IsError() {
+		t.Fatal("expected an error result")
+	}
+	if !strings.Contains(result.GetError().Error(), "boom") {
+		t.Errorf("expected panic value in error, got %v", result.GetError())
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	slow := ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	ctx := WithToolName(context.Background(), "slow")
+	result, err := TimeoutMiddleware(10*time.Millisecond)(slow).Handle(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || !result.IsError() {
+		t.Fatal("expected timeout error result")
+	}
+	if !strings.Contains(result.GetError().Error(), "timed out") {
+		t.Errorf("unexpected error message: %v", result.GetError())
+	}
+
+	fast := ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+		return &ToolResultImpl{Content: []Content{&TextContent{Text: "ok"}}}, nil
+	})
+	result, err = TimeoutMiddleware(time.Second)(fast).Handle(ctx, nil)
+	if err != nil || result.IsError() {
+		t.Fatalf("expected success, got result=%v err=%v", result, err)
+	}
+}
+
+func TestTimeoutMiddleware_RecoversPanic(t *testing.T) {
+	handler := ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+		panic("boom")
+	})
+
+	result, err := TimeoutMiddleware(time.Second)(handler).Handle(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected panic to be converted to a result, got error: %v", err)
+	}
+	if result == nil || !result.IsError() || !strings.Contains(result.GetError().Error(), "boom") {
+		t.Errorf("expected an error result carrying the panic, got %v", result)
+	}
+}
+
+func TestRedactionMiddleware(t *testing.T) {
+	var seenParams json.RawMessage
+	var redacted json.RawMessage
+
+	handler := ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+		seenParams = params
+		redacted, _ = RedactedArgumentsFromContext(ctx)
+		return &ToolResultImpl{}, nil
+	})
+
+	params := json.RawMessage(`{"user":"alice","password":"secret","nested":{"token":"abc"}}`)
+	RedactionMiddleware("password", "token")(handler).Handle(context.Background(), params)
+
+	if string(seenParams) != string(params) {
+		t.Errorf("handler should receive original arguments, got %s", seenParams)
+	}
+	if strings.Contains(string(redacted), "secret") || strings.Contains(string(redacted), "abc") {
+		t.Errorf("redacted arguments leak secrets: %s", redacted)
+	}
+	if !strings.Contains(string(redacted), "alice") {
+		t.Errorf("redacted arguments should keep other fields: %s", redacted)
+	}
+}
+
+func TestServerMiddlewareApplication(t *testing.T) {
+	impl := Implementation{Name: "test-server", Version: "1.0.0"}
+	server := NewServer(impl, (*config.Config)(nil), createTestLogger(t)).(*Server)
+
+	var applied []string
+	tag := func(name string) ToolMiddleware {
+		return func(next ToolHandler) ToolHandler {
+			return ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+				applied = append(applied, name+":"+ToolNameFromContext(ctx))
+				return next.Handle(ctx, params)
+			})
+		}
+	}
+
+	server.Use(tag("global"))
+	server.UseForTool("special", tag("special"))
+
+	special := server.pipeline.ToolHandler("special", &mockToolHandler{})
+	plain := server.pipeline.ToolHandler("plain", &mockToolHandler{})
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "special"
+	if _, err := special(context.Background(), req); err != nil {
+		t.Fatalf("adapter failed: %v", err)
+	}
+
+	req.Params.Name = "plain"
+	if _, err := plain(context.Background(), req); err != nil {
+		t.Fatalf("adapter failed: %v", err)
+	}
+
+	expected := "global:special,special:special,global:plain"
+	if got := strings.Join(applied, ","); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestPipeline_ComposesChainOncePerTool(t *testing.T) {
+	pipeline := NewPipeline(createTestLogger(t))
+
+	composed := 0
+	counting := func(next ToolHandler) ToolHandler {
+		composed++
+		return next
+	}
+	pipeline.Use(counting)
+
+	handler := pipeline.ToolHandler("echo", &mockToolHandler{})
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "echo"
+	for range 3 {
+		if _, err := handler(context.Background(), req); err != nil {
+			t.Fatalf("handler failed: %v", err)
+		}
+	}
+	if composed != 1 {
+		t.Errorf("expected the chain to be composed once, got %d", composed)
+	}
+
+	// Middlewares added later still apply, after one more composition
+	var applied bool
+	pipeline.UseForTool("echo", func(next ToolHandler) ToolHandler {
+		return ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+			applied = true
+			return next.Handle(ctx, params)
+		})
+	})
+	if _, err := handler(context.Background(), req); err != nil {
+		t.Fatalf("handler failed: %v", err)
+	}
+	if !applied || composed != 2 {
+		t.Errorf("expected the added middleware to apply after one recomposition, applied=%v composed=%d", applied, composed)
+	}
+}
@@ -0,0 +1,367 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/tracing"
+)
+
+// Pipeline is what every tool call and resource read goes through, whichever
+// MCP library serves it: calls are admitted unless the server is draining,
+// traced under the client's traceparent, and tool calls run through the
+// middleware chain. The server and the library adapters share one.
+type Pipeline struct {
+	logger *logger.Logger
+
+	middlewares     []ToolMiddleware
+	toolMiddlewares map[string][]ToolMiddleware
+	middlewareMu    sync.RWMutex
+	// generation changes with the middlewares, so handlers know to rebuild
+	// their chains
+	generation atomic.Uint64
+
+	inflight inflight
+
+	requestSpans sync.Map // session and JSON-RPC request id -> *tracing.Span
+}
+
+// NewPipeline creates a pipeline without middlewares
+func NewPipeline(log *logger.Logger) *Pipeline {
+	return &Pipeline{
+		logger:          log,
+		toolMiddlewares: make(map[string][]ToolMiddleware),
+	}
+}
+
+// Use registers middlewares applied to every tool call
+func (p *Pipeline) Use(middlewares ...ToolMiddleware) {
+	p.middlewareMu.Lock()
+	defer p.middlewareMu.Unlock()
+
+	p.middlewares = append(p.middlewares, middlewares...)
+	p.generation.Add(1)
+}
+
+// UseForTool registers middlewares applied only to calls of the named tool.
+// They run inside the global middlewares.
+func (p *Pipeline) UseForTool(name string, middlewares ...ToolMiddleware) {
+	p.middlewareMu.Lock()
+	defer p.middlewareMu.Unlock()
+
+	p.toolMiddlewares[name] = append(p.toolMiddlewares[name], middlewares...)
+	p.generation.Add(1)
+}
+
+func (p *Pipeline) middlewareChain(name string) []ToolMiddleware {
+	p.middlewareMu.RLock()
+	defer p.middlewareMu.RUnlock()
+
+	chain := make([]ToolMiddleware, 0, len(p.middlewares)+len(p.toolMiddlewares[name]))
+	chain = append(chain, p.middlewares...)
+	chain = append(chain, p.toolMiddlewares[name]...)
+	return chain
+}
+
+// composedTool is a tool handler wrapped in the middlewares of one generation
+type composedTool struct {
+	generation uint64
+	handler    ToolHandler
+}
+
+// ToolHandler adapts the handler of the named tool to mcp-go. The middleware
+// chain is composed once, and again only after middlewares are added.
+func (p *Pipeline) ToolHandler(name string, handler ToolHandler) server.ToolHandlerFunc {
+	var composed atomic.Pointer[composedTool]
+	chain := func() ToolHandler {
+		// Loaded before the middlewares, so a concurrent change is picked
+		// up by the next call at the latest
+		generation := p.generation.Load()
+		if current := composed.Load(); current != nil && current.generation == generation {
+			return current.handler
+		}
+		current := &composedTool{
+			generation: generation,
+			handler:    ChainToolMiddleware(handler, p.middlewareChain(name)...),
+		}
+		composed.Store(current)
+		return current.handler
+	}
+
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		p.logger.Info("executing tool",
+			"name", name,
+		)
+
+		ctx, done, err := p.inflight.begin(ctx)
+		if err != nil {
+			p.logger.Warn("tool call refused", "name", name, "error", err)
+			return NewToolErrorResult(err.Error(), err), nil
+		}
+		defer done()
+
+		// Convert arguments to our format
+		var args json.RawMessage
+		if request.Params.Arguments != nil {
+			argsBytes, err := json.Marshal(request.Params.Arguments)
+			if err != nil {
+				p.logger.Error("failed to marshal tool arguments", "error", err)
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid arguments: %v", err)), nil
+			}
+			args = argsBytes
+		}
+
+		ctx = tracing.ExtractTraceparent(ctx, traceparentFromMeta(request.Params.Meta))
+		ctx, span := tracing.Start(ctx, string(mcp.MethodToolsCall),
+			tracing.WithSpanKind(tracing.SpanKindServer),
+			tracing.WithAttributes(
+				tracing.String("rpc.system", "jsonrpc"),
+				tracing.String("rpc.method", string(mcp.MethodToolsCall)),
+				tracing.String("mcp.tool.name", name),
+			),
+		)
+		defer span.End()
+
+		ctx = WithToolName(ctx, name)
+		ctx = WithCallMeta(ctx, request.Params.Meta)
+
+		result, err := chain().Handle(ctx, args)
+		if err != nil {
+			err = drainError(ctx, "tool call", err)
+			p.logger.Error("tool execution failed", "error", err)
+			span.RecordError(err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		if result == nil {
+			return mcp.NewToolResultText(""), nil
+		}
+
+		if result.IsError() {
+			errorMsg := "Tool execution failed"
+			if result.GetError() != nil {
+				errorMsg = result.GetError().Error()
+			}
+			p.logger.Error("tool returned error", "error", errorMsg)
+			span.SetStatus(tracing.StatusError, errorMsg)
+			return AttachWarnings(NewToolErrorResult(errorMsg, result.GetError()), result), nil
+		}
+
+		contents := result.GetContent()
+		if len(contents) == 0 {
+			return AttachWarnings(mcp.NewToolResultText(""), result), nil
+		}
+
+		// For now, return the first content item as text
+		// In a full implementation, we'd handle multiple content types
+		return AttachWarnings(mcp.NewToolResultText(contents[0].GetText()), result), nil
+	}
+}
+
+// ResourceHandler adapts a resource handler to mcp-go
+func (p *Pipeline) ResourceHandler(handler ResourceHandler) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		p.logger.Info("reading resource",
+			"uri", request.Params.URI,
+		)
+
+		ctx, done, err := p.inflight.begin(ctx)
+		if err != nil {
+			p.logger.Warn("resource read refused", "uri", request.Params.URI, "error", err)
+			return nil, err
+		}
+		defer done()
+
+		ctx, span := tracing.Start(ctx, string(mcp.MethodResourcesRead),
+			tracing.WithSpanKind(tracing.SpanKindServer),
+			tracing.WithAttributes(
+				tracing.String("rpc.system", "jsonrpc"),
+				tracing.String("rpc.method", string(mcp.MethodResourcesRead)),
+				tracing.String("mcp.resource.uri", request.Params.URI),
+			),
+		)
+		defer span.End()
+
+		content, err := handler.Read(ctx, request.Params.URI)
+		if err != nil {
+			err = drainError(ctx, "resource read", err)
+			p.logger.Error("resource read failed", "error", err)
+			span.RecordError(err)
+			return nil, err
+		}
+
+		var results []mcp.ResourceContents
+
+		for _, c := range content.GetContent() {
+			switch c.Type() {
+			case "text":
+				results = append(results, mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: content.GetMimeType(),
+					Text:     c.GetText(),
+				})
+			case "blob":
+				// For blob content, encode as base64
+				results = append(results, mcp.BlobResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: content.GetMimeType(),
+					Blob:     base64.StdEncoding.EncodeToString(c.GetBlob()),
+				})
+			}
+		}
+
+		if len(results) == 0 {
+			// Return empty content if no content items
+			results = append(results, mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: content.GetMimeType(),
+				Text:     "",
+			})
+		}
+
+		return results, nil
+	}
+}
+
+// PromptHandler adapts a prompt handler to mcp-go. prompts/get is traced by
+// the hooks, as prompts have no spans of their own to parent.
+func (p *Pipeline) PromptHandler(name string, handler PromptHandler) server.PromptHandlerFunc {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		p.logger.Info("getting prompt",
+			"name", name,
+		)
+
+		ctx, done, err := p.inflight.begin(ctx)
+		if err != nil {
+			p.logger.Warn("prompt request refused", "name", name, "error", err)
+			return nil, err
+		}
+		defer done()
+
+		result, err := handler.Get(ctx, request.Params.Arguments)
+		if err != nil {
+			err = drainError(ctx, "prompt request", err)
+			p.logger.Error("prompt request failed", "name", name, "error", err)
+			return nil, err
+		}
+
+		messages := make([]mcp.PromptMessage, 0, len(result.Messages))
+		for _, message := range result.Messages {
+			messages = append(messages, mcp.NewPromptMessage(mcp.Role(message.Role), promptContent(message.Content)))
+		}
+		return mcp.NewGetPromptResult(result.Description, messages), nil
+	}
+}
+
+// promptContent maps prompt message content to mcp-go. Blobs become images
+// or audio by their detected media type.
+func promptContent(content Content) mcp.Content {
+	if content == nil || content.Type() != "blob" {
+		text := ""
+		if content != nil {
+			text = content.GetText()
+		}
+		return mcp.NewTextContent(text)
+	}
+
+	data := content.GetBlob()
+	mimeType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	encoded := base64.StdEncoding.EncodeToString(data)
+	if strings.HasPrefix(mimeType, "audio/") {
+		return mcp.NewAudioContent(encoded, mimeType)
+	}
+	return mcp.NewImageContent(encoded, mimeType)
+}
+
+// Hooks traces the JSON-RPC requests that have no handler of ours to wrap.
+// tools/call and resources/read are traced in the handlers instead, so their
+// spans can parent the tool and resource spans. Every mcp-go server serving
+// the pipeline's handlers installs them.
+func (p *Pipeline) Hooks() *server.Hooks {
+	hooks := &server.Hooks{}
+
+	hooks.AddBeforeAny(func(ctx context.Context, id any, method mcp.MCPMethod, message any) {
+		if id == nil || method == mcp.MethodToolsCall || method == mcp.MethodResourcesRead || tracing.GetTracer() == nil {
+			return
+		}
+		ctx = tracing.ExtractTraceparent(ctx, traceparentFromMessage(message))
+		_, span := tracing.Start(ctx, string(method),
+			tracing.WithSpanKind(tracing.SpanKindServer),
+			tracing.WithAttributes(
+				tracing.String("rpc.system", "jsonrpc"),
+				tracing.String("rpc.method", string(method)),
+				tracing.String("rpc.jsonrpc.request_id", fmt.Sprint(id)),
+			),
+		)
+		if span != nil {
+			p.requestSpans.Store(requestSpanKey(ctx, id), span)
+		}
+	})
+	hooks.AddOnSuccess(func(ctx context.Context, id any, method mcp.MCPMethod, message any, result any) {
+		p.endRequestSpan(ctx, id, nil)
+	})
+	hooks.AddOnError(func(ctx context.Context, id any, method mcp.MCPMethod, message any, err error) {
+		p.endRequestSpan(ctx, id, err)
+	})
+
+	return hooks
+}
+
+func (p *Pipeline) endRequestSpan(ctx context.Context, id any, err error) {
+	if id == nil {
+		return
+	}
+	value, ok := p.requestSpans.LoadAndDelete(requestSpanKey(ctx, id))
+	if !ok {
+		return
+	}
+	span := value.(*tracing.Span)
+	span.RecordError(err)
+	span.End()
+}
+
+// requestSpanKey identifies a request across sessions: request ids are only
+// unique within the session that sent them
+func requestSpanKey(ctx context.Context, id any) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID() + "/" + fmt.Sprint(id)
+	}
+	return "/" + fmt.Sprint(id)
+}
+
+// traceparentFromMeta returns the W3C traceparent a client placed in _meta
+func traceparentFromMeta(meta *mcp.Meta) string {
+	if meta == nil || meta.AdditionalFields == nil {
+		return ""
+	}
+	traceparent, _ := meta.AdditionalFields[tracing.TraceparentHeader].(string)
+	return traceparent
+}
+
+// traceparentFromMessage extracts params._meta.traceparent from a request
+// whose typed params do not expose _meta
+func traceparentFromMessage(message any) string {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return ""
+	}
+	var envelope struct {
+		Params struct {
+			Meta map[string]any `json:"_meta"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return ""
+	}
+	traceparent, _ := envelope.Params.Meta[tracing.TraceparentHeader].(string)
+	return traceparent
+}
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"mcp-server/internal/tracing"
+)
+
+type nopExporter struct{}
+
+func (nopExporter) ExportSpans(ctx context.Context, spans []tracing.SpanData) error { return nil }
+func (nopExporter) Shutdown(ctx context.Context) error                              { return nil }
+
+func TestPipelineHooks_KeySpansBySession(t *testing.T) {
+	tracer := tracing.NewTracer(nopExporter{}, tracing.Options{FlushInterval: time.Hour})
+	tracing.SetTracer(tracer)
+	t.Cleanup(func() {
+		tracing.SetTracer(nil)
+		tracer.Shutdown(context.Background())
+	})
+
+	pipeline := NewPipeline(createTestLogger(t))
+	hooks := pipeline.Hooks()
+	mcpServer := server.NewMCPServer("test", "1.0.0")
+	first := mcpServer.WithContext(context.Background(), &testSession{id: "first"})
+	second := mcpServer.WithContext(context.Background(), &testSession{id: "second"})
+
+	// Both sessions number their requests from 1
+	for _, ctx := range []context.Context{first, second} {
+		for _, hook := range hooks.OnBeforeAny {
+			hook(ctx, 1, mcp.MethodPing, &mcp.PingRequest{})
+		}
+	}
+	for _, hook := range hooks.OnSuccess {
+		hook(first, 1, mcp.MethodPing, &mcp.PingRequest{}, &mcp.EmptyResult{})
+	}
+
+	if _, open := pipeline.requestSpans.Load(requestSpanKey(first, 1)); open {
+		t.Error("expected the first session's span to be ended")
+	}
+	value, open := pipeline.requestSpans.Load(requestSpanKey(second, 1))
+	if !open {
+		t.Fatal("expected the second session's span to stay open")
+	}
+	if value.(*tracing.Span).SpanContext().TraceID == (tracing.TraceID{}) {
+		t.Error("expected the open span to be recording")
+	}
+}
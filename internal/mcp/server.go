@@ -2,7 +2,6 @@ package mcp
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -11,6 +10,7 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 	"mcp-server/internal/config"
 	"mcp-server/internal/logger"
+	"mcp-server/internal/ratelimit"
 )
 
 type Server struct {
@@ -23,6 +23,8 @@ type Server struct {
 	mu          sync.RWMutex
 	running     bool
 	transport   Transport
+
+	pipeline *Pipeline
 }
 
 func NewServer(impl Implementation, cfg *config.Config, log *logger.Logger) MCPServer {
@@ -32,6 +34,7 @@ func NewServer(impl Implementation, cfg *config.Config, log *logger.Logger) MCPS
 		config:    cfg,
 		tools:     make(map[string]Tool),
 		resources: make(map[string]Resource),
+		pipeline:  NewPipeline(log),
 	}
 }
 
@@ -54,6 +57,7 @@ func (s *Server) Start(ctx context.Context, transport Transport) error {
 		server.WithToolCapabilities(false),
 		server.WithResourceCapabilities(true, true),
 		server.WithRecovery(),
+		server.WithHooks(s.pipeline.Hooks()),
 	)
 
 	s.transport = transport
@@ -135,6 +139,23 @@ func (s *Server) GetImplementation() Implementation {
 	return s.impl
 }
 
+// Use registers middlewares applied to every tool call
+func (s *Server) Use(middlewares ...ToolMiddleware) {
+	s.pipeline.Use(middlewares...)
+}
+
+// UseForTool registers middlewares applied only to calls of the named tool.
+// They run inside the global middlewares.
+func (s *Server) UseForTool(name string, middlewares ...ToolMiddleware) {
+	s.pipeline.UseForTool(name, middlewares...)
+}
+
+// Pipeline returns the pipeline serving the calls of this server, for the
+// library adapters to share
+func (s *Server) Pipeline() *Pipeline {
+	return s.pipeline
+}
+
 func (s *Server) registerTool(tool Tool) error {
 	options := []mcp.ToolOption{
 		mcp.WithDescription(tool.Description()),
@@ -176,7 +197,7 @@ func (s *Server) registerTool(tool Tool) error {
 
 	mcpTool := mcp.NewTool(tool.Name(), options...)
 
-	handler := s.createToolHandlerAdapter(tool.Handler())
+	handler := s.pipeline.ToolHandler(tool.Name(), tool.Handler())
 
 	s.mcpServer.AddTool(mcpTool, handler)
 
@@ -191,106 +212,58 @@ func (s *Server) registerResource(resource Resource) error {
 		mcp.WithMIMEType(resource.MimeType()),
 	)
 
-	handler := s.createResourceHandlerAdapter(resource.Handler())
+	handler := s.pipeline.ResourceHandler(resource.Handler())
 
 	s.mcpServer.AddResource(mcpResource, handler)
 
 	return nil
 }
 
-func (s *Server) createToolHandlerAdapter(handler ToolHandler) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		s.logger.Info("executing tool",
-			"name", request.Params.Name,
-		)
-
-		// Convert arguments to our format
-		var args json.RawMessage
-		if request.Params.Arguments != nil {
-			argsBytes, err := json.Marshal(request.Params.Arguments)
-			if err != nil {
-				s.logger.Error("failed to marshal tool arguments", "error", err)
-				return mcp.NewToolResultError(fmt.Sprintf("Invalid arguments: %v", err)), nil
-			}
-			args = argsBytes
-		}
-
-		result, err := handler.Handle(ctx, args)
-		if err != nil {
-			s.logger.Error("tool execution failed", "error", err)
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-
-		if result.IsError() {
-			errorMsg := "Tool execution failed"
-			if result.GetError() != nil {
-				errorMsg = result.GetError().Error()
-			}
-			s.logger.Error("tool returned error", "error", errorMsg)
-			return mcp.NewToolResultError(errorMsg), nil
-		}
+// RetryAfterMetaKey is the _meta field carrying the retry hint, in
+// milliseconds, of a throttled tool call
+const RetryAfterMetaKey = "retryAfterMs"
 
-		contents := result.GetContent()
-		if len(contents) == 0 {
-			return mcp.NewToolResultText(""), nil
-		}
+// NoCacheMetaKey is the _meta field a client sets to true to bypass cached
+// tool results
+const NoCacheMetaKey = "noCache"
 
-		// For now, return the first content item as text
-		// In a full implementation, we'd handle multiple content types
-		firstContent := contents[0]
-		if firstContent.Type() == "text" {
-			return mcp.NewToolResultText(firstContent.GetText()), nil
-		}
+// WarningsMetaKey is the _meta field listing advisory warnings about a tool
+// result, such as the deprecation of the called tool version
+const WarningsMetaKey = "warnings"
 
-		return mcp.NewToolResultText(firstContent.GetText()), nil
+// WithCallMeta applies the per-call options a client placed in _meta
+func WithCallMeta(ctx context.Context, meta *mcp.Meta) context.Context {
+	if meta == nil {
+		return ctx
 	}
+	if noCache, _ := meta.AdditionalFields[NoCacheMetaKey].(bool); noCache {
+		ctx = WithCacheBypass(ctx)
+	}
+	return ctx
 }
 
-func (s *Server) createResourceHandlerAdapter(handler ResourceHandler) func(context.Context, mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		s.logger.Info("reading resource",
-			"uri", request.Params.URI,
-		)
-
-		content, err := handler.Read(ctx, request.Params.URI)
-		if err != nil {
-			s.logger.Error("resource read failed", "error", err)
-			return nil, err
-		}
-
-		var results []mcp.ResourceContents
-
-		for _, c := range content.GetContent() {
-			switch c.Type() {
-			case "text":
-				results = append(results, mcp.TextResourceContents{
-					URI:      request.Params.URI,
-					MIMEType: content.GetMimeType(),
-					Text:     c.GetText(),
-				})
-			case "blob":
-				// For blob content, encode as base64
-				blob := c.GetBlob()
-				encoded := base64.StdEncoding.EncodeToString(blob)
-				results = append(results, mcp.BlobResourceContents{
-					URI:      request.Params.URI,
-					MIMEType: content.GetMimeType(),
-					Blob:     encoded,
-				})
-			}
-		}
-
-		if len(results) == 0 {
-			// Return empty content if no content items
-			results = append(results, mcp.TextResourceContents{
-				URI:      request.Params.URI,
-				MIMEType: content.GetMimeType(),
-				Text:     "",
-			})
-		}
+// NewToolErrorResult builds the protocol result for a failed tool call,
+// attaching a retry hint when the call was rate limited
+func NewToolErrorResult(message string, err error) *mcp.CallToolResult {
+	result := mcp.NewToolResultError(message)
+	if retryAfter, ok := ratelimit.RetryAfter(err); ok {
+		result.Meta = map[string]any{RetryAfterMetaKey: retryAfter.Milliseconds()}
+	}
+	return result
+}
 
-		return results, nil
+// AttachWarnings copies the warnings of a tool result into the _meta of the
+// protocol result
+func AttachWarnings(callResult *mcp.CallToolResult, result ToolResult) *mcp.CallToolResult {
+	warnings := ResultWarnings(result)
+	if len(warnings) == 0 {
+		return callResult
+	}
+	if callResult.Meta == nil {
+		callResult.Meta = make(map[string]any)
 	}
+	callResult.Meta[WarningsMetaKey] = warnings
+	return callResult
 }
 
 func (s *Server) Serve(ctx context.Context) error {
@@ -311,3 +284,4 @@ func (s *Server) Serve(ctx context.Context) error {
 	// In a full implementation, we'd adapt our transport interface
 	return server.ServeStdio(s.mcpServer)
 }
+
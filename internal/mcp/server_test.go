@@ -318,7 +318,7 @@ func TestToolHandlerAdapter(t *testing.T) {
 			},
 		}
 
-		adapter := server.createToolHandlerAdapter(handler)
+		adapter := server.pipeline.ToolHandler("test", handler)
 
 		// Mock request (simplified)
 		// Note: In real testing, we'd need to mock the mark3labs types properly
@@ -345,7 +345,7 @@ func TestToolHandlerAdapter(t *testing.T) {
 			},
 		}
 
-		adapter := server.createToolHandlerAdapter(handler)
+		adapter := server.pipeline.ToolHandler("test", handler)
 
 		ctx := context.Background()
 		req := mcp.CallToolRequest{}
@@ -362,6 +362,30 @@ func TestToolHandlerAdapter(t *testing.T) {
 			t.Fatal("Handler adapter returned nil result for error case")
 		}
 	})
+
+	// Test warnings reach the result metadata
+	t.Run("Warnings", func(t *testing.T) {
+		handler := &mockToolHandler{
+			handleFunc: func(ctx context.Context, params json.RawMessage) (ToolResult, error) {
+				result := &ToolResultImpl{Content: []Content{&TextContent{Text: "old result"}}}
+				return WithWarnings(result, "tool test@1.0.0 is deprecated"), nil
+			},
+		}
+
+		adapter := server.pipeline.ToolHandler("test@1.0.0", handler)
+
+		req := mcp.CallToolRequest{}
+		req.Params.Name = "test@1.0.0"
+		result, err := adapter(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Handler adapter failed: %v", err)
+		}
+
+		warnings, _ := result.Meta[WarningsMetaKey].([]string)
+		if len(warnings) != 1 || warnings[0] != "tool test@1.0.0 is deprecated" {
+			t.Errorf("expected deprecation warning in _meta, got %v", result.Meta)
+		}
+	})
 }
 
 func TestErrorConditions(t *testing.T) {
@@ -0,0 +1,291 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Outcome classifies a completed call
+type Outcome string
+
+const (
+	// OutcomeSuccess means the call completed and returned a result
+	OutcomeSuccess Outcome = "success"
+	// OutcomeError means the call failed while executing
+	OutcomeError Outcome = "error"
+	// OutcomeRejected means the call was refused before execution,
+	// e.g. by argument validation or an open circuit breaker
+	OutcomeRejected Outcome = "rejected"
+)
+
+// rateWindowSeconds is the window requests per second are averaged over
+const rateWindowSeconds = 60
+
+// Snapshot is a point-in-time view of the calls recorded for one key
+type Snapshot struct {
+	Calls    int64          `json:"calls"`
+	Success  int64          `json:"success"`
+	Errors   int64          `json:"errors"`
+	Rejected int64          `json:"rejected"`
+	InFlight int64          `json:"in_flight"`
+	BytesIn  int64          `json:"bytes_in"`
+	BytesOut int64          `json:"bytes_out"`
+	Latency  LatencySummary `json:"latency"`
+}
+
+// series holds the metrics of a single key
+type series struct {
+	calls    int64
+	outcomes map[Outcome]int64
+	inFlight int64
+	bytesIn  int64
+	bytesOut int64
+	latency  *histogram
+}
+
+func newSeries() *series {
+	return &series{
+		outcomes: make(map[Outcome]int64),
+		latency:  newHistogram(),
+	}
+}
+
+func (s *series) snapshot() Snapshot {
+	return Snapshot{
+		Calls:    s.calls,
+		Success:  s.outcomes[OutcomeSuccess],
+		Errors:   s.outcomes[OutcomeError],
+		Rejected: s.outcomes[OutcomeRejected],
+		InFlight: s.inFlight,
+		BytesIn:  s.bytesIn,
+		BytesOut: s.bytesOut,
+		Latency:  s.latency.summary(),
+	}
+}
+
+// Collector records call counts, latencies, in-flight calls and payload sizes
+// per key (a tool name or resource URI) and in aggregate. A nil Collector
+// records nothing and reports empty snapshots.
+type Collector struct {
+	mu        sync.Mutex
+	series    map[string]*series
+	total     *series
+	rate      [rateWindowSeconds]int64
+	rateStamp [rateWindowSeconds]int64
+	startTime time.Time
+	now       func() time.Time
+}
+
+// NewCollector creates an empty collector
+func NewCollector() *Collector {
+	return &Collector{
+		series:    make(map[string]*series),
+		total:     newSeries(),
+		startTime: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Start marks the beginning of a call for key and returns the function that
+// records its completion. The returned function must be called exactly once.
+func (c *Collector) Start(key string, bytesIn int) func(outcome Outcome, bytesOut int) {
+	if c == nil {
+		return func(Outcome, int) {}
+	}
+
+	c.mu.Lock()
+	s := c.seriesFor(key)
+	s.inFlight++
+	c.total.inFlight++
+	start := c.now()
+	c.mu.Unlock()
+
+	return func(outcome Outcome, bytesOut int) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		end := c.now()
+		duration := end.Sub(start)
+
+		// s stays the series captured at the start: if the key was removed
+		// while the call was running, the call only counts in the totals
+		for _, target := range []*series{s, c.total} {
+			if target.inFlight > 0 {
+				target.inFlight--
+			}
+			target.calls++
+			target.outcomes[outcome]++
+			target.bytesIn += int64(bytesIn)
+			target.bytesOut += int64(bytesOut)
+			target.latency.observe(duration)
+		}
+		c.recordRate(end)
+	}
+}
+
+// seriesFor returns the series for key, creating it if needed; callers hold c.mu
+func (c *Collector) seriesFor(key string) *series {
+	s, exists := c.series[key]
+	if !exists {
+		s = newSeries()
+		c.series[key] = s
+	}
+	return s
+}
+
+func (c *Collector) recordRate(at time.Time) {
+	second := at.Unix()
+	slot := second % rateWindowSeconds
+	if c.rateStamp[slot] != second {
+		c.rateStamp[slot] = second
+		c.rate[slot] = 0
+	}
+	c.rate[slot]++
+}
+
+// Remove discards the metrics recorded for key; aggregate totals are kept
+func (c *Collector) Remove(key string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.series, key)
+}
+
+// Snapshot returns the metrics recorded for key
+func (c *Collector) Snapshot(key string) (Snapshot, bool) {
+	if c == nil {
+		return Snapshot{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, exists := c.series[key]
+	if !exists {
+		return Snapshot{}, false
+	}
+	return s.snapshot(), true
+}
+
+// Snapshots returns the metrics recorded for every key
+func (c *Collector) Snapshots() map[string]Snapshot {
+	snapshots := make(map[string]Snapshot)
+	if c == nil {
+		return snapshots
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, s := range c.series {
+		snapshots[key] = s.snapshot()
+	}
+	return snapshots
+}
+
+// Keys returns the recorded keys in sorted order
+func (c *Collector) Keys() []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.series))
+	for key := range c.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Total returns the aggregate metrics across all keys
+func (c *Collector) Total() Snapshot {
+	if c == nil {
+		return Snapshot{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total.snapshot()
+}
+
+// RequestsPerSecond returns the completed call rate over the last minute, or
+// since creation if the collector is younger than that
+func (c *Collector) RequestsPerSecond() float64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	var completed int64
+	for slot, stamp := range c.rateStamp {
+		if age := now.Unix() - stamp; age >= 0 && age < rateWindowSeconds {
+			completed += c.rate[slot]
+		}
+	}
+
+	window := now.Sub(c.startTime).Seconds()
+	if window > rateWindowSeconds {
+		window = rateWindowSeconds
+	}
+	if window < 1 {
+		window = 1
+	}
+	return float64(completed) / window
+}
+
+// Combine merges the aggregate metrics of several collectors. Quantiles are
+// computed over the union of their recent samples rather than averaged.
+func Combine(collectors ...*Collector) Snapshot {
+	var combined Snapshot
+	var samples []time.Duration
+	var sum time.Duration
+	var minLatency, maxLatency time.Duration
+	buckets := make([]int64, len(DefaultLatencyBuckets))
+
+	for _, c := range collectors {
+		if c == nil {
+			continue
+		}
+		c.mu.Lock()
+		t := c.total
+		combined.Calls += t.calls
+		combined.Success += t.outcomes[OutcomeSuccess]
+		combined.Errors += t.outcomes[OutcomeError]
+		combined.Rejected += t.outcomes[OutcomeRejected]
+		combined.InFlight += t.inFlight
+		combined.BytesIn += t.bytesIn
+		combined.BytesOut += t.bytesOut
+		if t.latency.count > 0 {
+			if combined.Latency.Count == 0 || t.latency.min < minLatency {
+				minLatency = t.latency.min
+			}
+			if t.latency.max > maxLatency {
+				maxLatency = t.latency.max
+			}
+		}
+		combined.Latency.Count += t.latency.count
+		sum += t.latency.sum
+		for i, count := range t.latency.buckets {
+			buckets[i] += count
+		}
+		samples = append(samples, t.latency.samples()...)
+		c.mu.Unlock()
+	}
+
+	combined.Latency.SumMs = milliseconds(sum)
+	combined.Latency.MinMs = milliseconds(minLatency)
+	combined.Latency.MaxMs = milliseconds(maxLatency)
+	if combined.Latency.Count > 0 {
+		combined.Latency.AvgMs = combined.Latency.SumMs / float64(combined.Latency.Count)
+	}
+	combined.Latency.Buckets = make([]Bucket, len(DefaultLatencyBuckets))
+	for i, bound := range DefaultLatencyBuckets {
+		combined.Latency.Buckets[i] = Bucket{UpperBound: bound, Count: buckets[i]}
+	}
+	combined.Latency.setQuantiles(samples)
+	return combined
+}
@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeClock advances by a fixed step on every call to now
+type fakeClock struct {
+	current time.Time
+	step    time.Duration
+}
+
+func (f *fakeClock) now() time.Time {
+	t := f.current
+	f.current = f.current.Add(f.step)
+	return t
+}
+
+func newTestCollector(step time.Duration) *Collector {
+	clock := &fakeClock{current: time.Unix(1000, 0), step: step}
+	c := NewCollector()
+	c.startTime = clock.current
+	c.now = clock.now
+	return c
+}
+
+func TestCollector_CountsByOutcome(t *testing.T) {
+	c := newTestCollector(time.Millisecond)
+
+	c.Start("echo", 10)(OutcomeSuccess, 20)
+	c.Start("echo", 5)(OutcomeError, 0)
+	c.Start("echo", 0)(OutcomeRejected, 7)
+	c.Start("other", 1)(OutcomeSuccess, 1)
+
+	snapshot, ok := c.Snapshot("echo")
+	if !ok {
+		t.Fatal("expected snapshot for echo")
+	}
+	if snapshot.Calls != 3 || snapshot.Success != 1 || snapshot.Errors != 1 || snapshot.Rejected != 1 {
+		t.Errorf("unexpected counts: %+v", snapshot)
+	}
+	if snapshot.BytesIn != 15 || snapshot.BytesOut != 27 {
+		t.Errorf("unexpected byte counts: in=%d out=%d", snapshot.BytesIn, snapshot.BytesOut)
+	}
+
+	total := c.Total()
+	if total.Calls != 4 || total.Success != 2 {
+		t.Errorf("unexpected totals: %+v", total)
+	}
+}
+
+func TestCollector_InFlight(t *testing.T) {
+	c := newTestCollector(time.Millisecond)
+
+	done := c.Start("slow", 0)
+	snapshot, _ := c.Snapshot("slow")
+	if snapshot.InFlight != 1 || c.Total().InFlight != 1 {
+		t.Errorf("expected one call in flight, got %d", snapshot.InFlight)
+	}
+
+	done(OutcomeSuccess, 0)
+	snapshot, _ = c.Snapshot("slow")
+	if snapshot.InFlight != 0 || c.Total().InFlight != 0 {
+		t.Errorf("expected no calls in flight, got %d", snapshot.InFlight)
+	}
+}
+
+func TestCollector_ExactQuantiles(t *testing.T) {
+	c := newTestCollector(0)
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	c.now = clock.now
+
+	// Latencies of 1ms..100ms
+	for i := 1; i <= 100; i++ {
+		done := c.Start("tool", 0)
+		clock.current = clock.current.Add(time.Duration(i) * time.Millisecond)
+		done(OutcomeSuccess, 0)
+	}
+
+	latency := c.Total().Latency
+	tests := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"p50", latency.P50Ms, 50.5},
+		{"p95", latency.P95Ms, 95.05},
+		{"p99", latency.P99Ms, 99.01},
+		{"min", latency.MinMs, 1},
+		{"max", latency.MaxMs, 100},
+		{"avg", latency.AvgMs, 50.5},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.expected) > 0.001 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.got)
+		}
+	}
+
+	// Buckets are cumulative: 10 samples <= 10ms, all samples <= 100ms
+	for _, bucket := range latency.Buckets {
+		switch bucket.UpperBound {
+		case 10 * time.Millisecond:
+			if bucket.Count != 10 {
+				t.Errorf("expected 10 samples <= 10ms, got %d", bucket.Count)
+			}
+		case 100 * time.Millisecond:
+			if bucket.Count != 100 {
+				t.Errorf("expected 100 samples <= 100ms, got %d", bucket.Count)
+			}
+		}
+	}
+}
+
+func TestCollector_QuantilesUseRecentWindow(t *testing.T) {
+	c := newTestCollector(0)
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	c.now = clock.now
+
+	record := func(d time.Duration) {
+		done := c.Start("tool", 0)
+		clock.current = clock.current.Add(d)
+		done(OutcomeSuccess, 0)
+	}
+
+	for i := 0; i < DefaultWindowSize; i++ {
+		record(time.Second)
+	}
+	for i := 0; i < DefaultWindowSize; i++ {
+		record(time.Millisecond)
+	}
+
+	latency := c.Total().Latency
+	if latency.P99Ms != 1 {
+		t.Errorf("expected quantiles over recent samples only, got p99=%v", latency.P99Ms)
+	}
+	if latency.MaxMs != 1000 {
+		t.Errorf("expected max over all samples, got %v", latency.MaxMs)
+	}
+}
+
+func TestCollector_RequestsPerSecond(t *testing.T) {
+	c := newTestCollector(0)
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	c.startTime = clock.current
+	c.now = clock.now
+
+	for i := 0; i < 120; i++ {
+		c.Start("tool", 0)(OutcomeSuccess, 0)
+		clock.current = clock.current.Add(500 * time.Millisecond)
+	}
+
+	if rps := c.RequestsPerSecond(); math.Abs(rps-2) > 0.05 {
+		t.Errorf("expected about 2 requests per second, got %v", rps)
+	}
+
+	clock.current = clock.current.Add(2 * time.Minute)
+	if rps := c.RequestsPerSecond(); rps != 0 {
+		t.Errorf("expected rate to decay to 0, got %v", rps)
+	}
+}
+
+func TestCombine(t *testing.T) {
+	tools := newTestCollector(time.Millisecond)
+	resources := newTestCollector(3 * time.Millisecond)
+
+	tools.Start("echo", 1)(OutcomeSuccess, 2)
+	resources.Start("file://a", 0)(OutcomeError, 0)
+
+	combined := Combine(tools, resources, nil)
+	if combined.Calls != 2 || combined.Success != 1 || combined.Errors != 1 {
+		t.Errorf("unexpected combined counts: %+v", combined)
+	}
+	if combined.Latency.MinMs != 1 || combined.Latency.MaxMs != 3 {
+		t.Errorf("unexpected combined latency range: %+v", combined.Latency)
+	}
+}
+
+func TestCollector_NilIsSafe(t *testing.T) {
+	var c *Collector
+	c.Start("tool", 1)(OutcomeSuccess, 1)
+	c.Remove("tool")
+	if _, ok := c.Snapshot("tool"); ok {
+		t.Error("expected no snapshot from nil collector")
+	}
+	if c.Total().Calls != 0 || c.RequestsPerSecond() != 0 || len(c.Snapshots()) != 0 {
+		t.Error("expected empty metrics from nil collector")
+	}
+}
+
+func TestCollector_RemoveDuringCall(t *testing.T) {
+	c := newTestCollector(time.Millisecond)
+
+	done := c.Start("removed", 0)
+	c.Remove("removed")
+	done(OutcomeSuccess, 0)
+
+	if _, ok := c.Snapshot("removed"); ok {
+		t.Error("expected a call finishing after removal not to re-create its series")
+	}
+	if total := c.Total(); total.Calls != 1 || total.InFlight != 0 {
+		t.Errorf("expected the call in the totals, got %+v", total)
+	}
+}
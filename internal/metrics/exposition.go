@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Format selects the text exposition format
+type Format int
+
+const (
+	// FormatPrometheus is the Prometheus text format, version 0.0.4
+	FormatPrometheus Format = iota
+	// FormatOpenMetrics is the OpenMetrics text format, version 1.0.0
+	FormatOpenMetrics
+)
+
+const (
+	PrometheusContentType  = "text/plain; version=0.0.4; charset=utf-8"
+	OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// NegotiateFormat picks the exposition format from an Accept header. ok is
+// false when the client asked for neither format. A bare text/plain, as
+// browsers and curl send, does not count: the Prometheus format is only
+// chosen when asked for by its version=0.0.4 parameter.
+func NegotiateFormat(accept string) (format Format, ok bool) {
+	prometheus := false
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		switch strings.ToLower(strings.TrimSpace(params[0])) {
+		case "application/openmetrics-text":
+			return FormatOpenMetrics, true
+		case "text/plain":
+			for _, param := range params[1:] {
+				if strings.ReplaceAll(strings.TrimSpace(param), " ", "") == "version=0.0.4" {
+					prometheus = true
+				}
+			}
+		}
+	}
+	return FormatPrometheus, prometheus
+}
+
+// Label is a metric label pair
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Exposition builds a metrics document in the Prometheus or OpenMetrics text format
+type Exposition struct {
+	format Format
+	buf    bytes.Buffer
+}
+
+// NewExposition creates an empty exposition document
+func NewExposition(format Format) *Exposition {
+	return &Exposition{format: format}
+}
+
+// ContentType returns the HTTP content type of the document
+func (e *Exposition) ContentType() string {
+	if e.format == FormatOpenMetrics {
+		return OpenMetricsContentType
+	}
+	return PrometheusContentType
+}
+
+// Gauge starts a gauge family
+func (e *Exposition) Gauge(name, help string) {
+	e.header(name, "gauge", help)
+}
+
+// Counter starts a counter family; samples must be named name + "_total"
+func (e *Exposition) Counter(name, help string) {
+	if e.format == FormatOpenMetrics {
+		e.header(name, "counter", help)
+		return
+	}
+	e.header(name+"_total", "counter", help)
+}
+
+// Histogram starts a histogram family; use HistogramSamples for its samples
+func (e *Exposition) Histogram(name, help string) {
+	e.header(name, "histogram", help)
+}
+
+func (e *Exposition) header(name, metricType, help string) {
+	e.buf.WriteString("# HELP " + name + " " + escapeHelp(help) + "\n")
+	e.buf.WriteString("# TYPE " + name + " " + metricType + "\n")
+}
+
+// Sample writes a single sample of the current family
+func (e *Exposition) Sample(name string, value float64, labels ...Label) {
+	e.buf.WriteString(name)
+	if len(labels) > 0 {
+		e.buf.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			e.buf.WriteString(label.Name + `="` + escapeLabelValue(label.Value) + `"`)
+		}
+		e.buf.WriteByte('}')
+	}
+	e.buf.WriteByte(' ')
+	e.buf.WriteString(formatValue(value))
+	e.buf.WriteByte('\n')
+}
+
+// HistogramSamples writes the buckets, sum and count of a latency summary in seconds
+func (e *Exposition) HistogramSamples(name string, latency LatencySummary, labels ...Label) {
+	for _, bucket := range latency.Buckets {
+		le := Label{Name: "le", Value: formatValue(bucket.UpperBound.Seconds())}
+		e.Sample(name+"_bucket", float64(bucket.Count), append(labels[:len(labels):len(labels)], le)...)
+	}
+	e.Sample(name+"_bucket", float64(latency.Count), append(labels[:len(labels):len(labels)], Label{Name: "le", Value: "+Inf"})...)
+	e.Sample(name+"_sum", latency.SumMs/1000, labels...)
+	e.Sample(name+"_count", float64(latency.Count), labels...)
+}
+
+// WriteTo writes the document, terminated as the format requires
+func (e *Exposition) WriteTo(w io.Writer) (int64, error) {
+	data := e.buf.Bytes()
+	if e.format == FormatOpenMetrics {
+		data = append(data[:len(data):len(data)], "# EOF\n"...)
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
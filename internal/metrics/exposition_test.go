@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		accept   string
+		format   Format
+		accepted bool
+	}{
+		{"application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5", FormatOpenMetrics, true},
+		{"text/plain;version=0.0.4", FormatPrometheus, true},
+		{"text/plain; version=0.0.4; charset=utf-8", FormatPrometheus, true},
+		{"text/plain", FormatPrometheus, false},
+		{"text/html,text/plain;q=0.9,*/*;q=0.8", FormatPrometheus, false},
+		{"application/json", FormatPrometheus, false},
+		{"*/*", FormatPrometheus, false},
+		{"", FormatPrometheus, false},
+	}
+
+	for _, tt := range tests {
+		format, ok := NegotiateFormat(tt.accept)
+		if format != tt.format || ok != tt.accepted {
+			t.Errorf("NegotiateFormat(%q) = %v, %v; want %v, %v", tt.accept, format, ok, tt.format, tt.accepted)
+		}
+	}
+}
+
+func TestExposition_CounterNaming(t *testing.T) {
+	render := func(format Format) string {
+		e := NewExposition(format)
+		e.Counter("mcp_tool_calls", "Tool calls.")
+		e.Sample("mcp_tool_calls_total", 3, Label{Name: "tool", Value: "echo"})
+		var out strings.Builder
+		e.WriteTo(&out)
+		return out.String()
+	}
+
+	prometheus := render(FormatPrometheus)
+	if !strings.Contains(prometheus, "# TYPE mcp_tool_calls_total counter\n") {
+		t.Errorf("expected Prometheus counter type on the _total name, got:\n%s", prometheus)
+	}
+	if strings.Contains(prometheus, "# EOF") {
+		t.Error("Prometheus format must not end with # EOF")
+	}
+
+	openMetrics := render(FormatOpenMetrics)
+	if !strings.Contains(openMetrics, "# TYPE mcp_tool_calls counter\n") {
+		t.Errorf("expected OpenMetrics counter type on the family name, got:\n%s", openMetrics)
+	}
+	if !strings.HasSuffix(openMetrics, "mcp_tool_calls_total{tool=\"echo\"} 3\n# EOF\n") {
+		t.Errorf("expected sample followed by # EOF, got:\n%s", openMetrics)
+	}
+}
+
+func TestExposition_HistogramSamples(t *testing.T) {
+	c := newTestCollector(0)
+	clock := &fakeClock{current: time.Unix(1000, 0)}
+	c.now = clock.now
+	for _, d := range []time.Duration{2 * time.Millisecond, 20 * time.Millisecond, 20 * time.Second} {
+		done := c.Start("echo", 0)
+		clock.current = clock.current.Add(d)
+		done(OutcomeSuccess, 0)
+	}
+	snapshot, _ := c.Snapshot("echo")
+
+	e := NewExposition(FormatPrometheus)
+	e.Histogram("latency_seconds", "Latency.")
+	e.HistogramSamples("latency_seconds", snapshot.Latency, Label{Name: "tool", Value: "echo"})
+	var out strings.Builder
+	e.WriteTo(&out)
+	text := out.String()
+
+	expected := []string{
+		`latency_seconds_bucket{tool="echo",le="0.001"} 0`,
+		`latency_seconds_bucket{tool="echo",le="0.005"} 1`,
+		`latency_seconds_bucket{tool="echo",le="0.025"} 2`,
+		`latency_seconds_bucket{tool="echo",le="10"} 2`,
+		`latency_seconds_bucket{tool="echo",le="+Inf"} 3`,
+		`latency_seconds_sum{tool="echo"} 20.022`,
+		`latency_seconds_count{tool="echo"} 3`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("expected line %q in:\n%s", line, text)
+		}
+	}
+}
+
+func TestExposition_EscapesLabelValues(t *testing.T) {
+	e := NewExposition(FormatPrometheus)
+	e.Sample("m", 1, Label{Name: "uri", Value: "file:///a \"b\"\\c\n"})
+	var out strings.Builder
+	e.WriteTo(&out)
+
+	if expected := `m{uri="file:///a \"b\"\\c\n"} 1` + "\n"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
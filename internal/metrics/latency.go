@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds of the cumulative latency histogram
+var DefaultLatencyBuckets = []time.Duration{
+	1 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// DefaultWindowSize is the number of most recent samples quantiles are computed over
+const DefaultWindowSize = 1024
+
+// Bucket is a cumulative histogram bucket
+type Bucket struct {
+	UpperBound time.Duration `json:"upper_bound"`
+	Count      int64         `json:"count"`
+}
+
+// LatencySummary describes the latency distribution of a series. Count, sum,
+// min, max and buckets cover every recorded call; quantiles are exact over
+// the most recent DefaultWindowSize calls.
+type LatencySummary struct {
+	Count   int64    `json:"count"`
+	SumMs   float64  `json:"sum_ms"`
+	AvgMs   float64  `json:"avg_ms"`
+	MinMs   float64  `json:"min_ms"`
+	MaxMs   float64  `json:"max_ms"`
+	P50Ms   float64  `json:"p50_ms"`
+	P90Ms   float64  `json:"p90_ms"`
+	P95Ms   float64  `json:"p95_ms"`
+	P99Ms   float64  `json:"p99_ms"`
+	Buckets []Bucket `json:"-"`
+}
+
+// histogram tracks a latency distribution; callers provide synchronization
+type histogram struct {
+	count   int64
+	sum     time.Duration
+	min     time.Duration
+	max     time.Duration
+	buckets []int64
+	window  []time.Duration
+	next    int
+}
+
+func newHistogram() *histogram {
+	return &histogram{
+		buckets: make([]int64, len(DefaultLatencyBuckets)),
+		window:  make([]time.Duration, 0, DefaultWindowSize),
+	}
+}
+
+func (h *histogram) observe(d time.Duration) {
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count++
+	h.sum += d
+
+	for i, bound := range DefaultLatencyBuckets {
+		if d <= bound {
+			h.buckets[i]++
+		}
+	}
+
+	if len(h.window) < cap(h.window) {
+		h.window = append(h.window, d)
+		return
+	}
+	h.window[h.next] = d
+	h.next = (h.next + 1) % len(h.window)
+}
+
+func (h *histogram) samples() []time.Duration {
+	return append([]time.Duration(nil), h.window...)
+}
+
+func (h *histogram) summary() LatencySummary {
+	summary := LatencySummary{
+		Count:   h.count,
+		SumMs:   milliseconds(h.sum),
+		MinMs:   milliseconds(h.min),
+		MaxMs:   milliseconds(h.max),
+		Buckets: make([]Bucket, len(DefaultLatencyBuckets)),
+	}
+	if h.count > 0 {
+		summary.AvgMs = summary.SumMs / float64(h.count)
+	}
+	for i, bound := range DefaultLatencyBuckets {
+		summary.Buckets[i] = Bucket{UpperBound: bound, Count: h.buckets[i]}
+	}
+	summary.setQuantiles(h.samples())
+	return summary
+}
+
+func (s *LatencySummary) setQuantiles(samples []time.Duration) {
+	if len(samples) == 0 {
+		return
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	s.P50Ms = milliseconds(quantile(samples, 0.50))
+	s.P90Ms = milliseconds(quantile(samples, 0.90))
+	s.P95Ms = milliseconds(quantile(samples, 0.95))
+	s.P99Ms = milliseconds(quantile(samples, 0.99))
+}
+
+// quantile returns the q-th quantile of sorted samples, interpolating
+// linearly between the closest ranks
+func quantile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := q * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	fraction := rank - float64(lower)
+	return sorted[lower] + time.Duration(fraction*float64(sorted[upper]-sorted[lower]))
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
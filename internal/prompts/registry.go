@@ -0,0 +1,199 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"mcp-server/internal/events"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/registry"
+)
+
+// DefaultPromptRegistry implements PromptRegistry. Prompts need no
+// creation step, so each entity's factory is the prompt itself and it
+// becomes the instance once published.
+type DefaultPromptRegistry struct {
+	entities  *registry.Registry[mcp.Prompt, mcp.Prompt, struct{}]
+	publisher Publisher
+	logger    *logger.Logger
+	mu        sync.Mutex // serializes publishing with status changes
+}
+
+// NewDefaultPromptRegistry creates a prompt registry publishing through
+// publisher
+func NewDefaultPromptRegistry(publisher Publisher, log *logger.Logger) PromptRegistry {
+	return &DefaultPromptRegistry{
+		entities:  registry.NewRegistry[mcp.Prompt, mcp.Prompt, struct{}](events.KindPrompt, log, registry.Hooks{}),
+		publisher: publisher,
+		logger:    log,
+	}
+}
+
+// Register implements PromptRegistry.Register. While the registry is
+// running the prompt is published right away; a prompt that cannot be
+// published is not kept.
+func (r *DefaultPromptRegistry) Register(prompt mcp.Prompt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := prompt.Name()
+	if err := r.entities.Register(name, prompt, struct{}{}); err != nil {
+		return fmt.Errorf("%w: %s", ErrPromptAlreadyExists, name)
+	}
+	if !r.entities.Running() {
+		return nil
+	}
+	if err := r.activate(name, prompt); err != nil {
+		r.entities.Unregister(name)
+		return err
+	}
+	return nil
+}
+
+// Unregister implements PromptRegistry.Unregister
+func (r *DefaultPromptRegistry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.entities.Entry(name)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrPromptNotFound, name)
+	}
+	if entry.Loaded {
+		r.withdraw(name)
+	}
+	if err := r.entities.Unregister(name); err != nil {
+		return fmt.Errorf("%w: %s", ErrPromptNotFound, name)
+	}
+	return nil
+}
+
+// Get implements PromptRegistry.Get
+func (r *DefaultPromptRegistry) Get(name string) (mcp.Prompt, error) {
+	entry, exists := r.entities.Entry(name)
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrPromptNotFound, name)
+	}
+	if entry.Status == PromptStatusDisabled {
+		return nil, fmt.Errorf("%w: %s", ErrPromptDisabled, name)
+	}
+	return entry.Factory, nil
+}
+
+// List implements PromptRegistry.List
+func (r *DefaultPromptRegistry) List() []PromptInfo {
+	entries := r.entities.Entries()
+	result := make([]PromptInfo, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, PromptInfo{
+			Name:        entry.Key,
+			Description: entry.Factory.Description(),
+			Arguments:   entry.Factory.Arguments(),
+			Status:      entry.Status,
+			History:     entry.History,
+		})
+	}
+	return result
+}
+
+// TransitionStatusWith implements PromptRegistry.TransitionStatusWith.
+// Disabling withdraws the prompt from clients; enabling publishes it again.
+func (r *DefaultPromptRegistry) TransitionStatusWith(name string, newStatus PromptStatus, cause registry.TransitionCause) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.entities.Entry(name)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrPromptNotFound, name)
+	}
+
+	switch newStatus {
+	case PromptStatusDisabled:
+		if entry.Loaded {
+			r.withdraw(name)
+		}
+		if err := r.entities.Transition(name, PromptStatusDisabled, cause); err != nil {
+			return fmt.Errorf("%w: %v", ErrTransitionNotAllowed, err)
+		}
+		return nil
+	case PromptStatusActive:
+		if entry.Status == PromptStatusActive {
+			return nil
+		}
+		if entry.Status != PromptStatusDisabled && entry.Status != PromptStatusError {
+			return fmt.Errorf("%w: only disabled or failed prompts can be enabled, prompt is %s", ErrTransitionNotAllowed, entry.Status)
+		}
+		if err := r.entities.Transition(name, PromptStatusRegistered, cause); err != nil {
+			return fmt.Errorf("%w: %v", ErrTransitionNotAllowed, err)
+		}
+		return r.activate(name, entry.Factory)
+	default:
+		return fmt.Errorf("%w: prompts can only be disabled or enabled, not moved to %s", ErrTransitionNotAllowed, newStatus)
+	}
+}
+
+// Start implements PromptRegistry.Start, publishing the prompts registered
+// so far
+func (r *DefaultPromptRegistry) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entities.Start()
+	for _, entry := range r.entities.Entries() {
+		if entry.Status != PromptStatusRegistered {
+			continue
+		}
+		if err := r.activate(entry.Key, entry.Factory); err != nil {
+			r.logger.Warn("failed to publish prompt", "name", entry.Key, "error", err)
+		}
+	}
+	return nil
+}
+
+// Stop implements PromptRegistry.Stop, withdrawing every published prompt
+func (r *DefaultPromptRegistry) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.entities.Entries() {
+		if entry.Loaded {
+			r.withdraw(entry.Key)
+		}
+	}
+	r.entities.Stop(registry.SystemCause("registry stopped"))
+	return nil
+}
+
+// Health implements PromptRegistry.Health
+func (r *DefaultPromptRegistry) Health() registry.RegistryHealth {
+	return r.entities.Health()
+}
+
+// SetEvents publishes lifecycle events of the registry's prompts on bus
+func (r *DefaultPromptRegistry) SetEvents(bus *events.Bus) {
+	r.entities.SetEvents(bus)
+}
+
+// activate publishes a registered prompt and moves it to active, or to
+// error when publishing fails; the caller must hold r.mu
+func (r *DefaultPromptRegistry) activate(name string, prompt mcp.Prompt) error {
+	if err := r.publisher.RegisterPrompt(prompt); err != nil {
+		r.entities.TryTransition(name, PromptStatusError, registry.ErrorCause(registry.ActorSystem, "publish failed", err))
+		return fmt.Errorf("failed to publish prompt %s: %w", name, err)
+	}
+	r.entities.SetInstance(name, prompt)
+	r.entities.TryTransition(name, PromptStatusLoaded, registry.SystemCause("loaded"))
+	r.entities.TryTransition(name, PromptStatusActive, registry.SystemCause("published"))
+	return nil
+}
+
+// withdraw stops serving a published prompt; the caller must hold r.mu.
+// The publisher failing does not stop the status change.
+func (r *DefaultPromptRegistry) withdraw(name string) {
+	if err := r.publisher.UnregisterPrompt(name); err != nil {
+		r.logger.Warn("failed to withdraw prompt", "name", name, "error", err)
+	}
+	r.entities.DropInstance(name)
+}
@@ -0,0 +1,125 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mcp-server/internal/logger"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/registry"
+)
+
+type stubPrompt struct {
+	name string
+}
+
+func (p *stubPrompt) Name() string                    { return p.name }
+func (p *stubPrompt) Description() string             { return "A stub prompt" }
+func (p *stubPrompt) Arguments() []mcp.PromptArgument { return nil }
+func (p *stubPrompt) Handler() mcp.PromptHandler      { return nil }
+
+// recordingPublisher records the prompts it serves and fails publishing
+// while err is set
+type recordingPublisher struct {
+	published map[string]bool
+	err       error
+}
+
+func (p *recordingPublisher) RegisterPrompt(prompt mcp.Prompt) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published[prompt.Name()] = true
+	return nil
+}
+
+func (p *recordingPublisher) UnregisterPrompt(name string) error {
+	delete(p.published, name)
+	return nil
+}
+
+func newTestRegistry(t *testing.T) (PromptRegistry, *recordingPublisher) {
+	t.Helper()
+	log, err := logger.NewDefault()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	publisher := &recordingPublisher{published: make(map[string]bool)}
+	return NewDefaultPromptRegistry(publisher, log), publisher
+}
+
+func status(r PromptRegistry, name string) PromptStatus {
+	for _, info := range r.List() {
+		if info.Name == name {
+			return info.Status
+		}
+	}
+	return ""
+}
+
+func TestDefaultPromptRegistry_Lifecycle(t *testing.T) {
+	r, publisher := newTestRegistry(t)
+	ctx := context.Background()
+
+	if err := r.Register(&stubPrompt{name: "summarize"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if publisher.published["summarize"] || status(r, "summarize") != PromptStatusRegistered {
+		t.Fatalf("Expected the prompt held back until start, got %s", status(r, "summarize"))
+	}
+	if err := r.Register(&stubPrompt{name: "summarize"}); !errors.Is(err, ErrPromptAlreadyExists) {
+		t.Errorf("Expected ErrPromptAlreadyExists, got %v", err)
+	}
+
+	r.Start(ctx)
+	if !publisher.published["summarize"] || status(r, "summarize") != PromptStatusActive {
+		t.Fatalf("Expected the prompt published on start, got %s", status(r, "summarize"))
+	}
+
+	cause := registry.TransitionCause{Actor: registry.ActorAdmin, Reason: "off topic"}
+	if err := r.TransitionStatusWith("summarize", PromptStatusDisabled, cause); err != nil {
+		t.Fatalf("Disable failed: %v", err)
+	}
+	if publisher.published["summarize"] {
+		t.Error("Expected the disabled prompt withdrawn")
+	}
+	if _, err := r.Get("summarize"); !errors.Is(err, ErrPromptDisabled) {
+		t.Errorf("Expected ErrPromptDisabled, got %v", err)
+	}
+
+	if err := r.TransitionStatusWith("summarize", PromptStatusActive, cause); err != nil {
+		t.Fatalf("Enable failed: %v", err)
+	}
+	if !publisher.published["summarize"] || status(r, "summarize") != PromptStatusActive {
+		t.Errorf("Expected the enabled prompt published again, got %s", status(r, "summarize"))
+	}
+
+	if err := r.Unregister("summarize"); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if publisher.published["summarize"] || len(r.List()) != 0 {
+		t.Error("Expected the unregistered prompt withdrawn and forgotten")
+	}
+	if err := r.Unregister("summarize"); !errors.Is(err, ErrPromptNotFound) {
+		t.Errorf("Expected ErrPromptNotFound, got %v", err)
+	}
+}
+
+func TestDefaultPromptRegistry_PublishFailure(t *testing.T) {
+	r, publisher := newTestRegistry(t)
+	r.Start(context.Background())
+
+	publisher.err = errors.New("no adapter")
+	if err := r.Register(&stubPrompt{name: "summarize"}); err == nil {
+		t.Fatal("Expected registering an unpublishable prompt to fail")
+	}
+	if len(r.List()) != 0 {
+		t.Error("Expected the unpublishable prompt not to be kept")
+	}
+
+	publisher.err = nil
+	if err := r.Register(&stubPrompt{name: "summarize"}); err != nil {
+		t.Errorf("Expected the prompt to register once publishing works, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-server/internal/events"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/registry"
+)
+
+type PromptStatus = registry.LifecycleStatus
+
+const (
+	PromptStatusRegistered = registry.StatusRegistered
+	PromptStatusLoaded     = registry.StatusLoaded
+	PromptStatusActive     = registry.StatusActive
+	PromptStatusError      = registry.StatusError
+	PromptStatusDisabled   = registry.StatusDisabled
+)
+
+type PromptInfo struct {
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Arguments   []mcp.PromptArgument        `json:"arguments"`
+	Status      PromptStatus                `json:"status"`
+	History     []registry.TransitionRecord `json:"history,omitempty"`
+}
+
+// Publisher serves prompts to clients. The tool registry is one: it
+// forwards prompts to its library adapter.
+type Publisher interface {
+	RegisterPrompt(prompt mcp.Prompt) error
+	UnregisterPrompt(name string) error
+}
+
+// PromptRegistry keeps the lifecycle of prompts on the same generic
+// registry as tools and resources. Active prompts are published; disabled
+// ones are withdrawn until enabled again.
+type PromptRegistry interface {
+	Register(prompt mcp.Prompt) error
+	Unregister(name string) error
+	Get(name string) (mcp.Prompt, error)
+	List() []PromptInfo
+
+	// TransitionStatusWith disables a prompt or enables a disabled one,
+	// recording cause in its history
+	TransitionStatusWith(name string, newStatus PromptStatus, cause registry.TransitionCause) error
+
+	// Lifecycle management
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	Health() registry.RegistryHealth
+	SetEvents(bus *events.Bus)
+}
+
+var (
+	ErrPromptNotFound       = fmt.Errorf("prompt not found")
+	ErrPromptAlreadyExists  = fmt.Errorf("prompt already exists")
+	ErrPromptDisabled       = fmt.Errorf("prompt is disabled")
+	ErrTransitionNotAllowed = fmt.Errorf("status transition not allowed")
+)
@@ -0,0 +1,54 @@
+package proxy
+
+import (
+	"context"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/prompts"
+	"mcp-server/internal/resources"
+	"mcp-server/internal/tools"
+)
+
+// Manager runs the configured upstreams, so clients can reach the tools,
+// resources and prompts of several MCP servers through this one
+type Manager struct {
+	upstreams []*Upstream
+	logger    *logger.Logger
+}
+
+func NewManager(cfg *config.Config, toolRegistry tools.ToolRegistry, resourceRegistry resources.ResourceRegistry, promptRegistry prompts.PromptRegistry, log *logger.Logger) *Manager {
+	m := &Manager{logger: log}
+	for _, upstreamConfig := range cfg.Upstreams {
+		m.upstreams = append(m.upstreams, NewUpstream(upstreamConfig, toolRegistry, resourceRegistry, promptRegistry, log))
+	}
+	return m
+}
+
+// Start connects every upstream in the background
+func (m *Manager) Start(ctx context.Context) {
+	for _, upstream := range m.upstreams {
+		m.logger.Info("starting upstream",
+			"upstream", upstream.config.Name,
+			"namespace", upstream.config.Namespace,
+			"transport", upstream.config.Transport,
+		)
+		upstream.Start(ctx)
+	}
+}
+
+// Stop disconnects every upstream and unmounts what it mounted
+func (m *Manager) Stop() {
+	for _, upstream := range m.upstreams {
+		upstream.Stop()
+	}
+}
+
+// Health reports every upstream in configuration order
+func (m *Manager) Health() []UpstreamHealth {
+	health := make([]UpstreamHealth, 0, len(m.upstreams))
+	for _, upstream := range m.upstreams {
+		health = append(health, upstream.Health())
+	}
+	return health
+}
@@ -0,0 +1,87 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-server/internal/mcp"
+)
+
+// localPromptName prefixes an upstream prompt name with the namespace, as
+// tool names are
+func localPromptName(namespace, name string) string {
+	return namespace + "_" + name
+}
+
+// ProxyPrompt publishes one upstream prompt under its namespaced name.
+// Requests are forwarded to the upstream under the original name.
+type ProxyPrompt struct {
+	name        string
+	description string
+	arguments   []mcp.PromptArgument
+	handler     *ProxyPromptHandler
+}
+
+func newPrompt(upstream *Upstream, name string, remote mcpgo.Prompt) *ProxyPrompt {
+	description := strings.TrimSpace(remote.Description)
+	if description == "" {
+		description = fmt.Sprintf("%s from upstream %s", remote.Name, upstream.config.Name)
+	}
+
+	arguments := make([]mcp.PromptArgument, 0, len(remote.Arguments))
+	for _, argument := range remote.Arguments {
+		arguments = append(arguments, mcp.PromptArgument{
+			Name:        argument.Name,
+			Description: argument.Description,
+			Required:    argument.Required,
+		})
+	}
+
+	return &ProxyPrompt{
+		name:        name,
+		description: description,
+		arguments:   arguments,
+		handler:     &ProxyPromptHandler{upstream: upstream, remote: remote.Name},
+	}
+}
+
+func (p *ProxyPrompt) Name() string {
+	return p.name
+}
+
+func (p *ProxyPrompt) Description() string {
+	return p.description
+}
+
+func (p *ProxyPrompt) Arguments() []mcp.PromptArgument {
+	return p.arguments
+}
+
+func (p *ProxyPrompt) Handler() mcp.PromptHandler {
+	return p.handler
+}
+
+type ProxyPromptHandler struct {
+	upstream *Upstream
+	remote   string
+}
+
+func (h *ProxyPromptHandler) Get(ctx context.Context, arguments map[string]string) (*mcp.PromptResult, error) {
+	result, err := h.upstream.getPrompt(ctx, h.remote, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]mcp.PromptMessage, 0, len(result.Messages))
+	for _, message := range result.Messages {
+		content, err := convertContent([]mcpgo.Content{message.Content})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, mcp.PromptMessage{Role: string(message.Role), Content: content[0]})
+	}
+	return &mcp.PromptResult{Description: result.Description, Messages: messages}, nil
+}
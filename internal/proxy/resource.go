@@ -0,0 +1,180 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/resources"
+)
+
+// resourceScheme is the URI scheme of mounted upstream resources
+const resourceScheme = "upstream"
+
+// localResourceURI maps an upstream resource URI into the namespace, e.g.
+// file:///notes.txt of namespace docs becomes
+// upstream://docs/file:%2F%2F%2Fnotes.txt
+func localResourceURI(namespace, uri string) string {
+	return resourceScheme + "://" + namespace + "/" + url.PathEscape(uri)
+}
+
+// ProxyResourceFactory mounts one upstream resource in the resource registry.
+// Reads are forwarded to the upstream under the original URI.
+type ProxyResourceFactory struct {
+	upstream    *Upstream
+	uri         string
+	version     string
+	name        string
+	description string
+	mimeType    string
+	remote      mcpgo.Resource
+}
+
+func newResourceFactory(upstream *Upstream, uri, version string, remote mcpgo.Resource) *ProxyResourceFactory {
+	// Resource names allow letters, digits, dashes, underscores and spaces
+	name := strings.Map(func(r rune) rune {
+		if r < 128 && (r == '-' || r == '_' || r == ' ' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')) {
+			return r
+		}
+		return '_'
+	}, remote.Name)
+	if strings.TrimSpace(name) == "" {
+		name = upstream.config.Namespace + " resource"
+	}
+
+	description := strings.TrimSpace(remote.Description)
+	if description == "" {
+		description = fmt.Sprintf("%s from upstream %s", remote.URI, upstream.config.Name)
+	}
+
+	mimeType := remote.MIMEType
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	return &ProxyResourceFactory{
+		upstream:    upstream,
+		uri:         uri,
+		version:     version,
+		name:        name,
+		description: description,
+		mimeType:    mimeType,
+		remote:      remote,
+	}
+}
+
+func (f *ProxyResourceFactory) URI() string {
+	return f.uri
+}
+
+func (f *ProxyResourceFactory) Name() string {
+	return f.name
+}
+
+func (f *ProxyResourceFactory) Description() string {
+	return f.description
+}
+
+func (f *ProxyResourceFactory) MimeType() string {
+	return f.mimeType
+}
+
+func (f *ProxyResourceFactory) Version() string {
+	return f.version
+}
+
+func (f *ProxyResourceFactory) Tags() []string {
+	return []string{"upstream", f.upstream.config.Namespace}
+}
+
+func (f *ProxyResourceFactory) Capabilities() []string {
+	return []string{"read", "proxy"}
+}
+
+func (f *ProxyResourceFactory) Create(ctx context.Context, config resources.ResourceConfig) (mcp.Resource, error) {
+	if !config.Enabled {
+		return nil, fmt.Errorf("proxy resource %s is disabled in configuration", f.uri)
+	}
+
+	return &ProxyResource{
+		factory: f,
+		handler: &ProxyResourceHandler{upstream: f.upstream, remote: f.remote.URI, mimeType: f.mimeType},
+	}, nil
+}
+
+func (f *ProxyResourceFactory) Validate(config resources.ResourceConfig) error {
+	if config.CacheTimeout < 0 {
+		return fmt.Errorf("invalid cache timeout: %d (must be non-negative)", config.CacheTimeout)
+	}
+
+	return nil
+}
+
+type ProxyResource struct {
+	factory *ProxyResourceFactory
+	handler *ProxyResourceHandler
+}
+
+func (r *ProxyResource) URI() string {
+	return r.factory.uri
+}
+
+func (r *ProxyResource) Name() string {
+	return r.factory.name
+}
+
+func (r *ProxyResource) Description() string {
+	return r.factory.description
+}
+
+func (r *ProxyResource) MimeType() string {
+	return r.factory.mimeType
+}
+
+func (r *ProxyResource) Handler() mcp.ResourceHandler {
+	return r.handler
+}
+
+type ProxyResourceHandler struct {
+	upstream *Upstream
+	remote   string
+	mimeType string
+}
+
+// Read forwards the read under the upstream URI; the local URI it was
+// reached by is only used for routing
+func (h *ProxyResourceHandler) Read(ctx context.Context, uri string) (mcp.ResourceContent, error) {
+	result, err := h.upstream.readResource(ctx, h.remote)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", resources.ErrResourceContent, err)
+	}
+
+	mimeType := h.mimeType
+	content := make([]mcp.Content, 0, len(result.Contents))
+	for _, item := range result.Contents {
+		converted, err := convertResourceContents(item)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", resources.ErrResourceContent, err)
+		}
+		content = append(content, converted)
+		if itemType := resourceContentsMimeType(item); itemType != "" && len(content) == 1 {
+			mimeType = itemType
+		}
+	}
+
+	return &mcp.ResourceContentImpl{Content: content, MimeType: mimeType}, nil
+}
+
+func resourceContentsMimeType(contents mcpgo.ResourceContents) string {
+	switch c := contents.(type) {
+	case mcpgo.TextResourceContents:
+		return c.MIMEType
+	case mcpgo.BlobResourceContents:
+		return c.MIMEType
+	}
+	return ""
+}
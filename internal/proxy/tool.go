@@ -0,0 +1,223 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/tools"
+)
+
+// ProxyToolFactory mounts one upstream tool in the tool registry under its
+// namespaced name. Calls are forwarded to the upstream under the original
+// name.
+type ProxyToolFactory struct {
+	upstream    *Upstream
+	name        string
+	version     string
+	description string
+	remote      mcpgo.Tool
+	schema      json.RawMessage
+}
+
+func newToolFactory(upstream *Upstream, name, version string, remote mcpgo.Tool) *ProxyToolFactory {
+	description := strings.TrimSpace(remote.Description)
+	if description == "" {
+		description = fmt.Sprintf("%s from upstream %s", remote.Name, upstream.config.Name)
+	}
+	if runes := []rune(description); len(runes) > tools.MaxDescriptionLength {
+		description = string(runes[:tools.MaxDescriptionLength])
+	}
+
+	return &ProxyToolFactory{
+		upstream:    upstream,
+		name:        name,
+		version:     version,
+		description: description,
+		remote:      remote,
+		schema:      inputSchema(remote),
+	}
+}
+
+// inputSchema returns the upstream tool's input schema as declared
+func inputSchema(tool mcpgo.Tool) json.RawMessage {
+	if len(tool.RawInputSchema) > 0 {
+		return tool.RawInputSchema
+	}
+	// Tool input schemas are plain structs and always marshal
+	schema, _ := json.Marshal(tool.InputSchema)
+	return schema
+}
+
+func (f *ProxyToolFactory) GetName() string {
+	return f.name
+}
+
+func (f *ProxyToolFactory) GetDescription() string {
+	return f.description
+}
+
+func (f *ProxyToolFactory) GetVersion() string {
+	return f.version
+}
+
+func (f *ProxyToolFactory) GetCapabilities() []string {
+	return []string{"proxy"}
+}
+
+func (f *ProxyToolFactory) Requirements() map[string]string {
+	return map[string]string{
+		"runtime": "proxy",
+	}
+}
+
+func (f *ProxyToolFactory) Create(ctx context.Context, config tools.ToolConfig) (mcp.Tool, error) {
+	if !config.Enabled {
+		return nil, fmt.Errorf("proxy tool %s is disabled in configuration", f.name)
+	}
+
+	return &ProxyTool{
+		name:        f.name,
+		description: f.description,
+		schema:      f.schema,
+		handler:     &ProxyHandler{upstream: f.upstream, remote: f.remote.Name},
+	}, nil
+}
+
+func (f *ProxyToolFactory) Validate(config tools.ToolConfig) error {
+	if config.Timeout < 0 {
+		return fmt.Errorf("invalid timeout value: %d (must be non-negative)", config.Timeout)
+	}
+
+	if config.MaxRetries < 0 {
+		return fmt.Errorf("invalid max retries value: %d (must be non-negative)", config.MaxRetries)
+	}
+
+	return nil
+}
+
+type ProxyTool struct {
+	name        string
+	description string
+	schema      json.RawMessage
+	handler     *ProxyHandler
+}
+
+func (t *ProxyTool) Name() string {
+	return t.name
+}
+
+func (t *ProxyTool) Description() string {
+	return t.description
+}
+
+func (t *ProxyTool) Parameters() json.RawMessage {
+	return t.schema
+}
+
+func (t *ProxyTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+type ProxyHandler struct {
+	upstream *Upstream
+	remote   string
+}
+
+func (h *ProxyHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	var arguments map[string]interface{}
+	if len(bytes.TrimSpace(params)) > 0 {
+		if err := json.Unmarshal(params, &arguments); err != nil {
+			return &mcp.ToolResultImpl{Error: fmt.Errorf("invalid parameters: %w", err), IsErrorFlag: true}, nil
+		}
+	}
+
+	result, err := h.upstream.callTool(ctx, h.remote, arguments)
+	if err != nil {
+		return &mcp.ToolResultImpl{Error: err, IsErrorFlag: true}, nil
+	}
+
+	content, err := convertContent(result.Content)
+	if err != nil {
+		return &mcp.ToolResultImpl{Error: err, IsErrorFlag: true}, nil
+	}
+	if result.IsError {
+		return &mcp.ToolResultImpl{Content: content, Error: errors.New(resultText(content)), IsErrorFlag: true}, nil
+	}
+	return &mcp.ToolResultImpl{Content: content, IsErrorFlag: false}, nil
+}
+
+// convertContent maps upstream content to local content. Images and audio
+// become blobs, embedded resources their text or blob, and anything else
+// its JSON encoding.
+func convertContent(contents []mcpgo.Content) ([]mcp.Content, error) {
+	converted := make([]mcp.Content, 0, len(contents))
+	for _, content := range contents {
+		switch c := content.(type) {
+		case mcpgo.TextContent:
+			converted = append(converted, &mcp.TextContent{Text: c.Text})
+		case mcpgo.ImageContent:
+			data, err := base64.StdEncoding.DecodeString(c.Data)
+			if err != nil {
+				return nil, fmt.Errorf("upstream returned invalid image data: %w", err)
+			}
+			converted = append(converted, &mcp.BlobContent{Data: data})
+		case mcpgo.AudioContent:
+			data, err := base64.StdEncoding.DecodeString(c.Data)
+			if err != nil {
+				return nil, fmt.Errorf("upstream returned invalid audio data: %w", err)
+			}
+			converted = append(converted, &mcp.BlobContent{Data: data})
+		case mcpgo.EmbeddedResource:
+			item, err := convertResourceContents(c.Resource)
+			if err != nil {
+				return nil, err
+			}
+			converted = append(converted, item)
+		default:
+			data, err := json.Marshal(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode upstream content: %w", err)
+			}
+			converted = append(converted, &mcp.TextContent{Text: string(data)})
+		}
+	}
+	return converted, nil
+}
+
+// convertResourceContents maps one upstream resource content item
+func convertResourceContents(contents mcpgo.ResourceContents) (mcp.Content, error) {
+	switch c := contents.(type) {
+	case mcpgo.TextResourceContents:
+		return &mcp.TextContent{Text: c.Text}, nil
+	case mcpgo.BlobResourceContents:
+		data, err := base64.StdEncoding.DecodeString(c.Blob)
+		if err != nil {
+			return nil, fmt.Errorf("upstream returned invalid blob data: %w", err)
+		}
+		return &mcp.BlobContent{Data: data}, nil
+	default:
+		return nil, fmt.Errorf("upstream returned unsupported resource contents %T", contents)
+	}
+}
+
+// resultText joins the text items of an error result into its message
+func resultText(content []mcp.Content) string {
+	var parts []string
+	for _, item := range content {
+		if item.Type() == "text" && item.GetText() != "" {
+			parts = append(parts, item.GetText())
+		}
+	}
+	if len(parts) == 0 {
+		return "upstream tool failed"
+	}
+	return strings.Join(parts, "\n")
+}
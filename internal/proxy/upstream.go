@@ -0,0 +1,665 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/prompts"
+	"mcp-server/internal/resources"
+	"mcp-server/internal/tools"
+)
+
+// ErrUpstreamUnavailable is returned for calls made while an upstream is
+// disconnected; they fail fast instead of waiting for a reconnect
+var ErrUpstreamUnavailable = fmt.Errorf("upstream unavailable")
+
+// semverPattern matches the versions the resource registry accepts
+var semverPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+([a-zA-Z0-9\-\.]*)?$`)
+
+// fallbackVersion is used when an upstream does not report a semantic version
+const fallbackVersion = "1.0.0"
+
+// dialFunc opens an initialized-ready client session to an upstream
+type dialFunc func(ctx context.Context) (*client.Client, error)
+
+// UpstreamHealth describes the connection to one upstream and what it mounted
+type UpstreamHealth struct {
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace"`
+	Transport  string   `json:"transport"`
+	Connected  bool     `json:"connected"`
+	Server     string   `json:"server,omitempty"`
+	LastError  string   `json:"last_error,omitempty"`
+	LastPing   string   `json:"last_ping,omitempty"`
+	Reconnects int64    `json:"reconnects"`
+	Tools      []string `json:"tools"`
+	Resources  []string `json:"resources"`
+	Prompts    []string `json:"prompts"`
+}
+
+// Upstream keeps a session to another MCP server and mirrors its tools,
+// resources and prompts into the local registries under the upstream's
+// namespace. The session is health checked with pings and re-established
+// with exponential backoff; list_changed notifications from the upstream
+// trigger a resync. Mounted entries stay registered while the upstream is
+// down and fail fast.
+type Upstream struct {
+	config    config.UpstreamConfig
+	tools     tools.ToolRegistry
+	resources resources.ResourceRegistry
+	prompts   prompts.PromptRegistry
+	logger    *logger.Logger
+	dial      dialFunc
+
+	mu               sync.RWMutex
+	client           *client.Client
+	server           mcpgo.Implementation
+	connected        bool
+	lastError        string
+	lastPing         time.Time
+	reconnects       int64
+	mountedTools     map[string]mcpgo.Tool
+	mountedResources map[string]mcpgo.Resource
+	mountedPrompts   map[string]mcpgo.Prompt
+
+	resync chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewUpstream creates an upstream for a validated configuration. Nothing is
+// connected until Start.
+func NewUpstream(cfg config.UpstreamConfig, toolRegistry tools.ToolRegistry, resourceRegistry resources.ResourceRegistry, promptRegistry prompts.PromptRegistry, log *logger.Logger) *Upstream {
+	u := newUpstream(cfg, toolRegistry, resourceRegistry, promptRegistry, log)
+	u.dial = u.dialConfigured
+	return u
+}
+
+func newUpstream(cfg config.UpstreamConfig, toolRegistry tools.ToolRegistry, resourceRegistry resources.ResourceRegistry, promptRegistry prompts.PromptRegistry, log *logger.Logger) *Upstream {
+	return &Upstream{
+		config:           cfg,
+		tools:            toolRegistry,
+		resources:        resourceRegistry,
+		prompts:          promptRegistry,
+		logger:           log,
+		mountedTools:     make(map[string]mcpgo.Tool),
+		mountedResources: make(map[string]mcpgo.Resource),
+		mountedPrompts:   make(map[string]mcpgo.Prompt),
+		resync:           make(chan struct{}, 1),
+	}
+}
+
+// Start connects in the background; a failing upstream does not delay or
+// fail server startup
+func (u *Upstream) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	u.cancel = cancel
+	u.done = make(chan struct{})
+	go u.run(ctx)
+}
+
+// Stop closes the session and unmounts everything the upstream mounted
+func (u *Upstream) Stop() {
+	if u.cancel == nil {
+		return
+	}
+	u.cancel()
+	<-u.done
+	u.cancel = nil
+
+	u.syncTools(nil)
+	u.syncResources(nil)
+	u.syncPrompts(nil)
+}
+
+func (u *Upstream) run(ctx context.Context) {
+	defer close(u.done)
+
+	backoff := u.config.ReconnectBackoff
+	for {
+		if err := u.connect(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			u.recordError(err)
+			u.logger.Warn("upstream connection failed",
+				"upstream", u.config.Name,
+				"retry_in", backoff,
+				"error", err,
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, u.config.MaxReconnectBackoff)
+			continue
+		}
+		backoff = u.config.ReconnectBackoff
+
+		err := u.monitor(ctx)
+		u.disconnect()
+		if ctx.Err() != nil {
+			return
+		}
+		u.recordError(err)
+		u.logger.Warn("upstream connection lost, reconnecting",
+			"upstream", u.config.Name,
+			"error", err,
+		)
+		u.mu.Lock()
+		u.reconnects++
+		u.mu.Unlock()
+	}
+}
+
+// connect opens a session, performs the MCP handshake and mounts the
+// upstream's current tools, resources and prompts
+func (u *Upstream) connect(ctx context.Context) error {
+	c, err := u.dial(ctx)
+	if err != nil {
+		return err
+	}
+	c.OnNotification(u.handleNotification)
+
+	initCtx, cancel := context.WithTimeout(ctx, u.config.RequestTimeout)
+	defer cancel()
+
+	request := mcpgo.InitializeRequest{}
+	request.Params.ProtocolVersion = mcpgo.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcpgo.Implementation{Name: "mcp-server-proxy", Version: fallbackVersion}
+	result, err := c.Initialize(initCtx, request)
+	if err != nil {
+		c.Close()
+		return fmt.Errorf("initialize failed: %w", err)
+	}
+
+	u.mu.Lock()
+	u.client = c
+	u.server = result.ServerInfo
+	u.connected = true
+	u.lastError = ""
+	u.lastPing = time.Now()
+	u.mu.Unlock()
+
+	u.logger.Info("upstream connected",
+		"upstream", u.config.Name,
+		"server", result.ServerInfo.Name,
+		"server_version", result.ServerInfo.Version,
+	)
+
+	if err := u.sync(ctx); err != nil {
+		u.disconnect()
+		return err
+	}
+	return nil
+}
+
+func (u *Upstream) disconnect() {
+	u.mu.Lock()
+	c := u.client
+	u.client = nil
+	u.connected = false
+	u.mu.Unlock()
+
+	if c != nil {
+		c.Close()
+	}
+}
+
+// monitor pings the upstream and applies resyncs until the session fails
+func (u *Upstream) monitor(ctx context.Context) error {
+	ticker := time.NewTicker(u.config.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-u.resync:
+			if err := u.sync(ctx); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := u.ping(ctx); err != nil {
+				return fmt.Errorf("health check failed: %w", err)
+			}
+		}
+	}
+}
+
+func (u *Upstream) ping(ctx context.Context) error {
+	c, err := u.session()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.config.RequestTimeout)
+	defer cancel()
+	if err := c.Ping(ctx); err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	u.lastPing = time.Now()
+	u.mu.Unlock()
+	return nil
+}
+
+// handleNotification queues a resync when the upstream's lists change. It
+// runs on the transport's goroutine, so the resync itself happens in monitor.
+func (u *Upstream) handleNotification(notification mcpgo.JSONRPCNotification) {
+	switch notification.Method {
+	case mcpgo.MethodNotificationToolsListChanged, mcpgo.MethodNotificationResourcesListChanged,
+		mcpgo.MethodNotificationPromptsListChanged:
+		select {
+		case u.resync <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// sync lists the upstream's tools, resources and prompts and reconciles the
+// local registries with them
+func (u *Upstream) sync(ctx context.Context) error {
+	c, err := u.session()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.config.RequestTimeout)
+	defer cancel()
+
+	capabilities := c.GetServerCapabilities()
+	var toolList []mcpgo.Tool
+	if capabilities.Tools != nil {
+		result, err := c.ListTools(ctx, mcpgo.ListToolsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list tools: %w", err)
+		}
+		toolList = result.Tools
+	}
+	var resourceList []mcpgo.Resource
+	if capabilities.Resources != nil {
+		result, err := c.ListResources(ctx, mcpgo.ListResourcesRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list resources: %w", err)
+		}
+		resourceList = result.Resources
+	}
+	var promptList []mcpgo.Prompt
+	if capabilities.Prompts != nil {
+		result, err := c.ListPrompts(ctx, mcpgo.ListPromptsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list prompts: %w", err)
+		}
+		promptList = result.Prompts
+	}
+
+	u.syncTools(toolList)
+	u.syncResources(resourceList)
+	u.syncPrompts(promptList)
+	return nil
+}
+
+// syncTools mounts new and changed tools and unmounts the ones the upstream
+// no longer lists
+func (u *Upstream) syncTools(list []mcpgo.Tool) {
+	desired := make(map[string]mcpgo.Tool, len(list))
+	for _, tool := range list {
+		name, ok := localToolName(u.config.Namespace, tool.Name)
+		if !ok {
+			u.logger.Warn("skipping upstream tool with an unrepresentable name",
+				"upstream", u.config.Name,
+				"tool", tool.Name,
+			)
+			continue
+		}
+		if _, exists := desired[name]; exists {
+			u.logger.Warn("skipping upstream tool whose local name collides with another",
+				"upstream", u.config.Name,
+				"tool", tool.Name,
+				"name", name,
+			)
+			continue
+		}
+		desired[name] = tool
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for name, tool := range u.mountedTools {
+		if next, exists := desired[name]; exists && sameDefinition(tool, next) {
+			continue
+		}
+		if err := u.tools.Unregister(name); err != nil {
+			u.logger.Warn("failed to unmount upstream tool", "upstream", u.config.Name, "name", name, "error", err)
+		}
+		delete(u.mountedTools, name)
+	}
+
+	version := u.version()
+	for name, tool := range desired {
+		if _, exists := u.mountedTools[name]; exists {
+			continue
+		}
+		if err := u.tools.Register(name, newToolFactory(u, name, version, tool)); err != nil {
+			u.logger.Warn("failed to mount upstream tool", "upstream", u.config.Name, "name", name, "error", err)
+			continue
+		}
+		u.mountedTools[name] = tool
+
+		// Creating the instance publishes the tool to protocol clients
+		if _, err := u.tools.Get(name); err != nil {
+			u.logger.Warn("failed to load upstream tool", "upstream", u.config.Name, "name", name, "error", err)
+			continue
+		}
+		if err := u.tools.TransitionStatus(name, tools.ToolStatusActive); err != nil {
+			u.logger.Warn("failed to activate upstream tool", "upstream", u.config.Name, "name", name, "error", err)
+		}
+	}
+}
+
+// syncResources mounts new and changed resources and unmounts the ones the
+// upstream no longer lists
+func (u *Upstream) syncResources(list []mcpgo.Resource) {
+	desired := make(map[string]mcpgo.Resource, len(list))
+	for _, resource := range list {
+		desired[localResourceURI(u.config.Namespace, resource.URI)] = resource
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for uri, resource := range u.mountedResources {
+		if next, exists := desired[uri]; exists && sameDefinition(resource, next) {
+			continue
+		}
+		if err := u.resources.Unregister(uri); err != nil {
+			u.logger.Warn("failed to unmount upstream resource", "upstream", u.config.Name, "uri", uri, "error", err)
+		}
+		delete(u.mountedResources, uri)
+	}
+
+	version := u.version()
+	for uri, resource := range desired {
+		if _, exists := u.mountedResources[uri]; exists {
+			continue
+		}
+		if err := u.resources.Register(uri, newResourceFactory(u, uri, version, resource)); err != nil {
+			u.logger.Warn("failed to mount upstream resource", "upstream", u.config.Name, "uri", uri, "error", err)
+			continue
+		}
+		u.mountedResources[uri] = resource
+
+		if _, err := u.resources.Get(uri); err != nil {
+			u.logger.Warn("failed to load upstream resource", "upstream", u.config.Name, "uri", uri, "error", err)
+			continue
+		}
+		if err := u.resources.TransitionStatus(uri, resources.ResourceStatusActive); err != nil {
+			u.logger.Warn("failed to activate upstream resource", "upstream", u.config.Name, "uri", uri, "error", err)
+		}
+	}
+}
+
+// syncPrompts registers new and changed prompts and unregisters the ones
+// the upstream no longer lists; the prompt registry publishes them
+func (u *Upstream) syncPrompts(list []mcpgo.Prompt) {
+	desired := make(map[string]mcpgo.Prompt, len(list))
+	for _, prompt := range list {
+		desired[localPromptName(u.config.Namespace, prompt.Name)] = prompt
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for name, prompt := range u.mountedPrompts {
+		if next, exists := desired[name]; exists && sameDefinition(prompt, next) {
+			continue
+		}
+		if err := u.prompts.Unregister(name); err != nil {
+			u.logger.Warn("failed to unmount upstream prompt", "upstream", u.config.Name, "name", name, "error", err)
+		}
+		delete(u.mountedPrompts, name)
+	}
+
+	for name, prompt := range desired {
+		if _, exists := u.mountedPrompts[name]; exists {
+			continue
+		}
+		if err := u.prompts.Register(newPrompt(u, name, prompt)); err != nil {
+			u.logger.Warn("failed to mount upstream prompt", "upstream", u.config.Name, "name", name, "error", err)
+			continue
+		}
+		u.mountedPrompts[name] = prompt
+	}
+}
+
+// version is the upstream server's version when it is semantic, so mounted
+// entries show which release they come from. Callers hold u.mu.
+func (u *Upstream) version() string {
+	if semverPattern.MatchString(u.server.Version) {
+		return u.server.Version
+	}
+	return fallbackVersion
+}
+
+// session returns the connected client, failing fast while disconnected
+func (u *Upstream) session() (*client.Client, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	if !u.connected || u.client == nil {
+		if u.lastError != "" {
+			return nil, fmt.Errorf("%w: %s: %s", ErrUpstreamUnavailable, u.config.Name, u.lastError)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrUpstreamUnavailable, u.config.Name)
+	}
+	return u.client, nil
+}
+
+// callTool forwards a call to the upstream under its original name
+func (u *Upstream) callTool(ctx context.Context, name string, arguments map[string]interface{}) (*mcpgo.CallToolResult, error) {
+	c, err := u.session()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.config.RequestTimeout)
+	defer cancel()
+
+	request := mcpgo.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	result, err := c.CallTool(ctx, request)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("upstream %s did not answer within %v", u.config.Name, u.config.RequestTimeout)
+		}
+		return nil, fmt.Errorf("upstream %s: %w", u.config.Name, err)
+	}
+	return result, nil
+}
+
+// readResource forwards a read to the upstream under the original URI
+func (u *Upstream) readResource(ctx context.Context, uri string) (*mcpgo.ReadResourceResult, error) {
+	c, err := u.session()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.config.RequestTimeout)
+	defer cancel()
+
+	request := mcpgo.ReadResourceRequest{}
+	request.Params.URI = uri
+	result, err := c.ReadResource(ctx, request)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("upstream %s did not answer within %v", u.config.Name, u.config.RequestTimeout)
+		}
+		return nil, fmt.Errorf("upstream %s: %w", u.config.Name, err)
+	}
+	return result, nil
+}
+
+// getPrompt forwards a prompt request to the upstream under its original name
+func (u *Upstream) getPrompt(ctx context.Context, name string, arguments map[string]string) (*mcpgo.GetPromptResult, error) {
+	c, err := u.session()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.config.RequestTimeout)
+	defer cancel()
+
+	request := mcpgo.GetPromptRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	result, err := c.GetPrompt(ctx, request)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("upstream %s did not answer within %v", u.config.Name, u.config.RequestTimeout)
+		}
+		return nil, fmt.Errorf("upstream %s: %w", u.config.Name, err)
+	}
+	return result, nil
+}
+
+func (u *Upstream) recordError(err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.lastError = err.Error()
+}
+
+// Health reports the connection state and the mounted entries
+func (u *Upstream) Health() UpstreamHealth {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	health := UpstreamHealth{
+		Name:       u.config.Name,
+		Namespace:  u.config.Namespace,
+		Transport:  u.config.Transport,
+		Connected:  u.connected,
+		LastError:  u.lastError,
+		Reconnects: u.reconnects,
+		Tools:      make([]string, 0, len(u.mountedTools)),
+		Resources:  make([]string, 0, len(u.mountedResources)),
+		Prompts:    make([]string, 0, len(u.mountedPrompts)),
+	}
+	if u.server.Name != "" {
+		health.Server = u.server.Name + " " + u.server.Version
+	}
+	if !u.lastPing.IsZero() {
+		health.LastPing = u.lastPing.UTC().Format(time.RFC3339)
+	}
+	for name := range u.mountedTools {
+		health.Tools = append(health.Tools, name)
+	}
+	for uri := range u.mountedResources {
+		health.Resources = append(health.Resources, uri)
+	}
+	for name := range u.mountedPrompts {
+		health.Prompts = append(health.Prompts, name)
+	}
+	sort.Strings(health.Tools)
+	sort.Strings(health.Resources)
+	sort.Strings(health.Prompts)
+	return health
+}
+
+// dialConfigured opens a session over the configured transport
+func (u *Upstream) dialConfigured(ctx context.Context) (*client.Client, error) {
+	switch u.config.Transport {
+	case config.UpstreamTransportHTTP:
+		headers := make(map[string]string, len(u.config.Headers))
+		for name, value := range u.config.Headers {
+			headers[name] = os.ExpandEnv(value)
+		}
+		c, err := client.NewStreamableHttpClient(u.config.URL,
+			transport.WithHTTPHeaders(headers),
+			transport.WithContinuousListening(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+		}
+		// The context outlives this call: it bounds the notification stream
+		if err := c.Start(ctx); err != nil {
+			return nil, fmt.Errorf("failed to start HTTP client: %w", err)
+		}
+		return c, nil
+	default:
+		env := environ(u.config.Env)
+		c, err := client.NewStdioMCPClientWithOptions(u.config.Command, nil, u.config.Args,
+			transport.WithCommandFunc(func(ctx context.Context, command string, _ []string, args []string) (*osexec.Cmd, error) {
+				cmd := osexec.CommandContext(ctx, command, args...)
+				cmd.Env = env
+				return cmd, nil
+			}),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to launch %s: %w", u.config.Command, err)
+		}
+		return c, nil
+	}
+}
+
+// environ returns the allowlisted variables of the server's environment;
+// child processes inherit nothing else
+func environ(allowed []string) []string {
+	env := make([]string, 0, len(allowed))
+	for _, name := range allowed {
+		if value, exists := os.LookupEnv(name); exists {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
+
+// sameDefinition reports whether two upstream definitions are identical, so
+// unchanged entries keep their registry state across resyncs
+func sameDefinition(a, b interface{}) bool {
+	left, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	right, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(left) == string(right)
+}
+
+// localToolName prefixes an upstream tool name with the namespace. Characters
+// tool names cannot hold become underscores; names that still do not fit
+// are not mounted.
+func localToolName(namespace, name string) (string, bool) {
+	sanitized := strings.Map(func(r rune) rune {
+		if r < 128 && (r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9')) {
+			return r
+		}
+		return '_'
+	}, name)
+	local := namespace + "_" + sanitized
+	if sanitized == "" || len(local) > 64 {
+		return "", false
+	}
+	return local, true
+}
@@ -0,0 +1,426 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/prompts"
+	"mcp-server/internal/resources"
+	"mcp-server/internal/tools"
+)
+
+// upstreamEnv switches the test binary into serving the test upstream over
+// stdio, so the stdio transport is exercised against a real child process
+const upstreamEnv = "MCP_PROXY_TEST_UPSTREAM"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(upstreamEnv) == "1" {
+		if err := server.ServeStdio(newTestUpstreamServer()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func newTestUpstreamServer() *server.MCPServer {
+	s := server.NewMCPServer("test-upstream", "2.1.0",
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(false, true),
+		server.WithPromptCapabilities(true),
+	)
+	s.AddTool(mcpgo.NewTool("greet",
+		mcpgo.WithDescription("Greets someone"),
+		mcpgo.WithString("name", mcpgo.Required()),
+	), func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+		return mcpgo.NewToolResultText("hello " + request.GetString("name", "")), nil
+	})
+	s.AddTool(mcpgo.NewTool("fail",
+		mcpgo.WithDescription("Always fails"),
+	), func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+		return mcpgo.NewToolResultError("boom"), nil
+	})
+	s.AddTool(mcpgo.NewTool("getenv",
+		mcpgo.WithDescription("Reads an environment variable"),
+		mcpgo.WithString("name", mcpgo.Required()),
+	), func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+		return mcpgo.NewToolResultText(os.Getenv(request.GetString("name", ""))), nil
+	})
+	s.AddResource(mcpgo.NewResource("file:///notes.txt", "notes.txt",
+		mcpgo.WithResourceDescription("Team notes"),
+		mcpgo.WithMIMEType("text/plain"),
+	), func(ctx context.Context, request mcpgo.ReadResourceRequest) ([]mcpgo.ResourceContents, error) {
+		return []mcpgo.ResourceContents{mcpgo.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "text/plain",
+			Text:     "remember the milk",
+		}}, nil
+	})
+	s.AddPrompt(mcpgo.NewPrompt("summarize",
+		mcpgo.WithPromptDescription("Summarizes a topic"),
+		mcpgo.WithArgument("topic", mcpgo.RequiredArgument(), mcpgo.ArgumentDescription("What to summarize")),
+	), func(ctx context.Context, request mcpgo.GetPromptRequest) (*mcpgo.GetPromptResult, error) {
+		return mcpgo.NewGetPromptResult("Summary prompt", []mcpgo.PromptMessage{
+			mcpgo.NewPromptMessage(mcpgo.RoleUser, mcpgo.NewTextContent("Summarize "+request.Params.Arguments["topic"])),
+		}), nil
+	})
+	return s
+}
+
+// promptPublisher records the prompts a prompt registry publishes
+type promptPublisher struct {
+	mu      sync.Mutex
+	prompts map[string]mcp.Prompt
+}
+
+func (p *promptPublisher) RegisterPrompt(prompt mcp.Prompt) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prompts[prompt.Name()] = prompt
+	return nil
+}
+
+func (p *promptPublisher) UnregisterPrompt(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.prompts, name)
+	return nil
+}
+
+func (p *promptPublisher) prompt(name string) mcp.Prompt {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.prompts[name]
+}
+
+func newTestRegistries(t *testing.T) (tools.ToolRegistry, resources.ResourceRegistry, prompts.PromptRegistry, *logger.Logger) {
+	t.Helper()
+	log, err := logger.NewDefault()
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	cfg := &config.Config{}
+	toolRegistry := tools.NewDefaultToolRegistry(cfg, log)
+	return toolRegistry, resources.NewDefaultResourceRegistry(cfg, log), prompts.NewDefaultPromptRegistry(toolRegistry, log), log
+}
+
+func testUpstreamConfig() config.UpstreamConfig {
+	return config.UpstreamConfig{
+		Name:                "docs",
+		Namespace:           "docs",
+		HealthInterval:      50 * time.Millisecond,
+		RequestTimeout:      5 * time.Second,
+		ReconnectBackoff:    10 * time.Millisecond,
+		MaxReconnectBackoff: 50 * time.Millisecond,
+	}
+}
+
+// waitFor polls a condition until it holds or the test times out
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func hasTool(registry tools.ToolRegistry, name string) bool {
+	for _, info := range registry.List() {
+		if info.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func callTool(t *testing.T, registry tools.ToolRegistry, name, params string) (string, error) {
+	t.Helper()
+	tool, err := registry.Get(name)
+	if err != nil {
+		t.Fatalf("Failed to get tool %s: %v", name, err)
+	}
+	result, err := tool.Handler().Handle(context.Background(), json.RawMessage(params))
+	if err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if result.IsError() {
+		return "", result.GetError()
+	}
+	return result.GetContent()[0].GetText(), nil
+}
+
+func TestUpstream_HTTP(t *testing.T) {
+	upstreamServer := newTestUpstreamServer()
+	ts := server.NewTestStreamableHTTPServer(upstreamServer)
+	defer ts.Close()
+
+	toolRegistry, resourceRegistry, promptRegistry, log := newTestRegistries(t)
+	cfg := testUpstreamConfig()
+	cfg.Transport = config.UpstreamTransportHTTP
+	cfg.URL = ts.URL
+	upstream := NewUpstream(cfg, toolRegistry, resourceRegistry, promptRegistry, log)
+	upstream.Start(context.Background())
+	defer upstream.Stop()
+
+	waitFor(t, "tools to mount", func() bool { return hasTool(toolRegistry, "docs_greet") })
+
+	text, err := callTool(t, toolRegistry, "docs_greet", `{"name": "Ada"}`)
+	if err != nil || text != "hello Ada" {
+		t.Errorf("Expected 'hello Ada', got %q (error: %v)", text, err)
+	}
+	if _, err := callTool(t, toolRegistry, "docs_fail", `{}`); err == nil || err.Error() != "boom" {
+		t.Errorf("Expected upstream error 'boom', got %v", err)
+	}
+
+	tool, _ := toolRegistry.Get("docs_greet")
+	if !strings.Contains(string(tool.Parameters()), `"name"`) {
+		t.Errorf("Expected the upstream input schema, got %s", tool.Parameters())
+	}
+
+	uri := localResourceURI("docs", "file:///notes.txt")
+	resource, err := resourceRegistry.Get(uri)
+	if err != nil {
+		t.Fatalf("Failed to get resource %s: %v", uri, err)
+	}
+	content, err := resource.Handler().Read(context.Background(), uri)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if content.GetContent()[0].GetText() != "remember the milk" || content.GetMimeType() != "text/plain" {
+		t.Errorf("Unexpected resource content %q (%s)", content.GetContent()[0].GetText(), content.GetMimeType())
+	}
+
+	health := upstream.Health()
+	if !health.Connected || health.Server != "test-upstream 2.1.0" || len(health.Tools) != 3 || len(health.Resources) != 1 {
+		t.Errorf("Unexpected health %+v", health)
+	}
+	for _, info := range toolRegistry.List() {
+		if info.Version != "2.1.0" {
+			t.Errorf("Expected tool %s to carry the upstream version, got %s", info.Name, info.Version)
+		}
+	}
+}
+
+func TestUpstream_Prompts(t *testing.T) {
+	upstreamServer := newTestUpstreamServer()
+	ts := server.NewTestStreamableHTTPServer(upstreamServer)
+	defer ts.Close()
+
+	toolRegistry, resourceRegistry, _, log := newTestRegistries(t)
+	publisher := &promptPublisher{prompts: make(map[string]mcp.Prompt)}
+	promptRegistry := prompts.NewDefaultPromptRegistry(publisher, log)
+	promptRegistry.Start(context.Background())
+	cfg := testUpstreamConfig()
+	cfg.HealthInterval = time.Hour
+	cfg.Transport = config.UpstreamTransportHTTP
+	cfg.URL = ts.URL
+	upstream := NewUpstream(cfg, toolRegistry, resourceRegistry, promptRegistry, log)
+	upstream.Start(context.Background())
+	defer upstream.Stop()
+
+	waitFor(t, "prompt to mount", func() bool { return publisher.prompt("docs_summarize") != nil })
+
+	if infos := promptRegistry.List(); len(infos) != 1 || infos[0].Status != prompts.PromptStatusActive {
+		t.Errorf("Expected the prompt active in the prompt registry, got %+v", infos)
+	}
+
+	prompt := publisher.prompt("docs_summarize")
+	arguments := prompt.Arguments()
+	if prompt.Description() != "Summarizes a topic" || len(arguments) != 1 || arguments[0].Name != "topic" || !arguments[0].Required {
+		t.Errorf("Unexpected prompt %s: %q %+v", prompt.Name(), prompt.Description(), arguments)
+	}
+
+	result, err := prompt.Handler().Get(context.Background(), map[string]string{"topic": "the milk"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if len(result.Messages) != 1 || result.Messages[0].Role != "user" || result.Messages[0].Content.GetText() != "Summarize the milk" {
+		t.Errorf("Unexpected prompt result %+v", result)
+	}
+	if health := upstream.Health(); len(health.Prompts) != 1 || health.Prompts[0] != "docs_summarize" {
+		t.Errorf("Expected the prompt in health, got %+v", health.Prompts)
+	}
+
+	upstreamServer.DeletePrompts("summarize")
+	waitFor(t, "removed prompt to unmount", func() bool { return publisher.prompt("docs_summarize") == nil })
+	if infos := promptRegistry.List(); len(infos) != 0 {
+		t.Errorf("Expected the prompt unregistered, got %+v", infos)
+	}
+}
+
+func TestUpstream_ListChanged(t *testing.T) {
+	upstreamServer := newTestUpstreamServer()
+	ts := server.NewTestStreamableHTTPServer(upstreamServer)
+	defer ts.Close()
+
+	toolRegistry, resourceRegistry, promptRegistry, log := newTestRegistries(t)
+	cfg := testUpstreamConfig()
+	// Only notifications can trigger a resync within the test
+	cfg.HealthInterval = time.Hour
+	cfg.Transport = config.UpstreamTransportHTTP
+	cfg.URL = ts.URL
+	upstream := NewUpstream(cfg, toolRegistry, resourceRegistry, promptRegistry, log)
+	upstream.Start(context.Background())
+	defer upstream.Stop()
+
+	waitFor(t, "tools to mount", func() bool { return hasTool(toolRegistry, "docs_greet") })
+
+	upstreamServer.AddTool(mcpgo.NewTool("late", mcpgo.WithDescription("Added later")),
+		func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+			return mcpgo.NewToolResultText("late"), nil
+		})
+	waitFor(t, "added tool to mount", func() bool { return hasTool(toolRegistry, "docs_late") })
+
+	upstreamServer.DeleteTools("greet")
+	waitFor(t, "removed tool to unmount", func() bool { return !hasTool(toolRegistry, "docs_greet") })
+
+	if _, err := toolRegistry.Get("docs_greet"); !errors.Is(err, tools.ErrToolNotFound) {
+		t.Errorf("Expected ErrToolNotFound for removed tool, got %v", err)
+	}
+}
+
+func TestUpstream_Stdio(t *testing.T) {
+	t.Setenv(upstreamEnv, "1")
+	t.Setenv("PROXY_TEST_ALLOWED", "visible")
+	t.Setenv("PROXY_TEST_SECRET", "hidden")
+
+	toolRegistry, resourceRegistry, promptRegistry, log := newTestRegistries(t)
+	cfg := testUpstreamConfig()
+	cfg.Transport = config.UpstreamTransportStdio
+	cfg.Command = os.Args[0]
+	cfg.Env = []string{upstreamEnv, "PROXY_TEST_ALLOWED"}
+	upstream := NewUpstream(cfg, toolRegistry, resourceRegistry, promptRegistry, log)
+	upstream.Start(context.Background())
+
+	waitFor(t, "tools to mount", func() bool { return hasTool(toolRegistry, "docs_getenv") })
+
+	if text, err := callTool(t, toolRegistry, "docs_getenv", `{"name": "PROXY_TEST_ALLOWED"}`); err != nil || text != "visible" {
+		t.Errorf("Expected allowlisted variable to be passed, got %q (error: %v)", text, err)
+	}
+	if text, err := callTool(t, toolRegistry, "docs_getenv", `{"name": "PROXY_TEST_SECRET"}`); err != nil || text != "" {
+		t.Errorf("Expected other variables to be withheld, got %q (error: %v)", text, err)
+	}
+
+	upstream.Stop()
+	if len(toolRegistry.List()) != 0 || len(resourceRegistry.List()) != 0 {
+		t.Errorf("Expected Stop to unmount everything, got %d tools and %d resources",
+			len(toolRegistry.List()), len(resourceRegistry.List()))
+	}
+}
+
+func TestUpstream_Reconnect(t *testing.T) {
+	upstreamServer := newTestUpstreamServer()
+	ts := server.NewTestStreamableHTTPServer(upstreamServer)
+	defer ts.Close()
+
+	toolRegistry, resourceRegistry, promptRegistry, log := newTestRegistries(t)
+	cfg := testUpstreamConfig()
+	cfg.Transport = config.UpstreamTransportHTTP
+	cfg.URL = ts.URL
+	upstream := newUpstream(cfg, toolRegistry, resourceRegistry, promptRegistry, log)
+
+	// The first dials fail, later ones succeed until the upstream goes down
+	var dials, down atomic.Int32
+	upstream.dial = func(ctx context.Context) (*client.Client, error) {
+		if dials.Add(1) <= 2 || down.Load() == 1 {
+			return nil, fmt.Errorf("connection refused")
+		}
+		return upstream.dialConfigured(ctx)
+	}
+	upstream.Start(context.Background())
+	defer upstream.Stop()
+
+	waitFor(t, "connection after failed dials", func() bool { return upstream.Health().Connected })
+	if health := upstream.Health(); health.LastError != "" {
+		t.Errorf("Expected the last error to clear once connected, got %q", health.LastError)
+	}
+
+	// Health checks notice the upstream is gone; calls then fail fast while
+	// the mounted tools stay registered
+	down.Store(1)
+	ts.Listener.Close()
+	ts.CloseClientConnections()
+	waitFor(t, "disconnect", func() bool { return !upstream.Health().Connected })
+
+	if !hasTool(toolRegistry, "docs_greet") {
+		t.Error("Expected mounted tools to stay registered while disconnected")
+	}
+	if _, err := callTool(t, toolRegistry, "docs_greet", `{"name": "Ada"}`); !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("Expected ErrUpstreamUnavailable while disconnected, got %v", err)
+	}
+	waitFor(t, "reconnect attempts", func() bool { return upstream.Health().Reconnects >= 1 })
+}
+
+func TestLocalToolName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{"search", "gh_search", true},
+		{"list-issues", "gh_list_issues", true},
+		{"files.read", "gh_files_read", true},
+		{"", "", false},
+		{strings.Repeat("x", 62), "", false},
+	}
+
+	for _, tt := range tests {
+		local, ok := localToolName("gh", tt.name)
+		if local != tt.expected || ok != tt.ok {
+			t.Errorf("localToolName(%q) = %q, %v; expected %q, %v", tt.name, local, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestConvertContent(t *testing.T) {
+	content, err := convertContent([]mcpgo.Content{
+		mcpgo.NewTextContent("text"),
+		mcpgo.NewImageContent("aW1hZ2U=", "image/png"),
+		mcpgo.NewEmbeddedResource(mcpgo.BlobResourceContents{URI: "file:///a", Blob: "YmxvYg=="}),
+		mcpgo.NewResourceLink("file:///b", "b", "", "text/plain"),
+	})
+	if err != nil {
+		t.Fatalf("convertContent failed: %v", err)
+	}
+
+	expected := []struct {
+		kind string
+		text string
+	}{
+		{"text", "text"},
+		{"blob", "image"},
+		{"blob", "blob"},
+		{"text", `{"type":"resource_link","uri":"file:///b","name":"b","description":"","mimeType":"text/plain"}`},
+	}
+	if len(content) != len(expected) {
+		t.Fatalf("Expected %d items, got %d", len(expected), len(content))
+	}
+	for i, item := range content {
+		if item.Type() != expected[i].kind || item.GetText() != expected[i].text {
+			t.Errorf("Item %d: expected %s %q, got %s %q", i, expected[i].kind, expected[i].text, item.Type(), item.GetText())
+		}
+	}
+
+	if _, err := convertContent([]mcpgo.Content{mcpgo.NewImageContent("not base64!", "image/png")}); err == nil {
+		t.Error("Expected invalid image data to fail")
+	}
+}
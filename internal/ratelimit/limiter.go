@@ -0,0 +1,188 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a request exceeds its rate limit
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// idleSweepInterval is how often buckets that refilled completely are dropped
+const idleSweepInterval = time.Minute
+
+// Limit is a sustained rate in requests per second with a burst allowance.
+// A non-positive rate means unlimited.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether the limit never rejects requests
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+func (l Limit) burst() float64 {
+	if l.Burst < 1 {
+		return 1
+	}
+	return float64(l.Burst)
+}
+
+// LimitError describes a rejected request and when a retry can succeed
+type LimitError struct {
+	Scope      string
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s rate limit exceeded; retry after %v", e.Scope, e.RetryAfter)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// RetryAfter returns the retry hint carried by a rate limit error
+func RetryAfter(err error) (time.Duration, bool) {
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		return limitErr.RetryAfter, true
+	}
+	return 0, false
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter keeps one token bucket per key, e.g. per session or client address
+type Limiter struct {
+	scope     string
+	limit     Limit
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// NewLimiter creates a limiter; scope names the key space in error messages
+func NewLimiter(scope string, limit Limit) *Limiter {
+	return &Limiter{
+		scope:   scope,
+		limit:   limit,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Limit returns the configured limit
+func (l *Limiter) Limit() Limit {
+	if l == nil {
+		return Limit{}
+	}
+	return l.limit
+}
+
+// Allow takes a token from the bucket of key, returning a *LimitError when
+// the bucket is empty. A nil limiter allows everything.
+func (l *Limiter) Allow(key string) error {
+	return AllowAll(Check{Limiter: l, Key: key})
+}
+
+// Check names the bucket of one limiter a request draws from
+type Check struct {
+	Limiter *Limiter
+	Key     string
+}
+
+// AllowAll takes a token from every bucket, or from none of them: a request
+// rejected by one limiter does not use up the budget of the others. The
+// *LimitError names the first empty bucket. Nil and unlimited limiters are
+// skipped. The limiters are locked in the order given, so every caller
+// sharing limiters must pass them in the same order.
+func AllowAll(checks ...Check) error {
+	var locked []*Limiter
+	defer func() {
+		for _, l := range locked {
+			l.mu.Unlock()
+		}
+	}()
+
+	buckets := make([]*bucket, 0, len(checks))
+	for _, check := range checks {
+		l := check.Limiter
+		if l == nil || l.limit.Unlimited() {
+			continue
+		}
+		if !slices.Contains(locked, l) {
+			l.mu.Lock()
+			locked = append(locked, l)
+		}
+
+		b := l.refill(check.Key)
+		// The epsilon absorbs float drift so a retry at exactly the hinted
+		// time succeeds
+		if b.tokens < 1-1e-9 {
+			return l.limitError(b)
+		}
+		buckets = append(buckets, b)
+	}
+
+	for _, b := range buckets {
+		b.tokens = math.Max(0, b.tokens-1)
+	}
+	return nil
+}
+
+// refill returns the bucket of key topped up to now; callers hold l.mu
+func (l *Limiter) refill(key string) *bucket {
+	now := l.now()
+	l.sweep(now)
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: l.limit.burst(), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.limit.burst(), b.tokens+now.Sub(b.last).Seconds()*l.limit.Rate)
+	b.last = now
+	return b
+}
+
+func (l *Limiter) limitError(b *bucket) *LimitError {
+	wait := time.Duration((1 - b.tokens) / l.limit.Rate * float64(time.Second)).Round(time.Microsecond)
+	return &LimitError{Scope: l.scope, RetryAfter: (wait + time.Millisecond - 1).Truncate(time.Millisecond)}
+}
+
+// Len returns the number of tracked keys
+func (l *Limiter) Len() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// sweep drops buckets that have refilled completely, since a fresh bucket
+// behaves identically; this bounds memory for short-lived sessions
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	refill := time.Duration(l.limit.burst() / l.limit.Rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
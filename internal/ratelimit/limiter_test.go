@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestLimiter(limit Limit) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewLimiter("session", limit)
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+func TestLimiter_BurstThenRefill(t *testing.T) {
+	limiter, clock := newTestLimiter(Limit{Rate: 2, Burst: 3})
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Allow("a"); err != nil {
+			t.Fatalf("request %d within burst rejected: %v", i, err)
+		}
+	}
+
+	err := limiter.Allow("a")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Allow() error = %v, want ErrRateLimited", err)
+	}
+	retryAfter, ok := RetryAfter(err)
+	if !ok || retryAfter != 500*time.Millisecond {
+		t.Errorf("RetryAfter = %v, want 500ms", retryAfter)
+	}
+
+	clock.Advance(retryAfter)
+	if err := limiter.Allow("a"); err != nil {
+		t.Errorf("request after retry hint rejected: %v", err)
+	}
+}
+
+func TestLimiter_KeysAreIndependent(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{Rate: 1, Burst: 1})
+
+	if err := limiter.Allow("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := limiter.Allow("a"); err == nil {
+		t.Error("second request for key a should be rejected")
+	}
+	if err := limiter.Allow("b"); err != nil {
+		t.Errorf("key b should have its own bucket: %v", err)
+	}
+}
+
+func TestLimiter_SweepsIdleBuckets(t *testing.T) {
+	limiter, clock := newTestLimiter(Limit{Rate: 10, Burst: 10})
+
+	for i := 0; i < 5; i++ {
+		limiter.Allow(fmt.Sprintf("session-%d", i))
+	}
+	if got := limiter.Len(); got != 5 {
+		t.Fatalf("Len() = %d, want 5", got)
+	}
+
+	clock.Advance(2 * idleSweepInterval)
+	limiter.Allow("fresh")
+	if got := limiter.Len(); got != 1 {
+		t.Errorf("Len() after sweep = %d, want 1", got)
+	}
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{})
+	for i := 0; i < 100; i++ {
+		if err := limiter.Allow("a"); err != nil {
+			t.Fatalf("unlimited limiter rejected request: %v", err)
+		}
+	}
+
+	var nilLimiter *Limiter
+	if err := nilLimiter.Allow("a"); err != nil {
+		t.Errorf("nil limiter rejected request: %v", err)
+	}
+}
+
+func TestAllowAll_ConsumesNothingOnRejection(t *testing.T) {
+	session, _ := newTestLimiter(Limit{Rate: 1, Burst: 5})
+	tool, _ := newTestLimiter(Limit{Rate: 1, Burst: 1})
+	tool.scope = "tool"
+
+	if err := AllowAll(Check{Limiter: session, Key: "s1"}, Check{Limiter: tool}); err != nil {
+		t.Fatalf("first request rejected: %v", err)
+	}
+	// The empty tool bucket rejects the next requests; they must not drain
+	// the session budget
+	for i := 0; i < 10; i++ {
+		err := AllowAll(Check{Limiter: session, Key: "s1"}, Check{Limiter: tool})
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) || limitErr.Scope != "tool" {
+			t.Fatalf("expected tool limit, got %v", err)
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		if err := session.Allow("s1"); err != nil {
+			t.Fatalf("session budget was drained by rejected requests: %v", err)
+		}
+	}
+	if err := session.Allow("s1"); err == nil {
+		t.Error("expected the session budget to be used up")
+	}
+}
+
+func TestLimitError_Message(t *testing.T) {
+	err := fmt.Errorf("tool echo: %w", &LimitError{Scope: "client", RetryAfter: 1500 * time.Millisecond})
+	if got := err.Error(); got != "tool echo: client rate limit exceeded; retry after 1.5s" {
+		t.Errorf("Error() = %q", got)
+	}
+	if _, ok := RetryAfter(errors.New("other")); ok {
+		t.Error("RetryAfter should not match unrelated errors")
+	}
+}
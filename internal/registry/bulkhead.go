@@ -0,0 +1,141 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrBulkheadFull is returned when all slots are busy and the wait queue is full
+	ErrBulkheadFull = errors.New("bulkhead full")
+	// ErrBulkheadTimeout is returned when a queued call waited longer than the queue timeout
+	ErrBulkheadTimeout = errors.New("bulkhead queue timeout")
+)
+
+// BulkheadConfig bounds concurrent executions and the callers waiting for one
+type BulkheadConfig struct {
+	MaxConcurrent int
+	MaxQueue      int
+	QueueTimeout  time.Duration
+}
+
+// saturationWindow is how long a rejected or timed out call keeps a bulkhead
+// reported as saturated
+const saturationWindow = 30 * time.Second
+
+// BulkheadStats is a point-in-time view of a bulkhead. A bulkhead is
+// saturated when calls are waiting for a slot or were turned away within the
+// saturation window; merely using every slot is normal operation.
+type BulkheadStats struct {
+	MaxConcurrent int   `json:"max_concurrent"`
+	MaxQueue      int   `json:"max_queue"`
+	InFlight      int   `json:"in_flight"`
+	Queued        int   `json:"queued"`
+	Rejected      int64 `json:"rejected"`
+	TimedOut      int64 `json:"timed_out"`
+	Saturated     bool  `json:"saturated"`
+}
+
+// Bulkhead limits how many calls execute at once so a slow dependency cannot
+// consume every goroutine. Callers beyond the limit wait in a bounded queue.
+type Bulkhead struct {
+	name     string
+	config   BulkheadConfig
+	slots    chan struct{}
+	queued   atomic.Int64
+	rejected atomic.Int64
+	timedOut atomic.Int64
+	// lastTurnedAway is when a call was last rejected or timed out, in Unix
+	// nanoseconds
+	lastTurnedAway atomic.Int64
+	now            func() time.Time
+}
+
+// NewBulkhead creates a bulkhead; MaxConcurrent below one is treated as one
+func NewBulkhead(name string, config BulkheadConfig) *Bulkhead {
+	if config.MaxConcurrent < 1 {
+		config.MaxConcurrent = 1
+	}
+	if config.MaxQueue < 0 {
+		config.MaxQueue = 0
+	}
+	return &Bulkhead{
+		name:   name,
+		config: config,
+		slots:  make(chan struct{}, config.MaxConcurrent),
+		now:    time.Now,
+	}
+}
+
+// Acquire takes an execution slot, waiting in the queue if necessary. The
+// returned release function must be called exactly once when the call ends;
+// extra calls are ignored.
+func (b *Bulkhead) Acquire(ctx context.Context) (func(), error) {
+	select {
+	case b.slots <- struct{}{}:
+		return b.releaser(), nil
+	default:
+	}
+
+	if b.queued.Add(1) > int64(b.config.MaxQueue) {
+		b.queued.Add(-1)
+		b.rejected.Add(1)
+		b.lastTurnedAway.Store(b.now().UnixNano())
+		return nil, fmt.Errorf("%w: %s has %d executions in flight and %d queued",
+			ErrBulkheadFull, b.name, b.config.MaxConcurrent, b.config.MaxQueue)
+	}
+	defer b.queued.Add(-1)
+
+	var timeout <-chan time.Time
+	if b.config.QueueTimeout > 0 {
+		timer := time.NewTimer(b.config.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return b.releaser(), nil
+	case <-timeout:
+		b.timedOut.Add(1)
+		b.lastTurnedAway.Store(b.now().UnixNano())
+		return nil, fmt.Errorf("%w: %s waited %v for an execution slot",
+			ErrBulkheadTimeout, b.name, b.config.QueueTimeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *Bulkhead) releaser() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-b.slots })
+	}
+}
+
+// Stats returns the current occupancy and rejection counters
+func (b *Bulkhead) Stats() BulkheadStats {
+	queued := int(b.queued.Load())
+	recentlyTurnedAway := false
+	if last := b.lastTurnedAway.Load(); last != 0 {
+		recentlyTurnedAway = b.now().Sub(time.Unix(0, last)) < saturationWindow
+	}
+	return BulkheadStats{
+		MaxConcurrent: b.config.MaxConcurrent,
+		MaxQueue:      b.config.MaxQueue,
+		InFlight:      len(b.slots),
+		Queued:        queued,
+		Rejected:      b.rejected.Load(),
+		TimedOut:      b.timedOut.Load(),
+		Saturated:     queued > 0 || recentlyTurnedAway,
+	}
+}
+
+// GetName returns the bulkhead name
+func (b *Bulkhead) GetName() string {
+	return b.name
+}
@@ -2,7 +2,10 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sony/gobreaker/v2"
@@ -13,8 +16,21 @@ type CircuitBreakerConfig struct {
 	MaxRequests uint32
 	Interval    time.Duration
 	Timeout     time.Duration
+
+	// MinRequests and FailureRatio control when the breaker trips; zero values
+	// fall back to 3 requests and a 0.6 failure ratio
+	MinRequests  uint32
+	FailureRatio float64
+
+	// OnStateChange is notified whenever the breaker changes state
+	OnStateChange func(name string, from, to string)
 }
 
+const (
+	defaultMinRequests  = 3
+	defaultFailureRatio = 0.6
+)
+
 // DefaultCircuitBreakerConfig returns sensible defaults for entity creation circuit breaker
 func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
 	return CircuitBreakerConfig{
@@ -24,39 +40,124 @@ func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
 	}
 }
 
+// ErrCircuitOpen is returned when a call is rejected because the breaker is open
+// or already has the maximum number of half-open probes in flight
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
 // CircuitBreakerFactory wraps any factory with circuit breaker protection
 type CircuitBreakerFactory[T any] struct {
-	name    string
-	breaker *gobreaker.CircuitBreaker[T]
+	name     string
+	config   CircuitBreakerConfig
+	settings gobreaker.Settings
+	breaker  *gobreaker.CircuitBreaker[T]
+	openedAt atomic.Int64 // unix nanoseconds of the last transition to open
+	mu       sync.RWMutex
 }
 
 // NewCircuitBreakerFactory creates a new circuit breaker wrapped factory
 func NewCircuitBreakerFactory[T any](name string, config CircuitBreakerConfig) *CircuitBreakerFactory[T] {
-	settings := gobreaker.Settings{
-		Name:        fmt.Sprintf("factory_%s", name),
+	return newCircuitBreaker[T](name, fmt.Sprintf("factory_%s", name), config)
+}
+
+// NewExecutionCircuitBreaker creates a circuit breaker guarding entity execution
+// rather than creation
+func NewExecutionCircuitBreaker[T any](name string, config CircuitBreakerConfig) *CircuitBreakerFactory[T] {
+	return newCircuitBreaker[T](name, fmt.Sprintf("execution_%s", name), config)
+}
+
+func newCircuitBreaker[T any](name, breakerName string, config CircuitBreakerConfig) *CircuitBreakerFactory[T] {
+	minRequests := config.MinRequests
+	if minRequests == 0 {
+		minRequests = defaultMinRequests
+	}
+	failureRatio := config.FailureRatio
+	if failureRatio <= 0 {
+		failureRatio = defaultFailureRatio
+	}
+
+	cb := &CircuitBreakerFactory[T]{
+		name:   name,
+		config: config,
+	}
+
+	cb.settings = gobreaker.Settings{
+		Name:        breakerName,
 		MaxRequests: config.MaxRequests,
 		Interval:    config.Interval,
 		Timeout:     config.Timeout,
 		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-			return counts.Requests >= 3 && failureRatio >= 0.6
+			ratio := float64(counts.TotalFailures) / float64(counts.Requests)
+			return counts.Requests >= minRequests && ratio >= failureRatio
 		},
-		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
-			// State changes can be logged by the caller if needed
+		OnStateChange: func(_ string, from gobreaker.State, to gobreaker.State) {
+			cb.recordStateChange(to)
+			if config.OnStateChange != nil {
+				config.OnStateChange(name, stateName(from), stateName(to))
+			}
 		},
 	}
+	cb.breaker = gobreaker.NewCircuitBreaker[T](cb.settings)
 
-	breaker := gobreaker.NewCircuitBreaker[T](settings)
+	return cb
+}
 
-	return &CircuitBreakerFactory[T]{
-		name:    name,
-		breaker: breaker,
+func (cb *CircuitBreakerFactory[T]) recordStateChange(to gobreaker.State) {
+	if to == gobreaker.StateOpen {
+		cb.openedAt.Store(time.Now().UnixNano())
 	}
 }
 
+func (cb *CircuitBreakerFactory[T]) current() *gobreaker.CircuitBreaker[T] {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+	return cb.breaker
+}
+
+// Call runs fn with circuit breaker protection and returns its result and
+// error unchanged. Rejected calls return an error wrapping ErrCircuitOpen.
+func (cb *CircuitBreakerFactory[T]) Call(fn func() (T, error)) (T, error) {
+	result, err := cb.current().Execute(fn)
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return result, fmt.Errorf("%w: %s (%v)", ErrCircuitOpen, cb.name, err)
+	}
+	return result, err
+}
+
+// Reset closes the circuit breaker and clears its counts
+func (cb *CircuitBreakerFactory[T]) Reset() {
+	cb.mu.Lock()
+	previous := cb.breaker.State()
+	cb.breaker = gobreaker.NewCircuitBreaker[T](cb.settings)
+	cb.openedAt.Store(0)
+	cb.mu.Unlock()
+
+	if previous != gobreaker.StateClosed && cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(cb.name, stateName(previous), stateName(gobreaker.StateClosed))
+	}
+}
+
+// RetryAfter returns how long until an open breaker allows a half-open probe
+func (cb *CircuitBreakerFactory[T]) RetryAfter() time.Duration {
+	openedAt := cb.openedAt.Load()
+	if cb.current().State() != gobreaker.StateOpen || openedAt == 0 {
+		return 0
+	}
+
+	timeout := cb.settings.Timeout
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+
+	remaining := time.Until(time.Unix(0, openedAt).Add(timeout))
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
 // Execute runs the provided function with circuit breaker protection
 func (cb *CircuitBreakerFactory[T]) Execute(fn func() (T, error)) (T, error) {
-	result, err := cb.breaker.Execute(fn)
+	result, err := cb.current().Execute(fn)
 	if err != nil {
 		var zero T
 		return zero, fmt.Errorf("circuit breaker protected operation failed: %w", err)
@@ -66,7 +167,7 @@ func (cb *CircuitBreakerFactory[T]) Execute(fn func() (T, error)) (T, error) {
 
 // ExecuteWithContext runs the provided function with circuit breaker protection and context
 func (cb *CircuitBreakerFactory[T]) ExecuteWithContext(ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
-	result, err := cb.breaker.Execute(func() (T, error) {
+	result, err := cb.current().Execute(func() (T, error) {
 		return fn(ctx)
 	})
 	if err != nil {
@@ -78,17 +179,17 @@ func (cb *CircuitBreakerFactory[T]) ExecuteWithContext(ctx context.Context, fn f
 
 // GetState returns the current state of the circuit breaker
 func (cb *CircuitBreakerFactory[T]) GetState() gobreaker.State {
-	return cb.breaker.State()
+	return cb.current().State()
 }
 
 // GetCounts returns the current counts from the circuit breaker
 func (cb *CircuitBreakerFactory[T]) GetCounts() gobreaker.Counts {
-	return cb.breaker.Counts()
+	return cb.current().Counts()
 }
 
 // IsOpen returns true if the circuit breaker is open
 func (cb *CircuitBreakerFactory[T]) IsOpen() bool {
-	return cb.breaker.State() == gobreaker.StateOpen
+	return cb.current().State() == gobreaker.StateOpen
 }
 
 // GetName returns the name of the circuit breaker
@@ -98,7 +199,11 @@ func (cb *CircuitBreakerFactory[T]) GetName() string {
 
 // Status returns a string representation of the circuit breaker status
 func (cb *CircuitBreakerFactory[T]) Status() string {
-	switch cb.breaker.State() {
+	return stateName(cb.current().State())
+}
+
+func stateName(state gobreaker.State) string {
+	switch state {
 	case gobreaker.StateClosed:
 		return "closed"
 	case gobreaker.StateHalfOpen:
@@ -123,7 +228,7 @@ type CircuitBreakerMetrics struct {
 
 // GetMetrics returns current circuit breaker metrics
 func (cb *CircuitBreakerFactory[T]) GetMetrics() CircuitBreakerMetrics {
-	counts := cb.breaker.Counts()
+	counts := cb.current().Counts()
 	
 	return CircuitBreakerMetrics{
 		Name:         cb.name,
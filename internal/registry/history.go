@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"context"
+	"time"
+)
+
+// MaxTransitionHistory bounds the transitions kept per tool or resource
+const MaxTransitionHistory = 32
+
+// Actor identifies what caused a status transition
+type Actor string
+
+const (
+	ActorSystem     Actor = "system"
+	ActorAdmin      Actor = "admin"
+	ActorSupervisor Actor = "supervisor"
+)
+
+// TransitionCause explains a status transition. Reason says what happened
+// and Error carries the error behind it, if any.
+type TransitionCause struct {
+	Actor  Actor
+	Reason string
+	Error  string
+}
+
+// SystemCause is the cause of transitions made by the registry itself
+func SystemCause(reason string) TransitionCause {
+	return TransitionCause{Actor: ActorSystem, Reason: reason}
+}
+
+// ErrorCause is the cause of transitions forced by an error
+func ErrorCause(actor Actor, reason string, err error) TransitionCause {
+	cause := TransitionCause{Actor: actor, Reason: reason}
+	if err != nil {
+		cause.Error = err.Error()
+	}
+	return cause
+}
+
+// TransitionRecord is one entry in the status history of a tool or resource
+type TransitionRecord struct {
+	From      LifecycleStatus `json:"from,omitempty"`
+	To        LifecycleStatus `json:"to"`
+	Reason    string          `json:"reason,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Actor     Actor           `json:"actor"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// AppendTransition returns history with the transition appended, dropping
+// the oldest entries beyond MaxTransitionHistory. It never modifies history,
+// so snapshots handed out earlier stay valid without copying.
+func AppendTransition(history []TransitionRecord, from, to LifecycleStatus, cause TransitionCause) []TransitionRecord {
+	if cause.Actor == "" {
+		cause.Actor = ActorSystem
+	}
+
+	keep := min(len(history), MaxTransitionHistory-1)
+	next := make([]TransitionRecord, keep, keep+1)
+	copy(next, history[len(history)-keep:])
+
+	return append(next, TransitionRecord{
+		From:      from,
+		To:        to,
+		Reason:    cause.Reason,
+		Error:     cause.Error,
+		Actor:     cause.Actor,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+type actorKey struct{}
+
+// WithActor records who is acting in ctx, for registry operations that take
+// a context rather than a TransitionCause
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor recorded in ctx, or ActorSystem
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return actor
+	}
+	return ActorSystem
+}
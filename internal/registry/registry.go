@@ -0,0 +1,573 @@
+package registry
+
+import (
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mcp-server/internal/events"
+	"mcp-server/internal/logger"
+)
+
+// registryShards is how many independently locked shards a Registry spreads
+// its entities over, so writes to different entities rarely contend
+const registryShards = 16
+
+// Entry is a snapshot of one entity in a Registry. Entries are immutable
+// once published; their History is shared and must not be modified.
+type Entry[F, T, P any] struct {
+	Key string
+	// StateKey is what the entity's state is persisted under. Unlike Key,
+	// it does not depend on the order entities were registered in.
+	StateKey string
+	Factory  F
+	// Policy is what the owner keeps per entity besides its factory, such
+	// as its rate limiter; it is set at registration and never replaced
+	Policy   P
+	Instance T
+	Loaded   bool // Instance holds a created instance
+	Status   LifecycleStatus
+
+	// History holds the most recent status transitions, oldest first
+	History        []TransitionRecord
+	Restarts       int
+	BreakerResetAt *time.Time
+}
+
+// Hooks let the owner of a Registry react to it. They are called without
+// any registry lock held.
+type Hooks struct {
+	// Removed is told when an entity is unregistered, e.g. to drop its
+	// metrics
+	Removed func(key string)
+}
+
+// entity holds the current Entry of one entity. Writers replace the entry
+// under their shard lock; readers load it without locking.
+type entity[F, T, P any] struct {
+	entry   atomic.Pointer[Entry[F, T, P]]
+	breaker *CircuitBreakerFactory[T] // guards instance creation
+}
+
+// shard publishes its entities as a copy-on-write map, replaced whenever an
+// entity is registered or unregistered
+type shard[F, T, P any] struct {
+	mu       sync.Mutex // serializes writers only
+	entities atomic.Pointer[map[string]*entity[F, T, P]]
+}
+
+func (s *shard[F, T, P]) load() map[string]*entity[F, T, P] {
+	return *s.entities.Load()
+}
+
+func (s *shard[F, T, P]) lookup(key string) (*entity[F, T, P], bool) {
+	e, exists := s.load()[key]
+	return e, exists
+}
+
+// view is an immutable snapshot of every entity, sorted by key, for the
+// calls that read the whole registry
+type view[F, T, P any] struct {
+	generation uint64
+	entries    []Entry[F, T, P]
+	statuses   map[string]string
+}
+
+// Registry keeps the lifecycle of one kind of entity: the factories
+// registered under a key, the instances created from them, their status and
+// its history, creation circuit breakers and the operator decisions kept in
+// a StateStore. Status changes are published as events. F is the factory
+// type, T the instance type and P the owner's per-entity policy.
+//
+// A Registry is safe for concurrent use, and reads never lock. Each entity
+// is published as an immutable Entry, and writers, serialized per shard,
+// replace it; listings come from a sorted view rebuilt only after a write,
+// and the counts Health reports are kept up to date by the writers. Owners
+// keep their own lock for what spans several entities and call into the
+// Registry with it held; the Registry never calls back into its owner while
+// holding a shard lock.
+type Registry[F, T, P any] struct {
+	kind   string
+	logger *logger.Logger
+	hooks  Hooks
+	shards [registryShards]shard[F, T, P]
+
+	total, active, failed atomic.Int64
+
+	// generation counts writes; view is rebuilt when it falls behind
+	generation atomic.Uint64
+	view       atomic.Pointer[view[F, T, P]]
+	viewMu     sync.Mutex // serializes rebuilding view
+
+	running   atomic.Bool
+	lastCheck atomic.Int64 // unix nanoseconds of the last start
+	events    atomic.Pointer[events.Bus]
+
+	// stateMu guards the persisted state; it is taken after shard locks
+	stateMu   sync.Mutex
+	state     StateStore
+	persisted map[string]EntityState
+	dirty     map[string]bool // state keys changed since the last flush
+	// flushMu serializes saving the dirty states; it is taken before stateMu
+	// and never with a shard lock held
+	flushMu sync.Mutex
+}
+
+// NewRegistry creates an empty registry for entities of kind, which names
+// them in events, logs and the state store
+func NewRegistry[F, T, P any](kind string, log *logger.Logger, hooks Hooks) *Registry[F, T, P] {
+	r := &Registry[F, T, P]{
+		kind:      kind,
+		logger:    log,
+		hooks:     hooks,
+		persisted: make(map[string]EntityState),
+		dirty:     make(map[string]bool),
+	}
+	for i := range r.shards {
+		r.shards[i].entities.Store(&map[string]*entity[F, T, P]{})
+	}
+	return r
+}
+
+func (r *Registry[F, T, P]) shard(key string) *shard[F, T, P] {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return &r.shards[hash.Sum32()%registryShards]
+}
+
+// update publishes the next entry of an entity and accounts for the change;
+// the caller must hold the entity's shard lock
+func (r *Registry[F, T, P]) update(e *entity[F, T, P], next Entry[F, T, P]) {
+	previous := e.entry.Swap(&next)
+	r.count(previous.Status, -1)
+	r.count(next.Status, 1)
+	r.generation.Add(1)
+}
+
+// count keeps the status counts reported by Health
+func (r *Registry[F, T, P]) count(status LifecycleStatus, delta int64) {
+	switch status {
+	case StatusActive:
+		r.active.Add(delta)
+	case StatusError:
+		r.failed.Add(delta)
+	}
+}
+
+// snapshot returns the view of every entity, rebuilding it when a write
+// happened since it was built. The generation is read before the entities,
+// so a view never claims writes it missed.
+func (r *Registry[F, T, P]) snapshot() *view[F, T, P] {
+	if v := r.view.Load(); v != nil && v.generation == r.generation.Load() {
+		return v
+	}
+
+	r.viewMu.Lock()
+	defer r.viewMu.Unlock()
+
+	generation := r.generation.Load()
+	if v := r.view.Load(); v != nil && v.generation == generation {
+		return v
+	}
+
+	v := &view[F, T, P]{generation: generation, statuses: make(map[string]string)}
+	for i := range r.shards {
+		for key, e := range r.shards[i].load() {
+			entry := e.entry.Load()
+			v.entries = append(v.entries, *entry)
+			v.statuses[key] = string(entry.Status)
+		}
+	}
+	sort.Slice(v.entries, func(i, j int) bool { return v.entries[i].Key < v.entries[j].Key })
+	r.view.Store(v)
+	return v
+}
+
+// Register adds an entity in StatusRegistered, persisting its state under
+// its key. While the registry is running, the entity's persisted state is
+// applied right away.
+func (r *Registry[F, T, P]) Register(key string, factory F, policy P) error {
+	return r.RegisterWithStateKey(key, key, factory, policy)
+}
+
+// RegisterWithStateKey is Register persisting the entity's state under
+// stateKey, for owners whose keys depend on registration order
+func (r *Registry[F, T, P]) RegisterWithStateKey(key, stateKey string, factory F, policy P) error {
+	// Deferred first so it runs once the shard lock is released
+	defer r.flushState()
+
+	s := r.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.lookup(key); exists {
+		return fmt.Errorf("%w: %s", ErrEntityAlreadyExists, key)
+	}
+
+	config := DefaultCircuitBreakerConfig()
+	config.OnStateChange = func(name, from, to string) {
+		r.logger.Warn("circuit breaker state changed", "kind", r.kind, "key", key, "from", from, "to", to)
+		r.Publish(events.Event{Type: events.TypeCircuitBreaker, Name: key, From: from, To: to})
+	}
+
+	e := &entity[F, T, P]{breaker: NewCircuitBreakerFactory[T](key, config)}
+	e.entry.Store(&Entry[F, T, P]{
+		Key:      key,
+		StateKey: stateKey,
+		Factory:  factory,
+		Policy:   policy,
+		Status:   StatusRegistered,
+		History:  AppendTransition(nil, "", StatusRegistered, SystemCause("registered")),
+	})
+
+	entities := maps.Clone(s.load())
+	entities[key] = e
+	s.entities.Store(&entities)
+	r.total.Add(1)
+	r.generation.Add(1)
+	r.Publish(events.Event{Type: events.TypeRegistered, Name: key, To: string(StatusRegistered), Actor: string(ActorSystem)})
+
+	if r.running.Load() {
+		// Entities registered later, such as upstream tools, get their
+		// persisted state as they appear
+		r.restore(e)
+	}
+	return nil
+}
+
+// Unregister removes an entity along with its instance
+func (r *Registry[F, T, P]) Unregister(key string) error {
+	s := r.shard(key)
+	s.mu.Lock()
+	e, exists := s.lookup(key)
+	if !exists {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrEntityNotFound, key)
+	}
+
+	entities := maps.Clone(s.load())
+	delete(entities, key)
+	s.entities.Store(&entities)
+	r.total.Add(-1)
+	r.count(e.entry.Load().Status, -1)
+	r.generation.Add(1)
+	r.Publish(events.Event{Type: events.TypeUnregistered, Name: key})
+	s.mu.Unlock()
+
+	if r.hooks.Removed != nil {
+		r.hooks.Removed(key)
+	}
+	return nil
+}
+
+func (r *Registry[F, T, P]) Has(key string) bool {
+	_, exists := r.shard(key).lookup(key)
+	return exists
+}
+
+// Len returns the number of registered entities
+func (r *Registry[F, T, P]) Len() int {
+	return int(r.total.Load())
+}
+
+// Keys returns the keys of the registered entities, sorted
+func (r *Registry[F, T, P]) Keys() []string {
+	entries := r.snapshot().entries
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+// Entry returns a snapshot of one entity
+func (r *Registry[F, T, P]) Entry(key string) (Entry[F, T, P], bool) {
+	e, exists := r.shard(key).lookup(key)
+	if !exists {
+		return Entry[F, T, P]{}, false
+	}
+	return *e.entry.Load(), true
+}
+
+// Entries returns snapshots of every entity, sorted by key. The slice is
+// shared between callers until the next write and must not be modified.
+func (r *Registry[F, T, P]) Entries() []Entry[F, T, P] {
+	return r.snapshot().entries
+}
+
+func (r *Registry[F, T, P]) Factory(key string) (F, bool) {
+	entry, exists := r.Entry(key)
+	return entry.Factory, exists
+}
+
+// Policy returns the policy an entity was registered with
+func (r *Registry[F, T, P]) Policy(key string) (P, bool) {
+	entry, exists := r.Entry(key)
+	return entry.Policy, exists
+}
+
+// Factories returns the factory of every entity by key
+func (r *Registry[F, T, P]) Factories() map[string]F {
+	entries := r.snapshot().entries
+	factories := make(map[string]F, len(entries))
+	for _, entry := range entries {
+		factories[entry.Key] = entry.Factory
+	}
+	return factories
+}
+
+func (r *Registry[F, T, P]) Status(key string) (LifecycleStatus, bool) {
+	entry, exists := r.Entry(key)
+	return entry.Status, exists
+}
+
+// Instance returns the created instance of an entity, if any
+func (r *Registry[F, T, P]) Instance(key string) (T, bool) {
+	entry, _ := r.Entry(key)
+	return entry.Instance, entry.Loaded
+}
+
+// Instances returns every created instance by key
+func (r *Registry[F, T, P]) Instances() map[string]T {
+	instances := make(map[string]T)
+	for _, entry := range r.snapshot().entries {
+		if entry.Loaded {
+			instances[entry.Key] = entry.Instance
+		}
+	}
+	return instances
+}
+
+// SetInstance stores the instance created for an entity, reporting whether
+// the entity is still registered
+func (r *Registry[F, T, P]) SetInstance(key string, instance T) bool {
+	s := r.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.lookup(key)
+	if !exists {
+		return false
+	}
+	next := *e.entry.Load()
+	next.Instance, next.Loaded = instance, true
+	r.update(e, next)
+	return true
+}
+
+// DropInstance forgets the instance of an entity, reporting whether it had
+// one
+func (r *Registry[F, T, P]) DropInstance(key string) bool {
+	s := r.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.lookup(key)
+	if !exists || !e.entry.Load().Loaded {
+		return false
+	}
+	r.update(e, withoutInstance(*e.entry.Load()))
+	return true
+}
+
+func withoutInstance[F, T, P any](entry Entry[F, T, P]) Entry[F, T, P] {
+	var zero T
+	entry.Instance, entry.Loaded = zero, false
+	return entry
+}
+
+// Breaker returns the circuit breaker guarding an entity's instance
+// creation, or nil when the entity is not registered
+func (r *Registry[F, T, P]) Breaker(key string) *CircuitBreakerFactory[T] {
+	if e, exists := r.shard(key).lookup(key); exists {
+		return e.breaker
+	}
+	return nil
+}
+
+// BreakerStatuses returns the state of every creation circuit breaker
+func (r *Registry[F, T, P]) BreakerStatuses() map[string]string {
+	statuses := make(map[string]string, r.Len())
+	for i := range r.shards {
+		for key, e := range r.shards[i].load() {
+			statuses[key] = e.breaker.Status()
+		}
+	}
+	return statuses
+}
+
+// Transition moves an entity to a new status, recording the cause in its
+// history and publishing the change. Entities moving to error or disabled
+// lose their instance. Moving to the current status changes nothing.
+func (r *Registry[F, T, P]) Transition(key string, to LifecycleStatus, cause TransitionCause) error {
+	defer r.flushState()
+
+	s := r.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.lookup(key)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrEntityNotFound, key)
+	}
+	if from := e.entry.Load().Status; !IsValidTransition(from, to) {
+		return fmt.Errorf("%w: cannot transition from %s to %s", ErrInvalidTransition, from, to)
+	}
+	r.transition(e, to, cause)
+	return nil
+}
+
+// TryTransition moves an entity to a new status when the transition is
+// valid, and reports whether the entity is now in that status
+func (r *Registry[F, T, P]) TryTransition(key string, to LifecycleStatus, cause TransitionCause) bool {
+	return r.Transition(key, to, cause) == nil
+}
+
+// transition applies a validated status change; the caller must hold the
+// entity's shard lock
+func (r *Registry[F, T, P]) transition(e *entity[F, T, P], to LifecycleStatus, cause TransitionCause) {
+	next := *e.entry.Load()
+	if next.Status == to {
+		return
+	}
+
+	from := next.Status
+	next.Status = to
+	next.History = AppendTransition(next.History, from, to, cause)
+	if to == StatusError || to == StatusDisabled {
+		next = withoutInstance(next)
+	}
+	r.update(e, next)
+	r.recordStatus(next.StateKey, to, cause)
+
+	recorded := next.History[len(next.History)-1]
+	r.Publish(events.Event{
+		Type:   events.TypeStatusChanged,
+		Name:   next.Key,
+		From:   string(from),
+		To:     string(to),
+		Reason: recorded.Reason,
+		Error:  recorded.Error,
+		Actor:  string(recorded.Actor),
+	})
+}
+
+// RecordRestart counts a successful restart of an entity and returns its
+// restart count
+func (r *Registry[F, T, P]) RecordRestart(key string) int {
+	defer r.flushState()
+
+	s := r.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.lookup(key)
+	if !exists {
+		return 0
+	}
+	next := *e.entry.Load()
+	next.Restarts++
+	r.update(e, next)
+	r.updateState(next.StateKey, func(state *EntityState) {
+		state.Restarts = next.Restarts
+	})
+	return next.Restarts
+}
+
+// RecordBreakerReset notes that an operator reset a circuit breaker of an
+// entity
+func (r *Registry[F, T, P]) RecordBreakerReset(key string) {
+	defer r.flushState()
+
+	s := r.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, exists := s.lookup(key)
+	if !exists {
+		return
+	}
+	resetAt := time.Now().UTC()
+	next := *e.entry.Load()
+	next.BreakerResetAt = &resetAt
+	r.update(e, next)
+	r.updateState(next.StateKey, func(state *EntityState) {
+		state.BreakerResetAt = &resetAt
+	})
+}
+
+// Start marks the registry running and re-applies the persisted state of
+// its entities. It returns the keys of the entities restored to disabled.
+func (r *Registry[F, T, P]) Start() []string {
+	r.running.Store(true)
+	r.lastCheck.Store(time.Now().UnixNano())
+	return r.restoreAll()
+}
+
+// Stop drops every instance and disables the entities that can be, then
+// marks the registry stopped
+func (r *Registry[F, T, P]) Stop(cause TransitionCause) {
+	defer r.flushState()
+
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mu.Lock()
+		for _, e := range s.load() {
+			if e.entry.Load().Loaded {
+				r.update(e, withoutInstance(*e.entry.Load()))
+			}
+			if IsValidTransition(e.entry.Load().Status, StatusDisabled) {
+				r.transition(e, StatusDisabled, cause)
+			}
+		}
+		s.mu.Unlock()
+	}
+	r.running.Store(false)
+}
+
+func (r *Registry[F, T, P]) Running() bool {
+	return r.running.Load()
+}
+
+// Health reports the entity counts, kept up to date by every write, and the
+// status of each entity. EntityStatuses is shared between callers until the
+// next write and must not be modified. Owners add what is specific to their
+// kind.
+func (r *Registry[F, T, P]) Health() RegistryHealth {
+	health := RegistryHealth{
+		Status:         "healthy",
+		EntityCount:    int(r.total.Load()),
+		ActiveEntities: int(r.active.Load()),
+		ErrorEntities:  int(r.failed.Load()),
+		LastCheck:      time.Unix(0, r.lastCheck.Load()).Format(time.RFC3339),
+		Errors:         []string{},
+		EntityStatuses: r.snapshot().statuses,
+	}
+
+	if !r.Running() {
+		health.Status = "stopped"
+	} else if health.ErrorEntities > 0 {
+		health.Status = "degraded"
+	}
+	if health.ErrorEntities > 0 {
+		health.Errors = append(health.Errors,
+			fmt.Sprintf("%d %ss in error state", health.ErrorEntities, r.kind))
+	}
+	return health
+}
+
+// SetEvents publishes the registry's lifecycle events on bus
+func (r *Registry[F, T, P]) SetEvents(bus *events.Bus) {
+	r.events.Store(bus)
+}
+
+// Publish publishes an event about the registry's entities, filling in
+// their kind
+func (r *Registry[F, T, P]) Publish(event events.Event) {
+	event.Kind = r.kind
+	r.events.Load().Publish(event)
+}
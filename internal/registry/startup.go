@@ -0,0 +1,308 @@
+package registry
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+)
+
+// Load outcomes reported for each entity
+const (
+	LoadPending = "pending"
+	LoadLoaded  = "loaded"
+	LoadFailed  = "failed"
+)
+
+// LoadTask is one entity to load at startup. It starts once the tasks named
+// in After have finished, loaded or not, so that requirements load first.
+// Keys in After that name no task are ignored; they must not form a cycle.
+type LoadTask struct {
+	Key   string
+	After []string
+}
+
+// LoadResult is the outcome of loading one entity
+type LoadResult struct {
+	Key        string `json:"key"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	// Background is set on entities that finished after the deadline
+	Background bool `json:"background,omitempty"`
+}
+
+// LoadReport describes the startup loading of a registry's entities. It
+// keeps changing after the deadline while entities load in the background.
+type LoadReport struct {
+	Kind             string       `json:"kind"`
+	StartedAt        time.Time    `json:"started_at"`
+	CompletedAt      *time.Time   `json:"completed_at,omitempty"`
+	DeadlineExceeded bool         `json:"deadline_exceeded"`
+	Total            int          `json:"total"`
+	Loaded           int          `json:"loaded"`
+	Failed           int          `json:"failed"`
+	Pending          int          `json:"pending"`
+	Entities         []LoadResult `json:"entities"`
+}
+
+// Complete reports whether every entity finished loading
+func (r LoadReport) Complete() bool {
+	return r.CompletedAt != nil
+}
+
+// Loader loads the entities of a registry at startup through a bounded
+// worker pool. Each entity gets the configured factory timeout; the whole
+// load waits no longer than the startup deadline and leaves the rest to
+// finish in the background.
+type Loader struct {
+	kind   string
+	config config.StartupConfig
+	logger *logger.Logger
+
+	mu       sync.Mutex
+	report   LoadReport
+	results  map[string]*LoadResult
+	finished map[string]chan struct{} // closed as each entity finishes
+	begun    chan struct{}            // closed once the first run begins
+}
+
+// NewLoader creates a loader for the tools or resources of a registry; kind
+// names them in logs and the report. Without a configured deadline it waits
+// for every entity, and without workers it loads one entity at a time.
+func NewLoader(kind string, cfg *config.Config, log *logger.Logger) *Loader {
+	var startupConfig config.StartupConfig
+	if cfg != nil {
+		startupConfig = cfg.Startup
+	}
+
+	return &Loader{
+		kind:     kind,
+		config:   startupConfig,
+		logger:   log,
+		results:  make(map[string]*LoadResult),
+		finished: make(map[string]chan struct{}),
+		begun:    make(chan struct{}),
+	}
+}
+
+// Run loads tasks with load and returns the report once every task finished
+// or the deadline passed. Tasks still running then keep going until done or
+// ctx is cancelled; Report follows them.
+func (l *Loader) Run(ctx context.Context, tasks []LoadTask, load func(ctx context.Context, key string) error) LoadReport {
+	l.begin(tasks)
+
+	// waiting counts the unfinished tasks each task starts after
+	known := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		known[task.Key] = true
+	}
+	waiting := make(map[string]int, len(tasks))
+	dependents := make(map[string][]string)
+	for _, task := range tasks {
+		for _, after := range task.After {
+			if known[after] && after != task.Key {
+				waiting[task.Key]++
+				dependents[after] = append(dependents[after], task.Key)
+			}
+		}
+	}
+
+	queue := make(chan string, len(tasks))
+	for _, task := range tasks {
+		if waiting[task.Key] == 0 {
+			queue <- task.Key
+		}
+	}
+
+	done := make(chan struct{})
+	var mu sync.Mutex // guards waiting and remaining
+	remaining := len(tasks)
+	if remaining == 0 {
+		close(done)
+	}
+
+	workers := min(max(l.config.Workers, 1), max(len(tasks), 1))
+	for range workers {
+		go func() {
+			for key := range queue {
+				l.runTask(ctx, key, load)
+
+				mu.Lock()
+				for _, dependent := range dependents[key] {
+					if waiting[dependent]--; waiting[dependent] == 0 {
+						queue <- dependent
+					}
+				}
+				if remaining--; remaining == 0 {
+					close(queue)
+					close(done)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var deadline <-chan time.Time
+	if l.config.Deadline > 0 {
+		timer := time.NewTimer(l.config.Deadline)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	select {
+	case <-done:
+	case <-deadline:
+		l.mu.Lock()
+		l.report.DeadlineExceeded = l.report.Pending > 0
+		l.mu.Unlock()
+	}
+
+	report := *l.Report()
+	l.logReport(report)
+	return report
+}
+
+// runTask loads one entity within the factory timeout and records the
+// outcome
+func (l *Loader) runTask(ctx context.Context, key string, load func(ctx context.Context, key string) error) {
+	loadCtx := ctx
+	if l.config.FactoryTimeout > 0 {
+		var cancel context.CancelFunc
+		loadCtx, cancel = context.WithTimeout(ctx, l.config.FactoryTimeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+	err := load(loadCtx, key)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := l.results[key]
+	result.DurationMS = time.Since(started).Milliseconds()
+	result.Background = l.report.DeadlineExceeded
+	l.report.Pending--
+	if err != nil {
+		result.Status, result.Error = LoadFailed, err.Error()
+		l.report.Failed++
+	} else {
+		result.Status = LoadLoaded
+		l.report.Loaded++
+	}
+	close(l.finished[key])
+
+	if l.report.Pending == 0 {
+		completed := time.Now().UTC()
+		l.report.CompletedAt = &completed
+		if l.report.DeadlineExceeded {
+			// Run already returned; report how the background loads ended
+			l.logger.Info("startup loading finished in background",
+				"kind", l.kind,
+				"loaded", l.report.Loaded,
+				"failed", l.report.Failed,
+				"duration", completed.Sub(l.report.StartedAt),
+			)
+		}
+	}
+}
+
+// begin resets the report for a new run with every task pending
+func (l *Loader) begin(tasks []LoadTask) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.report = LoadReport{
+		Kind:      l.kind,
+		StartedAt: time.Now().UTC(),
+		Total:     len(tasks),
+		Pending:   len(tasks),
+	}
+	l.results = make(map[string]*LoadResult, len(tasks))
+	l.finished = make(map[string]chan struct{}, len(tasks))
+	for _, task := range tasks {
+		l.results[task.Key] = &LoadResult{Key: task.Key, Status: LoadPending}
+		l.finished[task.Key] = make(chan struct{})
+	}
+	select {
+	case <-l.begun:
+	default:
+		close(l.begun)
+	}
+	if len(tasks) == 0 {
+		completed := l.report.StartedAt
+		l.report.CompletedAt = &completed
+	}
+}
+
+// Wait blocks until the entity under key finished loading, loaded or not,
+// or ctx is done. Before the first run it waits for the run to begin; keys
+// the run does not load return at once. It lets another registry's entities
+// wait for the ones they require.
+func (l *Loader) Wait(ctx context.Context, key string) error {
+	select {
+	case <-l.begun:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	finished, exists := l.finished[key]
+	l.mu.Unlock()
+	if !exists {
+		return nil
+	}
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Report returns a snapshot of the latest run, with entities sorted by key,
+// or nil before the first run
+func (l *Loader) Report() *LoadReport {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.report.StartedAt.IsZero() {
+		return nil
+	}
+	report := l.report
+	report.Entities = make([]LoadResult, 0, len(l.results))
+	for _, result := range l.results {
+		report.Entities = append(report.Entities, *result)
+	}
+	sort.Slice(report.Entities, func(i, j int) bool { return report.Entities[i].Key < report.Entities[j].Key })
+	return &report
+}
+
+func (l *Loader) logReport(report LoadReport) {
+	fields := []any{
+		"kind", l.kind,
+		"total", report.Total,
+		"loaded", report.Loaded,
+		"failed", report.Failed,
+		"pending", report.Pending,
+		"duration", time.Since(report.StartedAt),
+	}
+
+	if report.Pending == 0 {
+		l.logger.Info("startup loading completed", fields...)
+		return
+	}
+
+	var pending []string
+	for _, result := range report.Entities {
+		if result.Status == LoadPending {
+			pending = append(pending, result.Key)
+		}
+	}
+	l.logger.Warn("startup deadline passed, loading the rest in background",
+		append(fields, "deadline", l.config.Deadline, "pending_keys", pending)...)
+}
@@ -0,0 +1,312 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// EntityState is what survives a restart of a tool or resource: the status
+// an operator put it in and the counters that outlive a process
+type EntityState struct {
+	// Status is set only while an operator keeps the entity disabled
+	Status         LifecycleStatus `json:"status,omitempty"`
+	Reason         string          `json:"reason,omitempty"`
+	Actor          Actor           `json:"actor,omitempty"`
+	Restarts       int             `json:"restarts,omitempty"`
+	BreakerResetAt *time.Time      `json:"circuit_breaker_reset_at,omitempty"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// IsZero reports whether the state holds nothing worth keeping
+func (s EntityState) IsZero() bool {
+	return s.Status == "" && s.Restarts == 0 && s.BreakerResetAt == nil
+}
+
+// StateStore persists entity state across restarts. Kind separates tools
+// from resources, and key is the entity's state key within a kind.
+type StateStore interface {
+	Load(kind string) (map[string]EntityState, error)
+	Save(kind, key string, state EntityState) error
+	Delete(kind, key string) error
+}
+
+// JSONFileStore keeps entity state in a JSON file, rewritten atomically on
+// every change. It suits the handful of operator decisions it holds, not
+// high-frequency writes.
+type JSONFileStore struct {
+	path string
+
+	mu       sync.Mutex
+	entities map[string]map[string]EntityState
+}
+
+type stateFile struct {
+	Entities map[string]map[string]EntityState `json:"entities"`
+}
+
+// NewJSONFileStore opens the state file at path; a missing file is an empty
+// store and is created on the first save
+func NewJSONFileStore(path string) (*JSONFileStore, error) {
+	store := &JSONFileStore{
+		path:     path,
+		entities: make(map[string]map[string]EntityState),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var file stateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	for kind, states := range file.Entities {
+		if states != nil {
+			store.entities[kind] = states
+		}
+	}
+	return store, nil
+}
+
+// Load returns a copy of the states stored for a kind
+func (s *JSONFileStore) Load(kind string) (map[string]EntityState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make(map[string]EntityState, len(s.entities[kind]))
+	for key, state := range s.entities[kind] {
+		states[key] = state
+	}
+	return states, nil
+}
+
+func (s *JSONFileStore) Save(kind, key string, state EntityState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.entities[kind] == nil {
+		s.entities[kind] = make(map[string]EntityState)
+	}
+	previous, existed := s.entities[kind][key]
+	s.entities[kind][key] = state
+
+	if err := s.write(); err != nil {
+		if existed {
+			s.entities[kind][key] = previous
+		} else {
+			delete(s.entities[kind], key)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *JSONFileStore) Delete(kind, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.entities[kind][key]
+	if !existed {
+		return nil
+	}
+	delete(s.entities[kind], key)
+
+	if err := s.write(); err != nil {
+		s.entities[kind][key] = previous
+		return err
+	}
+	return nil
+}
+
+// write replaces the state file through a temporary file so a crash never
+// leaves it half written; the caller must hold s.mu
+func (s *JSONFileStore) write() error {
+	data, err := json.MarshalIndent(stateFile{Entities: s.entities}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+	return nil
+}
+
+// SetStateStore persists the operator decisions about the registry's
+// entities in store; they are re-applied on Start
+func (r *Registry[F, T, P]) SetStateStore(store StateStore) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+	r.state = store
+}
+
+// restoreAll loads the persisted state of the registry's kind and applies it
+// to the registered entities, returning the keys restored to disabled
+func (r *Registry[F, T, P]) restoreAll() []string {
+	r.stateMu.Lock()
+	if r.state == nil {
+		r.stateMu.Unlock()
+		return nil
+	}
+	states, err := r.state.Load(r.kind)
+	if err != nil {
+		r.stateMu.Unlock()
+		r.logger.Warn("failed to load persisted state", "kind", r.kind, "error", err)
+		return nil
+	}
+	r.persisted = states
+	r.stateMu.Unlock()
+
+	var disabled []string
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mu.Lock()
+		for key, e := range s.load() {
+			if r.restore(e) {
+				disabled = append(disabled, key)
+			}
+		}
+		s.mu.Unlock()
+	}
+	r.logger.Info("restored persisted state", "kind", r.kind, "entities", len(states))
+	return disabled
+}
+
+// restore applies the persisted state of one entity: its counters and the
+// disabled status an operator left it in. It reports whether the entity was
+// disabled. The caller must hold the entity's shard lock.
+func (r *Registry[F, T, P]) restore(e *entity[F, T, P]) bool {
+	key := e.entry.Load().Key
+	r.stateMu.Lock()
+	state, exists := r.persisted[e.entry.Load().StateKey]
+	r.stateMu.Unlock()
+	if !exists {
+		return false
+	}
+
+	next := *e.entry.Load()
+	next.Restarts = state.Restarts
+	next.BreakerResetAt = state.BreakerResetAt
+	r.update(e, next)
+	if state.Status != StatusDisabled || !IsValidTransition(next.Status, StatusDisabled) {
+		return false
+	}
+
+	r.transition(e, StatusDisabled, SystemCause("restored: "+state.Reason))
+	r.logger.Info("restored operator-disabled entity",
+		"kind", r.kind,
+		"key", key,
+		"reason", state.Reason,
+		"disabled_at", state.UpdatedAt.Format(time.RFC3339),
+	)
+	return true
+}
+
+// recordStatus keeps the status an operator put an entity in. Only disabled
+// is kept; any other admin transition lifts it.
+func (r *Registry[F, T, P]) recordStatus(stateKey string, status LifecycleStatus, cause TransitionCause) {
+	if cause.Actor != ActorAdmin {
+		return
+	}
+	r.updateState(stateKey, func(state *EntityState) {
+		if status == StatusDisabled {
+			state.Status, state.Reason, state.Actor = status, cause.Reason, cause.Actor
+			return
+		}
+		state.Status, state.Reason, state.Actor = "", "", ""
+	})
+}
+
+// updateState applies change to the persisted state of an entity and marks
+// it for the next flushState. It runs under the entity's shard lock, so it
+// never touches the store itself.
+func (r *Registry[F, T, P]) updateState(stateKey string, change func(*EntityState)) {
+	r.stateMu.Lock()
+	defer r.stateMu.Unlock()
+
+	if r.state == nil {
+		return
+	}
+
+	state := r.persisted[stateKey]
+	change(&state)
+	state.UpdatedAt = time.Now().UTC()
+
+	if state.IsZero() {
+		delete(r.persisted, stateKey)
+	} else {
+		r.persisted[stateKey] = state
+	}
+	r.dirty[stateKey] = true
+}
+
+// flushState saves the states changed since the last flush. It is called
+// once the shard lock is released, so the store's file write and fsync never
+// hold up other entities; flushMu keeps a flush from saving an older
+// snapshot over a newer one. Failures are logged rather than returned, since
+// the operation that changed the state already succeeded.
+func (r *Registry[F, T, P]) flushState() {
+	r.stateMu.Lock()
+	idle := len(r.dirty) == 0
+	r.stateMu.Unlock()
+	if idle {
+		return
+	}
+
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+
+	r.stateMu.Lock()
+	store := r.state
+	pending := make(map[string]*EntityState, len(r.dirty))
+	for stateKey := range r.dirty {
+		if state, exists := r.persisted[stateKey]; exists {
+			pending[stateKey] = &state
+		} else {
+			pending[stateKey] = nil
+		}
+	}
+	clear(r.dirty)
+	r.stateMu.Unlock()
+
+	if store == nil {
+		return
+	}
+	for stateKey, state := range pending {
+		var err error
+		if state == nil {
+			err = store.Delete(r.kind, stateKey)
+		} else {
+			err = store.Save(r.kind, stateKey, *state)
+		}
+		if err != nil {
+			r.logger.Warn("failed to persist state", "kind", r.kind, "key", stateKey, "error", err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"mcp-server/internal/logger"
+)
+
+// blockingStore holds every Save until released
+type blockingStore struct {
+	saving  chan string
+	release chan struct{}
+}
+
+func (s *blockingStore) Load(kind string) (map[string]EntityState, error) {
+	return map[string]EntityState{}, nil
+}
+
+func (s *blockingStore) Save(kind, key string, state EntityState) error {
+	s.saving <- key
+	<-s.release
+	return nil
+}
+
+func (s *blockingStore) Delete(kind, key string) error { return nil }
+
+func TestRegistry_SavesStateOutsideShardLock(t *testing.T) {
+	log, _ := logger.NewDefault()
+	store := &blockingStore{saving: make(chan string, 1), release: make(chan struct{})}
+	r := NewRegistry[string, string, struct{}]("tool", log, Hooks{})
+	r.SetStateStore(store)
+	r.RegisterWithStateKey("echo", "echo@1.0.0", "factory", struct{}{})
+	r.Start()
+
+	disabled := make(chan error, 1)
+	go func() {
+		disabled <- r.Transition("echo", StatusDisabled, TransitionCause{Actor: ActorAdmin, Reason: "maintenance"})
+	}()
+	if key := <-store.saving; key != "echo@1.0.0" {
+		t.Errorf("expected state saved under the state key, got %q", key)
+	}
+
+	// The entity is disabled and its shard free while the save is blocked
+	if status, _ := r.Status("echo"); status != StatusDisabled {
+		t.Errorf("expected the entity disabled before the save completes, got %s", status)
+	}
+	moved := make(chan error, 1)
+	go func() { moved <- r.Transition("echo", StatusError, SystemCause("failed")) }()
+	select {
+	case err := <-moved:
+		if err != nil {
+			t.Errorf("transition failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("transition waited for the state file write")
+	}
+
+	close(store.release)
+	if err := <-disabled; err != nil {
+		t.Errorf("disable failed: %v", err)
+	}
+}
@@ -0,0 +1,362 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+)
+
+// minSupervisorDelay keeps a supervisor from spinning when a retry is due
+const minSupervisorDelay = 10 * time.Millisecond
+
+// HealthChecker may be implemented by tool and resource factories so the
+// supervisor can probe whether the entities they create still work
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// SupervisedEntity is a tool or resource as seen by the supervisor
+type SupervisedEntity struct {
+	Key     string
+	Status  LifecycleStatus
+	Checker HealthChecker // nil when the factory cannot be probed
+
+	// Blocked entities are in error for a reason a restart cannot fix, such
+	// as an unmet requirement; they are left alone until it clears
+	Blocked bool
+}
+
+// SupervisorHooks connect a supervisor to the registry it watches. Hooks are
+// called without any supervisor lock held and may take registry locks.
+type SupervisorHooks struct {
+	// Entities lists the entities to supervise and their current status
+	Entities func() []SupervisedEntity
+	// Fail moves an entity whose probe failed to StatusError
+	Fail func(key string, cause error) error
+	// Restart recreates an entity in StatusError and activates it
+	Restart func(ctx context.Context, key string) error
+	// Disable gives up on an entity once its restarts are exhausted
+	Disable func(key string) error
+	// Attempted, when set, is told the outcome of every restart attempt
+	Attempted func(key string, attempt int, err error)
+}
+
+// recovery tracks the restarts of an entity since it was last healthy
+type recovery struct {
+	attempts    int
+	nextAttempt time.Time
+}
+
+// Supervisor periodically probes the entities of a registry, moves the ones
+// failing their probe to error, and restarts entities in error with
+// exponential backoff. After the configured number of failed restarts an
+// entity is disabled and left for an operator.
+type Supervisor struct {
+	kind   string
+	config config.SupervisorConfig
+	hooks  SupervisorHooks
+	logger *logger.Logger
+
+	mu         sync.Mutex
+	recovering map[string]*recovery
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewSupervisor creates a supervisor for the tools or resources of a
+// registry; kind names them in logs. It does nothing until started.
+func NewSupervisor(kind string, cfg *config.Config, hooks SupervisorHooks, log *logger.Logger) *Supervisor {
+	var supervisorConfig config.SupervisorConfig
+	if cfg != nil {
+		supervisorConfig = cfg.Supervisor
+	}
+
+	return &Supervisor{
+		kind:       kind,
+		config:     supervisorConfig,
+		hooks:      hooks,
+		logger:     log,
+		recovering: make(map[string]*recovery),
+	}
+}
+
+// Start runs the supervisor in the background until Stop. It is a no-op when
+// the supervisor is disabled or already running.
+func (s *Supervisor) Start() {
+	if s == nil || !s.config.Enabled {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.run(ctx, s.done)
+
+	s.logger.Info("supervisor started",
+		"kind", s.kind,
+		"interval", s.config.Interval,
+		"max_attempts", s.config.MaxAttempts,
+	)
+}
+
+// Stop cancels any probe or restart in flight and waits for the supervisor
+// to exit. Registries must call it without holding their own lock, since
+// the hooks may be waiting for it.
+func (s *Supervisor) Stop() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+
+	s.logger.Info("supervisor stopped", "kind", s.kind)
+}
+
+func (s *Supervisor) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	timer := time.NewTimer(s.config.Interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		timer.Reset(s.Check(ctx))
+	}
+}
+
+// Check makes one supervision pass and returns how long to wait before the
+// next one: the probe interval, or sooner when a restart is due
+func (s *Supervisor) Check(ctx context.Context) time.Duration {
+	entities := s.hooks.Entities()
+	next := s.config.Interval
+
+	seen := make(map[string]bool, len(entities))
+	for _, entity := range entities {
+		seen[entity.Key] = true
+
+		switch entity.Status {
+		case StatusActive, StatusLoaded:
+			if err := s.probe(ctx, entity); err != nil {
+				s.logger.Warn("supervisor probe failed",
+					"kind", s.kind,
+					"key", entity.Key,
+					"error", err,
+				)
+				if failErr := s.hooks.Fail(entity.Key, err); failErr != nil {
+					s.logger.Error("supervisor failed to move entity to error",
+						"kind", s.kind,
+						"key", entity.Key,
+						"error", failErr,
+					)
+					continue
+				}
+				s.restart(ctx, entity.Key)
+			} else {
+				s.recovered(entity.Key)
+			}
+		case StatusError:
+			if !entity.Blocked {
+				s.restart(ctx, entity.Key)
+			}
+		default:
+			// Registered entities are not loaded yet and disabled ones
+			// are an operator's decision
+			s.forget(entity.Key)
+		}
+
+		if wait, due := s.nextAttempt(entity.Key); due && wait < next {
+			next = wait
+		}
+	}
+
+	s.mu.Lock()
+	for key := range s.recovering {
+		if !seen[key] {
+			delete(s.recovering, key)
+		}
+	}
+	s.mu.Unlock()
+
+	return max(next, minSupervisorDelay)
+}
+
+func (s *Supervisor) probe(ctx context.Context, entity SupervisedEntity) error {
+	if entity.Checker == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.ProbeTimeout)
+	defer cancel()
+	return entity.Checker.HealthCheck(ctx)
+}
+
+// restart attempts to recover an entity in error once its backoff elapsed,
+// and disables it when the attempts are exhausted. Attempts only reset after
+// a passing probe, so an entity that restarts fine but keeps failing its
+// probe is disabled when it is back in error after the last attempt.
+func (s *Supervisor) restart(ctx context.Context, key string) {
+	now := time.Now()
+
+	s.mu.Lock()
+	state, exists := s.recovering[key]
+	if !exists {
+		state = &recovery{nextAttempt: now}
+		s.recovering[key] = state
+	}
+	if now.Before(state.nextAttempt) {
+		s.mu.Unlock()
+		return
+	}
+	if state.attempts >= s.config.MaxAttempts {
+		attempts := state.attempts
+		s.mu.Unlock()
+		s.giveUp(key, attempts, errors.New("entity kept failing its probe after restarting"))
+		return
+	}
+	state.attempts++
+	attempt := state.attempts
+	s.mu.Unlock()
+
+	err := s.hooks.Restart(WithActor(ctx, ActorSupervisor), key)
+	if s.hooks.Attempted != nil && ctx.Err() == nil {
+		s.hooks.Attempted(key, attempt, err)
+	}
+	if err == nil {
+		// Attempts keep counting until the entity passes a probe, so one
+		// that fails again right away still backs off
+		s.mu.Lock()
+		state.nextAttempt = time.Now().Add(s.backoff(attempt))
+		s.mu.Unlock()
+
+		s.logger.Info("supervisor restarted entity",
+			"kind", s.kind,
+			"key", key,
+			"attempt", attempt,
+		)
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	if attempt >= s.config.MaxAttempts {
+		s.giveUp(key, attempt, err)
+		return
+	}
+
+	backoff := s.backoff(attempt)
+	s.mu.Lock()
+	state.nextAttempt = time.Now().Add(backoff)
+	s.mu.Unlock()
+
+	s.logger.Warn("supervisor restart failed",
+		"kind", s.kind,
+		"key", key,
+		"attempt", attempt,
+		"max_attempts", s.config.MaxAttempts,
+		"retry_in", backoff,
+		"error", err,
+	)
+}
+
+// giveUp disables an entity whose restart attempts are exhausted
+func (s *Supervisor) giveUp(key string, attempts int, err error) {
+	s.logger.Error("supervisor giving up, disabling entity",
+		"kind", s.kind,
+		"key", key,
+		"attempts", attempts,
+		"error", err,
+	)
+	if disableErr := s.hooks.Disable(key); disableErr != nil {
+		s.logger.Error("supervisor failed to disable entity",
+			"kind", s.kind,
+			"key", key,
+			"error", disableErr,
+		)
+	}
+	s.forget(key)
+}
+
+// backoff doubles the initial backoff for every failed attempt, up to the
+// maximum
+func (s *Supervisor) backoff(attempt int) time.Duration {
+	backoff := s.config.InitialBackoff
+	for i := 1; i < attempt && backoff < s.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, s.config.MaxBackoff)
+}
+
+// recovered resets the restart attempts of an entity that passed its probe
+func (s *Supervisor) recovered(key string) {
+	s.mu.Lock()
+	state, exists := s.recovering[key]
+	delete(s.recovering, key)
+	s.mu.Unlock()
+
+	if exists {
+		s.logger.Info("supervisor saw entity recover",
+			"kind", s.kind,
+			"key", key,
+			"attempts", state.attempts,
+		)
+	}
+}
+
+func (s *Supervisor) forget(key string) {
+	s.mu.Lock()
+	delete(s.recovering, key)
+	s.mu.Unlock()
+}
+
+// nextAttempt reports how long until an entity's next restart is due
+func (s *Supervisor) nextAttempt(key string) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, exists := s.recovering[key]
+	if !exists {
+		return 0, false
+	}
+	return time.Until(state.nextAttempt), true
+}
+
+// Attempts returns the restart attempts of the entities the supervisor is
+// recovering
+func (s *Supervisor) Attempts() map[string]int {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempts := make(map[string]int, len(s.recovering))
+	for key, state := range s.recovering {
+		attempts[key] = state.attempts
+	}
+	return attempts
+}
@@ -0,0 +1,207 @@
+package resources
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"mcp-server/internal/cache"
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/tracing"
+)
+
+// CacheableResource is implemented by resources that choose how long their
+// content may be cached, overriding the registry default
+type CacheableResource interface {
+	CacheTimeout() time.Duration
+}
+
+// ModTimeResource is implemented by resources backed by something with a
+// modification time, such as a file. Cached content is dropped when it changes.
+type ModTimeResource interface {
+	CurrentModTime() (time.Time, error)
+}
+
+// ResourceCache is the read-through content cache shared by all resources of
+// a registry. It is bounded by entry count and total content size.
+type ResourceCache struct {
+	entries        *cache.LRU[*CachedContent]
+	validator      *ResourceValidator
+	defaultTimeout time.Duration
+	hits           atomic.Int64
+	misses         atomic.Int64
+
+	// invalidated is told about content dropped because its backing file
+	// changed or became unreadable; set before the cache is used
+	invalidated func(uri, reason string)
+}
+
+// NewResourceCache creates a cache from configuration, or nil when disabled
+func NewResourceCache(cfg config.ResourceCacheConfig, validator *ResourceValidator) *ResourceCache {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &ResourceCache{
+		entries: cache.New[*CachedContent](cache.Options{
+			MaxEntries: cfg.MaxSize,
+			MaxBytes:   cfg.MaxBytes,
+		}),
+		validator:      validator,
+		defaultTimeout: time.Duration(cfg.DefaultTimeout) * time.Second,
+	}
+}
+
+// get returns the cached content of uri if it has neither expired nor been
+// modified since it was stored
+func (c *ResourceCache) get(uri string, modTime time.Time) (mcp.ResourceContent, bool) {
+	cached, ok := c.entries.Get(uri)
+	if ok && !cached.ModTime.Equal(modTime) {
+		c.drop(uri, "modified")
+		ok = false
+	}
+	if ok && c.validator.ValidateCacheExpiration(*cached) != nil {
+		c.entries.Delete(uri)
+		ok = false
+	}
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	atomic.AddInt64(&cached.AccessCount, 1)
+	c.hits.Add(1)
+	return cached.Content, true
+}
+
+func (c *ResourceCache) put(uri string, content mcp.ResourceContent, modTime time.Time, timeout time.Duration) {
+	now := time.Now()
+	size := int64(resourceContentSize(content))
+	c.entries.SetWithTTL(uri, &CachedContent{
+		Content:   content,
+		Timestamp: now,
+		ExpiresAt: now.Add(timeout),
+		ModTime:   modTime,
+		Size:      size,
+	}, size, 0)
+}
+
+// drop removes content whose backing changed, reporting it if there was any
+func (c *ResourceCache) drop(uri, reason string) {
+	if c.entries.Delete(uri) && c.invalidated != nil {
+		c.invalidated(uri, reason)
+	}
+}
+
+// Invalidate drops the cached content of uri
+func (c *ResourceCache) Invalidate(uri string) {
+	if c != nil {
+		c.entries.Delete(uri)
+	}
+}
+
+// Purge drops all cached content
+func (c *ResourceCache) Purge() {
+	if c != nil {
+		c.entries.Purge()
+	}
+}
+
+// Stats returns occupancy and hit counters. Hits and misses are counted here
+// rather than by the LRU because expired and modified entries are misses.
+func (c *ResourceCache) Stats() cache.Stats {
+	if c == nil {
+		return cache.Stats{}
+	}
+	stats := c.entries.Stats()
+	stats.Hits = c.hits.Load()
+	stats.Misses = c.misses.Load()
+	stats.HitRate = 0
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(lookups) * 100
+	}
+	return stats
+}
+
+// cachedResource serves reads of its own URI from a ResourceCache
+type cachedResource struct {
+	mcp.Resource
+	handler *cachedResourceHandler
+}
+
+type cachedResourceHandler struct {
+	uri      string
+	timeout  time.Duration
+	modTimer ModTimeResource
+	cache    *ResourceCache
+	next     mcp.ResourceHandler
+}
+
+// WithCache returns a resource whose content is cached in c for the
+// resource's cache timeout, or c's default. Resources are returned unchanged
+// when c is nil or the timeout is zero.
+func WithCache(resource mcp.Resource, c *ResourceCache) mcp.Resource {
+	if c == nil {
+		return resource
+	}
+	timeout := c.defaultTimeout
+	if cacheable, ok := resource.(CacheableResource); ok && cacheable.CacheTimeout() > 0 {
+		timeout = cacheable.CacheTimeout()
+	}
+	if timeout <= 0 {
+		return resource
+	}
+
+	modTimer, _ := resource.(ModTimeResource)
+	return &cachedResource{
+		Resource: resource,
+		handler: &cachedResourceHandler{
+			uri:      resource.URI(),
+			timeout:  timeout,
+			modTimer: modTimer,
+			cache:    c,
+			next:     resource.Handler(),
+		},
+	}
+}
+
+func (r *cachedResource) Handler() mcp.ResourceHandler {
+	return r.handler
+}
+
+// Unwrap returns the original resource
+func (r *cachedResource) Unwrap() mcp.Resource {
+	return r.Resource
+}
+
+func (h *cachedResourceHandler) Read(ctx context.Context, uri string) (mcp.ResourceContent, error) {
+	if uri != h.uri {
+		return h.next.Read(ctx, uri)
+	}
+
+	var modTime time.Time
+	if h.modTimer != nil {
+		current, err := h.modTimer.CurrentModTime()
+		if err != nil {
+			// Let the handler report the missing or unreadable backing file
+			h.cache.drop(uri, "unreadable")
+			return h.next.Read(ctx, uri)
+		}
+		modTime = current
+	}
+
+	_, span := tracing.Start(ctx, "resource.cache.lookup",
+		tracing.WithAttributes(tracing.String("mcp.resource.uri", h.uri)))
+	content, hit := h.cache.get(uri, modTime)
+	span.SetAttributes(tracing.Bool("mcp.cache.hit", hit))
+	span.End()
+	if hit {
+		return content, nil
+	}
+
+	content, err := h.next.Read(ctx, uri)
+	if err == nil && content != nil {
+		h.cache.put(uri, content, modTime, h.timeout)
+	}
+	return content, err
+}
@@ -0,0 +1,202 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/mcp"
+)
+
+type countingResourceHandler struct {
+	reads int
+	text  string
+}
+
+func (h *countingResourceHandler) Read(ctx context.Context, uri string) (mcp.ResourceContent, error) {
+	h.reads++
+	return &mockResourceContent{
+		content:  []mcp.Content{&mockContent{contentType: "text", text: h.text}},
+		mimeType: "text/plain",
+	}, nil
+}
+
+// fileLikeResource reports its own cache timeout and modification time
+type fileLikeResource struct {
+	mockResource
+	timeout time.Duration
+	modTime time.Time
+	statErr error
+}
+
+func (r *fileLikeResource) CacheTimeout() time.Duration { return r.timeout }
+
+func (r *fileLikeResource) CurrentModTime() (time.Time, error) { return r.modTime, r.statErr }
+
+func newTestResourceCache(cfg config.ResourceCacheConfig) *ResourceCache {
+	log, _ := logger.NewDefault()
+	cfg.Enabled = true
+	return NewResourceCache(cfg, NewResourceValidator(&config.Config{}, log))
+}
+
+func TestWithCache_ReadThrough(t *testing.T) {
+	resourceCache := newTestResourceCache(config.ResourceCacheConfig{DefaultTimeout: 60, MaxSize: 10})
+	handler := &countingResourceHandler{text: "hello"}
+	resource := WithCache(&mockResource{uri: "test://a", handler: handler}, resourceCache)
+
+	for i := 0; i < 3; i++ {
+		content, err := resource.Handler().Read(context.Background(), "test://a")
+		if err != nil || content.GetContent()[0].GetText() != "hello" {
+			t.Fatalf("read %d failed: %v", i, err)
+		}
+	}
+	if handler.reads != 1 {
+		t.Errorf("expected one read through to the handler, got %d", handler.reads)
+	}
+
+	stats := resourceCache.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 1 || stats.Bytes != 5 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	resourceCache.Invalidate("test://a")
+	resource.Handler().Read(context.Background(), "test://a")
+	if handler.reads != 2 {
+		t.Errorf("expected read after invalidation to reach the handler, got %d", handler.reads)
+	}
+}
+
+func TestWithCache_ExpiryAndModTime(t *testing.T) {
+	resourceCache := newTestResourceCache(config.ResourceCacheConfig{DefaultTimeout: 60, MaxSize: 10})
+	var invalidated []string
+	resourceCache.invalidated = func(uri, reason string) {
+		invalidated = append(invalidated, reason)
+	}
+	handler := &countingResourceHandler{text: "v1"}
+	backing := &fileLikeResource{
+		mockResource: mockResource{uri: "file:///a.txt", handler: handler},
+		timeout:      20 * time.Millisecond,
+		modTime:      time.Unix(1000, 0),
+	}
+	resource := WithCache(backing, resourceCache)
+	read := func() {
+		if _, err := resource.Handler().Read(context.Background(), "file:///a.txt"); err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+	}
+
+	read()
+	read()
+	if handler.reads != 1 {
+		t.Fatalf("expected cached read, got %d handler reads", handler.reads)
+	}
+
+	backing.modTime = time.Unix(2000, 0)
+	read()
+	if handler.reads != 2 {
+		t.Errorf("expected modified file to be re-read, got %d handler reads", handler.reads)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	read()
+	if handler.reads != 3 {
+		t.Errorf("expected expired content to be re-read, got %d handler reads", handler.reads)
+	}
+
+	backing.statErr = errors.New("file not found")
+	read()
+	if handler.reads != 4 || resourceCache.Stats().Entries != 0 {
+		t.Errorf("expected unreadable file to bypass and drop the cache, got %d reads, %+v", handler.reads, resourceCache.Stats())
+	}
+
+	// Expiry is routine; only changes of the backing file are reported
+	if want := []string{"modified", "unreadable"}; !slices.Equal(invalidated, want) {
+		t.Errorf("expected invalidations %v, got %v", want, invalidated)
+	}
+}
+
+func TestWithCache_ByteBudget(t *testing.T) {
+	resourceCache := newTestResourceCache(config.ResourceCacheConfig{DefaultTimeout: 60, MaxSize: 10, MaxBytes: 2048})
+	for _, uri := range []string{"test://a", "test://b", "test://c"} {
+		handler := &countingResourceHandler{text: strings.Repeat("x", 1000)}
+		WithCache(&mockResource{uri: uri, handler: handler}, resourceCache).Handler().Read(context.Background(), uri)
+	}
+
+	stats := resourceCache.Stats()
+	if stats.Entries != 2 || stats.Bytes != 2000 || stats.Evictions != 1 {
+		t.Errorf("expected byte budget to evict the oldest entry, got %+v", stats)
+	}
+}
+
+func TestWithCache_Disabled(t *testing.T) {
+	resource := &mockResource{uri: "test://a", handler: &countingResourceHandler{}}
+	if WithCache(resource, nil) != mcp.Resource(resource) {
+		t.Error("expected resource to be returned unchanged without a cache")
+	}
+	log, _ := logger.NewDefault()
+	if NewResourceCache(config.ResourceCacheConfig{}, NewResourceValidator(&config.Config{}, log)) != nil {
+		t.Error("expected no cache when disabled")
+	}
+}
+
+func TestDefaultResourceRegistry_CachesReads(t *testing.T) {
+	cfg := &config.Config{
+		MCP: config.MCPConfig{
+			MaxResources:  100,
+			ResourceCache: config.ResourceCacheConfig{Enabled: true, DefaultTimeout: 60, MaxSize: 10},
+		},
+	}
+	log, _ := logger.NewDefault()
+	registry := NewDefaultResourceRegistry(cfg, log)
+	registry.Start(context.Background())
+
+	handler := &countingResourceHandler{text: "content"}
+	factory := &handlerResourceFactory{mockResourceFactory: *createTestResourceFactory("custom://cached").(*mockResourceFactory), handler: handler}
+	if err := registry.Register("custom://cached", factory); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	resource, err := registry.Get("custom://cached")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	resource.Handler().Read(context.Background(), "custom://cached")
+	resource.Handler().Read(context.Background(), "custom://cached")
+	if handler.reads != 1 {
+		t.Errorf("expected second read to be served from cache, got %d handler reads", handler.reads)
+	}
+
+	health := registry.Health()
+	if health.CachedResources != 1 || health.CacheHitRate != 50 || health.Cache == nil {
+		t.Errorf("unexpected cache health: cached=%d hit_rate=%v cache=%+v", health.CachedResources, health.CacheHitRate, health.Cache)
+	}
+
+	snapshot, _ := registry.Metrics().Snapshot("custom://cached")
+	if snapshot.Calls != 2 {
+		t.Errorf("expected cache hits to be recorded as reads, got %d", snapshot.Calls)
+	}
+
+	if err := registry.RefreshResource(context.Background(), "custom://cached"); err != nil {
+		t.Fatalf("refresh failed: %v", err)
+	}
+	resource, _ = registry.Get("custom://cached")
+	resource.Handler().Read(context.Background(), "custom://cached")
+	if handler.reads != 2 {
+		t.Errorf("expected refresh to invalidate cached content, got %d handler reads", handler.reads)
+	}
+}
+
+// handlerResourceFactory creates resources served by a fixed handler
+type handlerResourceFactory struct {
+	mockResourceFactory
+	handler mcp.ResourceHandler
+}
+
+func (f *handlerResourceFactory) Create(ctx context.Context, config ResourceConfig) (mcp.Resource, error) {
+	return &mockResource{uri: f.uri, name: f.name, description: f.description, mimeType: f.mimeType, handler: f.handler}, nil
+}
@@ -24,6 +24,7 @@ type FileSystemResourceFactory struct {
 	maxFileSize     int64
 	allowedExts     []string
 	blockedPatterns []string
+	cacheTimeout    time.Duration
 	logger          *logger.Logger
 }
 
@@ -37,6 +38,7 @@ type FileSystemFactoryConfig struct {
 	MaxFileSize        int64
 	AllowedExtensions  []string
 	BlockedPatterns    []string
+	CacheTimeout       time.Duration // overrides ResourceConfig.CacheTimeout when set
 	Logger            *logger.Logger
 }
 
@@ -75,6 +77,7 @@ func NewFileSystemResourceFactory(config FileSystemFactoryConfig) (*FileSystemRe
 		maxFileSize:     config.MaxFileSize,
 		allowedExts:     config.AllowedExtensions,
 		blockedPatterns: config.BlockedPatterns,
+		cacheTimeout:    config.CacheTimeout,
 		logger:          config.Logger,
 	}
 
@@ -154,6 +157,7 @@ func (f *FileSystemResourceFactory) Create(ctx context.Context, config resources
 		Name:             f.extractResourceName(config, filePath),
 		Description:      f.extractResourceDescription(config, filePath),
 		ValidationConfig: validationConfig,
+		CacheTimeout:     f.resourceCacheTimeout(config),
 		Logger:          f.logger,
 	}
 
@@ -176,6 +180,14 @@ func (f *FileSystemResourceFactory) Create(ctx context.Context, config resources
 	return resource, nil
 }
 
+// resourceCacheTimeout prefers the file resource setting over the registry default
+func (f *FileSystemResourceFactory) resourceCacheTimeout(config resources.ResourceConfig) time.Duration {
+	if f.cacheTimeout > 0 {
+		return f.cacheTimeout
+	}
+	return time.Duration(config.CacheTimeout) * time.Second
+}
+
 func (f *FileSystemResourceFactory) Validate(config resources.ResourceConfig) error {
 	f.logger.Debug("validating resource configuration")
 
@@ -12,17 +12,18 @@ import (
 )
 
 type FileSystemResource struct {
-	uri         string
-	filePath    string
-	name        string
-	description string
-	mimeType    string
-	size        int64
-	modTime     time.Time
-	permissions os.FileMode
-	validator   *FilePathValidator
-	logger      *logger.Logger
-	handler     mcp.ResourceHandler
+	uri          string
+	filePath     string
+	name         string
+	description  string
+	mimeType     string
+	size         int64
+	modTime      time.Time
+	permissions  os.FileMode
+	cacheTimeout time.Duration
+	validator    *FilePathValidator
+	logger       *logger.Logger
+	handler      mcp.ResourceHandler
 }
 
 type FileSystemResourceConfig struct {
@@ -30,6 +31,7 @@ type FileSystemResourceConfig struct {
 	Name               string
 	Description        string
 	ValidationConfig   ValidationConfig
+	CacheTimeout       time.Duration
 	Logger            *logger.Logger
 }
 
@@ -65,16 +67,17 @@ func NewFileSystemResource(config FileSystemResourceConfig) (*FileSystemResource
 	}
 
 	resource := &FileSystemResource{
-		uri:         uri,
-		filePath:    config.FilePath,
-		name:        name,
-		description: description,
-		mimeType:    fileInfo.MimeType,
-		size:        fileInfo.Size,
-		modTime:     fileInfo.ModTime,
-		permissions: fileInfo.Permissions,
-		validator:   validator,
-		logger:      config.Logger,
+		uri:          uri,
+		filePath:     config.FilePath,
+		name:         name,
+		description:  description,
+		mimeType:     fileInfo.MimeType,
+		size:         fileInfo.Size,
+		modTime:      fileInfo.ModTime,
+		permissions:  fileInfo.Permissions,
+		cacheTimeout: config.CacheTimeout,
+		validator:    validator,
+		logger:       config.Logger,
 	}
 
 	// Create handler after resource is initialized
@@ -137,6 +140,21 @@ func (r *FileSystemResource) GetModTime() time.Time {
 	return r.modTime
 }
 
+// CacheTimeout returns how long the file content may be served from cache
+func (r *FileSystemResource) CacheTimeout() time.Duration {
+	return r.cacheTimeout
+}
+
+// CurrentModTime stats the file, so cached content can be dropped as soon as
+// the file is modified
+func (r *FileSystemResource) CurrentModTime() (time.Time, error) {
+	info, err := os.Stat(r.filePath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
 func (r *FileSystemResource) GetPermissions() os.FileMode {
 	return r.permissions
 }
@@ -233,6 +233,45 @@ func TestFileSystemResource_RefreshMetadata(t *testing.T) {
 	}
 }
 
+func TestFileSystemResource_CacheHints(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "resource_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	log := createTestLogger(t)
+	testFile := createTestFile(t, tmpDir, "cached.txt", "initial content")
+
+	config := createTestResourceConfig(testFile, log)
+	config.CacheTimeout = 2 * time.Minute
+	resource, err := NewFileSystemResource(config)
+	if err != nil {
+		t.Fatalf("NewFileSystemResource failed: %v", err)
+	}
+
+	if resource.CacheTimeout() != 2*time.Minute {
+		t.Errorf("Expected cache timeout 2m, got %v", resource.CacheTimeout())
+	}
+
+	modTime, err := resource.CurrentModTime()
+	if err != nil {
+		t.Fatalf("CurrentModTime failed: %v", err)
+	}
+	later := modTime.Add(time.Minute)
+	if err := os.Chtimes(testFile, later, later); err != nil {
+		t.Fatalf("Failed to touch test file: %v", err)
+	}
+	if current, _ := resource.CurrentModTime(); !current.Equal(later) {
+		t.Errorf("Expected CurrentModTime to follow the file, got %v want %v", current, later)
+	}
+
+	os.Remove(testFile)
+	if _, err := resource.CurrentModTime(); err == nil {
+		t.Error("Expected error for removed file")
+	}
+}
+
 func TestFileSystemResource_Validate(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "resource_test")
 	if err != nil {
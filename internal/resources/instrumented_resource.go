@@ -0,0 +1,88 @@
+package resources
+
+import (
+	"context"
+	"errors"
+
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/metrics"
+	"mcp-server/internal/ratelimit"
+	"mcp-server/internal/tracing"
+)
+
+// instrumentedResource records the outcome, latency and size of every read
+// and traces it as a resource.read span
+type instrumentedResource struct {
+	mcp.Resource
+	handler *instrumentedHandler
+}
+
+type instrumentedHandler struct {
+	uri       string
+	collector *metrics.Collector
+	next      mcp.ResourceHandler
+}
+
+// WithMetrics returns a resource whose reads are recorded in collector
+func WithMetrics(resource mcp.Resource, collector *metrics.Collector) mcp.Resource {
+	if existing, ok := resource.(*instrumentedResource); ok {
+		return existing
+	}
+	return &instrumentedResource{
+		Resource: resource,
+		handler: &instrumentedHandler{
+			uri:       resource.URI(),
+			collector: collector,
+			next:      resource.Handler(),
+		},
+	}
+}
+
+func (r *instrumentedResource) Handler() mcp.ResourceHandler {
+	return r.handler
+}
+
+// Unwrap returns the original resource
+func (r *instrumentedResource) Unwrap() mcp.Resource {
+	return r.Resource
+}
+
+func (h *instrumentedHandler) Read(ctx context.Context, uri string) (mcp.ResourceContent, error) {
+	ctx, span := tracing.Start(ctx, "resource.read",
+		tracing.WithAttributes(tracing.String("mcp.resource.uri", h.uri)))
+	defer span.End()
+
+	done := h.collector.Start(h.uri, 0)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			done(metrics.OutcomeError, 0)
+			span.SetStatus(tracing.StatusError, "resource handler panicked")
+			panic(recovered)
+		}
+	}()
+
+	content, err := h.next.Read(ctx, uri)
+	if errors.Is(err, ratelimit.ErrRateLimited) {
+		done(metrics.OutcomeRejected, 0)
+		span.RecordError(err)
+		return content, err
+	}
+	if err != nil {
+		done(metrics.OutcomeError, 0)
+		span.RecordError(err)
+		return content, err
+	}
+	done(metrics.OutcomeSuccess, resourceContentSize(content))
+	return content, nil
+}
+
+func resourceContentSize(content mcp.ResourceContent) int {
+	if content == nil {
+		return 0
+	}
+	size := 0
+	for _, c := range content.GetContent() {
+		size += len(c.GetText()) + len(c.GetBlob())
+	}
+	return size
+}
@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/ratelimit"
+)
+
+// rateLimitedResource rejects reads once the caller used up its session or
+// client budget
+type rateLimitedResource struct {
+	mcp.Resource
+	handler *rateLimitedHandler
+}
+
+type rateLimitedHandler struct {
+	uri     string
+	callers *mcp.CallerLimiter
+	next    mcp.ResourceHandler
+}
+
+// WithRateLimit returns a resource whose reads draw from the caller budgets
+// of limiter
+func WithRateLimit(resource mcp.Resource, limiter *mcp.CallerLimiter) mcp.Resource {
+	return &rateLimitedResource{
+		Resource: resource,
+		handler: &rateLimitedHandler{
+			uri:     resource.URI(),
+			callers: limiter,
+			next:    resource.Handler(),
+		},
+	}
+}
+
+func (r *rateLimitedResource) Handler() mcp.ResourceHandler {
+	return r.handler
+}
+
+// Unwrap returns the original resource
+func (r *rateLimitedResource) Unwrap() mcp.Resource {
+	return r.Resource
+}
+
+func (h *rateLimitedHandler) Read(ctx context.Context, uri string) (mcp.ResourceContent, error) {
+	if err := ratelimit.AllowAll(h.callers.Checks(mcp.CallerFromContext(ctx))...); err != nil {
+		return nil, fmt.Errorf("resource %s: %w", h.uri, err)
+	}
+	return h.next.Read(ctx, uri)
+}
@@ -0,0 +1,49 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/ratelimit"
+)
+
+func TestDefaultResourceRegistry_RateLimitsReads(t *testing.T) {
+	registry := createTestResourceRegistry()
+	registry.SetCallerLimiter(mcp.NewCallerLimiter(ratelimit.Limit{Rate: 0.01, Burst: 2}))
+
+	uri := "file:///test/flooded.txt"
+	if err := registry.Register(uri, createTestResourceFactory(uri)); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	resource, err := registry.Get(uri)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+
+	ctx := mcp.WithCaller(context.Background(), mcp.Caller{SessionID: "runaway"})
+	for i := 0; i < 2; i++ {
+		if _, err := resource.Handler().Read(ctx, uri); err != nil {
+			t.Fatalf("read %d within burst rejected: %v", i, err)
+		}
+	}
+
+	_, err = resource.Handler().Read(ctx, uri)
+	if !errors.Is(err, ratelimit.ErrRateLimited) {
+		t.Fatalf("expected rate limited read, got %v", err)
+	}
+	if retryAfter, ok := ratelimit.RetryAfter(err); !ok || retryAfter <= 0 {
+		t.Errorf("expected retry hint, got %v", retryAfter)
+	}
+
+	other := mcp.WithCaller(context.Background(), mcp.Caller{SessionID: "quiet"})
+	if _, err := resource.Handler().Read(other, uri); err != nil {
+		t.Errorf("other session should not be throttled: %v", err)
+	}
+
+	snapshot, _ := registry.Metrics().Snapshot(uri)
+	if snapshot.Rejected != 1 {
+		t.Errorf("expected 1 rejected read in metrics, got %d", snapshot.Rejected)
+	}
+}
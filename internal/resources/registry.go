@@ -7,37 +7,54 @@ import (
 	"time"
 
 	"mcp-server/internal/config"
+	"mcp-server/internal/events"
 	"mcp-server/internal/logger"
 	"mcp-server/internal/mcp"
+	"mcp-server/internal/metrics"
 	"mcp-server/internal/registry"
+	"mcp-server/internal/tracing"
 )
 
 type DefaultResourceRegistry struct {
 	*registry.BaseLifecycleManager
-	factories        map[string]ResourceFactory
-	circuitFactories map[string]*registry.CircuitBreakerFactory[mcp.Resource]
-	resources        map[string]mcp.Resource
-	resourceInfo     map[string]ResourceInfo
-	cache           map[string]CachedContent
+	entities        *registry.Registry[ResourceFactory, mcp.Resource, struct{}]
+	cache           *ResourceCache // nil when disabled
 	validator       *ResourceValidator
+	metrics         *metrics.Collector
+	callers         *mcp.CallerLimiter // nil when rate limiting is disabled
+	supervisor      *registry.Supervisor
+	loader          *registry.Loader
 	startTime       time.Time
-	lastCheck       time.Time
-	cacheHits       int64
-	cacheMisses     int64
 	mu              sync.RWMutex
-	cacheMu         sync.RWMutex
 }
 
 func NewDefaultResourceRegistry(cfg *config.Config, log *logger.Logger) ResourceRegistry {
-	return &DefaultResourceRegistry{
+	validator := NewResourceValidator(cfg, log)
+	var resourceCache *ResourceCache
+	if cfg != nil {
+		resourceCache = NewResourceCache(cfg.MCP.ResourceCache, validator)
+	}
+
+	r := &DefaultResourceRegistry{
 		BaseLifecycleManager: registry.NewBaseLifecycleManager(cfg, log),
-		factories:            make(map[string]ResourceFactory),
-		circuitFactories:     make(map[string]*registry.CircuitBreakerFactory[mcp.Resource]),
-		resources:            make(map[string]mcp.Resource),
-		resourceInfo:         make(map[string]ResourceInfo),
-		cache:               make(map[string]CachedContent),
-		validator:           NewResourceValidator(cfg, log),
+		cache:               resourceCache,
+		validator:           validator,
+		metrics:             metrics.NewCollector(),
+	}
+	r.entities = registry.NewRegistry[ResourceFactory, mcp.Resource, struct{}](events.KindResource, log, registry.Hooks{
+		Removed: func(uri string) {
+			r.metrics.Remove(uri)
+			r.cache.Invalidate(uri)
+		},
+	})
+	if resourceCache != nil {
+		resourceCache.invalidated = func(uri, reason string) {
+			r.entities.Publish(events.Event{Type: events.TypeCacheInvalidated, Name: uri, Reason: reason})
+		}
 	}
+	r.supervisor = r.newSupervisor()
+	r.loader = registry.NewLoader(events.KindResource, cfg, log)
+	return r
 }
 
 func (r *DefaultResourceRegistry) validateRegistrationRequest(uri string, factory ResourceFactory) error {
@@ -49,7 +66,7 @@ func (r *DefaultResourceRegistry) validateRegistrationRequest(uri string, factor
 		return fmt.Errorf("%w: %v", ErrInvalidResourceURI, err)
 	}
 
-	if _, exists := r.factories[uri]; exists {
+	if r.entities.Has(uri) {
 		r.GetLogger().Error("resource already registered",
 			"uri", uri,
 		)
@@ -67,16 +84,10 @@ func (r *DefaultResourceRegistry) validateRegistrationRequest(uri string, factor
 	return nil
 }
 
-func (r *DefaultResourceRegistry) createCircuitBreakerFactory(uri string) *registry.CircuitBreakerFactory[mcp.Resource] {
-	circuitConfig := registry.DefaultCircuitBreakerConfig()
-	return registry.NewCircuitBreakerFactory[mcp.Resource](uri, circuitConfig)
-}
-
-func (r *DefaultResourceRegistry) storeFactoryInfo(uri string, factory ResourceFactory, circuitFactory *registry.CircuitBreakerFactory[mcp.Resource]) {
-	r.factories[uri] = factory
-	r.circuitFactories[uri] = circuitFactory
-
-	info := ResourceInfo{
+// resourceInfo describes a registered resource
+func resourceInfo(entry registry.Entry[ResourceFactory, mcp.Resource, struct{}]) ResourceInfo {
+	factory := entry.Factory
+	return ResourceInfo{
 		URI:          factory.URI(),
 		Name:         factory.Name(),
 		Description:  factory.Description(),
@@ -84,10 +95,11 @@ func (r *DefaultResourceRegistry) storeFactoryInfo(uri string, factory ResourceF
 		Version:      factory.Version(),
 		Tags:         factory.Tags(),
 		Capabilities: factory.Capabilities(),
-		Status:       ResourceStatusRegistered,
+		Status:       entry.Status,
 		Metadata:     make(map[string]string),
+		Restarts:     entry.Restarts,
+		History:      entry.History,
 	}
-	r.resourceInfo[uri] = info
 }
 
 func (r *DefaultResourceRegistry) Register(uri string, factory ResourceFactory) error {
@@ -105,8 +117,9 @@ func (r *DefaultResourceRegistry) Register(uri string, factory ResourceFactory)
 		return err
 	}
 
-	circuitFactory := r.createCircuitBreakerFactory(uri)
-	r.storeFactoryInfo(uri, factory, circuitFactory)
+	if err := r.entities.Register(uri, factory, struct{}{}); err != nil {
+		return fmt.Errorf("%w: %s", ErrResourceAlreadyExists, uri)
+	}
 
 	r.GetLogger().Info("resource factory registered successfully",
 		"uri", uri,
@@ -122,87 +135,90 @@ func (r *DefaultResourceRegistry) Unregister(uri string) error {
 
 	r.GetLogger().Info("unregistering resource", "uri", uri)
 
-	if _, exists := r.factories[uri]; !exists {
+	// The removal hook drops the resource's metrics and cached content
+	if err := r.entities.Unregister(uri); err != nil {
 		r.GetLogger().Warn("attempted to unregister non-existent resource", "uri", uri)
 		return fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
 	}
 
-	delete(r.factories, uri)
-	delete(r.circuitFactories, uri)
-	delete(r.resources, uri)
-	delete(r.resourceInfo, uri)
-
-	r.cacheMu.Lock()
-	delete(r.cache, uri)
-	r.cacheMu.Unlock()
-
 	r.GetLogger().Info("resource unregistered successfully", "uri", uri)
 	return nil
 }
 
-func (r *DefaultResourceRegistry) findExistingResource(uri string) (mcp.Resource, bool) {
-	if resource, exists := r.resources[uri]; exists {
-		return resource, true
-	}
-	return nil, false
-}
-
 func (r *DefaultResourceRegistry) createResourceInstance(ctx context.Context, factory ResourceFactory) (mcp.Resource, error) {
 	resourceConfig := ResourceConfig{
 		Enabled:       true,
 		Config:        make(map[string]interface{}),
-		CacheTimeout:  300,
+		CacheTimeout:  r.defaultCacheTimeout(),
 		AccessControl: make(map[string]string),
 	}
 
-	return factory.Create(ctx, resourceConfig)
+	ctx, span := tracing.Start(ctx, "resource.factory.create",
+		tracing.WithAttributes(tracing.String("mcp.resource.uri", factory.URI())))
+	defer span.End()
+
+	resource, err := factory.Create(ctx, resourceConfig)
+	span.RecordError(err)
+	return resource, err
 }
 
-func (r *DefaultResourceRegistry) validateAndStoreResource(uri string, resource mcp.Resource) error {
+// defaultCacheTimeout returns the cache timeout, in seconds, handed to
+// factories that do not choose their own
+func (r *DefaultResourceRegistry) defaultCacheTimeout() int {
+	if cfg := r.GetConfig(); cfg != nil && cfg.MCP.ResourceCache.DefaultTimeout > 0 {
+		return cfg.MCP.ResourceCache.DefaultTimeout
+	}
+	return 300
+}
+
+// wrapResource adds the read-through cache (if enabled), the caller rate
+// limits (if enabled) and read metrics to a freshly created resource.
+// Metrics are outermost so cache hits and throttled reads are recorded, and
+// cache hits still count against the rate limits.
+func (r *DefaultResourceRegistry) wrapResource(resource mcp.Resource) mcp.Resource {
+	r.mu.RLock()
+	callers := r.callers
+	r.mu.RUnlock()
+
+	resource = WithCache(resource, r.cache)
+	if callers != nil {
+		resource = WithRateLimit(resource, callers)
+	}
+	return WithMetrics(resource, r.metrics)
+}
+
+func (r *DefaultResourceRegistry) validateAndStoreResource(uri string, resource mcp.Resource) (mcp.Resource, error) {
 	if err := r.validator.ValidateResource(resource); err != nil {
 		r.GetLogger().Error("created resource validation failed",
 			"uri", uri,
 			"error", err,
 		)
-		return fmt.Errorf("%w: %v", ErrResourceValidation, err)
+		return nil, fmt.Errorf("%w: %v", ErrResourceValidation, err)
 	}
 
-	r.mu.Lock()
-	r.resources[uri] = resource
-	
-	if info, exists := r.resourceInfo[uri]; exists {
-		if IsValidTransition(info.Status, ResourceStatusLoaded) {
-			info.Status = ResourceStatusLoaded
-			r.resourceInfo[uri] = info
-		}
-	}
-	r.mu.Unlock()
+	resource = r.wrapResource(resource)
 
-	return nil
+	r.entities.SetInstance(uri, resource)
+	r.entities.TryTransition(uri, ResourceStatusLoaded, registry.SystemCause("instance created"))
+
+	return resource, nil
 }
 
 func (r *DefaultResourceRegistry) Get(uri string) (mcp.Resource, error) {
-	r.mu.RLock()
-
-	if resource, exists := r.findExistingResource(uri); exists {
-		r.mu.RUnlock()
+	if resource, exists := r.entities.Instance(uri); exists {
 		return resource, nil
 	}
 
-	factory, exists := r.factories[uri]
+	factory, exists := r.entities.Factory(uri)
 	if !exists {
-		r.mu.RUnlock()
 		return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
 	}
 
-	circuitFactory, circuitExists := r.circuitFactories[uri]
-	if !circuitExists {
-		r.mu.RUnlock()
+	circuitFactory := r.entities.Breaker(uri)
+	if circuitFactory == nil {
 		return nil, fmt.Errorf("%w: circuit breaker not found for %s", ErrResourceNotFound, uri)
 	}
 
-	r.mu.RUnlock()
-
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -218,7 +234,8 @@ func (r *DefaultResourceRegistry) Get(uri string) (mcp.Resource, error) {
 		return nil, fmt.Errorf("%w: %v", ErrResourceCreation, err)
 	}
 
-	if err := r.validateAndStoreResource(uri, resource); err != nil {
+	resource, err = r.validateAndStoreResource(uri, resource)
+	if err != nil {
 		return nil, err
 	}
 
@@ -230,10 +247,7 @@ func (r *DefaultResourceRegistry) Get(uri string) (mcp.Resource, error) {
 }
 
 func (r *DefaultResourceRegistry) GetFactory(uri string) (ResourceFactory, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	factory, exists := r.factories[uri]
+	factory, exists := r.entities.Factory(uri)
 	if !exists {
 		return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
 	}
@@ -242,89 +256,82 @@ func (r *DefaultResourceRegistry) GetFactory(uri string) (ResourceFactory, error
 }
 
 func (r *DefaultResourceRegistry) List() []ResourceInfo {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	result := make([]ResourceInfo, 0, len(r.resourceInfo))
-	for _, info := range r.resourceInfo {
-		result = append(result, info)
+	entries := r.entities.Entries()
+	result := make([]ResourceInfo, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, resourceInfo(entry))
 	}
 
 	return result
 }
 
 func (r *DefaultResourceRegistry) loadSingleResource(ctx context.Context, uri string, factory ResourceFactory) error {
+	actor := registry.ActorFromContext(ctx)
+
 	resource, err := r.createResourceInstance(ctx, factory)
 	if err != nil {
-		r.handleResourceLoadError(uri, fmt.Sprintf("failed to create resource %s: %v", uri, err), err)
+		r.handleResourceLoadError(uri, registry.ErrorCause(actor, "creation failed", err), err)
 		return err
 	}
 
 	if err := r.validator.ValidateResource(resource); err != nil {
-		r.handleResourceLoadError(uri, fmt.Sprintf("resource validation failed for %s: %v", uri, err), err)
+		r.handleResourceLoadError(uri, registry.ErrorCause(actor, "validation failed", err), err)
 		return err
 	}
 
-	r.validateAndStoreBulkResource(uri, resource)
+	r.validateAndStoreBulkResource(uri, resource, actor)
 	r.GetLogger().Debug("resource loaded successfully", "uri", uri)
 	return nil
 }
 
-func (r *DefaultResourceRegistry) handleResourceLoadError(uri string, errorMsg string, err error) {
+func (r *DefaultResourceRegistry) handleResourceLoadError(uri string, cause registry.TransitionCause, err error) {
 	r.GetLogger().Error("resource creation failed during load",
 		"uri", uri,
 		"error", err,
 	)
 
-	r.mu.Lock()
-	if info, exists := r.resourceInfo[uri]; exists {
-		if IsValidTransition(info.Status, ResourceStatusError) {
-			info.Status = ResourceStatusError
-			r.resourceInfo[uri] = info
-		}
+	if r.entities.TryTransition(uri, ResourceStatusError, cause) {
+		r.invalidateCache(uri, string(ResourceStatusError))
 	}
-	r.mu.Unlock()
 }
 
-func (r *DefaultResourceRegistry) validateAndStoreBulkResource(uri string, resource mcp.Resource) {
-	r.mu.Lock()
-	r.resources[uri] = resource
-	if info, exists := r.resourceInfo[uri]; exists {
-		info.Status = ResourceStatusLoaded
-		r.resourceInfo[uri] = info
-	}
-	r.mu.Unlock()
+func (r *DefaultResourceRegistry) validateAndStoreBulkResource(uri string, resource mcp.Resource, actor registry.Actor) {
+	r.entities.SetInstance(uri, r.wrapResource(resource))
+	r.entities.TryTransition(uri, ResourceStatusLoaded, registry.TransitionCause{Actor: actor, Reason: "loaded"})
 }
 
+// LoadResources implements ResourceRegistry.LoadResources. Resources load in
+// parallel within the startup limits; the ones still loading at the startup
+// deadline finish in the background and are left out of the returned error.
 func (r *DefaultResourceRegistry) LoadResources(ctx context.Context) error {
-	r.mu.RLock()
 	factories := make(map[string]ResourceFactory)
-	for uri, factory := range r.factories {
-		factories[uri] = factory
+	for _, entry := range r.entities.Entries() {
+		// Disabled resources stay down until an operator enables them
+		if entry.Status == ResourceStatusDisabled {
+			continue
+		}
+		factories[entry.Key] = entry.Factory
 	}
-	r.mu.RUnlock()
 
 	r.GetLogger().Info("loading resources",
 		"count", len(factories),
 	)
 
-	var errors []string
-	loaded := 0
+	tasks := make([]registry.LoadTask, 0, len(factories))
+	for uri := range factories {
+		tasks = append(tasks, registry.LoadTask{Key: uri})
+	}
+	report := r.loader.Run(ctx, tasks, func(ctx context.Context, uri string) error {
+		return r.loadSingleResource(ctx, uri, factories[uri])
+	})
 
-	for uri, factory := range factories {
-		if err := r.loadSingleResource(ctx, uri, factory); err != nil {
-			errors = append(errors, err.Error())
-		} else {
-			loaded++
+	var errors []string
+	for _, result := range report.Entities {
+		if result.Status == registry.LoadFailed {
+			errors = append(errors, result.Error)
 		}
 	}
 
-	r.GetLogger().Info("resource loading completed",
-		"total", len(factories),
-		"loaded", loaded,
-		"errors", len(errors),
-	)
-
 	if len(errors) > 0 {
 		return fmt.Errorf("failed to load %d resources: %v", len(errors), errors)
 	}
@@ -339,37 +346,19 @@ func (r *DefaultResourceRegistry) validateSingleResource(uri string, resource mc
 			"error", err,
 		)
 
-		r.mu.Lock()
-		if info, exists := r.resourceInfo[uri]; exists {
-			if IsValidTransition(info.Status, ResourceStatusError) {
-				info.Status = ResourceStatusError
-				r.resourceInfo[uri] = info
-			}
+		if r.entities.TryTransition(uri, ResourceStatusError, registry.ErrorCause(registry.ActorSystem, "validation failed", err)) {
+			r.invalidateCache(uri, string(ResourceStatusError))
 		}
-		r.mu.Unlock()
 		
 		return fmt.Errorf("validation failed for resource %s: %v", uri, err)
 	}
 
-	r.mu.Lock()
-	if info, exists := r.resourceInfo[uri]; exists {
-		if IsValidTransition(info.Status, ResourceStatusActive) {
-			info.Status = ResourceStatusActive
-			r.resourceInfo[uri] = info
-		}
-	}
-	r.mu.Unlock()
-
+	r.entities.TryTransition(uri, ResourceStatusActive, registry.SystemCause("activated"))
 	return nil
 }
 
 func (r *DefaultResourceRegistry) ValidateResources(ctx context.Context) error {
-	r.mu.RLock()
-	resources := make(map[string]mcp.Resource)
-	for uri, resource := range r.resources {
-		resources[uri] = resource
-	}
-	r.mu.RUnlock()
+	resources := r.entities.Instances()
 
 	r.GetLogger().Info("validating resources",
 		"count", len(resources),
@@ -418,35 +407,31 @@ func (r *DefaultResourceRegistry) validateStatusTransition(uri string, currentSt
 	return nil
 }
 
-func (r *DefaultResourceRegistry) updateResourceStatus(uri string, newStatus ResourceStatus) {
-	if info, exists := r.resourceInfo[uri]; exists {
-		info.Status = newStatus
-		r.resourceInfo[uri] = info
+// invalidateCache drops the cached content of a resource
+func (r *DefaultResourceRegistry) invalidateCache(uri, reason string) {
+	if r.cache == nil {
+		return
 	}
+	r.cache.Invalidate(uri)
+	r.entities.Publish(events.Event{Type: events.TypeCacheInvalidated, Name: uri, Reason: reason})
 }
 
+// handleStatusTransitionCleanup drops the cached content of a resource that
+// went down; the lifecycle core has already dropped its instance
 func (r *DefaultResourceRegistry) handleStatusTransitionCleanup(uri string, newStatus ResourceStatus) {
 	switch newStatus {
-	case ResourceStatusDisabled:
-		if _, exists := r.resources[uri]; exists {
-			delete(r.resources, uri)
-			r.GetLogger().Debug("removed resource instance for disabled resource", "uri", uri)
-		}
-		r.cacheMu.Lock()
-		delete(r.cache, uri)
-		r.cacheMu.Unlock()
-	case ResourceStatusError:
-		if _, exists := r.resources[uri]; exists {
-			delete(r.resources, uri)
-			r.GetLogger().Debug("removed resource instance for error resource", "uri", uri)
-		}
-		r.cacheMu.Lock()
-		delete(r.cache, uri)
-		r.cacheMu.Unlock()
+	case ResourceStatusDisabled, ResourceStatusError:
+		r.invalidateCache(uri, string(newStatus))
 	}
 }
 
 func (r *DefaultResourceRegistry) TransitionStatus(uri string, newStatus ResourceStatus) error {
+	return r.TransitionStatusWith(uri, newStatus, registry.SystemCause(""))
+}
+
+// TransitionStatusWith changes the status of a resource, recording the cause
+// in its history and the status change event
+func (r *DefaultResourceRegistry) TransitionStatusWith(uri string, newStatus ResourceStatus, cause registry.TransitionCause) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -455,7 +440,7 @@ func (r *DefaultResourceRegistry) TransitionStatus(uri string, newStatus Resourc
 		"new_status", string(newStatus),
 	)
 
-	info, exists := r.resourceInfo[uri]
+	currentStatus, exists := r.entities.Status(uri)
 	if !exists {
 		r.GetLogger().Error("attempted to transition status of non-existent resource",
 			"uri", uri,
@@ -464,13 +449,13 @@ func (r *DefaultResourceRegistry) TransitionStatus(uri string, newStatus Resourc
 		return fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
 	}
 
-	currentStatus := info.Status
-	
 	if err := r.validateStatusTransition(uri, currentStatus, newStatus); err != nil {
 		return err
 	}
 
-	r.updateResourceStatus(uri, newStatus)
+	if err := r.entities.Transition(uri, newStatus, cause); err != nil {
+		return err
+	}
 	r.handleStatusTransitionCleanup(uri, newStatus)
 
 	r.GetLogger().Info("resource status transition completed successfully",
@@ -496,13 +481,13 @@ func (r *DefaultResourceRegistry) recreateResourceInstance(ctx context.Context,
 	resource, err := r.createResourceInstance(ctx, factory)
 	if err != nil {
 		r.GetLogger().Error("resource recreation failed during refresh", "uri", uri, "error", err)
-		r.TransitionStatus(uri, ResourceStatusError)
+		r.TransitionStatusWith(uri, ResourceStatusError, registry.ErrorCause(registry.ActorFromContext(ctx), "refresh failed", err))
 		return nil, fmt.Errorf("%w: failed to recreate resource %s: %v", ErrResourceRefresh, uri, err)
 	}
 
 	if err := r.validator.ValidateResource(resource); err != nil {
 		r.GetLogger().Error("resource validation failed during refresh", "uri", uri, "error", err)
-		r.TransitionStatus(uri, ResourceStatusError)
+		r.TransitionStatusWith(uri, ResourceStatusError, registry.ErrorCause(registry.ActorFromContext(ctx), "refresh validation failed", err))
 		return nil, fmt.Errorf("%w: resource validation failed for %s: %v", ErrResourceRefresh, uri, err)
 	}
 
@@ -510,29 +495,16 @@ func (r *DefaultResourceRegistry) recreateResourceInstance(ctx context.Context,
 }
 
 func (r *DefaultResourceRegistry) updateResourceAndCache(uri string, resource mcp.Resource) {
-	r.mu.Lock()
-	r.resources[uri] = resource
-	r.mu.Unlock()
-
-	r.cacheMu.Lock()
-	delete(r.cache, uri)
-	r.cacheMu.Unlock()
+	r.entities.SetInstance(uri, r.wrapResource(resource))
+	r.invalidateCache(uri, "refresh")
 }
 
 func (r *DefaultResourceRegistry) RefreshResource(ctx context.Context, uri string) error {
-	r.mu.RLock()
-	factory, exists := r.factories[uri]
+	entry, exists := r.entities.Entry(uri)
 	if !exists {
-		r.mu.RUnlock()
-		return fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
-	}
-	
-	info, infoExists := r.resourceInfo[uri]
-	if !infoExists {
-		r.mu.RUnlock()
 		return fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
 	}
-	r.mu.RUnlock()
+	info, factory := resourceInfo(entry), entry.Factory
 
 	r.GetLogger().Info("refreshing resource", "uri", uri)
 
@@ -546,6 +518,7 @@ func (r *DefaultResourceRegistry) RefreshResource(ctx context.Context, uri strin
 	}
 
 	r.updateResourceAndCache(uri, resource)
+	r.entities.RecordRestart(uri)
 
 	r.GetLogger().Info("resource refresh completed successfully", "uri", uri)
 	return nil
@@ -566,13 +539,19 @@ func (r *DefaultResourceRegistry) Start(ctx context.Context) error {
 	}
 
 	r.startTime = time.Now()
-	r.lastCheck = time.Now()
+	for _, uri := range r.entities.Start() {
+		r.invalidateCache(uri, string(ResourceStatusDisabled))
+	}
+	r.supervisor.Start()
 
 	r.GetLogger().Info("resource registry started successfully")
 	return nil
 }
 
 func (r *DefaultResourceRegistry) Stop(ctx context.Context) error {
+	// The supervisor's restarts take r.mu, so it must stop first
+	r.supervisor.Stop()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -582,17 +561,8 @@ func (r *DefaultResourceRegistry) Stop(ctx context.Context) error {
 
 	r.GetLogger().Info("stopping resource registry")
 
-	r.resources = make(map[string]mcp.Resource)
-	r.cacheMu.Lock()
-	r.cache = make(map[string]CachedContent)
-	r.cacheMu.Unlock()
-	
-	for uri, info := range r.resourceInfo {
-		if IsValidTransition(info.Status, ResourceStatusDisabled) {
-			info.Status = ResourceStatusDisabled
-			r.resourceInfo[uri] = info
-		}
-	}
+	r.entities.Stop(registry.SystemCause("registry stopped"))
+	r.cache.Purge()
 
 	if err := r.BaseLifecycleManager.Stop(ctx); err != nil {
 		return fmt.Errorf("failed to stop base lifecycle manager: %w", err)
@@ -603,44 +573,26 @@ func (r *DefaultResourceRegistry) Stop(ctx context.Context) error {
 }
 
 func (r *DefaultResourceRegistry) Health() RegistryHealth {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	r.cacheMu.RLock()
-	cacheSize := len(r.cache)
-	totalRequests := r.cacheHits + r.cacheMisses
-	var hitRate float64
-	if totalRequests > 0 {
-		hitRate = float64(r.cacheHits) / float64(totalRequests) * 100.0
-	}
-	r.cacheMu.RUnlock()
+	cacheStats := r.cache.Stats()
+	counts := r.entities.Health()
 
 	health := RegistryHealth{
 		Status:            "healthy",
-		ResourceCount:     len(r.resourceInfo),
-		ActiveResources:   0,
-		ErrorResources:    0,
-		CachedResources:   cacheSize,
-		CacheHitRate:      hitRate,
-		LastCheck:         r.lastCheck.Format(time.RFC3339),
+		ResourceCount:     counts.EntityCount,
+		ActiveResources:   counts.ActiveEntities,
+		ErrorResources:    counts.ErrorEntities,
+		CachedResources:   cacheStats.Entries,
+		CacheHitRate:      cacheStats.HitRate,
+		LastCheck:         counts.LastCheck,
 		Errors:            []string{},
-		ResourceStatuses:  make(map[string]string),
-		CircuitBreakers:   make(map[string]string),
+		ResourceStatuses:  counts.EntityStatuses,
+		CircuitBreakers:   r.entities.BreakerStatuses(),
 	}
-
-	for uri, info := range r.resourceInfo {
-		health.ResourceStatuses[uri] = string(info.Status)
-		
-		switch info.Status {
-		case ResourceStatusActive:
-			health.ActiveResources++
-		case ResourceStatusError:
-			health.ErrorResources++
-		}
+	if r.cache != nil {
+		health.Cache = &cacheStats
 	}
-
-	for uri, cb := range r.circuitFactories {
-		health.CircuitBreakers[uri] = cb.Status()
+	if attempts := r.supervisor.Attempts(); len(attempts) > 0 {
+		health.Recovering = attempts
 	}
 
 	if !r.IsRunning() {
@@ -655,4 +607,37 @@ func (r *DefaultResourceRegistry) Health() RegistryHealth {
 	}
 
 	return health
-}
\ No newline at end of file
+}
+
+// SetEvents publishes lifecycle events of the registry's resources on bus
+func (r *DefaultResourceRegistry) SetEvents(bus *events.Bus) {
+	r.entities.SetEvents(bus)
+}
+
+// WaitLoaded implements ResourceRegistry.WaitLoaded
+func (r *DefaultResourceRegistry) WaitLoaded(ctx context.Context, uri string) error {
+	return r.loader.Wait(ctx, uri)
+}
+
+// StartupReport implements ResourceRegistry.StartupReport
+func (r *DefaultResourceRegistry) StartupReport() *registry.LoadReport {
+	return r.loader.Report()
+}
+
+// SetCallerLimiter implements ResourceRegistry.SetCallerLimiter
+func (r *DefaultResourceRegistry) SetCallerLimiter(limiter *mcp.CallerLimiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.callers = limiter
+}
+
+// SetStateStore persists operator decisions about the registry's resources
+// in store; they are re-applied on Start
+func (r *DefaultResourceRegistry) SetStateStore(store registry.StateStore) {
+	r.entities.SetStateStore(store)
+}
+
+// Metrics returns the collector recording resource reads
+func (r *DefaultResourceRegistry) Metrics() *metrics.Collector {
+	return r.metrics
+}
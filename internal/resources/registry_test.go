@@ -3,6 +3,7 @@ package resources
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"mcp-server/internal/config"
 	"mcp-server/internal/logger"
 	"mcp-server/internal/mcp"
+	"mcp-server/internal/registry"
 )
 
 type mockResourceContent struct {
@@ -378,6 +380,74 @@ func TestDefaultResourceRegistry_LoadResources(t *testing.T) {
 	}
 }
 
+// slowResourceFactory creates its resource once release is closed, or fails
+// when ctx ends first
+type slowResourceFactory struct {
+	mockResourceFactory
+	release chan struct{}
+}
+
+func (f *slowResourceFactory) Create(ctx context.Context, config ResourceConfig) (mcp.Resource, error) {
+	select {
+	case <-f.release:
+		return f.mockResourceFactory.Create(ctx, config)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestDefaultResourceRegistry_LoadResourcesInParallelWithinDeadline(t *testing.T) {
+	cfg := &config.Config{
+		MCP:     config.MCPConfig{MaxResources: 100},
+		Startup: config.StartupConfig{Workers: 4, FactoryTimeout: 5 * time.Second, Deadline: 50 * time.Millisecond},
+	}
+	log, _ := logger.NewDefault()
+	registry := NewDefaultResourceRegistry(cfg, log)
+	ctx := context.Background()
+
+	// Two slow resources would take two deadlines back to back; in
+	// parallel both are left loading at the first one
+	release := make(chan struct{})
+	for _, uri := range []string{"file:///slow_a.txt", "file:///slow_b.txt"} {
+		slow := &slowResourceFactory{mockResourceFactory: *createTestResourceFactory(uri).(*mockResourceFactory), release: release}
+		if err := registry.Register(uri, slow); err != nil {
+			t.Fatalf("failed to register %s: %v", uri, err)
+		}
+	}
+	if err := registry.Register("file:///fast.txt", createTestResourceFactory("file:///fast.txt")); err != nil {
+		t.Fatalf("failed to register fast resource: %v", err)
+	}
+	if err := registry.Start(ctx); err != nil {
+		t.Fatalf("failed to start registry: %v", err)
+	}
+	defer registry.Stop(ctx)
+
+	if err := registry.LoadResources(ctx); err != nil {
+		t.Fatalf("expected resources still loading not to fail the load, got %v", err)
+	}
+	report := registry.StartupReport()
+	if !report.DeadlineExceeded || report.Loaded != 1 || report.Pending != 2 {
+		t.Fatalf("unexpected report at the deadline: %+v", report)
+	}
+	if _, err := registry.Get("file:///fast.txt"); err != nil {
+		t.Errorf("expected the fast resource to be ready, got %v", err)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for !registry.StartupReport().Complete() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if report := registry.StartupReport(); !report.Complete() || report.Loaded != 3 {
+		t.Fatalf("expected the slow resources to finish in background, got %+v", report)
+	}
+	for _, info := range registry.List() {
+		if info.Status != ResourceStatusLoaded {
+			t.Errorf("expected %s loaded, got %s", info.URI, info.Status)
+		}
+	}
+}
+
 func TestDefaultResourceRegistry_ValidateResources(t *testing.T) {
 	registry := createTestResourceRegistry()
 	ctx := context.Background()
@@ -449,6 +519,101 @@ func TestDefaultResourceRegistry_TransitionStatus(t *testing.T) {
 	}
 }
 
+func TestDefaultResourceRegistry_StatusHistory(t *testing.T) {
+	resourceRegistry := createTestResourceRegistry()
+	ctx := context.Background()
+	uri := "file:///test/resource.txt"
+
+	if err := resourceRegistry.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting registry, got: %v", err)
+	}
+	if err := resourceRegistry.Register(uri, createTestResourceFactory(uri)); err != nil {
+		t.Fatalf("Expected no error registering resource, got: %v", err)
+	}
+
+	cause := registry.ErrorCause(registry.ActorSupervisor, "health check failed", fmt.Errorf("disk unavailable"))
+	if err := resourceRegistry.TransitionStatusWith(uri, ResourceStatusError, cause); err != nil {
+		t.Fatalf("Expected no error transitioning status, got: %v", err)
+	}
+
+	history := resourceRegistry.List()[0].History
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 history entries, got %d", len(history))
+	}
+	if history[0].To != ResourceStatusRegistered || history[0].Actor != registry.ActorSystem {
+		t.Errorf("Expected registration first, got %+v", history[0])
+	}
+	last := history[1]
+	if last.From != ResourceStatusRegistered || last.To != ResourceStatusError {
+		t.Errorf("Expected registered -> error, got %s -> %s", last.From, last.To)
+	}
+	if last.Actor != registry.ActorSupervisor || last.Reason != "health check failed" || last.Error != "disk unavailable" {
+		t.Errorf("Unexpected transition cause: %+v", last)
+	}
+	if last.Timestamp.IsZero() {
+		t.Error("Expected the transition to be timestamped")
+	}
+}
+
+func TestDefaultResourceRegistry_RestoresPersistedState(t *testing.T) {
+	ctx := context.Background()
+	uri := "file:///test/resource.txt"
+	path := filepath.Join(t.TempDir(), "state.json")
+	store, err := registry.NewJSONFileStore(path)
+	if err != nil {
+		t.Fatalf("Expected no error opening store, got: %v", err)
+	}
+
+	resourceRegistry := createTestResourceRegistry()
+	resourceRegistry.SetStateStore(store)
+	if err := resourceRegistry.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting registry, got: %v", err)
+	}
+	if err := resourceRegistry.Register(uri, createTestResourceFactory(uri)); err != nil {
+		t.Fatalf("Expected no error registering resource, got: %v", err)
+	}
+	if err := resourceRegistry.LoadResources(ctx); err != nil {
+		t.Fatalf("Expected no error loading resources, got: %v", err)
+	}
+	if err := resourceRegistry.RefreshResource(ctx, uri); err != nil {
+		t.Fatalf("Expected no error refreshing resource, got: %v", err)
+	}
+	admin := registry.TransitionCause{Actor: registry.ActorAdmin, Reason: "disable requested by oncall"}
+	if err := resourceRegistry.TransitionStatusWith(uri, ResourceStatusDisabled, admin); err != nil {
+		t.Fatalf("Expected no error transitioning status, got: %v", err)
+	}
+	resourceRegistry.Stop(ctx)
+
+	// A new process reads the same file
+	store, err = registry.NewJSONFileStore(path)
+	if err != nil {
+		t.Fatalf("Expected no error reopening store, got: %v", err)
+	}
+	restored := createTestResourceRegistry()
+	restored.SetStateStore(store)
+	if err := restored.Register(uri, createTestResourceFactory(uri)); err != nil {
+		t.Fatalf("Expected no error registering resource, got: %v", err)
+	}
+	if err := restored.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting registry, got: %v", err)
+	}
+	defer restored.Stop(ctx)
+	if err := restored.LoadResources(ctx); err != nil {
+		t.Fatalf("Expected no error loading resources, got: %v", err)
+	}
+
+	info := restored.List()[0]
+	if info.Status != ResourceStatusDisabled {
+		t.Fatalf("Expected the operator's disable to survive loading, got %s", info.Status)
+	}
+	if info.Restarts != 1 {
+		t.Errorf("Expected 1 restart restored, got %d", info.Restarts)
+	}
+	if last := info.History[len(info.History)-1]; last.Actor != registry.ActorSystem || last.Reason != "restored: "+admin.Reason {
+		t.Errorf("Expected the restore to be recorded, got %+v", last)
+	}
+}
+
 func TestDefaultResourceRegistry_RefreshResource(t *testing.T) {
 	registry := createTestResourceRegistry()
 	ctx := context.Background()
@@ -644,3 +809,134 @@ func TestDefaultResourceRegistry_ErrorHandling(t *testing.T) {
 		t.Errorf("Expected error status for failing resource, got '%s'", info[0].Status)
 	}
 }
+
+func TestDefaultResourceRegistry_RecordsReadMetrics(t *testing.T) {
+	registry := createTestResourceRegistry()
+	uri := "file:///test/metrics.txt"
+	if err := registry.Register(uri, createTestResourceFactory(uri)); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	resource, err := registry.Get(uri)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := resource.Handler().Read(context.Background(), uri); err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+	}
+
+	snapshot, ok := registry.Metrics().Snapshot(uri)
+	if !ok {
+		t.Fatal("expected metrics for resource")
+	}
+	if snapshot.Calls != 2 || snapshot.Success != 2 || snapshot.Errors != 0 {
+		t.Errorf("unexpected read counts: %+v", snapshot)
+	}
+	if expected := int64(2 * len("mock content for "+uri)); snapshot.BytesOut != expected {
+		t.Errorf("expected %d bytes out, got %d", expected, snapshot.BytesOut)
+	}
+
+	cached, _ := registry.Get(uri)
+	if cached != resource {
+		t.Error("expected cached resource to keep its instrumentation")
+	}
+}
+
+// Benchmark tests
+
+// newBenchmarkResourceRegistry returns a started registry holding count
+// loaded resources. It logs only errors so logging does not dominate the
+// timings.
+func newBenchmarkResourceRegistry(b *testing.B, count int) (*DefaultResourceRegistry, []string) {
+	b.Helper()
+	log, _ := logger.New(logger.Config{Level: "error", Format: "text", Service: "bench", Version: "bench"})
+	r := NewDefaultResourceRegistry(&config.Config{MCP: config.MCPConfig{MaxResources: count}}, log).(*DefaultResourceRegistry)
+
+	ctx := context.Background()
+	uris := make([]string, count)
+	for i := range uris {
+		uris[i] = fmt.Sprintf("file:///bench/resource_%d.txt", i)
+		if err := r.Register(uris[i], createTestResourceFactory(uris[i])); err != nil {
+			b.Fatalf("failed to register %s: %v", uris[i], err)
+		}
+	}
+	if err := r.Start(ctx); err != nil {
+		b.Fatalf("failed to start registry: %v", err)
+	}
+	if err := r.LoadResources(ctx); err != nil {
+		b.Fatalf("failed to load resources: %v", err)
+	}
+	b.Cleanup(func() { r.Stop(ctx) })
+	return r, uris
+}
+
+// churnResourceStatuses moves resources between active and loaded until the
+// benchmark ends, like the status transitions of a busy server
+func churnResourceStatuses(b *testing.B, r *DefaultResourceRegistry, uris []string) {
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			status := ResourceStatusActive
+			if i/len(uris)%2 == 1 {
+				status = ResourceStatusLoaded
+			}
+			r.TransitionStatus(uris[i%len(uris)], status)
+		}
+	}()
+	b.Cleanup(func() {
+		close(stop)
+		<-done
+	})
+}
+
+// benchmarkResourceReaders runs read in parallel, alone and alongside a
+// goroutine churning resource statuses
+func benchmarkResourceReaders(b *testing.B, read func(r *DefaultResourceRegistry, uri string)) {
+	for _, churn := range []bool{false, true} {
+		name := "readers"
+		if churn {
+			name = "readers_and_writer"
+		}
+		b.Run(name, func(b *testing.B) {
+			r, uris := newBenchmarkResourceRegistry(b, 100)
+			if churn {
+				churnResourceStatuses(b, r, uris)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					read(r, uris[i%len(uris)])
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDefaultResourceRegistry_Get(b *testing.B) {
+	benchmarkResourceReaders(b, func(r *DefaultResourceRegistry, uri string) {
+		if _, err := r.Get(uri); err != nil {
+			b.Error(err)
+		}
+	})
+}
+
+func BenchmarkDefaultResourceRegistry_List(b *testing.B) {
+	benchmarkResourceReaders(b, func(r *DefaultResourceRegistry, _ string) {
+		r.List()
+	})
+}
+
+func BenchmarkDefaultResourceRegistry_Health(b *testing.B) {
+	benchmarkResourceReaders(b, func(r *DefaultResourceRegistry, _ string) {
+		r.Health()
+	})
+}
@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-server/internal/events"
+	"mcp-server/internal/registry"
+)
+
+// newSupervisor connects a supervisor to the resources of the registry.
+// Resources whose factories implement HealthChecker are probed while loaded
+// or active; resources in error are recreated and activated.
+func (r *DefaultResourceRegistry) newSupervisor() *registry.Supervisor {
+	return registry.NewSupervisor("resource", r.GetConfig(), registry.SupervisorHooks{
+		Entities: r.supervisedResources,
+		Fail: func(uri string, cause error) error {
+			r.GetLogger().Warn("resource failed its health check", "uri", uri, "error", cause)
+			return r.TransitionStatusWith(uri, ResourceStatusError, registry.ErrorCause(registry.ActorSupervisor, "health check failed", cause))
+		},
+		Restart: r.recoverResource,
+		Disable: func(uri string) error {
+			return r.TransitionStatusWith(uri, ResourceStatusDisabled, registry.TransitionCause{Actor: registry.ActorSupervisor, Reason: "restart attempts exhausted"})
+		},
+		Attempted: r.publishRestartAttempt,
+	}, r.GetLogger())
+}
+
+func (r *DefaultResourceRegistry) publishRestartAttempt(uri string, attempt int, err error) {
+	event := events.Event{Type: events.TypeRestartAttempt, Name: uri, Attempt: attempt, Actor: string(registry.ActorSupervisor)}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	r.entities.Publish(event)
+}
+
+func (r *DefaultResourceRegistry) supervisedResources() []registry.SupervisedEntity {
+	entries := r.entities.Entries()
+	entities := make([]registry.SupervisedEntity, 0, len(entries))
+	for _, entry := range entries {
+		entity := registry.SupervisedEntity{Key: entry.Key, Status: entry.Status}
+		if checker, ok := entry.Factory.(HealthChecker); ok {
+			entity.Checker = checker
+		}
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+// recoverResource reloads a resource in error. RefreshResource only
+// recreates loaded or active resources, so the resource is first reset to
+// registered, then loaded like at startup and activated.
+func (r *DefaultResourceRegistry) recoverResource(ctx context.Context, uri string) error {
+	factory, err := r.GetFactory(uri)
+	if err != nil {
+		return err
+	}
+
+	actor := registry.ActorFromContext(ctx)
+	if err := r.TransitionStatusWith(uri, ResourceStatusRegistered, registry.TransitionCause{Actor: actor, Reason: "restart"}); err != nil {
+		return err
+	}
+	if err := r.loadSingleResource(ctx, uri, factory); err != nil {
+		return fmt.Errorf("%w: failed to reload resource %s: %v", ErrResourceRefresh, uri, err)
+	}
+	if err := r.TransitionStatusWith(uri, ResourceStatusActive, registry.TransitionCause{Actor: actor, Reason: "restarted"}); err != nil {
+		return err
+	}
+	r.entities.RecordRestart(uri)
+	return nil
+}
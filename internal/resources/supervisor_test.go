@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+)
+
+type probedResourceFactory struct {
+	*mockResourceFactory
+	healthErr error
+}
+
+func (f *probedResourceFactory) HealthCheck(ctx context.Context) error {
+	return f.healthErr
+}
+
+func resourceStatus(r *DefaultResourceRegistry, uri string) ResourceStatus {
+	for _, info := range r.List() {
+		if info.URI == uri {
+			return info.Status
+		}
+	}
+	return ResourceStatusUnknown
+}
+
+func TestSupervisor_RecoversResource(t *testing.T) {
+	cfg := &config.Config{
+		MCP: config.MCPConfig{MaxResources: 100},
+		Supervisor: config.SupervisorConfig{
+			Interval:       time.Hour,
+			ProbeTimeout:   time.Second,
+			InitialBackoff: time.Nanosecond,
+			MaxBackoff:     time.Nanosecond,
+			MaxAttempts:    2,
+		},
+	}
+	log, _ := logger.NewDefault()
+	r := NewDefaultResourceRegistry(cfg, log).(*DefaultResourceRegistry)
+	ctx := context.Background()
+	r.Start(ctx)
+	defer r.Stop(ctx)
+
+	uri := "file:///data.txt"
+	factory := &probedResourceFactory{mockResourceFactory: createTestResourceFactory(uri).(*mockResourceFactory)}
+	if err := r.Register(uri, factory); err != nil {
+		t.Fatalf("failed to register resource: %v", err)
+	}
+	if err := r.LoadResources(ctx); err != nil {
+		t.Fatalf("failed to load resources: %v", err)
+	}
+	if err := r.TransitionStatus(uri, ResourceStatusActive); err != nil {
+		t.Fatalf("failed to activate resource: %v", err)
+	}
+
+	// A failing probe moves the resource to error; recreation fails too
+	factory.healthErr = errors.New("disk unmounted")
+	factory.createError = errors.New("disk unmounted")
+	r.supervisor.Check(ctx)
+	if status := resourceStatus(r, uri); status != ResourceStatusError {
+		t.Fatalf("expected resource in error, got %s", status)
+	}
+	if attempts := r.Health().Recovering[uri]; attempts != 1 {
+		t.Errorf("expected 1 restart attempt, got %d", attempts)
+	}
+
+	// Once the disk is back the resource is reloaded and activated
+	factory.healthErr = nil
+	factory.createError = nil
+	time.Sleep(time.Millisecond)
+	r.supervisor.Check(ctx)
+	if status := resourceStatus(r, uri); status != ResourceStatusActive {
+		t.Fatalf("expected resource active after recovery, got %s", status)
+	}
+	if _, err := r.Get(uri); err != nil {
+		t.Errorf("expected recovered resource to be readable: %v", err)
+	}
+
+	r.supervisor.Check(ctx)
+	if recovering := r.Health().Recovering; len(recovering) != 0 {
+		t.Errorf("expected attempts to reset once healthy, got %v", recovering)
+	}
+}
@@ -5,12 +5,19 @@ import (
 	"fmt"
 	"time"
 
+	"mcp-server/internal/cache"
+	"mcp-server/internal/events"
 	"mcp-server/internal/mcp"
+	"mcp-server/internal/metrics"
 	"mcp-server/internal/registry"
 )
 
 type ResourceStatus = registry.LifecycleStatus
 
+// HealthChecker may be implemented by resource factories so the supervisor
+// can probe whether their resources still work
+type HealthChecker = registry.HealthChecker
+
 const (
 	ResourceStatusUnknown    = registry.StatusUnknown
 	ResourceStatusRegistered = registry.StatusRegistered
@@ -30,6 +37,13 @@ type ResourceInfo struct {
 	Capabilities []string          `json:"capabilities"`
 	Status       ResourceStatus    `json:"status"`
 	Metadata     map[string]string `json:"metadata"`
+
+	// Restarts counts successful refreshes and recoveries, across process
+	// restarts when a state store is configured
+	Restarts int `json:"restarts,omitempty"`
+
+	// History holds the most recent status transitions, oldest first
+	History []registry.TransitionRecord `json:"history,omitempty"`
 }
 
 type ResourceConfig struct {
@@ -43,6 +57,8 @@ type CachedContent struct {
 	Content     mcp.ResourceContent
 	Timestamp   time.Time
 	ExpiresAt   time.Time
+	ModTime     time.Time
+	Size        int64
 	AccessCount int64
 }
 
@@ -69,6 +85,8 @@ type RegistryHealth struct {
 	Errors            []string            `json:"errors,omitempty"`
 	ResourceStatuses  map[string]string   `json:"resource_statuses"`
 	CircuitBreakers   map[string]string   `json:"circuit_breakers"`
+	Cache             *cache.Stats        `json:"cache,omitempty"`
+	Recovering        map[string]int      `json:"recovering,omitempty"` // supervisor restart attempts
 }
 
 type ResourceRegistry interface {
@@ -81,14 +99,30 @@ type ResourceRegistry interface {
 
 	// Resource lifecycle
 	LoadResources(ctx context.Context) error
+	// StartupReport describes the latest LoadResources, including the
+	// resources it left loading in the background; nil before the first
+	StartupReport() *registry.LoadReport
+	// WaitLoaded blocks until LoadResources finished loading the resource,
+	// loaded or not, or ctx is done
+	WaitLoaded(ctx context.Context, uri string) error
 	ValidateResources(ctx context.Context) error
 	TransitionStatus(uri string, newStatus ResourceStatus) error
+	// TransitionStatusWith is TransitionStatus recording who made the
+	// transition and why in the resource's history
+	TransitionStatusWith(uri string, newStatus ResourceStatus, cause registry.TransitionCause) error
 	RefreshResource(ctx context.Context, uri string) error
 
 	// Registry operations
 	Start(ctx context.Context) error
 	Stop(ctx context.Context) error
 	Health() RegistryHealth
+	Metrics() *metrics.Collector
+	SetEvents(bus *events.Bus)
+	// SetStateStore persists operator decisions; they are re-applied on Start
+	SetStateStore(store registry.StateStore)
+	// SetCallerLimiter makes reads draw from the session and client budgets
+	// shared with tool calls; it is set before resources are loaded
+	SetCallerLimiter(limiter *mcp.CallerLimiter)
 }
 
 var (
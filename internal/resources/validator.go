@@ -47,16 +47,17 @@ func (v *ResourceValidator) validateBasicURI(uri string) error {
 
 func (v *ResourceValidator) validateURIScheme(uri string, parsedURI *url.URL) error {
 	supportedSchemes := map[string]bool{
-		"file":   true,
-		"config": true,
-		"api":    true,
-		"custom": true,
-		"http":   true,
-		"https":  true,
+		"file":     true,
+		"config":   true,
+		"api":      true,
+		"custom":   true,
+		"http":     true,
+		"https":    true,
+		"upstream": true,
 	}
 
 	if !supportedSchemes[parsedURI.Scheme] {
-		return fmt.Errorf("unsupported URI scheme: %s (supported: file, config, api, custom, http, https)", parsedURI.Scheme)
+		return fmt.Errorf("unsupported URI scheme: %s (supported: file, config, api, custom, http, https, upstream)", parsedURI.Scheme)
 	}
 
 	switch parsedURI.Scheme {
@@ -75,6 +76,10 @@ func (v *ResourceValidator) validateURIScheme(uri string, parsedURI *url.URL) er
 		if parsedURI.Host == "" {
 			return fmt.Errorf("api URI must have a host")
 		}
+	case "upstream":
+		if parsedURI.Host == "" || parsedURI.Path == "" {
+			return fmt.Errorf("upstream URI must have a namespace host and a path")
+		}
 	}
 
 	return nil
@@ -55,6 +55,11 @@ func TestResourceValidator_ValidateURI(t *testing.T) {
 			uri:         "https://example.com/resource",
 			expectError: false,
 		},
+		{
+			name:        "valid upstream URI",
+			uri:         "upstream://docs/file:%2F%2F%2Fnotes.txt",
+			expectError: false,
+		},
 		{
 			name:        "empty URI",
 			uri:         "",
@@ -97,6 +102,12 @@ func TestResourceValidator_ValidateURI(t *testing.T) {
 			expectError: true,
 			errorSubstr: "api URI must have a host",
 		},
+		{
+			name:        "upstream URI without path",
+			uri:         "upstream://docs",
+			expectError: true,
+			errorSubstr: "upstream URI must have a namespace host and a path",
+		},
 		{
 			name:        "URI too long",
 			uri:         "file:///" + strings.Repeat("a", 2050),
@@ -0,0 +1,289 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"mcp-server/internal/registry"
+	"mcp-server/internal/resources"
+	"mcp-server/internal/tools"
+)
+
+const (
+	AdminActionDisable = "disable"
+	AdminActionEnable  = "enable"
+	AdminActionRestart = "restart"
+	AdminActionRefresh = "refresh"
+
+	// maxActorLength bounds the self-reported actor recorded in the audit log
+	maxActorLength = 64
+)
+
+// AdminLifecycleResponse reports the status an admin action left a tool or
+// resource in, and where it can go from there
+type AdminLifecycleResponse struct {
+	Kind               string   `json:"kind"`
+	Name               string   `json:"name"`
+	Action             string   `json:"action"`
+	PreviousStatus     string   `json:"previous_status,omitempty"`
+	Status             string   `json:"status,omitempty"`
+	AllowedTransitions []string `json:"allowed_transitions,omitempty"`
+	Timestamp          string   `json:"timestamp"`
+	Error              string   `json:"error,omitempty"`
+}
+
+// adminAuthorized requires the admin bearer token. Without a configured
+// token the admin API is refused altogether. Refused requests are audited.
+func (s *Server) adminAuthorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := s.config.Server.Admin.Token
+		if token == "" {
+			s.denyAdmin(w, r, http.StatusForbidden, "admin API is disabled (hint: set MCP_ADMIN_TOKEN)")
+			return
+		}
+
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			s.denyAdmin(w, r, http.StatusUnauthorized, "missing or invalid admin token")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (s *Server) denyAdmin(w http.ResponseWriter, r *http.Request, status int, message string) {
+	s.logger.Warn("admin request refused",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+		"reason", message,
+	)
+	s.audit.Record(AuditRecord{
+		Actor:      adminActor(r),
+		RemoteAddr: r.RemoteAddr,
+		Action:     r.Method + " " + r.URL.Path,
+		Outcome:    AuditOutcomeDenied,
+		Error:      message,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// adminActor names who performed an admin action. The token is shared, so
+// the X-Admin-Actor header lets on-call engineers identify themselves.
+func adminActor(r *http.Request) string {
+	actor := strings.TrimSpace(r.Header.Get("X-Admin-Actor"))
+	if actor == "" {
+		return "admin"
+	}
+	if len(actor) > maxActorLength {
+		actor = actor[:maxActorLength]
+	}
+	return actor
+}
+
+// handleToolLifecycle disables, enables or restarts a tool. Enabling and
+// restarting recreate the instance and activate it; restarting a loaded or
+// active tool disables it first, since restarts start from disabled or error.
+func (s *Server) handleToolLifecycle(w http.ResponseWriter, r *http.Request, toolName, action string) {
+	s.logger.Info("tool lifecycle action requested",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"tool_name", toolName,
+		"action", action,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := AdminLifecycleResponse{Kind: "tool", Name: toolName, Action: action}
+	cause := adminCause(r, action)
+	ctx := registry.WithActor(r.Context(), registry.ActorAdmin)
+
+	info, err := s.getToolInfo(toolName)
+	if err != nil {
+		s.writeAdminResult(w, r, response, fmt.Errorf("%w: %s", tools.ErrToolNotFound, toolName))
+		return
+	}
+	response.PreviousStatus = string(info.Status)
+
+	switch action {
+	case AdminActionDisable:
+		err = s.toolRegistry.TransitionStatusWith(toolName, tools.ToolStatusDisabled, cause)
+	case AdminActionEnable:
+		if info.Status != tools.ToolStatusDisabled {
+			err = fmt.Errorf("%w: only disabled tools can be enabled, tool is %s", tools.ErrTransitionNotAllowed, info.Status)
+			break
+		}
+		err = s.restartTool(ctx, toolName, cause)
+	case AdminActionRestart:
+		if info.Status == tools.ToolStatusActive || info.Status == tools.ToolStatusLoaded {
+			if err = s.toolRegistry.TransitionStatusWith(toolName, tools.ToolStatusDisabled, cause); err != nil {
+				break
+			}
+		}
+		err = s.restartTool(ctx, toolName, cause)
+	}
+
+	if current, lookupErr := s.getToolInfo(toolName); lookupErr == nil {
+		response.Status = string(current.Status)
+		response.AllowedTransitions = allowedTransitions(current.Status)
+	}
+	s.writeAdminResult(w, r, response, err)
+}
+
+// restartTool recreates a disabled or failed tool and activates it
+func (s *Server) restartTool(ctx context.Context, toolName string, cause registry.TransitionCause) error {
+	if err := s.toolRegistry.RestartTool(ctx, toolName); err != nil {
+		return err
+	}
+	return s.toolRegistry.TransitionStatusWith(toolName, tools.ToolStatusActive, cause)
+}
+
+// adminCause attributes a transition to the admin API, naming the actor who
+// asked for it in the reason
+func adminCause(r *http.Request, action string) registry.TransitionCause {
+	return registry.TransitionCause{
+		Actor:  registry.ActorAdmin,
+		Reason: action + " requested by " + adminActor(r),
+	}
+}
+
+// handleAdminResourceRefresh recreates a loaded or active resource and drops
+// its cached content
+func (s *Server) handleAdminResourceRefresh(w http.ResponseWriter, r *http.Request) {
+	uri := r.URL.Query().Get("uri")
+	s.logger.Info("resource refresh requested",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"uri", uri,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := AdminLifecycleResponse{Kind: "resource", Name: uri, Action: AdminActionRefresh}
+	if uri == "" {
+		response.Timestamp = time.Now().UTC().Format(time.RFC3339)
+		response.Error = "uri query parameter is required"
+		s.writeAdminResponse(w, http.StatusBadRequest, response)
+		return
+	}
+
+	info, exists := s.findResourceInfo(uri)
+	if !exists {
+		s.writeAdminResult(w, r, response, fmt.Errorf("%w: %s", resources.ErrResourceNotFound, uri))
+		return
+	}
+	response.PreviousStatus = string(info.Status)
+
+	err := s.resourceRegistry.RefreshResource(registry.WithActor(r.Context(), registry.ActorAdmin), uri)
+
+	if current, exists := s.findResourceInfo(uri); exists {
+		response.Status = string(current.Status)
+		response.AllowedTransitions = allowedTransitions(current.Status)
+	}
+	s.writeAdminResult(w, r, response, err)
+}
+
+func (s *Server) findResourceInfo(uri string) (resources.ResourceInfo, bool) {
+	for _, info := range s.resourceRegistry.List() {
+		if info.URI == uri {
+			return info, true
+		}
+	}
+	return resources.ResourceInfo{}, false
+}
+
+// allowedTransitions lists the statuses reachable from a status, sorted so
+// responses are stable
+func allowedTransitions(status registry.LifecycleStatus) []string {
+	var allowed []string
+	for _, next := range registry.GetAllowedTransitions(status) {
+		allowed = append(allowed, string(next))
+	}
+	sort.Strings(allowed)
+	return allowed
+}
+
+// writeAdminResult audits an admin action and writes its response
+func (s *Server) writeAdminResult(w http.ResponseWriter, r *http.Request, response AdminLifecycleResponse, err error) {
+	response.Timestamp = time.Now().UTC().Format(time.RFC3339)
+
+	record := AuditRecord{
+		Timestamp:      response.Timestamp,
+		Actor:          adminActor(r),
+		RemoteAddr:     r.RemoteAddr,
+		Action:         response.Kind + "." + response.Action,
+		Target:         response.Name,
+		PreviousStatus: response.PreviousStatus,
+		Status:         response.Status,
+		Outcome:        AuditOutcomeSuccess,
+	}
+	status := http.StatusOK
+	if err != nil {
+		response.Error = err.Error()
+		record.Outcome = AuditOutcomeFailure
+		record.Error = err.Error()
+		status = adminErrorStatus(err)
+	}
+	s.audit.Record(record)
+
+	s.writeAdminResponse(w, status, response)
+}
+
+func (s *Server) writeAdminResponse(w http.ResponseWriter, status int, response AdminLifecycleResponse) {
+	w.Header().Set("Content-Type", "application/json")
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("failed to marshal admin response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	w.Write(jsonData)
+}
+
+// adminErrorStatus maps lifecycle errors to HTTP statuses: unknown entities
+// are 404, actions the current status or configuration does not allow are
+// 409
+func adminErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tools.ErrToolNotFound), errors.Is(err, resources.ErrResourceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, tools.ErrInvalidTransition),
+		errors.Is(err, tools.ErrTransitionNotAllowed),
+		errors.Is(err, tools.ErrRestartNotAllowed),
+		errors.Is(err, tools.ErrRequirementsNotMet),
+		errors.Is(err, tools.ErrNoCircuitBreaker),
+		errors.Is(err, resources.ErrInvalidTransition),
+		errors.Is(err, resources.ErrTransitionNotAllowed),
+		errors.Is(err, resources.ErrRefreshNotAllowed):
+		return http.StatusConflict
+	case errors.Is(err, tools.ErrRegistryNotRunning), errors.Is(err, resources.ErrRegistryNotRunning):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"mcp-server/internal/logger"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+	AuditOutcomeDenied  = "denied"
+)
+
+// AuditRecord describes one admin request, successful or not
+type AuditRecord struct {
+	Timestamp      string `json:"timestamp"`
+	Actor          string `json:"actor"`
+	RemoteAddr     string `json:"remote_addr"`
+	Action         string `json:"action"`
+	Target         string `json:"target,omitempty"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	Status         string `json:"status,omitempty"`
+	Outcome        string `json:"outcome"`
+	Error          string `json:"error,omitempty"`
+}
+
+// auditLog records admin actions in the server log and, when configured,
+// appends them to a JSON lines file that survives log rotation policies
+type auditLog struct {
+	mu     sync.Mutex
+	file   *os.File
+	logger *logger.Logger
+}
+
+// newAuditLog opens the audit file for appending. A file that cannot be
+// opened is reported and audit records still reach the server log.
+func newAuditLog(path string, log *logger.Logger) *auditLog {
+	audit := &auditLog{logger: log}
+	if path == "" {
+		return audit
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		log.Error("failed to open admin audit log, recording to the server log only",
+			"path", path,
+			"error", err,
+		)
+		return audit
+	}
+	audit.file = file
+	return audit
+}
+
+// Record is a no-op on a nil audit log, so handlers need not check for one
+func (a *auditLog) Record(record AuditRecord) {
+	if a == nil {
+		return
+	}
+	if record.Timestamp == "" {
+		record.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+
+	a.logger.Info("admin audit",
+		"actor", record.Actor,
+		"remote_addr", record.RemoteAddr,
+		"action", record.Action,
+		"target", record.Target,
+		"previous_status", record.PreviousStatus,
+		"status", record.Status,
+		"outcome", record.Outcome,
+		"error", record.Error,
+	)
+
+	if a.file == nil {
+		return
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		a.logger.Error("failed to encode admin audit record", "error", err)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		a.logger.Error("failed to write admin audit record", "error", err)
+	}
+}
+
+func (a *auditLog) Close() error {
+	if a == nil || a.file == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.file.Close()
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-server/internal/events"
+)
+
+const (
+	// eventStreamBuffer is how far a stream client may fall behind before
+	// its stream is closed and it has to resume with Last-Event-ID
+	eventStreamBuffer = 256
+	// eventStreamKeepalive keeps idle streams from being cut by proxies
+	eventStreamKeepalive = 15 * time.Second
+)
+
+// eventFilter selects the events a stream client asked for
+type eventFilter struct {
+	types map[events.Type]bool // nil matches every type
+	kind  string               // empty matches tools and resources
+}
+
+func (f eventFilter) matches(event events.Event) bool {
+	if f.types != nil && !f.types[event.Type] {
+		return false
+	}
+	return f.kind == "" || f.kind == event.Kind
+}
+
+// handleEvents streams registry events as server-sent events. Clients can
+// narrow the feed with the types and kind query parameters, and resume after
+// a disconnect by sending the Last-Event-ID header; events still in the bus
+// history are replayed first.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	s.logger.Info("event stream requested",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.events == nil {
+		http.Error(w, "event feed is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	filter, lastID, err := parseEventStreamRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The server write timeout would otherwise cut every stream short
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	backlog, stream, cancel := s.events.Subscribe(lastID, eventStreamBuffer)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range backlog {
+		if filter.matches(event) {
+			s.writeEvent(w, event)
+		}
+	}
+	flusher.Flush()
+
+	keepalive := time.NewTicker(eventStreamKeepalive)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.streams.Done():
+			return
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		case event, ok := <-stream:
+			if !ok {
+				// Fell behind the bus; the client reconnects with
+				// Last-Event-ID and catches up from history
+				s.logger.Warn("event stream client fell behind, closing stream", "remote_addr", r.RemoteAddr)
+				return
+			}
+			if filter.matches(event) {
+				s.writeEvent(w, event)
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func (s *Server) writeEvent(w http.ResponseWriter, event events.Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Error("failed to marshal event", "event_id", event.ID, "error", err)
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
+
+func parseEventStreamRequest(r *http.Request) (eventFilter, uint64, error) {
+	var filter eventFilter
+	query := r.URL.Query()
+
+	if types := query.Get("types"); types != "" {
+		filter.types = make(map[events.Type]bool)
+		for _, eventType := range strings.Split(types, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				filter.types[events.Type(eventType)] = true
+			}
+		}
+	}
+
+	switch kind := query.Get("kind"); kind {
+	case "", events.KindTool, events.KindResource, events.KindPrompt:
+		filter.kind = kind
+	default:
+		return filter, 0, fmt.Errorf("kind must be %s, %s or %s, got %q", events.KindTool, events.KindResource, events.KindPrompt, kind)
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.Get("last_event_id")
+	}
+	if lastEventID == "" {
+		return filter, 0, nil
+	}
+	lastID, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		return filter, 0, fmt.Errorf("invalid last event id %q", lastEventID)
+	}
+	return filter, lastID, nil
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"net/http"
+	"runtime"
+	"sort"
+
+	"mcp-server/internal/cache"
+	"mcp-server/internal/metrics"
+	"mcp-server/internal/registry"
+	"mcp-server/internal/resources"
+	"mcp-server/internal/tools"
+)
+
+var exposedStatuses = []registry.LifecycleStatus{
+	registry.StatusRegistered,
+	registry.StatusLoaded,
+	registry.StatusActive,
+	registry.StatusError,
+	registry.StatusDisabled,
+}
+
+var exposedBreakerStates = []string{"closed", "half-open", "open"}
+
+// handlePrometheusMetrics serves metrics in the Prometheus or OpenMetrics
+// text format, chosen by the Accept header
+func (s *Server) handlePrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	if !s.config.MCP.EnableMetrics {
+		http.NotFound(w, r)
+		return
+	}
+
+	format, _ := metrics.NegotiateFormat(r.Header.Get("Accept"))
+	s.writeExposition(w, format)
+}
+
+func (s *Server) writeExposition(w http.ResponseWriter, format metrics.Format) {
+	exposition := s.buildExposition(format)
+
+	w.Header().Set("Content-Type", exposition.ContentType())
+	w.WriteHeader(http.StatusOK)
+	if _, err := exposition.WriteTo(w); err != nil {
+		s.logger.Error("failed to write metrics exposition", "error", err)
+	}
+}
+
+func (s *Server) buildExposition(format metrics.Format) *metrics.Exposition {
+	e := metrics.NewExposition(format)
+
+	toolHealth, toolList, resourceHealth, resourceList := s.collectRegistryData()
+
+	s.exposeRegistryMetrics(e, toolHealth, toolList, resourceHealth, resourceList)
+	s.exposeCallMetrics(e, "mcp_tool_call", "tool", "Tool call", s.toolRegistry.Metrics())
+	s.exposeCallMetrics(e, "mcp_resource_read", "uri", "Resource read", s.resourceRegistry.Metrics())
+	s.exposeRuntimeMetrics(e)
+
+	return e
+}
+
+func (s *Server) exposeRegistryMetrics(e *metrics.Exposition, toolHealth tools.RegistryHealth, toolList []tools.ToolInfo,
+	resourceHealth resources.RegistryHealth, resourceList []resources.ResourceInfo) {
+	toolStatuses := make(map[registry.LifecycleStatus]int)
+	for _, tool := range toolList {
+		toolStatuses[tool.Status]++
+	}
+	e.Gauge("mcp_tools", "Number of registered tools by lifecycle status.")
+	for _, status := range exposedStatuses {
+		e.Sample("mcp_tools", float64(toolStatuses[status]), metrics.Label{Name: "status", Value: string(status)})
+	}
+
+	resourceStatuses := make(map[registry.LifecycleStatus]int)
+	for _, resource := range resourceList {
+		resourceStatuses[resource.Status]++
+	}
+	e.Gauge("mcp_resources", "Number of registered resources by lifecycle status.")
+	for _, status := range exposedStatuses {
+		e.Sample("mcp_resources", float64(resourceStatuses[status]), metrics.Label{Name: "status", Value: string(status)})
+	}
+
+	exposeBreakerStates(e, "mcp_tool_circuit_breaker_state", "tool",
+		"Tool execution circuit breaker state; 1 for the current state.", toolHealth.CircuitBreakers)
+	exposeBreakerStates(e, "mcp_resource_circuit_breaker_state", "uri",
+		"Resource creation circuit breaker state; 1 for the current state.", resourceHealth.CircuitBreakers)
+
+	exposeBulkheads(e, toolHealth)
+	exposeResultCaches(e, toolHealth.ResultCaches)
+
+	e.Gauge("mcp_resource_cache_entries", "Number of cached resource contents.")
+	e.Sample("mcp_resource_cache_entries", float64(resourceHealth.CachedResources))
+	e.Gauge("mcp_resource_cache_hit_ratio", "Resource cache hit ratio between 0 and 1.")
+	e.Sample("mcp_resource_cache_hit_ratio", resourceHealth.CacheHitRate/100)
+	if resourceHealth.Cache != nil {
+		e.Gauge("mcp_resource_cache_bytes", "Total size of cached resource contents in bytes.")
+		e.Sample("mcp_resource_cache_bytes", float64(resourceHealth.Cache.Bytes))
+		e.Counter("mcp_resource_cache_evictions", "Resource contents evicted to stay within the entry or byte budget.")
+		e.Sample("mcp_resource_cache_evictions_total", float64(resourceHealth.Cache.Evictions))
+	}
+}
+
+func exposeBreakerStates(e *metrics.Exposition, name, labelName, help string, breakers map[string]string) {
+	e.Gauge(name, help)
+	for _, key := range sortedKeys(breakers) {
+		for _, state := range exposedBreakerStates {
+			value := 0.0
+			if breakers[key] == state {
+				value = 1
+			}
+			e.Sample(name, value, metrics.Label{Name: labelName, Value: key}, metrics.Label{Name: "state", Value: state})
+		}
+	}
+}
+
+// bulkheadFamilies describes the metric families exported for each bulkhead
+var bulkheadFamilies = []struct {
+	name, help string
+	counter    bool
+	value      func(registry.BulkheadStats) float64
+}{
+	{"bulkhead_capacity", "maximum concurrent executions.", false,
+		func(s registry.BulkheadStats) float64 { return float64(s.MaxConcurrent) }},
+	{"bulkhead_in_flight", "executions holding a slot.", false,
+		func(s registry.BulkheadStats) float64 { return float64(s.InFlight) }},
+	{"bulkhead_queued", "calls waiting for a slot.", false,
+		func(s registry.BulkheadStats) float64 { return float64(s.Queued) }},
+	{"bulkhead_rejected", "calls rejected because the queue was full.", true,
+		func(s registry.BulkheadStats) float64 { return float64(s.Rejected) }},
+	{"bulkhead_timed_out", "calls that gave up waiting in the queue.", true,
+		func(s registry.BulkheadStats) float64 { return float64(s.TimedOut) }},
+}
+
+// exposeBulkheads exports per-tool bulkheads labelled by tool and the global
+// bulkhead as separate unlabelled families so sums over tools stay correct
+func exposeBulkheads(e *metrics.Exposition, health tools.RegistryHealth) {
+	names := sortedKeys(health.Bulkheads)
+	for _, family := range bulkheadFamilies {
+		sample := declareBulkheadFamily(e, "mcp_tool_"+family.name, "Per-tool bulkhead: "+family.help, family.counter)
+		for _, tool := range names {
+			e.Sample(sample, family.value(health.Bulkheads[tool]), metrics.Label{Name: "tool", Value: tool})
+		}
+	}
+
+	if health.GlobalBulkhead == nil {
+		return
+	}
+	for _, family := range bulkheadFamilies {
+		sample := declareBulkheadFamily(e, "mcp_global_"+family.name, "Global tool bulkhead: "+family.help, family.counter)
+		e.Sample(sample, family.value(*health.GlobalBulkhead))
+	}
+}
+
+func declareBulkheadFamily(e *metrics.Exposition, name, help string, counter bool) string {
+	if counter {
+		e.Counter(name, help)
+		return name + "_total"
+	}
+	e.Gauge(name, help)
+	return name
+}
+
+// exposeResultCaches exports the tool result caches labelled by tool
+func exposeResultCaches(e *metrics.Exposition, caches map[string]cache.Stats) {
+	names := sortedKeys(caches)
+
+	e.Gauge("mcp_tool_result_cache_entries", "Number of cached tool results.")
+	for _, tool := range names {
+		e.Sample("mcp_tool_result_cache_entries", float64(caches[tool].Entries), metrics.Label{Name: "tool", Value: tool})
+	}
+	e.Counter("mcp_tool_result_cache_lookups", "Tool result cache lookups, by result.")
+	for _, tool := range names {
+		stats := caches[tool]
+		e.Sample("mcp_tool_result_cache_lookups_total", float64(stats.Hits),
+			metrics.Label{Name: "tool", Value: tool}, metrics.Label{Name: "result", Value: "hit"})
+		e.Sample("mcp_tool_result_cache_lookups_total", float64(stats.Misses),
+			metrics.Label{Name: "tool", Value: tool}, metrics.Label{Name: "result", Value: "miss"})
+	}
+	e.Counter("mcp_tool_result_cache_evictions", "Tool results evicted to stay within the entry limit.")
+	for _, tool := range names {
+		e.Sample("mcp_tool_result_cache_evictions_total", float64(caches[tool].Evictions), metrics.Label{Name: "tool", Value: tool})
+	}
+}
+
+func (s *Server) exposeCallMetrics(e *metrics.Exposition, prefix, labelName, subject string, collector *metrics.Collector) {
+	snapshots := collector.Snapshots()
+	keys := sortedKeys(snapshots)
+
+	e.Counter(prefix+"s", subject+"s completed, by outcome.")
+	for _, key := range keys {
+		snapshot := snapshots[key]
+		outcomes := []struct {
+			outcome metrics.Outcome
+			count   int64
+		}{
+			{metrics.OutcomeSuccess, snapshot.Success},
+			{metrics.OutcomeError, snapshot.Errors},
+			{metrics.OutcomeRejected, snapshot.Rejected},
+		}
+		for _, o := range outcomes {
+			e.Sample(prefix+"s_total", float64(o.count),
+				metrics.Label{Name: labelName, Value: key}, metrics.Label{Name: "outcome", Value: string(o.outcome)})
+		}
+	}
+
+	e.Histogram(prefix+"_duration_seconds", subject+" latency in seconds.")
+	for _, key := range keys {
+		e.HistogramSamples(prefix+"_duration_seconds", snapshots[key].Latency, metrics.Label{Name: labelName, Value: key})
+	}
+
+	e.Gauge(prefix+"s_in_flight", subject+"s currently executing.")
+	for _, key := range keys {
+		e.Sample(prefix+"s_in_flight", float64(snapshots[key].InFlight), metrics.Label{Name: labelName, Value: key})
+	}
+
+	e.Counter(prefix+"_request_bytes", subject+" argument bytes received.")
+	for _, key := range keys {
+		e.Sample(prefix+"_request_bytes_total", float64(snapshots[key].BytesIn), metrics.Label{Name: labelName, Value: key})
+	}
+
+	e.Counter(prefix+"_response_bytes", subject+" content bytes returned.")
+	for _, key := range keys {
+		e.Sample(prefix+"_response_bytes_total", float64(snapshots[key].BytesOut), metrics.Label{Name: labelName, Value: key})
+	}
+}
+
+func (s *Server) exposeRuntimeMetrics(e *metrics.Exposition) {
+	memStats := s.collectPerformanceData()
+
+	e.Gauge("go_info", "Information about the Go environment.")
+	e.Sample("go_info", 1, metrics.Label{Name: "version", Value: runtime.Version()})
+	e.Gauge("go_goroutines", "Number of goroutines that currently exist.")
+	e.Sample("go_goroutines", float64(runtime.NumGoroutine()))
+	e.Gauge("go_memstats_alloc_bytes", "Number of bytes allocated and still in use.")
+	e.Sample("go_memstats_alloc_bytes", float64(memStats.Alloc))
+	e.Gauge("go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.")
+	e.Sample("go_memstats_heap_inuse_bytes", float64(memStats.HeapInuse))
+	e.Gauge("go_memstats_sys_bytes", "Number of bytes obtained from the system.")
+	e.Sample("go_memstats_sys_bytes", float64(memStats.Sys))
+	e.Counter("go_gc_cycles", "Number of completed GC cycles.")
+	e.Sample("go_gc_cycles_total", float64(memStats.NumGC))
+	e.Counter("go_gc_pause_seconds", "Cumulative time spent in GC stop-the-world pauses.")
+	e.Sample("go_gc_pause_seconds_total", float64(memStats.PauseTotalNs)/1e9)
+	e.Gauge("mcp_uptime_seconds", "Seconds since the server started.")
+	e.Sample("mcp_uptime_seconds", s.collectUptimeData().Seconds())
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/ratelimit"
+)
+
+// RateLimitResponse is returned with 429 Too Many Requests
+type RateLimitResponse struct {
+	Error        string `json:"error"`
+	RetryAfterMs int64  `json:"retry_after_ms"`
+}
+
+func newDiscoveryLimiter(cfg config.HTTPRateLimitConfig) *ratelimit.Limiter {
+	if !cfg.Enabled {
+		return nil
+	}
+	return ratelimit.NewLimiter("discovery", ratelimit.Limit{Rate: cfg.RequestsPerSecond, Burst: cfg.Burst})
+}
+
+// newCallerLimiter creates the session budget shared by every tool call and
+// resource read from the global tool rate limits
+func newCallerLimiter(cfg config.ToolRateLimitConfig) *mcp.CallerLimiter {
+	if !cfg.Enabled {
+		return nil
+	}
+	return mcp.NewCallerLimiter(ratelimit.Limit{Rate: cfg.PerSession.RequestsPerSecond, Burst: cfg.PerSession.Burst})
+}
+
+// rateLimited throttles a discovery endpoint per client address. Probes and
+// metrics scrapes are deliberately left unthrottled.
+func (s *Server) rateLimited(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := s.discoveryLimiter.Allow(clientAddress(r))
+		if err == nil {
+			next(w, r)
+			return
+		}
+
+		retryAfter, _ := ratelimit.RetryAfter(err)
+		s.logger.Warn("discovery request rate limited",
+			"path", r.URL.Path,
+			"remote_addr", r.RemoteAddr,
+			"retry_after", retryAfter,
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(RateLimitResponse{
+			Error:        err.Error(),
+			RetryAfterMs: retryAfter.Milliseconds(),
+		})
+	}
+}
+
+// clientAddress identifies the client by IP so one client cannot evade the
+// limit by opening new connections
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
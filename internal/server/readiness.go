@@ -0,0 +1,345 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/resources/files"
+	"mcp-server/internal/tools"
+)
+
+// Outcomes of a readiness check
+const (
+	checkPass = "pass"
+	checkFail = "fail"
+)
+
+// ReadinessCheckFunc reports whether one dependency of the server is ready,
+// returning an error when it is not. The details, if any, are shown on
+// /ready either way.
+type ReadinessCheckFunc func(ctx context.Context) (details any, err error)
+
+// ReadinessCheckResult is the outcome of one readiness check
+type ReadinessCheckResult struct {
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	Details    any    `json:"details,omitempty"`
+}
+
+type readinessCheck struct {
+	name     string
+	critical bool
+	check    ReadinessCheckFunc
+}
+
+// StartupResponse answers the startup probe
+type StartupResponse struct {
+	Status    string         `json:"status"`
+	Timestamp string         `json:"timestamp"`
+	Service   string         `json:"service"`
+	Version   string         `json:"version"`
+	Startup   *StartupReport `json:"startup,omitempty"`
+}
+
+// fileHealthChecker is implemented by factories serving files from disk
+type fileHealthChecker interface {
+	HealthCheck() files.FileSystemHealthCheck
+}
+
+// AddReadinessCheck plugs a check into /ready, replacing any check of the
+// same name. The readiness configuration can disable the check or override
+// whether it is critical.
+func (s *Server) AddReadinessCheck(name string, critical bool, check ReadinessCheckFunc) {
+	if checkConfig, exists := s.config.Readiness.Checks[name]; exists {
+		if !checkConfig.Enabled {
+			s.logger.Info("readiness check disabled", "check", name)
+			return
+		}
+		critical = checkConfig.Critical
+	}
+
+	s.readinessMu.Lock()
+	defer s.readinessMu.Unlock()
+
+	for i, existing := range s.readinessChecks {
+		if existing.name == name {
+			s.readinessChecks[i] = readinessCheck{name: name, critical: critical, check: check}
+			return
+		}
+	}
+	s.readinessChecks = append(s.readinessChecks, readinessCheck{name: name, critical: critical, check: check})
+}
+
+// addDefaultReadinessChecks registers the checks for the dependencies the
+// server runs itself
+func (s *Server) addDefaultReadinessChecks() {
+	s.AddReadinessCheck(config.ReadinessCheckTransport, true, s.checkTransport)
+	s.AddReadinessCheck(config.ReadinessCheckRegistries, true, s.checkRegistries)
+	s.AddReadinessCheck(config.ReadinessCheckRequiredTools, true, s.checkRequiredTools)
+	s.AddReadinessCheck(config.ReadinessCheckFileResources, true, s.checkFileResources)
+	s.AddReadinessCheck(config.ReadinessCheckUpstreams, false, s.checkUpstreams)
+}
+
+// runReadinessChecks runs every check side by side, each within the
+// readiness timeout
+func (s *Server) runReadinessChecks(ctx context.Context) map[string]ReadinessCheckResult {
+	s.readinessMu.RLock()
+	checks := append([]readinessCheck(nil), s.readinessChecks...)
+	s.readinessMu.RUnlock()
+
+	if len(checks) == 0 {
+		return nil
+	}
+
+	results := make([]ReadinessCheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.runReadinessCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	byName := make(map[string]ReadinessCheckResult, len(checks))
+	for i, check := range checks {
+		byName[check.name] = results[i]
+	}
+	return byName
+}
+
+// runReadinessCheck runs one check, failing it once the timeout passed even
+// if the check ignores its context
+func (s *Server) runReadinessCheck(ctx context.Context, check readinessCheck) ReadinessCheckResult {
+	timeout := s.config.Readiness.Timeout
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type outcome struct {
+		details any
+		err     error
+	}
+	done := make(chan outcome, 1)
+	started := time.Now()
+	go func() {
+		details, err := check.check(ctx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	var result outcome
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result.err = fmt.Errorf("check did not finish within %v", timeout)
+	}
+
+	checkResult := ReadinessCheckResult{
+		Status:     checkPass,
+		Critical:   check.critical,
+		DurationMS: time.Since(started).Milliseconds(),
+		Details:    result.details,
+	}
+	if result.err != nil {
+		checkResult.Status = checkFail
+		checkResult.Error = result.err.Error()
+	}
+	return checkResult
+}
+
+// readinessStatus sums up the check results: a failed critical check makes
+// the server not ready, a failed non-critical one degraded
+func readinessStatus(results map[string]ReadinessCheckResult) (string, int) {
+	status := "ready"
+	for _, result := range results {
+		if result.Status != checkFail {
+			continue
+		}
+		if result.Critical {
+			return "not_ready", http.StatusServiceUnavailable
+		}
+		status = "degraded"
+	}
+	return status, http.StatusOK
+}
+
+func (s *Server) checkTransport(ctx context.Context) (any, error) {
+	if !s.mcpRunning.Load() {
+		return nil, fmt.Errorf("MCP transport is not serving")
+	}
+	if !s.started.Load() {
+		return nil, fmt.Errorf("startup loading in progress")
+	}
+	return nil, nil
+}
+
+func (s *Server) checkRegistries(ctx context.Context) (any, error) {
+	toolStatus := s.toolRegistry.Health().Status
+	resourceStatus := s.resourceRegistry.Health().Status
+	details := map[string]string{
+		"tools":     toolStatus,
+		"resources": resourceStatus,
+	}
+
+	var stopped []string
+	if toolStatus == "stopped" {
+		stopped = append(stopped, "tool")
+	}
+	if resourceStatus == "stopped" {
+		stopped = append(stopped, "resource")
+	}
+	if len(stopped) > 0 {
+		return details, fmt.Errorf("%s registry not running", strings.Join(stopped, " and "))
+	}
+	return details, nil
+}
+
+// checkRequiredTools reports the status of every required tool, resolving a
+// bare name to the tool's default version. Loaded tools serve calls as
+// active ones do, so both pass.
+func (s *Server) checkRequiredTools(ctx context.Context) (any, error) {
+	refs := s.config.Readiness.RequiredTools
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	toolList := s.toolRegistry.List()
+	details := make(map[string]string, len(refs))
+	var unavailable []string
+	for _, ref := range refs {
+		name, version := tools.ParseToolRef(ref)
+		status := "not registered"
+		for _, info := range toolList {
+			if info.Name == name && (info.Version == version || version == "" && info.Default) {
+				status = string(info.Status)
+				break
+			}
+		}
+
+		details[ref] = status
+		if status != string(tools.ToolStatusActive) && status != string(tools.ToolStatusLoaded) {
+			unavailable = append(unavailable, fmt.Sprintf("%s (%s)", ref, status))
+		}
+	}
+
+	if len(unavailable) > 0 {
+		return details, fmt.Errorf("required tools not available: %s", strings.Join(unavailable, ", "))
+	}
+	return details, nil
+}
+
+// checkFileResources asks every file resource factory whether its
+// directories exist and can be read
+func (s *Server) checkFileResources(ctx context.Context) (any, error) {
+	details := make(map[string]files.FileSystemHealthCheck)
+	var inaccessible []string
+	for _, info := range s.resourceRegistry.List() {
+		factory, err := s.resourceRegistry.GetFactory(info.URI)
+		if err != nil {
+			continue
+		}
+		checker, ok := factory.(fileHealthChecker)
+		if !ok {
+			continue
+		}
+
+		health := checker.HealthCheck()
+		details[info.URI] = health
+		if !health.OverallAccessible {
+			var directories []string
+			for directory, status := range health.DirectoryHealth {
+				if !status.Exists || !status.Readable {
+					directories = append(directories, directory)
+				}
+			}
+			sort.Strings(directories)
+			inaccessible = append(inaccessible, fmt.Sprintf("%s (%s)", info.URI, strings.Join(directories, ", ")))
+		}
+	}
+
+	if len(details) == 0 {
+		return nil, nil
+	}
+	if len(inaccessible) > 0 {
+		sort.Strings(inaccessible)
+		return details, fmt.Errorf("file resource directories not accessible: %s", strings.Join(inaccessible, "; "))
+	}
+	return details, nil
+}
+
+func (s *Server) checkUpstreams(ctx context.Context) (any, error) {
+	if s.upstreams == nil {
+		return nil, nil
+	}
+	health := s.upstreams.Health()
+	if len(health) == 0 {
+		return nil, nil
+	}
+
+	details := make(map[string]string, len(health))
+	var disconnected []string
+	for _, upstream := range health {
+		if upstream.Connected {
+			details[upstream.Name] = "connected"
+			continue
+		}
+		details[upstream.Name] = "disconnected"
+		if upstream.LastError != "" {
+			details[upstream.Name] = "disconnected: " + upstream.LastError
+		}
+		disconnected = append(disconnected, upstream.Name)
+	}
+
+	if len(disconnected) > 0 {
+		return details, fmt.Errorf("upstreams not connected: %s", strings.Join(disconnected, ", "))
+	}
+	return details, nil
+}
+
+// handleStartup answers the startup probe: it fails until the registries
+// loaded their entities, or the startup deadline passed, and the MCP
+// transport started
+func (s *Server) handleStartup(w http.ResponseWriter, r *http.Request) {
+	s.logger.Info("startup check requested",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	response := StartupResponse{
+		Status:    "starting",
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Service:   s.config.Logger.Service,
+		Version:   s.config.Logger.Version,
+		Startup:   s.startupReport(),
+	}
+	status := http.StatusServiceUnavailable
+	if s.started.Load() {
+		response.Status = "started"
+		status = http.StatusOK
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("failed to marshal startup response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	w.Write(jsonData)
+}
@@ -6,13 +6,24 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"mcp-server/internal/cache"
 	"mcp-server/internal/config"
+	"mcp-server/internal/events"
 	"mcp-server/internal/logger"
 	"mcp-server/internal/mcp"
+	"mcp-server/internal/metrics"
+	"mcp-server/internal/prompts"
+	"mcp-server/internal/proxy"
+	"mcp-server/internal/ratelimit"
+	"mcp-server/internal/registry"
 	"mcp-server/internal/resources"
 	"mcp-server/internal/tools"
+	"mcp-server/internal/tracing"
 )
 
 type HealthResponse struct {
@@ -23,17 +34,29 @@ type HealthResponse struct {
 }
 
 type ReadyResponse struct {
-	Status    string `json:"status"`
-	Timestamp string `json:"timestamp"`
-	Service   string `json:"service"`
-	Version   string `json:"version"`
+	Status    string         `json:"status"`
+	Timestamp string         `json:"timestamp"`
+	Service   string         `json:"service"`
+	Version   string         `json:"version"`
+	Startup   *StartupReport `json:"startup,omitempty"`
+
+	// Checks holds the outcome of every readiness check by name
+	Checks map[string]ReadinessCheckResult `json:"checks,omitempty"`
+}
+
+// StartupReport shows how the tools and resources loaded at startup,
+// including the ones still loading in the background
+type StartupReport struct {
+	Tools     *registry.LoadReport `json:"tools,omitempty"`
+	Resources *registry.LoadReport `json:"resources,omitempty"`
 }
 
 type ToolsHealthResponse struct {
-	Status    string                    `json:"status"`
-	Timestamp string                    `json:"timestamp"`
-	Summary   ToolHealthSummary         `json:"summary"`
-	Tools     map[string]ToolHealthInfo `json:"tools"`
+	Status         string                    `json:"status"`
+	Timestamp      string                    `json:"timestamp"`
+	Summary        ToolHealthSummary         `json:"summary"`
+	Tools          map[string]ToolHealthInfo `json:"tools"`
+	GlobalBulkhead *registry.BulkheadStats   `json:"global_bulkhead,omitempty"`
 }
 
 type ToolHealthSummary struct {
@@ -53,6 +76,11 @@ type ToolHealthInfo struct {
 	Capabilities []string  `json:"capabilities"`
 	LastCheck    string    `json:"last_check"`
 	ErrorMessage string    `json:"error_message,omitempty"`
+	CircuitBreaker string  `json:"circuit_breaker,omitempty"`
+	Bulkhead       *registry.BulkheadStats `json:"bulkhead,omitempty"`
+	ResultCache    *cache.Stats            `json:"result_cache,omitempty"`
+	Deprecation    *tools.Deprecation      `json:"deprecation,omitempty"`
+	UnmetRequirements []string             `json:"unmet_requirements,omitempty"`
 }
 
 type ToolDiscoveryResponse struct {
@@ -60,27 +88,39 @@ type ToolDiscoveryResponse struct {
 }
 
 type ToolInfo struct {
-	Name         string   `json:"name"`
-	Description  string   `json:"description"`
-	Version      string   `json:"version"`
-	Status       string   `json:"status"`
-	Capabilities []string `json:"capabilities"`
+	ID           string             `json:"id"`
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	Version      string             `json:"version"`
+	Default      bool               `json:"default"`
+	Deprecation  *tools.Deprecation `json:"deprecation,omitempty"`
+	Status       string             `json:"status"`
+	Capabilities []string           `json:"capabilities"`
 }
 
 type ToolDetailResponse struct {
+	ID           string                 `json:"id"`
 	Name         string                 `json:"name"`
 	Description  string                 `json:"description"`
 	Version      string                 `json:"version"`
+	Default      bool                   `json:"default"`
+	Deprecation  *tools.Deprecation     `json:"deprecation,omitempty"`
 	Status       string                 `json:"status"`
 	Capabilities []string               `json:"capabilities"`
 	Parameters   map[string]interface{} `json:"parameters"`
 	Requirements map[string]string      `json:"requirements"`
+	Metrics      metrics.Snapshot       `json:"metrics"`
+	History      []registry.TransitionRecord `json:"history"`
 }
 
 type ResourceDiscoveryResponse struct {
 	Resources []ResourceInfo `json:"resources"`
 }
 
+type UpstreamsResponse struct {
+	Upstreams []proxy.UpstreamHealth `json:"upstreams"`
+}
+
 type ResourceInfo struct {
 	URI         string `json:"uri"`
 	Name        string `json:"name"`
@@ -89,12 +129,26 @@ type ResourceInfo struct {
 	Status      string `json:"status"`
 }
 
+type ResourceDetailResponse struct {
+	URI          string                      `json:"uri"`
+	Name         string                      `json:"name"`
+	Description  string                      `json:"description"`
+	MimeType     string                      `json:"mime_type"`
+	Version      string                      `json:"version"`
+	Tags         []string                    `json:"tags"`
+	Capabilities []string                    `json:"capabilities"`
+	Status       string                      `json:"status"`
+	Metadata     map[string]string           `json:"metadata"`
+	History      []registry.TransitionRecord `json:"history"`
+}
+
 type MetricsResponse struct {
 	Status           string            `json:"status"`
 	Timestamp        string            `json:"timestamp"`
 	Registry         RegistryMetrics   `json:"registry"`
 	Adapter          AdapterMetrics    `json:"adapter"`
 	Tools            ToolMetrics       `json:"tools"`
+	Resources        ResourceMetrics   `json:"resources"`
 	Performance      PerformanceMetrics `json:"performance"`
 }
 
@@ -119,10 +173,31 @@ type AdapterMetrics struct {
 }
 
 type ToolMetrics struct {
-	TotalExecutions int64   `json:"total_executions"`
-	SuccessfulRuns  int64   `json:"successful_runs"`
-	FailedRuns      int64   `json:"failed_runs"`
-	AverageLatency  float64 `json:"average_latency_ms"`
+	TotalExecutions int64                             `json:"total_executions"`
+	SuccessfulRuns  int64                             `json:"successful_runs"`
+	FailedRuns      int64                             `json:"failed_runs"`
+	RejectedRuns    int64                             `json:"rejected_runs"`
+	InFlight        int64                             `json:"in_flight"`
+	BytesIn         int64                             `json:"bytes_in"`
+	BytesOut        int64                             `json:"bytes_out"`
+	AverageLatency  float64                           `json:"average_latency_ms"`
+	P95LatencyMs    float64                           `json:"p95_latency_ms"`
+	P99LatencyMs    float64                           `json:"p99_latency_ms"`
+	PerTool         map[string]metrics.Snapshot       `json:"per_tool"`
+	Bulkheads       map[string]registry.BulkheadStats `json:"bulkheads,omitempty"`
+	GlobalBulkhead  *registry.BulkheadStats           `json:"global_bulkhead,omitempty"`
+}
+
+type ResourceMetrics struct {
+	TotalReads      int64                       `json:"total_reads"`
+	SuccessfulReads int64                       `json:"successful_reads"`
+	FailedReads     int64                       `json:"failed_reads"`
+	InFlight        int64                       `json:"in_flight"`
+	BytesOut        int64                       `json:"bytes_out"`
+	AverageLatency  float64                     `json:"average_latency_ms"`
+	P95LatencyMs    float64                     `json:"p95_latency_ms"`
+	P99LatencyMs    float64                     `json:"p99_latency_ms"`
+	PerResource     map[string]metrics.Snapshot `json:"per_resource"`
 }
 
 type PerformanceMetrics struct {
@@ -137,14 +212,42 @@ type Server struct {
 	mcpServer        mcp.MCPServer
 	toolRegistry     tools.ToolRegistry
 	resourceRegistry resources.ResourceRegistry
+	promptRegistry   prompts.PromptRegistry
+	upstreams        *proxy.Manager
+	audit            *auditLog
+	events           *events.Bus
+	webhooks         *events.Dispatcher
 	logger           *logger.Logger
 	config           *config.Config
 	mux              *http.ServeMux
+	tracer           *tracing.Tracer
+	discoveryLimiter *ratelimit.Limiter
 	startTime        time.Time
+
+	// streams is cancelled on shutdown to end long-lived event streams
+	streams     context.Context
+	stopStreams context.CancelFunc
+
+	// draining is set once shutdown starts draining in-flight work
+	draining atomic.Bool
+
+	// mcpRunning is set while the MCP transport serves; started once the
+	// startup loading finished or its deadline passed
+	mcpRunning atomic.Bool
+	started    atomic.Bool
+
+	// stopLoading cancels the startup loading StartMCP runs in the
+	// background; loading is closed once it returned
+	stopLoading context.CancelFunc
+	loading     chan struct{}
+
+	readinessMu     sync.RWMutex
+	readinessChecks []readinessCheck
 }
 
 func New(cfg *config.Config, log *logger.Logger) *Server {
 	mux := http.NewServeMux()
+	tracer := newTracer(cfg, log)
 
 	toolRegistryFactory := tools.NewRegistryFactory(cfg, log)
 	toolRegistry, err := toolRegistryFactory.CreateRegistry()
@@ -159,24 +262,72 @@ func New(cfg *config.Config, log *logger.Logger) *Server {
 		log.Error("failed to create resource registry", "error", err)
 		resourceRegistry = resources.NewDefaultResourceRegistry(cfg, log)
 	}
+	toolRegistry.SetResourceLookup(func(uri string) (registry.LifecycleStatus, bool) {
+		for _, info := range resourceRegistry.List() {
+			if info.URI == uri {
+				return info.Status, true
+			}
+		}
+		return "", false
+	})
+	// Tools requiring resources wait for them to load alongside
+	toolRegistry.SetResourceWait(resourceRegistry.WaitLoaded)
+
+	// Prompts are published through the tool registry's library adapter
+	promptRegistry := prompts.NewDefaultPromptRegistry(toolRegistry, log)
+
+	bus := events.NewBus(cfg.Events.History)
+	toolRegistry.SetEvents(bus)
+	resourceRegistry.SetEvents(bus)
+	promptRegistry.SetEvents(bus)
+
+	// Tool calls and resource reads of a caller draw from the same budgets
+	callers := newCallerLimiter(cfg.Tools.RateLimit)
+	toolRegistry.SetCallerLimiter(callers)
+	resourceRegistry.SetCallerLimiter(callers)
+
+	if cfg.State.Path != "" {
+		store, err := registry.NewJSONFileStore(cfg.State.Path)
+		if err != nil {
+			log.Error("failed to open state store, operator decisions will not persist", "path", cfg.State.Path, "error", err)
+		} else {
+			toolRegistry.SetStateStore(store)
+			resourceRegistry.SetStateStore(store)
+		}
+	}
 
 	mcpImpl := mcp.Implementation{
 		Name:    cfg.Logger.Service,
 		Version: cfg.Logger.Version,
 	}
 	mcpSrv := mcp.NewServer(mcpImpl, cfg, log)
+	mcpSrv.Use(
+		mcp.RecoveryMiddleware(log),
+		mcp.LoggingMiddleware(log),
+		mcp.TimeoutMiddleware(cfg.MCP.ProtocolTimeout),
+	)
+	// Registry tools are published through the library adapter, which
+	// serves their calls through the same pipeline
+	toolRegistry.SetPipeline(mcpSrv.Pipeline())
 
 	server := &Server{
 		logger:           log,
 		config:           cfg,
 		mux:              mux,
+		tracer:           tracer,
+		discoveryLimiter: newDiscoveryLimiter(cfg.Server.RateLimit),
 		mcpServer:        mcpSrv,
 		toolRegistry:     toolRegistry,
 		resourceRegistry: resourceRegistry,
+		promptRegistry:   promptRegistry,
+		upstreams:        proxy.NewManager(cfg, toolRegistry, resourceRegistry, promptRegistry, log),
+		audit:            newAuditLog(cfg.Server.Admin.AuditLog, log),
+		events:           bus,
+		webhooks:         events.NewDispatcher(cfg.Events.Webhooks, bus, log),
 		startTime:        time.Now(),
 		httpServer: &http.Server{
 			Addr:           fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
-			Handler:        mux,
+			Handler:        tracing.HTTPMiddleware(mux),
 			ReadTimeout:    cfg.Server.ReadTimeout,
 			WriteTimeout:   cfg.Server.WriteTimeout,
 			IdleTimeout:    cfg.Server.IdleTimeout,
@@ -184,18 +335,52 @@ func New(cfg *config.Config, log *logger.Logger) *Server {
 		},
 	}
 
+	server.streams, server.stopStreams = context.WithCancel(context.Background())
+	server.httpServer.RegisterOnShutdown(server.stopStreams)
+
+	server.addDefaultReadinessChecks()
 	server.setupRoutes()
 	return server
 }
 
+// newTracer installs the process-wide tracer when tracing is enabled
+func newTracer(cfg *config.Config, log *logger.Logger) *tracing.Tracer {
+	if !cfg.Tracing.Enabled {
+		return nil
+	}
+
+	exporter, err := tracing.NewExporter(cfg.Tracing, cfg.Logger.Service)
+	if err != nil {
+		log.Error("failed to create trace exporter, tracing disabled", "error", err)
+		return nil
+	}
+
+	tracer := tracing.NewTracer(exporter, tracing.Options{
+		ServiceName:   cfg.Logger.Service,
+		BatchSize:     cfg.Tracing.BatchSize,
+		FlushInterval: cfg.Tracing.FlushInterval,
+		Logger:        log,
+	})
+	tracing.SetTracer(tracer)
+	log.Info("tracing enabled", "exporter", cfg.Tracing.Exporter)
+	return tracer
+}
+
 func (s *Server) setupRoutes() {
 	s.mux.HandleFunc("/health", s.handleHealth)
 	s.mux.HandleFunc("/ready", s.handleReady)
+	s.mux.HandleFunc("/startup", s.handleStartup)
 	s.mux.HandleFunc("/metrics", s.handleMetrics)
-	s.mux.HandleFunc("/tools/", s.handleToolsRoute)
-	s.mux.HandleFunc("/tools", s.handleToolsDiscovery)
-	s.mux.HandleFunc("/resources", s.handleResourcesDiscovery)
-	s.mux.HandleFunc("/resources/health", s.handleResourcesHealth)
+	s.mux.HandleFunc("/metrics/prometheus", s.handlePrometheusMetrics)
+	s.mux.HandleFunc("/tools/", s.rateLimited(s.handleToolsRoute))
+	s.mux.HandleFunc("/tools", s.rateLimited(s.handleToolsDiscovery))
+	s.mux.HandleFunc("/resources", s.rateLimited(s.handleResourcesDiscovery))
+	s.mux.HandleFunc("/resources/health", s.rateLimited(s.handleResourcesHealth))
+	s.mux.HandleFunc("/resources/", s.rateLimited(s.handleResourceDetail))
+	s.mux.HandleFunc("/admin/tools/", s.adminAuthorized(s.handleAdminToolsRoute))
+	s.mux.HandleFunc("/admin/resources/refresh", s.adminAuthorized(s.handleAdminResourceRefresh))
+	s.mux.HandleFunc("/upstreams", s.rateLimited(s.handleUpstreams))
+	s.mux.HandleFunc("/events", s.rateLimited(s.handleEvents))
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
@@ -244,9 +429,12 @@ func (s *Server) handleToolsDiscovery(w http.ResponseWriter, r *http.Request) {
 	
 	for i, toolInfo := range toolInfos {
 		tools[i] = ToolInfo{
+			ID:           toolInfo.Key(),
 			Name:         toolInfo.Name,
 			Description:  toolInfo.Description,
 			Version:      toolInfo.Version,
+			Default:      toolInfo.Default,
+			Deprecation:  toolInfo.Deprecation,
 			Status:       string(toolInfo.Status),
 			Capabilities: toolInfo.Capabilities,
 		}
@@ -330,25 +518,45 @@ func (s *Server) buildToolDetailResponse(toolName string) (*ToolDetailResponse,
 	parameters := s.parseToolParameters(tool, toolName)
 
 	response := &ToolDetailResponse{
+		ID:           toolInfo.Key(),
 		Name:         toolInfo.Name,
 		Description:  toolInfo.Description,
 		Version:      toolInfo.Version,
+		Default:      toolInfo.Default,
+		Deprecation:  toolInfo.Deprecation,
 		Status:       string(toolInfo.Status),
 		Capabilities: toolInfo.Capabilities,
 		Parameters:   parameters,
 		Requirements: factory.Requirements(),
+		History:      toolInfo.History,
+	}
+	if snapshot, ok := s.toolRegistry.Metrics().Snapshot(toolInfo.Key()); ok {
+		response.Metrics = snapshot
 	}
 
 	return response, nil
 }
 
+// getToolInfo finds the version a tool reference addresses: name@version
+// names one version and a bare name the default version
 func (s *Server) getToolInfo(toolName string) (*tools.ToolInfo, error) {
+	name, version := tools.ParseToolRef(toolName)
+
+	var byKey *tools.ToolInfo
 	toolInfos := s.toolRegistry.List()
 	for _, info := range toolInfos {
-		if info.Name == toolName {
+		switch {
+		case info.Name == name && version != "" && info.Version == version:
 			return &info, nil
+		case info.Name == name && version == "" && info.Default:
+			return &info, nil
+		case info.Key() == toolName:
+			byKey = &info
 		}
 	}
+	if byKey != nil {
+		return byKey, nil
+	}
 	return nil, fmt.Errorf("tool info not found")
 }
 
@@ -439,6 +647,54 @@ func (s *Server) handleResourcesDiscovery(w http.ResponseWriter, r *http.Request
 	)
 }
 
+// handleResourceDetail serves /resources/{uri}. The URI must be
+// percent-encoded, since the slashes in it would otherwise be cleaned away.
+func (s *Server) handleResourceDetail(w http.ResponseWriter, r *http.Request) {
+	uri := strings.TrimPrefix(r.URL.Path, "/resources/")
+	s.logger.Info("resource detail requested",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"uri", uri,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	info, exists := s.findResourceInfo(uri)
+	if uri == "" || !exists {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "resource not found"}`))
+		return
+	}
+
+	response := ResourceDetailResponse{
+		URI:          info.URI,
+		Name:         info.Name,
+		Description:  info.Description,
+		MimeType:     info.MimeType,
+		Version:      info.Version,
+		Tags:         info.Tags,
+		Capabilities: info.Capabilities,
+		Status:       string(info.Status),
+		Metadata:     info.Metadata,
+		History:      info.History,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("failed to marshal resource detail response",
+			"uri", uri,
+			"error", err,
+		)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
 func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
 	s.logger.Info("readiness check requested",
 		"method", r.Method,
@@ -451,6 +707,14 @@ func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Service:   s.config.Logger.Service,
 		Version:   s.config.Logger.Version,
+		Startup:   s.startupReport(),
+		Checks:    s.runReadinessChecks(r.Context()),
+	}
+	var status int
+	response.Status, status = readinessStatus(response.Checks)
+	if s.draining.Load() {
+		response.Status = "draining"
+		status = http.StatusServiceUnavailable
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -464,21 +728,56 @@ func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 	w.Write(jsonData)
 
-	s.logger.Info("readiness check completed successfully",
+	s.logger.Info("readiness check completed",
 		"status", response.Status,
 		"timestamp", response.Timestamp,
 	)
 }
 
+// startupReport collects the startup loading reports of the registries, or
+// nil before they loaded
+func (s *Server) startupReport() *StartupReport {
+	var report StartupReport
+	if s.toolRegistry != nil {
+		report.Tools = s.toolRegistry.StartupReport()
+	}
+	if s.resourceRegistry != nil {
+		report.Resources = s.resourceRegistry.StartupReport()
+	}
+	if report.Tools == nil && report.Resources == nil {
+		return nil
+	}
+	return &report
+}
+
+// Drain reports the server as not ready and waits for in-flight tool calls
+// and resource reads until ctx is done, cancelling those still running. The
+// HTTP server keeps serving meanwhile so load balancers see the readiness
+// change.
+func (s *Server) Drain(ctx context.Context) error {
+	s.draining.Store(true)
+	s.logger.Info("draining server, readiness is now failing")
+	return s.mcpServer.Drain(ctx)
+}
+
 func (s *Server) ListenAndServe() error {
 	return s.httpServer.ListenAndServe()
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
-	return s.httpServer.Shutdown(ctx)
+	err := s.httpServer.Shutdown(ctx)
+	if s.tracer != nil {
+		if tracerErr := s.tracer.Shutdown(ctx); tracerErr != nil {
+			s.logger.Warn("failed to flush traces", "error", tracerErr)
+		}
+	}
+	if auditErr := s.audit.Close(); auditErr != nil {
+		s.logger.Warn("failed to close admin audit log", "error", auditErr)
+	}
+	return err
 }
 
 func (s *Server) Close() error {
@@ -487,8 +786,12 @@ func (s *Server) Close() error {
 
 func (s *Server) StartMCP(ctx context.Context) error {
 	s.logger.Info("Starting MCP server and tool registry")
+
+	// Webhooks start first so they see the registrations made at startup
+	s.webhooks.Start()
 	
 	if err := s.toolRegistry.Start(ctx); err != nil {
+		s.webhooks.Stop()
 		return fmt.Errorf("failed to start tool registry: %w", err)
 	}
 	s.logger.Info("Tool registry started successfully")
@@ -497,10 +800,11 @@ func (s *Server) StartMCP(ctx context.Context) error {
 		if stopErr := s.toolRegistry.Stop(ctx); stopErr != nil {
 			s.logger.Error("failed to stop tool registry after resource registry start failure", "error", stopErr)
 		}
+		s.webhooks.Stop()
 		return fmt.Errorf("failed to start resource registry: %w", err)
 	}
 	s.logger.Info("Resource registry started successfully")
-	
+
 	transport := mcp.NewStdioTransport()
 	
 	if err := s.mcpServer.Start(ctx, transport); err != nil {
@@ -510,16 +814,70 @@ func (s *Server) StartMCP(ctx context.Context) error {
 		if stopErr := s.toolRegistry.Stop(ctx); stopErr != nil {
 			s.logger.Error("failed to stop tool registry after MCP server start failure", "error", stopErr)
 		}
+		s.webhooks.Stop()
 		return fmt.Errorf("failed to start MCP server: %w", err)
 	}
 	
+	s.mcpRunning.Store(true)
 	s.logger.Info("MCP server started successfully")
+
+	// Upstreams mount into the running registries and connect in the
+	// background, so an unreachable upstream does not fail startup
+	if err := s.promptRegistry.Start(ctx); err != nil {
+		s.logger.Error("failed to start prompt registry", "error", err)
+	}
+	s.upstreams.Start(ctx)
+
+	// Entities load in the background so StartMCP returns at once and the
+	// HTTP server can report progress on /startup meanwhile
+	loadCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.stopLoading = cancel
+	s.loading = make(chan struct{})
+	go func() {
+		defer close(s.loading)
+		s.loadEntities(loadCtx)
+		s.started.Store(true)
+	}()
 	return nil
 }
 
+// loadEntities loads the registered tools and resources side by side, each
+// within the startup limits; tools requiring a resource wait for it to
+// load. Failures are logged and left to the supervisor, so one broken
+// factory does not keep the server from starting.
+func (s *Server) loadEntities(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := s.toolRegistry.LoadTools(ctx); err != nil {
+			s.logger.Warn("some tools failed to load at startup", "error", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := s.resourceRegistry.LoadResources(ctx); err != nil {
+			s.logger.Warn("some resources failed to load at startup", "error", err)
+		}
+	}()
+	wg.Wait()
+}
+
 func (s *Server) StopMCP(ctx context.Context) error {
 	s.logger.Info("Stopping MCP server and tool registry")
+
+	// Startup loading stops before the registries it loads into
+	if s.stopLoading != nil {
+		s.stopLoading()
+		<-s.loading
+	}
+
+	s.upstreams.Stop()
+	if err := s.promptRegistry.Stop(ctx); err != nil {
+		s.logger.Error("failed to stop prompt registry", "error", err)
+	}
 	
+	s.mcpRunning.Store(false)
 	if err := s.mcpServer.Stop(ctx); err != nil {
 		s.logger.Error("failed to stop MCP server", "error", err)
 	} else {
@@ -532,7 +890,12 @@ func (s *Server) StopMCP(ctx context.Context) error {
 		s.logger.Info("Resource registry stopped successfully")
 	}
 	
-	if err := s.toolRegistry.Stop(ctx); err != nil {
+	err := s.toolRegistry.Stop(ctx)
+
+	// Stopped after the registries so webhooks see their final events
+	s.webhooks.Stop()
+
+	if err != nil {
 		return fmt.Errorf("failed to stop tool registry: %w", err)
 	}
 	s.logger.Info("Tool registry stopped successfully")
@@ -540,10 +903,9 @@ func (s *Server) StopMCP(ctx context.Context) error {
 	return nil
 }
 
+// IsMCPRunning reports whether the MCP transport is serving
 func (s *Server) IsMCPRunning() bool {
-	// This is a simple implementation - in a real scenario we might need
-	// to track the running state more carefully
-	return s.mcpServer != nil
+	return s.mcpRunning.Load()
 }
 
 func (s *Server) collectRegistryData() (tools.RegistryHealth, []tools.ToolInfo, resources.RegistryHealth, []resources.ResourceInfo) {
@@ -605,22 +967,48 @@ func (s *Server) calculateAdapterMetrics(health tools.RegistryHealth, successRat
 	}
 }
 
-func (s *Server) calculateToolMetrics(health tools.RegistryHealth) ToolMetrics {
+func (s *Server) calculateToolMetrics(collector *metrics.Collector) ToolMetrics {
+	total := collector.Total()
 	return ToolMetrics{
-		TotalExecutions: 0,
-		SuccessfulRuns:  0,
-		FailedRuns:      int64(health.ErrorTools),
-		AverageLatency:  0.0,
+		TotalExecutions: total.Calls,
+		SuccessfulRuns:  total.Success,
+		FailedRuns:      total.Errors,
+		RejectedRuns:    total.Rejected,
+		InFlight:        total.InFlight,
+		BytesIn:         total.BytesIn,
+		BytesOut:        total.BytesOut,
+		AverageLatency:  total.Latency.AvgMs,
+		P95LatencyMs:    total.Latency.P95Ms,
+		P99LatencyMs:    total.Latency.P99Ms,
+		PerTool:         collector.Snapshots(),
 	}
 }
 
-func (s *Server) calculatePerformanceMetrics(memStats runtime.MemStats) PerformanceMetrics {
+func (s *Server) calculateResourceMetrics(collector *metrics.Collector) ResourceMetrics {
+	total := collector.Total()
+	return ResourceMetrics{
+		TotalReads:      total.Calls,
+		SuccessfulReads: total.Success,
+		FailedReads:     total.Errors,
+		InFlight:        total.InFlight,
+		BytesOut:        total.BytesOut,
+		AverageLatency:  total.Latency.AvgMs,
+		P95LatencyMs:    total.Latency.P95Ms,
+		P99LatencyMs:    total.Latency.P99Ms,
+		PerResource:     collector.Snapshots(),
+	}
+}
+
+func (s *Server) calculatePerformanceMetrics(memStats runtime.MemStats, toolCollector, resourceCollector *metrics.Collector) PerformanceMetrics {
 	memoryUsageMB := float64(memStats.Alloc) / 1024 / 1024
-	
+
+	// Latency quantiles are computed over tool calls and resource reads together
+	combined := metrics.Combine(toolCollector, resourceCollector)
+
 	return PerformanceMetrics{
-		RequestsPerSecond: 0.0,
-		P95LatencyMs:      0.0,
-		P99LatencyMs:      0.0,
+		RequestsPerSecond: toolCollector.RequestsPerSecond() + resourceCollector.RequestsPerSecond(),
+		P95LatencyMs:      combined.Latency.P95Ms,
+		P99LatencyMs:      combined.Latency.P99Ms,
 		MemoryUsageMB:     memoryUsageMB,
 	}
 }
@@ -642,8 +1030,13 @@ func (s *Server) buildMetricsResponse() MetricsResponse {
 	
 	registryMetrics := s.calculateRegistryMetrics(toolHealth, toolList, uptime)
 	adapterMetrics := s.calculateAdapterMetrics(toolHealth, registryMetrics.SuccessRate)
-	toolMetrics := s.calculateToolMetrics(toolHealth)
-	perfMetrics := s.calculatePerformanceMetrics(memStats)
+	toolCollector := s.toolRegistry.Metrics()
+	resourceCollector := s.resourceRegistry.Metrics()
+	toolMetrics := s.calculateToolMetrics(toolCollector)
+	toolMetrics.Bulkheads = toolHealth.Bulkheads
+	toolMetrics.GlobalBulkhead = toolHealth.GlobalBulkhead
+	resourceMetrics := s.calculateResourceMetrics(resourceCollector)
+	perfMetrics := s.calculatePerformanceMetrics(memStats, toolCollector, resourceCollector)
 	
 	// Include resource registry status in overall health determination
 	overallStatus := s.determineOverallHealth(toolHealth)
@@ -659,6 +1052,7 @@ func (s *Server) buildMetricsResponse() MetricsResponse {
 		Registry:    registryMetrics,
 		Adapter:     adapterMetrics,
 		Tools:       toolMetrics,
+		Resources:   resourceMetrics,
 		Performance: perfMetrics,
 	}
 }
@@ -696,6 +1090,7 @@ func (s *Server) buildToolHealthDetails(toolList []tools.ToolInfo, registryHealt
 	toolDetails := make(map[string]ToolHealthInfo)
 	
 	for _, tool := range toolList {
+		key := tool.Key()
 		details := ToolHealthInfo{
 			Name:         tool.Name,
 			Status:       string(tool.Status),
@@ -703,13 +1098,33 @@ func (s *Server) buildToolHealthDetails(toolList []tools.ToolInfo, registryHealt
 			Version:      tool.Version,
 			Capabilities: tool.Capabilities,
 			LastCheck:    registryHealth.LastCheck,
+			Deprecation:  tool.Deprecation,
 		}
 		
-		if tool.Status == tools.ToolStatusError {
+		if unmet, exists := registryHealth.UnmetRequirements[key]; exists {
+			details.UnmetRequirements = unmet
+			details.ErrorMessage = "Requirements not met: " + strings.Join(unmet, "; ")
+		} else if tool.Status == tools.ToolStatusError {
 			details.ErrorMessage = "Tool failed validation or creation"
 		}
+
+		details.CircuitBreaker = registryHealth.CircuitBreakers[key]
+		if details.CircuitBreaker == "open" && details.ErrorMessage == "" {
+			details.ErrorMessage = "Circuit breaker open after repeated execution failures"
+		}
+
+		if stats, exists := registryHealth.Bulkheads[key]; exists {
+			details.Bulkhead = &stats
+			if stats.Saturated && details.ErrorMessage == "" {
+				details.ErrorMessage = "All execution slots busy; calls are queueing"
+			}
+		}
+
+		if stats, exists := registryHealth.ResultCaches[key]; exists {
+			details.ResultCache = &stats
+		}
 		
-		toolDetails[tool.Name] = details
+		toolDetails[key] = details
 	}
 	
 	return toolDetails
@@ -725,6 +1140,14 @@ func (s *Server) determineToolsOverallHealth(summary ToolHealthSummary, registry
 	if summary.Active == 0 && summary.Total > 0 {
 		return "degraded"
 	}
+	if registryHealth.GlobalBulkhead != nil && registryHealth.GlobalBulkhead.Saturated {
+		return "degraded"
+	}
+	for _, stats := range registryHealth.Bulkheads {
+		if stats.Saturated {
+			return "degraded"
+		}
+	}
 	return "healthy"
 }
 
@@ -734,10 +1157,11 @@ func (s *Server) buildToolsHealthResponse() ToolsHealthResponse {
 	toolDetails := s.buildToolHealthDetails(toolList, registryHealth)
 	
 	return ToolsHealthResponse{
-		Status:    s.determineToolsOverallHealth(summary, registryHealth),
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Summary:   summary,
-		Tools:     toolDetails,
+		Status:         s.determineToolsOverallHealth(summary, registryHealth),
+		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+		Summary:        summary,
+		Tools:          toolDetails,
+		GlobalBulkhead: registryHealth.GlobalBulkhead,
 	}
 }
 
@@ -775,6 +1199,7 @@ type ResourcesHealthResponse struct {
 	Timestamp string                        `json:"timestamp"`
 	Summary   ResourceHealthSummary         `json:"summary"`
 	Resources map[string]ResourceHealthInfo `json:"resources"`
+	Cache     *cache.Stats                  `json:"cache,omitempty"`
 }
 
 type ResourceHealthSummary struct {
@@ -873,6 +1298,7 @@ func (s *Server) buildResourcesHealthResponse() ResourcesHealthResponse {
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Summary:   summary,
 		Resources: resourceDetails,
+		Cache:     resourceHealth.Cache,
 	}
 }
 
@@ -906,6 +1332,30 @@ func (s *Server) handleResourcesHealth(w http.ResponseWriter, r *http.Request) {
 	)
 }
 
+func (s *Server) handleUpstreams(w http.ResponseWriter, r *http.Request) {
+	s.logger.Info("upstreams requested",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	response := UpstreamsResponse{
+		Upstreams: s.upstreams.Health(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("failed to marshal upstreams response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
 func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	s.logger.Info("metrics requested",
 		"method", r.Method,
@@ -913,6 +1363,12 @@ func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
 		"remote_addr", r.RemoteAddr,
 	)
 
+	// Scrapers asking for a text format get the exposition instead of JSON
+	if format, ok := metrics.NegotiateFormat(r.Header.Get("Accept")); ok && s.config.MCP.EnableMetrics {
+		s.writeExposition(w, format)
+		return
+	}
+
 	response := s.buildMetricsResponse()
 
 	w.Header().Set("Content-Type", "application/json")
@@ -941,3 +1397,98 @@ func (s *Server) ToolRegistry() tools.ToolRegistry {
 func (s *Server) ResourceRegistry() resources.ResourceRegistry {
 	return s.resourceRegistry
 }
+
+type CircuitBreakerResetResponse struct {
+	Name           string `json:"name"`
+	CircuitBreaker string `json:"circuit_breaker"`
+	Timestamp      string `json:"timestamp"`
+}
+
+func (s *Server) handleAdminToolsRoute(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/admin/tools/")
+
+	if name, ok := strings.CutSuffix(path, "/circuit-breaker/reset"); ok && name != "" {
+		s.handleResetToolCircuitBreaker(w, r, name)
+		return
+	}
+
+	if i := strings.LastIndex(path, "/"); i > 0 {
+		switch action := path[i+1:]; action {
+		case AdminActionDisable, AdminActionEnable, AdminActionRestart:
+			s.handleToolLifecycle(w, r, path[:i], action)
+			return
+		}
+	}
+
+	http.NotFound(w, r)
+}
+
+func (s *Server) handleResetToolCircuitBreaker(w http.ResponseWriter, r *http.Request, toolName string) {
+	s.logger.Info("tool circuit breaker reset requested",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"tool_name", toolName,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := s.toolRegistry.ResetCircuitBreaker(toolName); err != nil {
+		s.logger.Info("tool circuit breaker reset failed",
+			"tool_name", toolName,
+			"error", err,
+		)
+		s.audit.Record(AuditRecord{
+			Actor:      adminActor(r),
+			RemoteAddr: r.RemoteAddr,
+			Action:     "tool.circuit_breaker_reset",
+			Target:     toolName,
+			Outcome:    AuditOutcomeFailure,
+			Error:      err.Error(),
+		})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(adminErrorStatus(err))
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	key := toolName
+	if info, err := s.getToolInfo(toolName); err == nil {
+		key = info.Key()
+	}
+	response := CircuitBreakerResetResponse{
+		Name:           toolName,
+		CircuitBreaker: s.toolRegistry.Health().CircuitBreakers[key],
+		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+	}
+	s.audit.Record(AuditRecord{
+		Timestamp:  response.Timestamp,
+		Actor:      adminActor(r),
+		RemoteAddr: r.RemoteAddr,
+		Action:     "tool.circuit_breaker_reset",
+		Target:     toolName,
+		Status:     response.CircuitBreaker,
+		Outcome:    AuditOutcomeSuccess,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("failed to marshal circuit breaker reset response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+
+	s.logger.Info("tool circuit breaker reset completed successfully",
+		"tool_name", toolName,
+		"circuit_breaker", response.CircuitBreaker,
+	)
+}
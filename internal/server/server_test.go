@@ -1,18 +1,33 @@
 package server
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+
+	"mcp-server/internal/cache"
 	"mcp-server/internal/config"
+	"mcp-server/internal/events"
 	"mcp-server/internal/logger"
 	"mcp-server/internal/mcp"
+	"mcp-server/internal/metrics"
+	"mcp-server/internal/proxy"
+	"mcp-server/internal/registry"
+	"mcp-server/internal/resources"
+	"mcp-server/internal/resources/files"
 	"mcp-server/internal/tools"
+	"mcp-server/internal/tools/echo"
 )
 
 // =============================================================================
@@ -22,6 +37,9 @@ import (
 type MockToolRegistry struct {
 	health   tools.RegistryHealth
 	toolList []tools.ToolInfo
+	resets   []string
+	resetErr error
+	metrics  *metrics.Collector
 }
 
 func (m *MockToolRegistry) Register(name string, factory tools.ToolFactory) error {
@@ -48,6 +66,10 @@ func (m *MockToolRegistry) LoadTools(ctx context.Context) error {
 	return nil
 }
 
+func (m *MockToolRegistry) StartupReport() *registry.LoadReport {
+	return nil
+}
+
 func (m *MockToolRegistry) ValidateTools(ctx context.Context) error {
 	return nil
 }
@@ -62,6 +84,17 @@ func (m *MockToolRegistry) TransitionStatus(name string, newStatus tools.ToolSta
 	return tools.ErrToolNotFound
 }
 
+func (m *MockToolRegistry) TransitionStatusWith(name string, newStatus tools.ToolStatus, cause registry.TransitionCause) error {
+	for i, tool := range m.toolList {
+		if tool.Name == name {
+			m.toolList[i].History = registry.AppendTransition(tool.History, tool.Status, newStatus, cause)
+			m.toolList[i].Status = newStatus
+			return nil
+		}
+	}
+	return tools.ErrToolNotFound
+}
+
 func (m *MockToolRegistry) RestartTool(ctx context.Context, name string) error {
 	for i, tool := range m.toolList {
 		if tool.Name == name {
@@ -76,6 +109,47 @@ func (m *MockToolRegistry) RestartTool(ctx context.Context, name string) error {
 	return tools.ErrToolNotFound
 }
 
+func (m *MockToolRegistry) ResetCircuitBreaker(name string) error {
+	if m.resetErr != nil {
+		return m.resetErr
+	}
+	for _, tool := range m.toolList {
+		if tool.Name == name {
+			m.resets = append(m.resets, name)
+			return nil
+		}
+	}
+	return tools.ErrToolNotFound
+}
+
+func (m *MockToolRegistry) SetDefaultVersion(name, version string) error {
+	return nil
+}
+
+func (m *MockToolRegistry) DeprecateVersion(name, version string, deprecation *tools.Deprecation) error {
+	return nil
+}
+
+func (m *MockToolRegistry) SetResourceLookup(lookup tools.ResourceLookup) {}
+
+func (m *MockToolRegistry) SetResourceWait(wait tools.ResourceWait) {}
+
+func (m *MockToolRegistry) SetPipeline(pipeline *mcp.Pipeline) {}
+
+func (m *MockToolRegistry) SetCallerLimiter(limiter *mcp.CallerLimiter) {}
+
+func (m *MockToolRegistry) RegisterPrompt(prompt mcp.Prompt) error { return nil }
+
+func (m *MockToolRegistry) UnregisterPrompt(name string) error { return nil }
+
+func (m *MockToolRegistry) SetEvents(bus *events.Bus) {}
+
+func (m *MockToolRegistry) SetStateStore(store registry.StateStore) {}
+
+func (m *MockToolRegistry) Metrics() *metrics.Collector {
+	return m.metrics
+}
+
 func (m *MockToolRegistry) Start(ctx context.Context) error {
 	return nil
 }
@@ -747,3 +821,1094 @@ func TestRestartTool_StatusValidation(t *testing.T) {
 		}
 	}
 }
+
+// =============================================================================
+// Circuit breaker tests
+// =============================================================================
+
+func TestHandleToolsHealth_CircuitBreakerState(t *testing.T) {
+	server := createTestServer()
+	health := buildRegistryHealthData("degraded")
+	health.CircuitBreakers = map[string]string{"test-tool-1": "open"}
+	server.toolRegistry = createMockToolRegistryWithHealth(health, buildHealthyToolList())
+
+	w := executeToolsHealthRequest(server)
+	response := parseToolsHealthResponse(t, w)
+
+	detail := response.Tools["test-tool-1"]
+	if detail.CircuitBreaker != "open" {
+		t.Errorf("expected circuit breaker open, got %q", detail.CircuitBreaker)
+	}
+	if detail.ErrorMessage == "" {
+		t.Error("expected error message for open circuit breaker")
+	}
+	if response.Tools["test-tool-2"].CircuitBreaker != "" {
+		t.Error("expected no circuit breaker state for tool without breaker")
+	}
+}
+
+func TestHandleAdminToolsRoute_ResetCircuitBreaker(t *testing.T) {
+	server := createTestServer()
+	registry := createMockToolRegistryWithTools(buildHealthyToolList())
+	server.toolRegistry = registry
+
+	req := httptest.NewRequest("POST", "/admin/tools/test-tool-1/circuit-breaker/reset", nil)
+	w := httptest.NewRecorder()
+	server.handleAdminToolsRoute(w, req)
+
+	validateJSONResponse(t, w, http.StatusOK)
+	if len(registry.resets) != 1 || registry.resets[0] != "test-tool-1" {
+		t.Errorf("expected reset of test-tool-1, got %v", registry.resets)
+	}
+
+	req = httptest.NewRequest("GET", "/admin/tools/test-tool-1/circuit-breaker/reset", nil)
+	w = httptest.NewRecorder()
+	server.handleAdminToolsRoute(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for GET, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest("POST", "/admin/tools/unknown/circuit-breaker/reset", nil)
+	w = httptest.NewRecorder()
+	server.handleAdminToolsRoute(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown tool, got %d", w.Code)
+	}
+
+	registry.resetErr = fmt.Errorf("%w: test-tool-1", tools.ErrNoCircuitBreaker)
+	req = httptest.NewRequest("POST", "/admin/tools/test-tool-1/circuit-breaker/reset", nil)
+	w = httptest.NewRecorder()
+	server.handleAdminToolsRoute(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a tool without breaker, got %d", w.Code)
+	}
+}
+
+func TestHandleMetrics_ReportsExecutionMetrics(t *testing.T) {
+	server := createTestServer()
+	registry := createMockToolRegistryWithHealth(buildRegistryHealthData("healthy"), buildHealthyToolList())
+	registry.metrics = metrics.NewCollector()
+	server.toolRegistry = registry
+	server.resourceRegistry = resources.NewDefaultResourceRegistry(server.config, server.logger)
+
+	registry.metrics.Start("test-tool-1", 10)(metrics.OutcomeSuccess, 20)
+	registry.metrics.Start("test-tool-1", 10)(metrics.OutcomeError, 0)
+	registry.metrics.Start("test-tool-2", 0)(metrics.OutcomeRejected, 5)
+	server.resourceRegistry.Metrics().Start("file:///data.txt", 0)(metrics.OutcomeSuccess, 100)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, req)
+	validateJSONResponse(t, w, http.StatusOK)
+
+	var response MetricsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+
+	toolMetrics := response.Tools
+	if toolMetrics.TotalExecutions != 3 || toolMetrics.SuccessfulRuns != 1 || toolMetrics.FailedRuns != 1 || toolMetrics.RejectedRuns != 1 {
+		t.Errorf("unexpected tool counts: %+v", toolMetrics)
+	}
+	if toolMetrics.BytesIn != 20 || toolMetrics.BytesOut != 25 {
+		t.Errorf("unexpected tool byte counts: in=%d out=%d", toolMetrics.BytesIn, toolMetrics.BytesOut)
+	}
+	if toolMetrics.PerTool["test-tool-1"].Calls != 2 {
+		t.Errorf("expected per-tool metrics, got %+v", toolMetrics.PerTool)
+	}
+	if response.Resources.TotalReads != 1 || response.Resources.PerResource["file:///data.txt"].BytesOut != 100 {
+		t.Errorf("unexpected resource metrics: %+v", response.Resources)
+	}
+	if response.Performance.RequestsPerSecond <= 0 {
+		t.Errorf("expected positive request rate, got %v", response.Performance.RequestsPerSecond)
+	}
+}
+
+func createExpositionTestServer(enabled bool) (*Server, *MockToolRegistry) {
+	server := createTestServer()
+	server.config.MCP.EnableMetrics = enabled
+	health := buildRegistryHealthData("healthy")
+	health.CircuitBreakers = map[string]string{"test-tool-1": "open"}
+	registry := createMockToolRegistryWithHealth(health, buildHealthyToolList())
+	registry.metrics = metrics.NewCollector()
+	server.toolRegistry = registry
+	server.resourceRegistry = resources.NewDefaultResourceRegistry(server.config, server.logger)
+	return server, registry
+}
+
+func TestHandlePrometheusMetrics_Exposition(t *testing.T) {
+	server, registry := createExpositionTestServer(true)
+	registry.metrics.Start("test-tool-1", 4)(metrics.OutcomeSuccess, 8)
+
+	req := httptest.NewRequest("GET", "/metrics/prometheus", nil)
+	w := httptest.NewRecorder()
+	server.handlePrometheusMetrics(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != metrics.PrometheusContentType {
+		t.Errorf("unexpected content type %q", contentType)
+	}
+
+	body := w.Body.String()
+	expected := []string{
+		`mcp_tools{status="active"} 1`,
+		`mcp_tools{status="loaded"} 1`,
+		`mcp_tool_circuit_breaker_state{tool="test-tool-1",state="open"} 1`,
+		`mcp_tool_circuit_breaker_state{tool="test-tool-1",state="closed"} 0`,
+		`mcp_resource_cache_hit_ratio 0`,
+		`mcp_tool_calls_total{tool="test-tool-1",outcome="success"} 1`,
+		`mcp_tool_call_duration_seconds_count{tool="test-tool-1"} 1`,
+		`mcp_tool_call_response_bytes_total{tool="test-tool-1"} 8`,
+		`go_goroutines `,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected %q in exposition:\n%s", line, body)
+		}
+	}
+}
+
+func TestHandleMetrics_NegotiatesOpenMetrics(t *testing.T) {
+	server, _ := createExpositionTestServer(true)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text;version=1.0.0")
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, req)
+
+	if contentType := w.Header().Get("Content-Type"); contentType != metrics.OpenMetricsContentType {
+		t.Errorf("expected OpenMetrics content type, got %q", contentType)
+	}
+	if !strings.HasSuffix(w.Body.String(), "# EOF\n") {
+		t.Error("expected OpenMetrics document to end with # EOF")
+	}
+
+	req = httptest.NewRequest("GET", "/metrics", nil)
+	w = httptest.NewRecorder()
+	server.handleMetrics(w, req)
+	validateJSONResponse(t, w, http.StatusOK)
+
+	// A plain text/plain, as curl or a browser may send, still gets JSON
+	req = httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "text/plain")
+	w = httptest.NewRecorder()
+	server.handleMetrics(w, req)
+	validateJSONResponse(t, w, http.StatusOK)
+}
+
+func TestHandlePrometheusMetrics_Disabled(t *testing.T) {
+	server, _ := createExpositionTestServer(false)
+
+	req := httptest.NewRequest("GET", "/metrics/prometheus", nil)
+	w := httptest.NewRecorder()
+	server.handlePrometheusMetrics(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 when metrics are disabled, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "text/plain;version=0.0.4")
+	w = httptest.NewRecorder()
+	server.handleMetrics(w, req)
+	validateJSONResponse(t, w, http.StatusOK)
+}
+
+func TestRateLimited_DiscoveryEndpoints(t *testing.T) {
+	server := createTestServer()
+	server.discoveryLimiter = newDiscoveryLimiter(config.HTTPRateLimitConfig{
+		Enabled:   true,
+		RateLimit: config.RateLimit{RequestsPerSecond: 0.5, Burst: 1},
+	})
+	handler := server.rateLimited(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/tools", nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	if w := request("192.0.2.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("expected first request to pass, got %d", w.Code)
+	}
+
+	// A new connection from the same address shares the bucket
+	w := request("192.0.2.1:5678")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+	if retryAfter := w.Header().Get("Retry-After"); retryAfter != "2" {
+		t.Errorf("expected Retry-After 2, got %q", retryAfter)
+	}
+	var response RateLimitResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if response.RetryAfterMs <= 0 || !strings.Contains(response.Error, "rate limit exceeded") {
+		t.Errorf("unexpected response %+v", response)
+	}
+
+	if w := request("192.0.2.2:1234"); w.Code != http.StatusOK {
+		t.Errorf("expected other client to pass, got %d", w.Code)
+	}
+}
+
+func TestRateLimited_Disabled(t *testing.T) {
+	server := createTestServer()
+	server.discoveryLimiter = newDiscoveryLimiter(config.HTTPRateLimitConfig{})
+	handler := server.rateLimited(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for i := 0; i < 50; i++ {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/tools", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d throttled with limiter disabled", i)
+		}
+	}
+}
+
+func TestHandleToolsHealth_SaturatedBulkhead(t *testing.T) {
+	server := createTestServer()
+	toolList := buildHealthyToolList()
+	health := buildRegistryHealthData("healthy")
+	health.Bulkheads = map[string]registry.BulkheadStats{
+		"test-tool-1": {MaxConcurrent: 2, MaxQueue: 4, InFlight: 2, Queued: 3, Saturated: true},
+	}
+	health.GlobalBulkhead = &registry.BulkheadStats{MaxConcurrent: 8, InFlight: 2}
+	server.toolRegistry = createMockToolRegistryWithHealth(health, toolList)
+
+	w := executeToolsHealthRequest(server)
+	validateJSONResponse(t, w, http.StatusOK)
+
+	response := parseToolsHealthResponse(t, w)
+	validateToolsHealthResponse(t, response, "degraded", len(toolList))
+
+	details := response.Tools["test-tool-1"]
+	if details.Bulkhead == nil || details.Bulkhead.Queued != 3 {
+		t.Errorf("expected bulkhead stats for test-tool-1, got %+v", details.Bulkhead)
+	}
+	if details.ErrorMessage == "" {
+		t.Error("expected saturation to be explained in error message")
+	}
+	if response.GlobalBulkhead == nil || response.GlobalBulkhead.MaxConcurrent != 8 {
+		t.Errorf("expected global bulkhead stats, got %+v", response.GlobalBulkhead)
+	}
+}
+
+func TestHandleToolsHealth_ResultCache(t *testing.T) {
+	server := createTestServer()
+	toolList := buildHealthyToolList()
+	health := buildRegistryHealthData("healthy")
+	health.ResultCaches = map[string]cache.Stats{
+		"test-tool-1": {Entries: 3, Hits: 9, Misses: 3, HitRate: 75},
+	}
+	server.toolRegistry = createMockToolRegistryWithHealth(health, toolList)
+
+	w := executeToolsHealthRequest(server)
+	validateJSONResponse(t, w, http.StatusOK)
+
+	response := parseToolsHealthResponse(t, w)
+	validateToolsHealthResponse(t, response, "healthy", len(toolList))
+
+	if stats := response.Tools["test-tool-1"].ResultCache; stats == nil || stats.Hits != 9 || stats.Misses != 3 {
+		t.Errorf("expected result cache stats for test-tool-1, got %+v", stats)
+	}
+	if stats := response.Tools["test-tool-2"].ResultCache; stats != nil {
+		t.Errorf("expected no result cache for test-tool-2, got %+v", stats)
+	}
+}
+
+func TestGetToolInfo_VersionRefs(t *testing.T) {
+	server := createTestServer()
+	sunset := time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)
+	server.toolRegistry = createMockToolRegistryWithTools([]tools.ToolInfo{
+		{ID: "echo", Name: "echo", Version: "1.0.0", Status: tools.ToolStatusActive,
+			Deprecation: &tools.Deprecation{Sunset: sunset}},
+		{ID: "echo@2.0.0", Name: "echo", Version: "2.0.0", Default: true, Status: tools.ToolStatusActive},
+	})
+
+	tests := []struct {
+		ref, wantID string
+	}{
+		{"echo", "echo@2.0.0"},
+		{"echo@1.0.0", "echo"},
+		{"echo@2.0.0", "echo@2.0.0"},
+	}
+	for _, tt := range tests {
+		info, err := server.getToolInfo(tt.ref)
+		if err != nil {
+			t.Errorf("getToolInfo(%q) failed: %v", tt.ref, err)
+			continue
+		}
+		if info.Key() != tt.wantID {
+			t.Errorf("getToolInfo(%q) resolved to %s, want %s", tt.ref, info.Key(), tt.wantID)
+		}
+	}
+	if _, err := server.getToolInfo("echo@3.0.0"); err == nil {
+		t.Error("expected unknown version to be not found")
+	}
+
+	w := httptest.NewRecorder()
+	server.handleToolsDiscovery(w, httptest.NewRequest("GET", "/tools", nil))
+	var response ToolDiscoveryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse discovery response: %v", err)
+	}
+	for _, tool := range response.Tools {
+		if tool.Default != (tool.Version == "2.0.0") || (tool.Deprecation != nil) != (tool.Version == "1.0.0") {
+			t.Errorf("unexpected version metadata for %s: %+v", tool.ID, tool)
+		}
+	}
+}
+
+func TestHandlePrometheusMetrics_Bulkheads(t *testing.T) {
+	server, toolRegistry := createExpositionTestServer(true)
+	toolRegistry.health.Bulkheads = map[string]registry.BulkheadStats{
+		"test-tool-1": {MaxConcurrent: 2, InFlight: 1, Rejected: 5},
+	}
+	toolRegistry.health.GlobalBulkhead = &registry.BulkheadStats{MaxConcurrent: 8, TimedOut: 2}
+
+	req := httptest.NewRequest("GET", "/metrics/prometheus", nil)
+	w := httptest.NewRecorder()
+	server.handlePrometheusMetrics(w, req)
+
+	body := w.Body.String()
+	for _, expected := range []string{
+		`mcp_tool_bulkhead_in_flight{tool="test-tool-1"} 1`,
+		`mcp_tool_bulkhead_rejected_total{tool="test-tool-1"} 5`,
+		"mcp_global_bulkhead_capacity 8",
+		"mcp_global_bulkhead_timed_out_total 2",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected exposition to contain %q", expected)
+		}
+	}
+}
+
+func TestHandleUpstreams(t *testing.T) {
+	server := createTestServer()
+	cfg := &config.Config{Upstreams: []config.UpstreamConfig{{
+		Name:      "docs",
+		Namespace: "docs",
+		Transport: config.UpstreamTransportHTTP,
+		URL:       "http://127.0.0.1:1/mcp",
+	}}}
+	server.upstreams = proxy.NewManager(cfg, createMockToolRegistry(), nil, nil, server.logger)
+
+	w := httptest.NewRecorder()
+	server.handleUpstreams(w, httptest.NewRequest("GET", "/upstreams", nil))
+	validateJSONResponse(t, w, http.StatusOK)
+
+	var response UpstreamsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse upstreams response: %v", err)
+	}
+	if len(response.Upstreams) != 1 {
+		t.Fatalf("expected 1 upstream, got %d", len(response.Upstreams))
+	}
+	upstream := response.Upstreams[0]
+	if upstream.Name != "docs" || upstream.Transport != "http" || upstream.Connected {
+		t.Errorf("unexpected upstream health: %+v", upstream)
+	}
+}
+
+func createAdminTestServer(t *testing.T, toolList []tools.ToolInfo) (*Server, *MockToolRegistry, string) {
+	server := createTestServer()
+	server.config.Server.Admin.Token = "0123456789abcdef"
+	auditPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	server.audit = newAuditLog(auditPath, server.logger)
+	t.Cleanup(func() { server.audit.Close() })
+
+	registry := createMockToolRegistryWithTools(toolList)
+	server.toolRegistry = registry
+	return server, registry, auditPath
+}
+
+func executeAdminRequest(server *Server, handler http.HandlerFunc, target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", target, nil)
+	req.Header.Set("Authorization", "Bearer "+server.config.Server.Admin.Token)
+	req.Header.Set("X-Admin-Actor", "oncall")
+	w := httptest.NewRecorder()
+	server.adminAuthorized(handler)(w, req)
+	return w
+}
+
+func parseAdminResponse(t *testing.T, w *httptest.ResponseRecorder) AdminLifecycleResponse {
+	var response AdminLifecycleResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse admin response: %v", err)
+	}
+	return response
+}
+
+func readAuditRecords(t *testing.T, path string) []AuditRecord {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+
+	var records []AuditRecord
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var record AuditRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("failed to parse audit record %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestAdminAuthorized(t *testing.T) {
+	server, _, auditPath := createAdminTestServer(t, nil)
+	reached := false
+	handler := server.adminAuthorized(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	})
+
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		expectedCode  int
+	}{
+		{"disabled without token", "", "Bearer 0123456789abcdef", http.StatusForbidden},
+		{"missing header", "0123456789abcdef", "", http.StatusUnauthorized},
+		{"wrong token", "0123456789abcdef", "Bearer fedcba9876543210", http.StatusUnauthorized},
+		{"wrong scheme", "0123456789abcdef", "Basic 0123456789abcdef", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.config.Server.Admin.Token = tt.token
+			req := httptest.NewRequest("POST", "/admin/tools/test-tool-1/disable", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			validateJSONResponse(t, w, tt.expectedCode)
+			if reached {
+				t.Error("expected handler not to be reached")
+			}
+		})
+	}
+
+	server.config.Server.Admin.Token = "0123456789abcdef"
+	req := httptest.NewRequest("POST", "/admin/tools/test-tool-1/disable", nil)
+	req.Header.Set("Authorization", "Bearer 0123456789abcdef")
+	handler(httptest.NewRecorder(), req)
+	if !reached {
+		t.Error("expected handler to be reached with a valid token")
+	}
+
+	records := readAuditRecords(t, auditPath)
+	if len(records) != len(tests) {
+		t.Fatalf("expected %d audit records, got %d", len(tests), len(records))
+	}
+	for _, record := range records {
+		if record.Outcome != AuditOutcomeDenied {
+			t.Errorf("expected denied outcome, got %+v", record)
+		}
+	}
+}
+
+func TestHandleAdminToolsRoute_Lifecycle(t *testing.T) {
+	server, registry, auditPath := createAdminTestServer(t, buildHealthyToolList())
+
+	w := executeAdminRequest(server, server.handleAdminToolsRoute, "/admin/tools/test-tool-1/disable")
+	validateJSONResponse(t, w, http.StatusOK)
+	response := parseAdminResponse(t, w)
+	if response.PreviousStatus != "active" || response.Status != "disabled" {
+		t.Errorf("expected active -> disabled, got %s -> %s", response.PreviousStatus, response.Status)
+	}
+	if strings.Join(response.AllowedTransitions, ",") != "disabled,error,registered" {
+		t.Errorf("unexpected transitions from disabled: %v", response.AllowedTransitions)
+	}
+	history := registry.toolList[0].History
+	if len(history) != 1 || history[0].Actor != "admin" || history[0].Reason != "disable requested by oncall" {
+		t.Errorf("expected the disable to be attributed to the admin, got %+v", history)
+	}
+
+	w = executeAdminRequest(server, server.handleAdminToolsRoute, "/admin/tools/test-tool-1/enable")
+	validateJSONResponse(t, w, http.StatusOK)
+	response = parseAdminResponse(t, w)
+	if response.PreviousStatus != "disabled" || response.Status != "active" {
+		t.Errorf("expected disabled -> active, got %s -> %s", response.PreviousStatus, response.Status)
+	}
+
+	w = executeAdminRequest(server, server.handleAdminToolsRoute, "/admin/tools/test-tool-1/enable")
+	validateJSONResponse(t, w, http.StatusConflict)
+	if response = parseAdminResponse(t, w); response.Error == "" || response.Status != "active" {
+		t.Errorf("expected enabling an active tool to fail and leave it active, got %+v", response)
+	}
+
+	w = executeAdminRequest(server, server.handleAdminToolsRoute, "/admin/tools/test-tool-1/restart")
+	validateJSONResponse(t, w, http.StatusOK)
+	validateRestartSuccess(t, registry, "test-tool-1", tools.ToolStatusActive)
+
+	w = executeAdminRequest(server, server.handleAdminToolsRoute, "/admin/tools/unknown/restart")
+	validateJSONResponse(t, w, http.StatusNotFound)
+
+	w = executeAdminRequest(server, server.handleAdminToolsRoute, "/admin/tools/test-tool-1/explode")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown action, got %d", w.Code)
+	}
+
+	records := readAuditRecords(t, auditPath)
+	if len(records) != 5 {
+		t.Fatalf("expected 5 audit records, got %d", len(records))
+	}
+	first := records[0]
+	if first.Actor != "oncall" || first.Action != "tool.disable" || first.Target != "test-tool-1" ||
+		first.PreviousStatus != "active" || first.Status != "disabled" || first.Outcome != AuditOutcomeSuccess {
+		t.Errorf("unexpected audit record: %+v", first)
+	}
+	if records[2].Outcome != AuditOutcomeFailure || records[2].Error == "" {
+		t.Errorf("expected failed enable to be audited, got %+v", records[2])
+	}
+}
+
+type stubResourceFactory struct {
+	uri string
+}
+
+func (f *stubResourceFactory) URI() string            { return f.uri }
+func (f *stubResourceFactory) Name() string           { return "stub" }
+func (f *stubResourceFactory) Description() string    { return "stub resource" }
+func (f *stubResourceFactory) MimeType() string       { return "text/plain" }
+func (f *stubResourceFactory) Version() string        { return "1.0.0" }
+func (f *stubResourceFactory) Tags() []string         { return nil }
+func (f *stubResourceFactory) Capabilities() []string { return []string{"read"} }
+func (f *stubResourceFactory) Create(ctx context.Context, config resources.ResourceConfig) (mcp.Resource, error) {
+	return nil, errors.New("not implemented")
+}
+func (f *stubResourceFactory) Validate(config resources.ResourceConfig) error { return nil }
+
+func TestHandleAdminResourceRefresh(t *testing.T) {
+	server, _, auditPath := createAdminTestServer(t, nil)
+	server.resourceRegistry = resources.NewDefaultResourceRegistry(server.config, server.logger)
+	if err := server.resourceRegistry.Register("file:///data.txt", &stubResourceFactory{uri: "file:///data.txt"}); err != nil {
+		t.Fatalf("failed to register resource: %v", err)
+	}
+
+	w := executeAdminRequest(server, server.handleAdminResourceRefresh, "/admin/resources/refresh")
+	validateJSONResponse(t, w, http.StatusBadRequest)
+
+	w = executeAdminRequest(server, server.handleAdminResourceRefresh, "/admin/resources/refresh?uri=file:///missing.txt")
+	validateJSONResponse(t, w, http.StatusNotFound)
+
+	// Only loaded or active resources can be refreshed
+	w = executeAdminRequest(server, server.handleAdminResourceRefresh, "/admin/resources/refresh?uri=file:///data.txt")
+	validateJSONResponse(t, w, http.StatusConflict)
+	response := parseAdminResponse(t, w)
+	if response.Kind != "resource" || response.PreviousStatus != "registered" || response.Status != "registered" {
+		t.Errorf("unexpected refresh response: %+v", response)
+	}
+	if strings.Join(response.AllowedTransitions, ",") != "disabled,error,loaded,registered" {
+		t.Errorf("unexpected transitions from registered: %v", response.AllowedTransitions)
+	}
+
+	req := httptest.NewRequest("GET", "/admin/resources/refresh?uri=file:///data.txt", nil)
+	w = httptest.NewRecorder()
+	server.handleAdminResourceRefresh(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for GET, got %d", w.Code)
+	}
+
+	records := readAuditRecords(t, auditPath)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 audit records, got %d", len(records))
+	}
+	if records[1].Action != "resource.refresh" || records[1].Outcome != AuditOutcomeFailure {
+		t.Errorf("unexpected audit record: %+v", records[1])
+	}
+}
+
+// readSSEEvent reads one event frame from a server-sent event stream,
+// skipping keepalive comments
+func readSSEEvent(t *testing.T, reader *bufio.Reader) map[string]string {
+	t.Helper()
+	frame := make(map[string]string)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event stream: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if len(frame) > 0 {
+				return frame
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		frame[field] = value
+	}
+}
+
+func TestHandleEvents(t *testing.T) {
+	server := createTestServer()
+	server.events = events.NewBus(16)
+	server.streams, server.stopStreams = context.WithCancel(context.Background())
+	defer server.stopStreams()
+
+	server.events.Publish(events.Event{Type: events.TypeRegistered, Kind: events.KindTool, Name: "echo"})
+	server.events.Publish(events.Event{Type: events.TypeRegistered, Kind: events.KindResource, Name: "file:///data.txt"})
+	server.events.Publish(events.Event{Type: events.TypeStatusChanged, Kind: events.KindTool, Name: "echo", From: "registered", To: "loaded"})
+
+	httpServer := httptest.NewServer(http.HandlerFunc(server.handleEvents))
+	defer httpServer.Close()
+
+	t.Run("resumes from last event id and filters by kind", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", httpServer.URL+"/events?kind=tool", nil)
+		req.Header.Set("Last-Event-ID", "1")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to open event stream: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+			t.Fatalf("expected text/event-stream, got %q", contentType)
+		}
+		reader := bufio.NewReader(resp.Body)
+
+		// Event 2 is a resource and is filtered out of the replay
+		frame := readSSEEvent(t, reader)
+		if frame["id"] != "3" || frame["event"] != string(events.TypeStatusChanged) {
+			t.Fatalf("unexpected replayed event: %v", frame)
+		}
+		var replayed events.Event
+		if err := json.Unmarshal([]byte(frame["data"]), &replayed); err != nil {
+			t.Fatalf("failed to decode event data: %v", err)
+		}
+		if replayed.From != "registered" || replayed.To != "loaded" {
+			t.Errorf("unexpected replayed transition: %+v", replayed)
+		}
+
+		server.events.Publish(events.Event{Type: events.TypeStatusChanged, Kind: events.KindResource, Name: "file:///data.txt", To: "loaded"})
+		server.events.Publish(events.Event{Type: events.TypeStatusChanged, Kind: events.KindTool, Name: "echo", From: "active", To: "error", Reason: "probe failed"})
+
+		frame = readSSEEvent(t, reader)
+		if frame["id"] != "5" || !strings.Contains(frame["data"], `"reason":"probe failed"`) {
+			t.Errorf("unexpected live event: %v", frame)
+		}
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		tests := []struct {
+			method string
+			target string
+			status int
+		}{
+			{"POST", "/events", http.StatusMethodNotAllowed},
+			{"GET", "/events?kind=widget", http.StatusBadRequest},
+			{"GET", "/events?last_event_id=abc", http.StatusBadRequest},
+		}
+		for _, tt := range tests {
+			w := httptest.NewRecorder()
+			server.handleEvents(w, httptest.NewRequest(tt.method, tt.target, nil))
+			if w.Code != tt.status {
+				t.Errorf("%s %s: expected %d, got %d", tt.method, tt.target, tt.status, w.Code)
+			}
+		}
+	})
+}
+
+func TestHandleResourceDetail(t *testing.T) {
+	server := createTestServer()
+	server.resourceRegistry = resources.NewDefaultResourceRegistry(server.config, server.logger)
+	if err := server.resourceRegistry.Register("file:///data.txt", &stubResourceFactory{uri: "file:///data.txt"}); err != nil {
+		t.Fatalf("failed to register resource: %v", err)
+	}
+	cause := registry.TransitionCause{Actor: registry.ActorAdmin, Reason: "disable requested by oncall"}
+	if err := server.resourceRegistry.TransitionStatusWith("file:///data.txt", resources.ResourceStatusDisabled, cause); err != nil {
+		t.Fatalf("failed to disable resource: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/resources/file%3A%2F%2F%2Fdata.txt", nil)
+	w := httptest.NewRecorder()
+	server.handleResourceDetail(w, req)
+	validateJSONResponse(t, w, http.StatusOK)
+
+	var response ResourceDetailResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse resource detail: %v", err)
+	}
+	if response.URI != "file:///data.txt" || response.Status != "disabled" {
+		t.Errorf("unexpected resource detail: %+v", response)
+	}
+	if len(response.History) != 2 {
+		t.Fatalf("expected 2 history entries, got %+v", response.History)
+	}
+	if last := response.History[1]; last.From != registry.StatusRegistered || last.To != registry.StatusDisabled || last.Actor != registry.ActorAdmin {
+		t.Errorf("unexpected transition: %+v", last)
+	}
+
+	req = httptest.NewRequest("GET", "/resources/file%3A%2F%2F%2Fmissing.txt", nil)
+	w = httptest.NewRecorder()
+	server.handleResourceDetail(w, req)
+	validateJSONResponse(t, w, http.StatusNotFound)
+}
+
+func TestDrain_FailsReadiness(t *testing.T) {
+	server := createTestServer()
+	server.mcpServer = mcp.NewServer(mcp.Implementation{Name: "test", Version: "1.0.0"}, server.config, server.logger)
+
+	readiness := func() ReadyResponse {
+		w := httptest.NewRecorder()
+		server.handleReady(w, httptest.NewRequest("GET", "/ready", nil))
+		var response ReadyResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to parse readiness response: %v", err)
+		}
+		if (response.Status == "ready") != (w.Code == http.StatusOK) {
+			t.Errorf("status %q answered with %d", response.Status, w.Code)
+		}
+		return response
+	}
+
+	if response := readiness(); response.Status != "ready" {
+		t.Fatalf("expected ready before draining, got %q", response.Status)
+	}
+	if err := server.Drain(context.Background()); err != nil {
+		t.Fatalf("expected an idle server to drain at once, got %v", err)
+	}
+	if response := readiness(); response.Status != "draining" {
+		t.Errorf("expected draining after the drain started, got %q", response.Status)
+	}
+}
+
+func TestCheckRequiredTools_PassesLoadedTools(t *testing.T) {
+	server := createTestServer()
+	server.config.Readiness = config.ReadinessConfig{RequiredTools: []string{"echo"}}
+	server.toolRegistry = tools.NewDefaultToolRegistry(server.config, server.logger)
+	if err := server.toolRegistry.Register("echo", echo.NewEchoFactory()); err != nil {
+		t.Fatalf("failed to register echo: %v", err)
+	}
+
+	if _, err := server.checkRequiredTools(context.Background()); err == nil || !strings.Contains(err.Error(), "echo (registered)") {
+		t.Errorf("expected a registered tool to fail the check, got %v", err)
+	}
+
+	// LoadTools leaves tools loaded, which serve calls as active ones do
+	ctx := context.Background()
+	if err := server.toolRegistry.Start(ctx); err != nil {
+		t.Fatalf("failed to start tool registry: %v", err)
+	}
+	defer server.toolRegistry.Stop(ctx)
+	if err := server.toolRegistry.LoadTools(ctx); err != nil {
+		t.Fatalf("failed to load tools: %v", err)
+	}
+	details, err := server.checkRequiredTools(ctx)
+	if err != nil {
+		t.Errorf("expected the loaded echo tool to pass, got %v", err)
+	}
+	if status := details.(map[string]string)["echo"]; status != string(tools.ToolStatusLoaded) {
+		t.Errorf("expected echo reported loaded, got %q", status)
+	}
+}
+
+func TestHandleReady_ReportsStartupLoading(t *testing.T) {
+	server := createTestServer()
+	server.toolRegistry = createMockToolRegistry()
+	server.resourceRegistry = resources.NewDefaultResourceRegistry(server.config, server.logger)
+	if err := server.resourceRegistry.Register("file:///broken.txt", &stubResourceFactory{uri: "file:///broken.txt"}); err != nil {
+		t.Fatalf("failed to register resource: %v", err)
+	}
+
+	ready := func() ReadyResponse {
+		w := httptest.NewRecorder()
+		server.handleReady(w, httptest.NewRequest("GET", "/ready", nil))
+		validateJSONResponse(t, w, http.StatusOK)
+		var response ReadyResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to parse readiness response: %v", err)
+		}
+		return response
+	}
+
+	if response := ready(); response.Startup != nil {
+		t.Errorf("expected no startup report before loading, got %+v", response.Startup)
+	}
+
+	ctx := context.Background()
+	if err := server.resourceRegistry.Start(ctx); err != nil {
+		t.Fatalf("failed to start resource registry: %v", err)
+	}
+	defer server.resourceRegistry.Stop(ctx)
+	server.loadEntities(ctx)
+
+	// A broken factory is reported but does not keep the server from
+	// becoming ready
+	response := ready()
+	if response.Status != "ready" || response.Startup == nil || response.Startup.Resources == nil {
+		t.Fatalf("expected a ready server with a resource startup report, got %+v", response)
+	}
+	report := response.Startup.Resources
+	if !report.Complete() || report.Total != 1 || report.Failed != 1 || report.Entities[0].Error == "" {
+		t.Errorf("unexpected resource startup report: %+v", report)
+	}
+	if response.Startup.Tools != nil {
+		t.Errorf("expected no tool report from a registry that did not load, got %+v", response.Startup.Tools)
+	}
+}
+
+func TestHandleReady_AggregatesChecks(t *testing.T) {
+	server := createTestServer()
+	server.config.Readiness = config.ReadinessConfig{
+		Timeout: 50 * time.Millisecond,
+		Checks: map[string]config.ReadinessCheckConfig{
+			"search-index": {Enabled: true, Critical: false},
+			"legacy":       {Enabled: false},
+		},
+	}
+
+	ready := func() (ReadyResponse, int) {
+		w := httptest.NewRecorder()
+		server.handleReady(w, httptest.NewRequest("GET", "/ready", nil))
+		var response ReadyResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to parse readiness response: %v", err)
+		}
+		return response, w.Code
+	}
+
+	server.AddReadinessCheck("database", true, func(ctx context.Context) (any, error) {
+		return map[string]int{"connections": 3}, nil
+	})
+	// Configured as non-critical, which wins over the critical flag here
+	server.AddReadinessCheck("search-index", true, func(ctx context.Context) (any, error) {
+		return nil, errors.New("index is rebuilding")
+	})
+	server.AddReadinessCheck("legacy", true, func(ctx context.Context) (any, error) {
+		return nil, errors.New("never runs")
+	})
+
+	response, code := ready()
+	if code != http.StatusOK || response.Status != "degraded" {
+		t.Fatalf("expected degraded with 200, got %q with %d", response.Status, code)
+	}
+	if len(response.Checks) != 2 {
+		t.Fatalf("expected the disabled check to be left out, got %+v", response.Checks)
+	}
+	if check := response.Checks["database"]; check.Status != checkPass || !check.Critical || check.Details == nil {
+		t.Errorf("unexpected database check: %+v", check)
+	}
+	if check := response.Checks["search-index"]; check.Status != checkFail || check.Critical || check.Error != "index is rebuilding" {
+		t.Errorf("unexpected search-index check: %+v", check)
+	}
+
+	// A critical check that hangs fails once the timeout passed
+	hung := make(chan struct{})
+	t.Cleanup(func() { close(hung) })
+	server.AddReadinessCheck("database", true, func(ctx context.Context) (any, error) {
+		<-hung
+		return nil, nil
+	})
+	response, code = ready()
+	if code != http.StatusServiceUnavailable || response.Status != "not_ready" {
+		t.Fatalf("expected not_ready with 503, got %q with %d", response.Status, code)
+	}
+	if check := response.Checks["database"]; check.Status != checkFail || !strings.Contains(check.Error, "did not finish") {
+		t.Errorf("unexpected database check: %+v", check)
+	}
+}
+
+func TestHandleReady_ChecksServerDependencies(t *testing.T) {
+	server := createTestServer()
+	server.config.Readiness = config.ReadinessConfig{
+		Timeout:       time.Second,
+		RequiredTools: []string{"echo", "search@2.0.0"},
+	}
+	server.toolRegistry = createMockToolRegistryWithTools([]tools.ToolInfo{
+		{ID: "echo", Name: "echo", Version: "1.0.0", Default: true, Status: tools.ToolStatusActive},
+	})
+	server.resourceRegistry = resources.NewDefaultResourceRegistry(server.config, server.logger)
+	server.upstreams = proxy.NewManager(&config.Config{Upstreams: []config.UpstreamConfig{{
+		Name:      "docs",
+		Namespace: "docs",
+		Transport: config.UpstreamTransportHTTP,
+		URL:       "http://127.0.0.1:1/mcp",
+	}}}, server.toolRegistry, server.resourceRegistry, nil, server.logger)
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	factory, err := files.NewFileSystemResourceFactory(files.FileSystemFactoryConfig{
+		BaseURI:            "file://" + missing,
+		BasePath:           missing,
+		AllowedDirectories: []string{missing},
+		Logger:             server.logger,
+	})
+	if err != nil {
+		t.Fatalf("failed to create file factory: %v", err)
+	}
+	if err := server.resourceRegistry.Register("file://"+missing, factory); err != nil {
+		t.Fatalf("failed to register file factory: %v", err)
+	}
+	server.addDefaultReadinessChecks()
+
+	w := httptest.NewRecorder()
+	server.handleReady(w, httptest.NewRequest("GET", "/ready", nil))
+	var response ReadyResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse readiness response: %v", err)
+	}
+	if w.Code != http.StatusServiceUnavailable || response.Status != "not_ready" {
+		t.Fatalf("expected not_ready with 503, got %q with %d", response.Status, w.Code)
+	}
+
+	expected := map[string]string{
+		config.ReadinessCheckTransport:     "MCP transport is not serving",
+		config.ReadinessCheckRegistries:    "resource registry not running",
+		config.ReadinessCheckRequiredTools: "search@2.0.0 (not registered)",
+		config.ReadinessCheckFileResources: missing,
+		config.ReadinessCheckUpstreams:     "docs",
+	}
+	for name, message := range expected {
+		check, exists := response.Checks[name]
+		if !exists {
+			t.Errorf("expected a %s check", name)
+			continue
+		}
+		if check.Status != checkFail || !strings.Contains(check.Error, message) {
+			t.Errorf("expected %s to fail with %q, got %+v", name, message, check)
+		}
+	}
+	if response.Checks[config.ReadinessCheckUpstreams].Critical {
+		t.Error("expected the upstreams check to be non-critical by default")
+	}
+	if strings.Contains(response.Checks[config.ReadinessCheckRequiredTools].Error, "echo") {
+		t.Errorf("expected the active echo tool to pass, got %q", response.Checks[config.ReadinessCheckRequiredTools].Error)
+	}
+}
+
+// blockingResourceFactory fails to create its resource once released
+type blockingResourceFactory struct {
+	stubResourceFactory
+	release chan struct{}
+}
+
+func (f *blockingResourceFactory) Create(ctx context.Context, config resources.ResourceConfig) (mcp.Resource, error) {
+	select {
+	case <-f.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return f.stubResourceFactory.Create(ctx, config)
+}
+
+func TestStartMCP_LoadsInBackground(t *testing.T) {
+	base := createTestServer()
+	server := New(base.config, base.logger)
+	factory := &blockingResourceFactory{stubResourceFactory: stubResourceFactory{uri: "file:///slow.txt"}, release: make(chan struct{})}
+	if err := server.ResourceRegistry().Register(factory.uri, factory); err != nil {
+		t.Fatalf("failed to register resource: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := server.StartMCP(ctx); err != nil {
+		t.Fatalf("StartMCP failed: %v", err)
+	}
+	defer server.StopMCP(ctx)
+
+	startup := func() (StartupResponse, int) {
+		w := httptest.NewRecorder()
+		server.handleStartup(w, httptest.NewRequest("GET", "/startup", nil))
+		var response StartupResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to parse startup response: %v", err)
+		}
+		return response, w.Code
+	}
+
+	// StartMCP returned while the resource is still loading, and /startup
+	// reports the progress once loading began
+	deadline := time.Now().Add(5 * time.Second)
+	for server.ResourceRegistry().StartupReport() == nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	response, code := startup()
+	if code != http.StatusServiceUnavailable || response.Status != "starting" {
+		t.Fatalf("expected starting with 503, got %q with %d", response.Status, code)
+	}
+	if response.Startup == nil || response.Startup.Resources == nil || response.Startup.Resources.Pending != 1 {
+		t.Errorf("expected the slow resource reported pending, got %+v", response.Startup)
+	}
+
+	close(factory.release)
+	for !server.started.Load() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if response, code := startup(); code != http.StatusOK || response.Status != "started" {
+		t.Errorf("expected started with 200 once loading finished, got %q with %d", response.Status, code)
+	}
+}
+
+type panickingTool struct{}
+
+func (panickingTool) Name() string                { return "boom" }
+func (panickingTool) Description() string         { return "Panics on every call" }
+func (panickingTool) Parameters() json.RawMessage { return json.RawMessage(`{"type":"object"}`) }
+func (panickingTool) Handler() mcp.ToolHandler {
+	return mcp.ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+		panic("boom")
+	})
+}
+
+func TestNew_MiddlewareChainRecoversToolPanic(t *testing.T) {
+	base := createTestServer()
+	base.config.MCP.ProtocolTimeout = config.DefaultProtocolTimeout
+	server := New(base.config, base.logger)
+
+	// The tool runs as the registry serves it, instrumented inside the
+	// server's middlewares
+	tool := tools.WithMetrics(panickingTool{}, metrics.NewCollector())
+	handler := server.mcpServer.Pipeline().ToolHandler(tool.Name(), tool.Handler())
+
+	request := mcpgo.CallToolRequest{}
+	request.Params.Name = tool.Name()
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("expected the panic returned as a tool error, got %v", err)
+	}
+	if result == nil || !result.IsError {
+		t.Fatalf("expected an error result, got %+v", result)
+	}
+}
+
+func TestHandleStartup(t *testing.T) {
+	server := createTestServer()
+
+	startup := func() (StartupResponse, int) {
+		w := httptest.NewRecorder()
+		server.handleStartup(w, httptest.NewRequest("GET", "/startup", nil))
+		var response StartupResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to parse startup response: %v", err)
+		}
+		return response, w.Code
+	}
+
+	if response, code := startup(); code != http.StatusServiceUnavailable || response.Status != "starting" {
+		t.Errorf("expected starting with 503, got %q with %d", response.Status, code)
+	}
+
+	server.started.Store(true)
+	if response, code := startup(); code != http.StatusOK || response.Status != "started" {
+		t.Errorf("expected started with 200, got %q with %d", response.Status, code)
+	}
+}
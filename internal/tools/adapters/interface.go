@@ -24,6 +24,16 @@ type LibraryAdapter interface {
 	GetResource(uri string) (mcp.Resource, error)
 	ListResources() []string
 
+	// Prompt management; prompts are published as they are, without the
+	// lifecycle of tools and resources
+	RegisterPrompt(prompt mcp.Prompt) error
+	UnregisterPrompt(name string) error
+	ListPrompts() []string
+
+	// SetPipeline shares the server's call pipeline, so served calls run
+	// through its middlewares and drain like the server's own
+	SetPipeline(pipeline *mcp.Pipeline)
+
 	// Lifecycle management
 	Start(ctx context.Context) error
 	Stop(ctx context.Context) error
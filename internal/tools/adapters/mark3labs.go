@@ -2,9 +2,9 @@ package adapters
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -20,19 +20,58 @@ type Mark3LabsAdapter struct {
 	mcpServer   *server.MCPServer
 	tools       map[string]mcpintf.Tool
 	resources   map[string]mcpintf.Resource
+	prompts     map[string]mcpintf.Prompt
 	mu          sync.RWMutex
 	running     bool
 	lastCheck   time.Time
+
+	// snapshot is what Health and IsRunning read, so they never wait for
+	// a registration; writers holding mu publish a new one
+	snapshot atomic.Pointer[adapterSnapshot]
+
+	// pipeline serves the calls of the published tools
+	pipeline *mcpintf.Pipeline
+}
+
+// adapterSnapshot is an immutable copy of the state Health reports
+type adapterSnapshot struct {
+	running   bool
+	lastCheck time.Time
+	tools     int
+	resources int
 }
 
 func NewMark3LabsAdapter(cfg *config.Config, log *logger.Logger) *Mark3LabsAdapter {
-	return &Mark3LabsAdapter{
+	a := &Mark3LabsAdapter{
 		logger:    log,
 		config:    cfg,
 		tools:     make(map[string]mcpintf.Tool),
 		resources: make(map[string]mcpintf.Resource),
+		prompts:   make(map[string]mcpintf.Prompt),
 		lastCheck: time.Now(),
+		pipeline:  mcpintf.NewPipeline(log),
 	}
+	a.publishSnapshot()
+	return a
+}
+
+// publishSnapshot publishes the state Health reports; the caller must hold
+// a.mu
+func (a *Mark3LabsAdapter) publishSnapshot() {
+	a.snapshot.Store(&adapterSnapshot{
+		running:   a.running,
+		lastCheck: a.lastCheck,
+		tools:     len(a.tools),
+		resources: len(a.resources),
+	})
+}
+
+// SetPipeline makes the adapter serve calls through the server's pipeline.
+// It applies to tools published from then on, so it is set before Start.
+func (a *Mark3LabsAdapter) SetPipeline(pipeline *mcpintf.Pipeline) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pipeline = pipeline
 }
 
 func (a *Mark3LabsAdapter) RegisterTool(tool mcpintf.Tool) error {
@@ -50,6 +89,7 @@ func (a *Mark3LabsAdapter) RegisterTool(tool mcpintf.Tool) error {
 	}
 
 	a.tools[tool.Name()] = tool
+	a.publishSnapshot()
 
 	// Register with mark3labs server if running
 	if a.running && a.mcpServer != nil {
@@ -70,9 +110,12 @@ func (a *Mark3LabsAdapter) UnregisterTool(name string) error {
 	}
 
 	delete(a.tools, name)
+	a.publishSnapshot()
 
-	// Note: mark3labs/mcp-go doesn't have explicit tool unregistration
-	// Tools are managed by the server instance lifecycle
+	// Withdrawing a tool from a running server notifies its clients
+	if a.running && a.mcpServer != nil {
+		a.mcpServer.DeleteTools(name)
+	}
 
 	return nil
 }
@@ -114,6 +157,7 @@ func (a *Mark3LabsAdapter) RegisterResource(resource mcpintf.Resource) error {
 	}
 
 	a.resources[resource.URI()] = resource
+	a.publishSnapshot()
 
 	if a.running && a.mcpServer != nil {
 		return a.registerResourceWithServer(resource)
@@ -133,9 +177,11 @@ func (a *Mark3LabsAdapter) UnregisterResource(uri string) error {
 	}
 
 	delete(a.resources, uri)
+	a.publishSnapshot()
 
-	// Note: mark3labs/mcp-go doesn't have explicit resource unregistration
-	// Resources are managed by the server instance lifecycle
+	if a.running && a.mcpServer != nil {
+		a.mcpServer.RemoveResource(uri)
+	}
 
 	return nil
 }
@@ -163,6 +209,55 @@ func (a *Mark3LabsAdapter) ListResources() []string {
 	return resources
 }
 
+func (a *Mark3LabsAdapter) RegisterPrompt(prompt mcpintf.Prompt) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.logger.Info("registering prompt with mark3labs adapter", "name", prompt.Name())
+
+	if _, exists := a.prompts[prompt.Name()]; exists {
+		return fmt.Errorf("prompt '%s' already exists", prompt.Name())
+	}
+
+	a.prompts[prompt.Name()] = prompt
+
+	if a.running && a.mcpServer != nil {
+		a.registerPromptWithServer(prompt)
+	}
+
+	return nil
+}
+
+func (a *Mark3LabsAdapter) UnregisterPrompt(name string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.logger.Info("unregistering prompt from mark3labs adapter", "name", name)
+
+	if _, exists := a.prompts[name]; !exists {
+		return fmt.Errorf("prompt '%s' not found", name)
+	}
+
+	delete(a.prompts, name)
+
+	if a.running && a.mcpServer != nil {
+		a.mcpServer.DeletePrompts(name)
+	}
+
+	return nil
+}
+
+func (a *Mark3LabsAdapter) ListPrompts() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	prompts := make([]string, 0, len(a.prompts))
+	for name := range a.prompts {
+		prompts = append(prompts, name)
+	}
+	return prompts
+}
+
 func (a *Mark3LabsAdapter) Start(ctx context.Context) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -176,9 +271,11 @@ func (a *Mark3LabsAdapter) Start(ctx context.Context) error {
 	a.mcpServer = server.NewMCPServer(
 		"mcp-server",
 		"1.0.0",
-		server.WithToolCapabilities(false),
+		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
+		server.WithPromptCapabilities(true),
 		server.WithRecovery(),
+		server.WithHooks(a.pipeline.Hooks()),
 	)
 
 	for _, tool := range a.tools {
@@ -201,8 +298,13 @@ func (a *Mark3LabsAdapter) Start(ctx context.Context) error {
 		}
 	}
 
+	for _, prompt := range a.prompts {
+		a.registerPromptWithServer(prompt)
+	}
+
 	a.running = true
 	a.lastCheck = time.Now()
+	a.publishSnapshot()
 
 	a.logger.Info("mark3labs adapter started successfully")
 	return nil
@@ -222,25 +324,24 @@ func (a *Mark3LabsAdapter) Stop(ctx context.Context) error {
 	// The server is managed by its lifecycle
 	a.mcpServer = nil
 	a.running = false
+	a.publishSnapshot()
 
 	a.logger.Info("mark3labs adapter stopped")
 	return nil
 }
 
 func (a *Mark3LabsAdapter) IsRunning() bool {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
-	return a.running
+	return a.snapshot.Load().running
 }
 
+// Health reports the published snapshot without taking a.mu
 func (a *Mark3LabsAdapter) Health() AdapterHealth {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	snapshot := a.snapshot.Load()
 
 	status := "healthy"
 	var errors []string
 
-	if !a.running {
+	if !snapshot.running {
 		status = "stopped"
 	}
 
@@ -248,57 +349,30 @@ func (a *Mark3LabsAdapter) Health() AdapterHealth {
 		Status:        status,
 		Library:       "mark3labs",
 		Version:       "0.33.0",
-		ToolCount:     len(a.tools),
-		ResourceCount: len(a.resources),
-		LastCheck:     a.lastCheck.Format(time.RFC3339),
+		ToolCount:     snapshot.tools,
+		ResourceCount: snapshot.resources,
+		LastCheck:     snapshot.lastCheck.Format(time.RFC3339),
 		Errors:        errors,
 		Details: map[string]string{
 			"implementation": "mark3labs/mcp-go",
-			"server_status":  fmt.Sprintf("running=%v", a.running),
+			"server_status":  fmt.Sprintf("running=%v", snapshot.running),
 		},
 	}
 }
 
 func (a *Mark3LabsAdapter) registerToolWithServer(tool mcpintf.Tool) error {
-	// This is a simplified implementation - in a full version we'd properly
-	// parse the JSON schema and create appropriate mark3labs tool options
+	// Tools declaring an input schema advertise it as is, so clients see the
+	// same schema the registry validates arguments against
 	mcpTool := mcp.NewTool(tool.Name(),
 		mcp.WithDescription(tool.Description()),
 	)
-
-	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		// Convert arguments to JSON RawMessage
-		var args []byte
-		if request.Params.Arguments != nil {
-			var err error
-			args, err = json.Marshal(request.Params.Arguments)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Invalid arguments: %v", err)), nil
-			}
-		}
-		
-		result, err := tool.Handler().Handle(ctx, args)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-
-		if result.IsError() {
-			errorMsg := "Tool execution failed"
-			if result.GetError() != nil {
-				errorMsg = result.GetError().Error()
-			}
-			return mcp.NewToolResultError(errorMsg), nil
-		}
-
-		contents := result.GetContent()
-		if len(contents) == 0 {
-			return mcp.NewToolResultText(""), nil
-		}
-
-		firstContent := contents[0]
-		return mcp.NewToolResultText(firstContent.GetText()), nil
+	if params := tool.Parameters(); len(params) > 0 {
+		mcpTool = mcp.NewToolWithRawSchema(tool.Name(), tool.Description(), params)
 	}
 
+	// The pipeline runs the call past drains, tracing and the middlewares
+	handler := a.pipeline.ToolHandler(tool.Name(), tool.Handler())
+
 	a.mcpServer.AddTool(mcpTool, handler)
 	return nil
 }
@@ -311,34 +385,22 @@ func (a *Mark3LabsAdapter) registerResourceWithServer(resource mcpintf.Resource)
 		mcp.WithMIMEType(resource.MimeType()),
 	)
 
-	handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		content, err := resource.Handler().Read(ctx, request.Params.URI)
-		if err != nil {
-			return nil, err
-		}
+	// Reads go through the pipeline too, so drains wait for them
+	handler := a.pipeline.ResourceHandler(resource.Handler())
 
-		var results []mcp.ResourceContents
-		for _, c := range content.GetContent() {
-			if c.Type() == "text" {
-				results = append(results, mcp.TextResourceContents{
-					URI:      request.Params.URI,
-					MIMEType: content.GetMimeType(),
-					Text:     c.GetText(),
-				})
-			}
-		}
+	a.mcpServer.AddResource(mcpResource, handler)
+	return nil
+}
 
-		if len(results) == 0 {
-			results = append(results, mcp.TextResourceContents{
-				URI:      request.Params.URI,
-				MIMEType: content.GetMimeType(),
-				Text:     "",
-			})
+func (a *Mark3LabsAdapter) registerPromptWithServer(prompt mcpintf.Prompt) {
+	options := []mcp.PromptOption{mcp.WithPromptDescription(prompt.Description())}
+	for _, argument := range prompt.Arguments() {
+		argumentOptions := []mcp.ArgumentOption{mcp.ArgumentDescription(argument.Description)}
+		if argument.Required {
+			argumentOptions = append(argumentOptions, mcp.RequiredArgument())
 		}
-
-		return results, nil
+		options = append(options, mcp.WithArgument(argument.Name, argumentOptions...))
 	}
 
-	a.mcpServer.AddResource(mcpResource, handler)
-	return nil
+	a.mcpServer.AddPrompt(mcp.NewPrompt(prompt.Name(), options...), a.pipeline.PromptHandler(prompt.Name(), prompt.Handler()))
 }
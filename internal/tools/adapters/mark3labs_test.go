@@ -0,0 +1,391 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"mcp-server/internal/logger"
+	mcpintf "mcp-server/internal/mcp"
+	"mcp-server/internal/tracing"
+)
+
+func createTestLogger(t *testing.T) *logger.Logger {
+	t.Helper()
+	log, err := logger.New(logger.Config{
+		Level:   "info",
+		Format:  "text",
+		Service: "test",
+		Version: "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	return log
+}
+
+type stubTool struct {
+	name   string
+	handle mcpintf.ToolHandlerFunc
+}
+
+func (s *stubTool) Name() string                { return s.name }
+func (s *stubTool) Description() string         { return "stub tool" }
+func (s *stubTool) Parameters() json.RawMessage { return nil }
+func (s *stubTool) Handler() mcpintf.ToolHandler {
+	return s.handle
+}
+
+// newStartedAdapter starts an adapter serving calls through pipeline
+func newStartedAdapter(t *testing.T, pipeline *mcpintf.Pipeline, tools ...mcpintf.Tool) *Mark3LabsAdapter {
+	t.Helper()
+	adapter := NewMark3LabsAdapter(nil, createTestLogger(t))
+	adapter.SetPipeline(pipeline)
+	for _, tool := range tools {
+		if err := adapter.RegisterTool(tool); err != nil {
+			t.Fatalf("failed to register tool: %v", err)
+		}
+	}
+	if err := adapter.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start adapter: %v", err)
+	}
+	t.Cleanup(func() { adapter.Stop(context.Background()) })
+	return adapter
+}
+
+// callTool sends a tools/call request to the adapter's protocol server
+func callTool(t *testing.T, adapter *Mark3LabsAdapter, id int, name string, meta map[string]any) mcp.CallToolResult {
+	t.Helper()
+	params := map[string]any{"name": name, "arguments": map[string]any{"text": "hi"}}
+	if meta != nil {
+		params["_meta"] = meta
+	}
+	message, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id, "method": "tools/call", "params": params})
+
+	adapter.mu.RLock()
+	server := adapter.mcpServer
+	adapter.mu.RUnlock()
+
+	response, ok := server.HandleMessage(context.Background(), message).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("expected a successful response to %s", message)
+	}
+	return response.Result.(mcp.CallToolResult)
+}
+
+func resultText(result mcp.CallToolResult) string {
+	if len(result.Content) == 0 {
+		return ""
+	}
+	text, _ := result.Content[0].(mcp.TextContent)
+	return text.Text
+}
+
+func TestMark3LabsAdapter_CallsRunThroughMiddlewares(t *testing.T) {
+	pipeline := mcpintf.NewPipeline(createTestLogger(t))
+
+	var composed, applied atomic.Int32
+	pipeline.Use(func(next mcpintf.ToolHandler) mcpintf.ToolHandler {
+		composed.Add(1)
+		return mcpintf.ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (mcpintf.ToolResult, error) {
+			applied.Add(1)
+			if name := mcpintf.ToolNameFromContext(ctx); name != "echo" {
+				t.Errorf("expected the tool name in the context, got %q", name)
+			}
+			return next.Handle(ctx, params)
+		})
+	})
+
+	adapter := newStartedAdapter(t, pipeline, &stubTool{
+		name: "echo",
+		handle: func(ctx context.Context, params json.RawMessage) (mcpintf.ToolResult, error) {
+			return &mcpintf.ToolResultImpl{Content: []mcpintf.Content{&mcpintf.TextContent{Text: string(params)}}}, nil
+		},
+	})
+
+	for id := range 3 {
+		result := callTool(t, adapter, id, "echo", nil)
+		if result.IsError || !strings.Contains(resultText(result), `"text":"hi"`) {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+	}
+	if applied.Load() != 3 {
+		t.Errorf("expected the middleware to run for every call, got %d", applied.Load())
+	}
+	if composed.Load() != 1 {
+		t.Errorf("expected the chain to be composed once, got %d", composed.Load())
+	}
+}
+
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []tracing.SpanData
+}
+
+func (e *recordingExporter) ExportSpans(ctx context.Context, spans []tracing.SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(ctx context.Context) error { return nil }
+
+func (e *recordingExporter) byName(name string) (tracing.SpanData, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, span := range e.spans {
+		if span.Name == name {
+			return span, true
+		}
+	}
+	return tracing.SpanData{}, false
+}
+
+func TestMark3LabsAdapter_TracesUnderClientTraceparent(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := tracing.NewTracer(exporter, tracing.Options{ServiceName: "test", FlushInterval: time.Hour})
+	tracing.SetTracer(tracer)
+	t.Cleanup(func() {
+		tracing.SetTracer(nil)
+		tracer.Shutdown(context.Background())
+	})
+
+	var inner tracing.SpanContext
+	adapter := newStartedAdapter(t, mcpintf.NewPipeline(createTestLogger(t)), &stubTool{
+		name: "traced",
+		handle: func(ctx context.Context, params json.RawMessage) (mcpintf.ToolResult, error) {
+			inner = tracing.SpanContextFromContext(ctx)
+			return &mcpintf.ToolResultImpl{}, nil
+		},
+	})
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	callTool(t, adapter, 1, "traced", map[string]any{tracing.TraceparentHeader: traceparent})
+
+	adapter.mu.RLock()
+	server := adapter.mcpServer
+	adapter.mu.RUnlock()
+	message := []byte(`{"jsonrpc":"2.0","id":2,"method":"ping","params":{"_meta":{"traceparent":"` + traceparent + `"}}}`)
+	server.HandleMessage(context.Background(), message)
+	tracer.ForceFlush(context.Background())
+
+	call, ok := exporter.byName(string(mcp.MethodToolsCall))
+	if !ok {
+		t.Fatal("expected a span for the tool call")
+	}
+	if call.SpanContext.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || call.ParentSpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("expected the tool call span under the client's traceparent, got trace %s parent %s",
+			call.SpanContext.TraceID, call.ParentSpanID)
+	}
+	if inner.SpanID != call.SpanContext.SpanID {
+		t.Error("expected the tool handler to run inside the tool call span")
+	}
+
+	ping, ok := exporter.byName(string(mcp.MethodPing))
+	if !ok {
+		t.Fatal("expected a span for ping")
+	}
+	if ping.SpanContext.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected ping under the client's trace, got %s", ping.SpanContext.TraceID)
+	}
+}
+
+type stubPrompt struct{}
+
+func (stubPrompt) Name() string        { return "greeting" }
+func (stubPrompt) Description() string { return "stub prompt" }
+func (stubPrompt) Arguments() []mcpintf.PromptArgument {
+	return []mcpintf.PromptArgument{{Name: "name", Required: true}}
+}
+func (stubPrompt) Handler() mcpintf.PromptHandler { return stubPrompt{} }
+
+func (stubPrompt) Get(ctx context.Context, arguments map[string]string) (*mcpintf.PromptResult, error) {
+	return &mcpintf.PromptResult{
+		Description: "a greeting",
+		Messages: []mcpintf.PromptMessage{
+			{Role: "user", Content: &mcpintf.TextContent{Text: "Greet " + arguments["name"]}},
+		},
+	}, nil
+}
+
+func TestMark3LabsAdapter_ServesPrompts(t *testing.T) {
+	adapter := NewMark3LabsAdapter(nil, createTestLogger(t))
+	if err := adapter.RegisterPrompt(stubPrompt{}); err != nil {
+		t.Fatalf("failed to register prompt: %v", err)
+	}
+	if err := adapter.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start adapter: %v", err)
+	}
+	t.Cleanup(func() { adapter.Stop(context.Background()) })
+
+	adapter.mu.RLock()
+	server := adapter.mcpServer
+	adapter.mu.RUnlock()
+
+	message := []byte(`{"jsonrpc": "2.0", "id": 1, "method": "prompts/get", "params": {"name": "greeting", "arguments": {"name": "Ada"}}}`)
+	response, ok := server.HandleMessage(context.Background(), message).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("expected a successful response to %s", message)
+	}
+	result := response.Result.(mcp.GetPromptResult)
+	if len(result.Messages) != 1 || result.Messages[0].Role != mcp.RoleUser {
+		t.Fatalf("unexpected prompt result %+v", result)
+	}
+	if text, _ := result.Messages[0].Content.(mcp.TextContent); text.Text != "Greet Ada" {
+		t.Errorf("expected the rendered message, got %+v", result.Messages[0].Content)
+	}
+
+	if err := adapter.UnregisterPrompt("greeting"); err != nil {
+		t.Fatalf("failed to unregister prompt: %v", err)
+	}
+	if _, ok := server.HandleMessage(context.Background(), message).(mcp.JSONRPCError); !ok {
+		t.Error("expected the withdrawn prompt to be unknown")
+	}
+}
+
+type stubResource struct {
+	read func(ctx context.Context, uri string) (mcpintf.ResourceContent, error)
+}
+
+func (s *stubResource) URI() string         { return "test://slow" }
+func (s *stubResource) Name() string        { return "slow" }
+func (s *stubResource) Description() string { return "stub resource" }
+func (s *stubResource) MimeType() string    { return "text/plain" }
+func (s *stubResource) Handler() mcpintf.ResourceHandler {
+	return s
+}
+
+func (s *stubResource) Read(ctx context.Context, uri string) (mcpintf.ResourceContent, error) {
+	return s.read(ctx, uri)
+}
+
+func TestMark3LabsAdapter_DrainWaitsForCallsAndReads(t *testing.T) {
+	pipeline := mcpintf.NewPipeline(createTestLogger(t))
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+
+	adapter := newStartedAdapter(t, pipeline, &stubTool{
+		name: "slow",
+		handle: func(ctx context.Context, params json.RawMessage) (mcpintf.ToolResult, error) {
+			started <- struct{}{}
+			<-release
+			return &mcpintf.ToolResultImpl{Content: []mcpintf.Content{&mcpintf.TextContent{Text: "done"}}}, nil
+		},
+	})
+	err := adapter.RegisterResource(&stubResource{
+		read: func(ctx context.Context, uri string) (mcpintf.ResourceContent, error) {
+			started <- struct{}{}
+			<-release
+			return &mcpintf.ResourceContentImpl{Content: []mcpintf.Content{&mcpintf.TextContent{Text: "done"}}, MimeType: "text/plain"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to register resource: %v", err)
+	}
+
+	adapter.mu.RLock()
+	server := adapter.mcpServer
+	adapter.mu.RUnlock()
+
+	responses := make(chan mcp.JSONRPCMessage, 2)
+	go func() {
+		responses <- server.HandleMessage(context.Background(),
+			[]byte(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "slow"}}`))
+	}()
+	go func() {
+		responses <- server.HandleMessage(context.Background(),
+			[]byte(`{"jsonrpc": "2.0", "id": 2, "method": "resources/read", "params": {"uri": "test://slow"}}`))
+	}()
+	for range 2 {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the call and the read did not start")
+		}
+	}
+
+	drained := make(chan error, 1)
+	go func() { drained <- pipeline.Drain(context.Background()) }()
+
+	select {
+	case err := <-drained:
+		t.Fatalf("drain returned with a call and a read in flight: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-drained:
+		if err != nil {
+			t.Errorf("drain failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("drain did not return once the call and the read completed")
+	}
+
+	for range 2 {
+		if message := <-responses; !isResponse(message) {
+			t.Errorf("expected a successful response, got %+v", message)
+		}
+	}
+}
+
+func isResponse(message mcp.JSONRPCMessage) bool {
+	_, ok := message.(mcp.JSONRPCResponse)
+	return ok
+}
+
+// BenchmarkMark3LabsAdapter_Health reads health in parallel, alone and
+// alongside a goroutine registering and withdrawing tools
+func BenchmarkMark3LabsAdapter_Health(b *testing.B) {
+	for _, churn := range []bool{false, true} {
+		name := "readers"
+		if churn {
+			name = "readers_and_writer"
+		}
+		b.Run(name, func(b *testing.B) {
+			log, _ := logger.New(logger.Config{Level: "error", Format: "text", Service: "bench", Version: "bench"})
+			adapter := NewMark3LabsAdapter(nil, log)
+			if err := adapter.Start(context.Background()); err != nil {
+				b.Fatalf("failed to start adapter: %v", err)
+			}
+			defer adapter.Stop(context.Background())
+
+			stop, done := make(chan struct{}), make(chan struct{})
+			go func() {
+				defer close(done)
+				if !churn {
+					return
+				}
+				tool := &stubTool{name: "churn"}
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					adapter.RegisterTool(tool)
+					adapter.UnregisterTool(tool.name)
+				}
+			}()
+			defer func() {
+				close(stop)
+				<-done
+			}()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					adapter.Health()
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/registry"
+)
+
+// NewToolBulkhead creates a bulkhead from tool configuration
+func NewToolBulkhead(name string, cfg config.ToolBulkheadConfig) *registry.Bulkhead {
+	return registry.NewBulkhead(name, registry.BulkheadConfig{
+		MaxConcurrent: cfg.MaxConcurrent,
+		MaxQueue:      cfg.MaxQueue,
+		QueueTimeout:  cfg.QueueTimeout,
+	})
+}
+
+// bulkheadTool holds an execution slot in each bulkhead for the duration of
+// a call. Slots are released when the handler returns, not when the caller
+// gives up, so handlers that ignore cancellation stay accounted for.
+type bulkheadTool struct {
+	mcp.Tool
+	handler *bulkheadHandler
+}
+
+type bulkheadHandler struct {
+	name      string
+	bulkheads []*registry.Bulkhead
+	next      mcp.ToolHandler
+}
+
+// WithBulkheads returns a tool whose calls take a slot in every bulkhead, in
+// order; nil bulkheads are skipped
+func WithBulkheads(tool mcp.Tool, bulkheads ...*registry.Bulkhead) mcp.Tool {
+	active := make([]*registry.Bulkhead, 0, len(bulkheads))
+	for _, bulkhead := range bulkheads {
+		if bulkhead != nil {
+			active = append(active, bulkhead)
+		}
+	}
+	if len(active) == 0 {
+		return tool
+	}
+	return &bulkheadTool{
+		Tool: tool,
+		handler: &bulkheadHandler{
+			name:      tool.Name(),
+			bulkheads: active,
+			next:      tool.Handler(),
+		},
+	}
+}
+
+func (t *bulkheadTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+// Unwrap returns the original tool
+func (t *bulkheadTool) Unwrap() mcp.Tool {
+	return t.Tool
+}
+
+func (h *bulkheadHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	for _, bulkhead := range h.bulkheads {
+		release, err := bulkhead.Acquire(ctx)
+		if err != nil {
+			return mcp.NewErrorResult(fmt.Errorf("tool %s: %w", h.name, err)), nil
+		}
+		defer release()
+	}
+	return h.next.Handle(ctx, params)
+}
@@ -0,0 +1,166 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/registry"
+)
+
+// blockingTool holds every call until release is closed
+func blockingTool(name string, started chan<- struct{}, release <-chan struct{}) *mockTool {
+	return &mockTool{
+		name:        name,
+		description: "Blocking tool",
+		parameters:  json.RawMessage(`{"type": "object"}`),
+		handler: mcp.ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+			started <- struct{}{}
+			<-release
+			return &mcp.ToolResultImpl{}, nil
+		}),
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWithBulkheads_QueueAndReject(t *testing.T) {
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	bulkhead := registry.NewBulkhead("slow", registry.BulkheadConfig{MaxConcurrent: 1, MaxQueue: 1, QueueTimeout: time.Second})
+	tool := WithBulkheads(blockingTool("slow", started, release), bulkhead)
+
+	done := make(chan mcp.ToolResult, 2)
+	call := func() {
+		result, _ := tool.Handler().Handle(context.Background(), nil)
+		done <- result
+	}
+
+	go call()
+	<-started
+	// Every slot in use with nobody waiting is normal operation
+	if stats := bulkhead.Stats(); stats.InFlight != 1 || stats.Saturated {
+		t.Errorf("expected a busy but unsaturated bulkhead, got %+v", stats)
+	}
+
+	go call()
+	waitFor(t, func() bool { return bulkhead.Stats().Queued == 1 })
+
+	stats := bulkhead.Stats()
+	if stats.InFlight != 1 || !stats.Saturated {
+		t.Errorf("expected saturated bulkhead with 1 in flight, got %+v", stats)
+	}
+
+	result, err := tool.Handler().Handle(context.Background(), nil)
+	if err != nil || !result.IsError() || !errors.Is(result.GetError(), registry.ErrBulkheadFull) {
+		t.Fatalf("expected ErrBulkheadFull result, got result=%v err=%v", result, err)
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if result := <-done; result.IsError() {
+			t.Errorf("expected queued call to run, got %v", result.GetError())
+		}
+	}
+
+	// The recent rejection keeps the bulkhead saturated after the queue
+	// drained
+	stats = bulkhead.Stats()
+	if stats.InFlight != 0 || stats.Queued != 0 || stats.Rejected != 1 || !stats.Saturated {
+		t.Errorf("unexpected final stats %+v", stats)
+	}
+}
+
+func TestWithBulkheads_QueueTimeout(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	bulkhead := registry.NewBulkhead("slow", registry.BulkheadConfig{MaxConcurrent: 1, MaxQueue: 4, QueueTimeout: 20 * time.Millisecond})
+	tool := WithBulkheads(blockingTool("slow", started, release), bulkhead)
+
+	go tool.Handler().Handle(context.Background(), nil)
+	<-started
+
+	result, _ := tool.Handler().Handle(context.Background(), nil)
+	if !result.IsError() || !errors.Is(result.GetError(), registry.ErrBulkheadTimeout) {
+		t.Fatalf("expected ErrBulkheadTimeout result, got %v", result.GetError())
+	}
+	if stats := bulkhead.Stats(); stats.TimedOut != 1 {
+		t.Errorf("expected 1 timed out call, got %+v", stats)
+	}
+}
+
+func TestDefaultToolRegistry_GlobalBulkhead(t *testing.T) {
+	bulkhead := config.ToolBulkheadConfig{Enabled: true, MaxConcurrent: 1, MaxQueue: 0, QueueTimeout: time.Second}
+	cfg := &config.Config{
+		MCP: config.MCPConfig{MaxTools: 100},
+		Tools: config.ToolsConfig{
+			Bulkhead:       config.ToolBulkheadConfig{Enabled: true, MaxConcurrent: 4, MaxQueue: 4, QueueTimeout: time.Second},
+			GlobalBulkhead: bulkhead,
+		},
+	}
+	log, _ := logger.NewDefault()
+	reg := NewDefaultToolRegistry(cfg, log)
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	slowFactory := &handlerToolFactory{mockToolFactory: *createTestFactory("slow").(*mockToolFactory), tool: blockingTool("slow", started, release)}
+	calls := 0
+	fastFactory := &handlerToolFactory{mockToolFactory: *createTestFactory("fast").(*mockToolFactory), tool: countingTool("fast", &calls)}
+	if err := reg.Register("slow", slowFactory); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if err := reg.Register("fast", fastFactory); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	slow, _ := reg.Get("slow")
+	fast, _ := reg.Get("fast")
+
+	done := make(chan struct{})
+	go func() {
+		slow.Handler().Handle(context.Background(), json.RawMessage(`{}`))
+		close(done)
+	}()
+	<-started
+
+	result, _ := fast.Handler().Handle(context.Background(), json.RawMessage(`{}`))
+	if !result.IsError() || !errors.Is(result.GetError(), registry.ErrBulkheadFull) {
+		t.Fatalf("expected global bulkhead to reject, got %v", result.GetError())
+	}
+
+	health := reg.Health()
+	if health.GlobalBulkhead == nil || !health.GlobalBulkhead.Saturated {
+		t.Errorf("expected saturated global bulkhead in health, got %+v", health.GlobalBulkhead)
+	}
+	if health.Bulkheads["slow"].InFlight != 1 {
+		t.Errorf("expected slow tool to have 1 in flight, got %+v", health.Bulkheads["slow"])
+	}
+	if len(health.Errors) == 0 || health.Errors[len(health.Errors)-1] != "global tool bulkhead saturated" {
+		t.Errorf("expected saturation reported in health errors, got %v", health.Errors)
+	}
+
+	snapshot, _ := reg.Metrics().Snapshot("fast")
+	if snapshot.Rejected != 1 {
+		t.Errorf("expected bulkhead rejection counted as rejected, got %+v", snapshot)
+	}
+
+	close(release)
+	<-done
+	if result, _ := fast.Handler().Handle(context.Background(), json.RawMessage(`{}`)); result.IsError() {
+		t.Errorf("expected call to succeed once slots free up, got %v", result.GetError())
+	}
+}
@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"mcp-server/internal/cache"
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/tracing"
+)
+
+// ToolResultCache holds successful results of one tool keyed by the tool
+// version and its canonicalized arguments. It outlives tool instances and is
+// purged when the tool restarts.
+type ToolResultCache struct {
+	name    string
+	version string
+	entries *cache.LRU[mcp.ToolResult]
+}
+
+// NewToolResultCache creates an empty result cache from tool configuration
+func NewToolResultCache(name, version string, cfg config.ToolCacheConfig) *ToolResultCache {
+	return &ToolResultCache{
+		name:    name,
+		version: version,
+		entries: cache.New[mcp.ToolResult](cache.Options{
+			TTL:        cfg.TTL,
+			MaxEntries: cfg.MaxEntries,
+		}),
+	}
+}
+
+// Purge drops every cached result
+func (c *ToolResultCache) Purge() {
+	c.entries.Purge()
+}
+
+// Stats returns hit, miss and occupancy counters
+func (c *ToolResultCache) Stats() cache.Stats {
+	return c.entries.Stats()
+}
+
+// key identifies a call; ok is false when the arguments are not valid JSON
+func (c *ToolResultCache) key(params json.RawMessage) (string, bool) {
+	canonical, err := canonicalJSON(params)
+	if err != nil {
+		return "", false
+	}
+	return c.name + "@" + c.version + "\x00" + canonical, true
+}
+
+// canonicalJSON re-encodes a JSON document with object keys sorted and
+// insignificant whitespace removed, so equivalent arguments share a key
+func canonicalJSON(data json.RawMessage) (string, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return "null", nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// cachedTool serves repeated calls from a result cache. Only successful
+// results are stored; callers can skip the cache with WithCacheBypass.
+type cachedTool struct {
+	mcp.Tool
+	handler *cachedHandler
+}
+
+type cachedHandler struct {
+	cache *ToolResultCache
+	next  mcp.ToolHandler
+}
+
+// WithResultCache returns a tool whose results are cached in c
+func WithResultCache(tool mcp.Tool, c *ToolResultCache) mcp.Tool {
+	return &cachedTool{
+		Tool: tool,
+		handler: &cachedHandler{
+			cache: c,
+			next:  tool.Handler(),
+		},
+	}
+}
+
+func (t *cachedTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+// Unwrap returns the original tool
+func (t *cachedTool) Unwrap() mcp.Tool {
+	return t.Tool
+}
+
+func (h *cachedHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	if mcp.CacheBypassFromContext(ctx) {
+		return h.next.Handle(ctx, params)
+	}
+	key, ok := h.cache.key(params)
+	if !ok {
+		return h.next.Handle(ctx, params)
+	}
+
+	_, span := tracing.Start(ctx, "tool.cache.lookup",
+		tracing.WithAttributes(tracing.String("mcp.tool.name", h.cache.name)))
+	cached, hit := h.cache.entries.Get(key)
+	span.SetAttributes(tracing.Bool("mcp.cache.hit", hit))
+	span.End()
+	if hit {
+		return cached, nil
+	}
+
+	result, err := h.next.Handle(ctx, params)
+	if err == nil && result != nil && !result.IsError() {
+		h.cache.entries.Set(key, result, 0)
+	}
+	return result, err
+}
@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/registry"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	a, err := canonicalJSON(json.RawMessage(`{"b": [1, 2.50], "a": {"y": true, "x": null}}`))
+	if err != nil {
+		t.Fatalf("canonicalize failed: %v", err)
+	}
+	b, _ := canonicalJSON(json.RawMessage(`{"a":{"x":null,"y":true},"b":[1,2.50]}`))
+	if a != b {
+		t.Errorf("expected equivalent documents to match: %s != %s", a, b)
+	}
+	if a != `{"a":{"x":null,"y":true},"b":[1,2.50]}` {
+		t.Errorf("unexpected canonical form %s", a)
+	}
+
+	if _, err := canonicalJSON(json.RawMessage(`{"a":`)); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+}
+
+func TestWithResultCache(t *testing.T) {
+	calls := 0
+	resultCache := NewToolResultCache("lookup", "1.0.0", config.ToolCacheConfig{Enabled: true, TTL: time.Minute, MaxEntries: 10})
+	tool := WithResultCache(countingTool("lookup", &calls), resultCache)
+
+	ctx := context.Background()
+	tool.Handler().Handle(ctx, json.RawMessage(`{"q": "go", "limit": 5}`))
+	tool.Handler().Handle(ctx, json.RawMessage(`{"limit":5,"q":"go"}`))
+	if calls != 1 {
+		t.Errorf("expected reordered arguments to hit the cache, got %d calls", calls)
+	}
+
+	tool.Handler().Handle(ctx, json.RawMessage(`{"q": "rust", "limit": 5}`))
+	if calls != 2 {
+		t.Errorf("expected different arguments to miss, got %d calls", calls)
+	}
+
+	tool.Handler().Handle(mcp.WithCacheBypass(ctx), json.RawMessage(`{"q": "go", "limit": 5}`))
+	if calls != 3 {
+		t.Errorf("expected bypass to call the tool, got %d calls", calls)
+	}
+
+	stats := resultCache.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Entries != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestWithResultCache_SkipsErrors(t *testing.T) {
+	calls := 0
+	resultCache := NewToolResultCache("flaky", "1.0.0", config.ToolCacheConfig{Enabled: true, TTL: time.Minute, MaxEntries: 10})
+	tool := WithResultCache(failingTool("flaky", &calls), resultCache)
+
+	for i := 0; i < 2; i++ {
+		tool.Handler().Handle(context.Background(), json.RawMessage(`{}`))
+	}
+	if calls != 2 {
+		t.Errorf("expected failed results not to be cached, got %d calls", calls)
+	}
+	if stats := resultCache.Stats(); stats.Entries != 0 {
+		t.Errorf("expected empty cache, got %+v", stats)
+	}
+}
+
+func TestDefaultToolRegistry_ResultCache(t *testing.T) {
+	cached := config.ToolCacheConfig{Enabled: true, TTL: time.Minute, MaxEntries: 10}
+	cfg := &config.Config{
+		MCP: config.MCPConfig{MaxTools: 100},
+		Tools: config.ToolsConfig{
+			Overrides: map[string]config.ToolOverrideConfig{
+				"lookup": {Cache: &cached},
+			},
+		},
+	}
+	log, _ := logger.NewDefault()
+	reg := NewDefaultToolRegistry(cfg, log).(*DefaultToolRegistry)
+
+	calls := 0
+	factory := &handlerToolFactory{mockToolFactory: *createTestFactory("lookup").(*mockToolFactory), tool: countingTool("lookup", &calls)}
+	if err := reg.Register("lookup", factory); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if err := reg.Register("plain", createTestFactory("plain")); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if err := reg.Start(context.Background()); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	tool, err := reg.Get("lookup")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		tool.Handler().Handle(context.Background(), json.RawMessage(`{"id": 7}`))
+	}
+	if calls != 1 {
+		t.Errorf("expected repeated calls to be served from cache, got %d calls", calls)
+	}
+
+	health := reg.Health()
+	if stats := health.ResultCaches["lookup"]; stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("unexpected cache stats: %+v", stats)
+	}
+	if _, exists := health.ResultCaches["plain"]; exists {
+		t.Error("expected no cache for a tool that did not opt in")
+	}
+
+	snapshot, _ := reg.Metrics().Snapshot("lookup")
+	if snapshot.Success != 3 {
+		t.Errorf("expected cache hits to be recorded as calls, got %d successes", snapshot.Success)
+	}
+
+	reg.mu.Lock()
+	reg.transitionToError("lookup", registry.SystemCause("test"))
+	reg.mu.Unlock()
+	if err := reg.RestartTool(context.Background(), "lookup"); err != nil {
+		t.Fatalf("restart failed: %v", err)
+	}
+
+	tool, _ = reg.Get("lookup")
+	tool.Handler().Handle(context.Background(), json.RawMessage(`{"id": 7}`))
+	if calls != 2 {
+		t.Errorf("expected restart to invalidate cached results, got %d calls", calls)
+	}
+}
@@ -0,0 +1,228 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+)
+
+const (
+	// maxErrorOutput caps how much stderr is quoted in a failed call's error
+	maxErrorOutput = 1024
+	// maxOutputBytes caps how much stdout a call collects
+	maxOutputBytes = 10 << 20
+)
+
+// errOutputTooLarge stops collecting the output of a command exceeding
+// maxOutputBytes
+var errOutputTooLarge = fmt.Errorf("command output exceeds %d bytes", maxOutputBytes)
+
+// command runs one declared executable. Each argument is rendered from its
+// template separately and passed to the process as is, so call arguments
+// cannot inject shell syntax or extra arguments.
+type command struct {
+	path       string
+	args       []*template.Template
+	properties []string
+	dir        string
+	env        []string
+	timeout    time.Duration
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+}
+
+// parseArgs parses the argument templates of a declaration
+func parseArgs(args []string) ([]*template.Template, error) {
+	templates := make([]*template.Template, 0, len(args))
+	for i, arg := range args {
+		tmpl, err := template.New(fmt.Sprintf("arg%d", i)).
+			Option("missingkey=error").
+			Funcs(templateFuncs).
+			Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument template %q: %w", arg, err)
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+// commandPath resolves a relative command path, like ./bin/report, against
+// the working directory the command runs in, so the load-time check looks at
+// the file that will run. The result is absolute, as the process starts in
+// the working directory already. Bare names are left to the PATH lookup.
+func commandPath(cfg config.ExecToolConfig) string {
+	if cfg.WorkingDir == "" || filepath.IsAbs(cfg.Command) || !strings.ContainsRune(cfg.Command, '/') {
+		return cfg.Command
+	}
+	path := filepath.Join(cfg.WorkingDir, cfg.Command)
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+func newCommand(cfg config.ExecToolConfig, args []*template.Template, properties []string) *command {
+	return &command{
+		path:       commandPath(cfg),
+		args:       args,
+		properties: properties,
+		dir:        cfg.WorkingDir,
+		env:        cfg.Env,
+		timeout:    cfg.Timeout,
+	}
+}
+
+// render builds the argument list for a call. Properties declared in the
+// input schema but absent from the call render as empty strings, and
+// arguments rendering to an empty string are dropped, so optional flags can
+// be written as {{if .verbose}}--verbose{{end}}.
+func (c *command) render(arguments map[string]interface{}) ([]string, error) {
+	data := make(map[string]interface{}, len(arguments)+len(c.properties))
+	for _, property := range c.properties {
+		data[property] = ""
+	}
+	for name, value := range arguments {
+		data[name] = value
+	}
+
+	argv := make([]string, 0, len(c.args))
+	for _, tmpl := range c.args {
+		var arg strings.Builder
+		if err := tmpl.Execute(&arg, data); err != nil {
+			return nil, fmt.Errorf("failed to render arguments: %w", err)
+		}
+		if arg.Len() > 0 {
+			argv = append(argv, arg.String())
+		}
+	}
+	return argv, nil
+}
+
+// environ returns the allowlisted variables of the server's environment
+func (c *command) environ() []string {
+	env := make([]string, 0, len(c.env))
+	for _, key := range c.env {
+		if value, exists := os.LookupEnv(key); exists {
+			env = append(env, key+"="+value)
+		}
+	}
+	return env
+}
+
+// limitedBuffer collects output up to limit bytes and fails the write that
+// exceeds it, which closes the pipe to the command. The buffer is not
+// embedded, so io.Copy cannot bypass Write through its ReadFrom.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	limit    int
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.buf.Len(); len(p) > remaining {
+		b.buf.Write(p[:remaining])
+		b.exceeded = true
+		return remaining, errOutputTooLarge
+	}
+	return b.buf.Write(p)
+}
+
+// run executes the command and returns its stdout. A non-zero exit status
+// or an expired timeout is an error quoting the command's stderr, and stdout
+// beyond maxOutputBytes fails the call.
+func (c *command) run(ctx context.Context, argv []string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	stdout := limitedBuffer{limit: maxOutputBytes}
+	var stderr bytes.Buffer
+	cmd := osexec.CommandContext(ctx, c.path, argv...)
+	cmd.Dir = c.dir
+	cmd.Env = c.environ()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Children that inherited the pipes must not hold the call open
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("command timed out after %v", c.timeout)
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	// The command may have died writing to the closed pipe
+	if stdout.exceeded {
+		return nil, errOutputTooLarge
+	}
+
+	var exitErr *osexec.ExitError
+	if errors.As(err, &exitErr) {
+		message := fmt.Sprintf("command exited with status %d", exitErr.ExitCode())
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			if len(output) > maxErrorOutput {
+				output = output[len(output)-maxErrorOutput:]
+			}
+			message += ": " + output
+		}
+		return nil, errors.New(message)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to run command: %w", err)
+	}
+	return stdout.buf.Bytes(), nil
+}
+
+// outputItem is one content item of json output
+type outputItem struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+	Data string `json:"data,omitempty"` // base64, for blob items
+}
+
+// parseOutput converts stdout into result content according to the output mode
+func parseOutput(mode string, stdout []byte) ([]mcp.Content, error) {
+	if mode != config.ExecOutputJSON {
+		return []mcp.Content{&mcp.TextContent{Text: string(stdout)}}, nil
+	}
+
+	var items []outputItem
+	if err := json.Unmarshal(stdout, &items); err != nil {
+		return nil, fmt.Errorf("command output is not a JSON content array: %w", err)
+	}
+
+	content := make([]mcp.Content, 0, len(items))
+	for i, item := range items {
+		switch item.Type {
+		case "text":
+			content = append(content, &mcp.TextContent{Text: item.Text})
+		case "blob":
+			data, err := base64.StdEncoding.DecodeString(item.Data)
+			if err != nil {
+				return nil, fmt.Errorf("command output item %d has invalid base64 data: %w", i, err)
+			}
+			content = append(content, &mcp.BlobContent{Data: data})
+		default:
+			return nil, fmt.Errorf("command output item %d has unsupported type %q (expected text or blob)", i, item.Type)
+		}
+	}
+	return content, nil
+}
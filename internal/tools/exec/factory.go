@@ -0,0 +1,105 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/tools"
+)
+
+// defaultInputSchema accepts any arguments when a declaration has no schema
+const defaultInputSchema = `{"type": "object", "properties": {}}`
+
+// ExecFactory creates tools declared in configuration that run an external
+// executable, so existing CLI scripts can be exposed without a Go package
+// per tool
+type ExecFactory struct {
+	config  config.ExecToolConfig
+	command *command
+}
+
+func NewExecFactory(cfg config.ExecToolConfig) (*ExecFactory, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("exec tool name cannot be empty")
+	}
+	if cfg.Command == "" {
+		return nil, fmt.Errorf("exec tool %s: command cannot be empty", cfg.Name)
+	}
+	if cfg.Version == "" {
+		cfg.Version = config.DefaultExecToolVersion
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = config.DefaultExecToolTimeout
+	}
+	if cfg.Output == "" {
+		cfg.Output = config.DefaultExecToolOutput
+	}
+	if cfg.Output != config.ExecOutputText && cfg.Output != config.ExecOutputJSON {
+		return nil, fmt.Errorf("exec tool %s: unsupported output mode %q", cfg.Name, cfg.Output)
+	}
+	if len(cfg.InputSchema) == 0 {
+		cfg.InputSchema = json.RawMessage(defaultInputSchema)
+	}
+
+	schema, err := tools.CompileArgumentSchema(cfg.InputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("exec tool %s: %w", cfg.Name, err)
+	}
+	args, err := parseArgs(cfg.Args)
+	if err != nil {
+		return nil, fmt.Errorf("exec tool %s: %w", cfg.Name, err)
+	}
+
+	return &ExecFactory{
+		config:  cfg,
+		command: newCommand(cfg, args, schema.Properties()),
+	}, nil
+}
+
+func (f *ExecFactory) GetName() string {
+	return f.config.Name
+}
+
+func (f *ExecFactory) GetDescription() string {
+	return f.config.Description
+}
+
+func (f *ExecFactory) GetVersion() string {
+	return f.config.Version
+}
+
+func (f *ExecFactory) GetCapabilities() []string {
+	return []string{"subprocess"}
+}
+
+// Requirements declares the executable, so the tool is refused at load time
+// when it is not on PATH or, for a relative path, in the working directory
+func (f *ExecFactory) Requirements() map[string]string {
+	return map[string]string{
+		"runtime": "exec",
+		tools.RequirementExecutable + ":" + f.command.path: "",
+	}
+}
+
+func (f *ExecFactory) Create(ctx context.Context, config tools.ToolConfig) (mcp.Tool, error) {
+	if !config.Enabled {
+		return nil, fmt.Errorf("exec tool %s is disabled in configuration", f.config.Name)
+	}
+
+	return newExecTool(f.config, f.command), nil
+}
+
+func (f *ExecFactory) Validate(config tools.ToolConfig) error {
+	if config.Timeout < 0 {
+		return fmt.Errorf("invalid timeout value: %d (must be non-negative)", config.Timeout)
+	}
+
+	if config.MaxRetries < 0 {
+		return fmt.Errorf("invalid max retries value: %d (must be non-negative)", config.MaxRetries)
+	}
+
+	return nil
+}
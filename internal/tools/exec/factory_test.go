@@ -0,0 +1,97 @@
+package exec
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/tools"
+)
+
+func TestNewExecFactory(t *testing.T) {
+	factory, err := NewExecFactory(config.ExecToolConfig{
+		Name:        "word_count",
+		Description: "Count words",
+		Command:     "wc",
+		Args:        []string{"-w"},
+	})
+	if err != nil {
+		t.Fatalf("NewExecFactory() unexpected error: %v", err)
+	}
+
+	if factory.GetName() != "word_count" {
+		t.Errorf("GetName() = %q, expected %q", factory.GetName(), "word_count")
+	}
+	if factory.GetVersion() != config.DefaultExecToolVersion {
+		t.Errorf("GetVersion() = %q, expected %q", factory.GetVersion(), config.DefaultExecToolVersion)
+	}
+	if factory.config.Timeout != config.DefaultExecToolTimeout {
+		t.Errorf("expected default timeout %v, got %v", config.DefaultExecToolTimeout, factory.config.Timeout)
+	}
+
+	requirements, err := tools.ParseRequirements(factory.Requirements())
+	if err != nil {
+		t.Fatalf("Requirements() are not parseable: %v", err)
+	}
+	if len(requirements) != 1 || requirements[0] != (tools.Requirement{Kind: tools.RequirementExecutable, Target: "wc"}) {
+		t.Errorf("expected the command to be required on PATH, got %v", requirements)
+	}
+}
+
+func TestNewExecFactory_Invalid(t *testing.T) {
+	valid := config.ExecToolConfig{
+		Name:        "word_count",
+		Description: "Count words",
+		Command:     "wc",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(cfg *config.ExecToolConfig)
+		errMsg string
+	}{
+		{"missing name", func(cfg *config.ExecToolConfig) { cfg.Name = "" }, "name cannot be empty"},
+		{"missing command", func(cfg *config.ExecToolConfig) { cfg.Command = "" }, "command cannot be empty"},
+		{"unknown output", func(cfg *config.ExecToolConfig) { cfg.Output = "xml" }, "unsupported output mode"},
+		{"bad template", func(cfg *config.ExecToolConfig) { cfg.Args = []string{"{{.text"} }, "invalid argument template"},
+		{"bad schema", func(cfg *config.ExecToolConfig) { cfg.InputSchema = []byte(`{"type": 1}`) }, "word_count"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			_, err := NewExecFactory(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("NewExecFactory() error = %v, expected it to contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestExecFactory_Create(t *testing.T) {
+	factory, err := NewExecFactory(config.ExecToolConfig{
+		Name:        "word_count",
+		Description: "Count words",
+		Command:     "wc",
+	})
+	if err != nil {
+		t.Fatalf("NewExecFactory() unexpected error: %v", err)
+	}
+
+	tool, err := factory.Create(context.Background(), tools.ToolConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if tool.Name() != "word_count" || tool.Description() != "Count words" {
+		t.Errorf("unexpected tool %q: %q", tool.Name(), tool.Description())
+	}
+	if string(tool.Parameters()) != defaultInputSchema {
+		t.Errorf("expected default input schema, got %s", tool.Parameters())
+	}
+
+	if _, err := factory.Create(context.Background(), tools.ToolConfig{Enabled: false}); err == nil {
+		t.Error("Create() expected error for disabled tool")
+	}
+}
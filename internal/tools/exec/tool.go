@@ -0,0 +1,79 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+)
+
+type ExecTool struct {
+	config  config.ExecToolConfig
+	handler *ExecHandler
+}
+
+func newExecTool(cfg config.ExecToolConfig, cmd *command) *ExecTool {
+	return &ExecTool{
+		config:  cfg,
+		handler: newExecHandler(cmd, cfg.Output),
+	}
+}
+
+func (t *ExecTool) Name() string {
+	return t.config.Name
+}
+
+func (t *ExecTool) Description() string {
+	return t.config.Description
+}
+
+func (t *ExecTool) Parameters() json.RawMessage {
+	return t.config.InputSchema
+}
+
+func (t *ExecTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+type ExecHandler struct {
+	command *command
+	output  string
+}
+
+func newExecHandler(cmd *command, output string) *ExecHandler {
+	return &ExecHandler{
+		command: cmd,
+		output:  output,
+	}
+}
+
+func (h *ExecHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	arguments := make(map[string]interface{})
+	if len(bytes.TrimSpace(params)) > 0 {
+		// Numbers keep their original form when rendered into arguments
+		decoder := json.NewDecoder(bytes.NewReader(params))
+		decoder.UseNumber()
+		if err := decoder.Decode(&arguments); err != nil {
+			return &mcp.ToolResultImpl{Error: fmt.Errorf("invalid parameters: %w", err), IsErrorFlag: true}, nil
+		}
+	}
+
+	argv, err := h.command.render(arguments)
+	if err != nil {
+		return &mcp.ToolResultImpl{Error: err, IsErrorFlag: true}, nil
+	}
+
+	stdout, err := h.command.run(ctx, argv)
+	if err != nil {
+		return &mcp.ToolResultImpl{Error: err, IsErrorFlag: true}, nil
+	}
+
+	content, err := parseOutput(h.output, stdout)
+	if err != nil {
+		return &mcp.ToolResultImpl{Error: err, IsErrorFlag: true}, nil
+	}
+	return &mcp.ToolResultImpl{Content: content, IsErrorFlag: false}, nil
+}
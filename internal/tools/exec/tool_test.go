@@ -0,0 +1,273 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/tools"
+)
+
+// createScriptTool declares a tool running an inline shell script; the
+// script sees the rendered arguments as $1, $2, ...
+func createScriptTool(t *testing.T, script string, modify func(cfg *config.ExecToolConfig)) mcp.Tool {
+	t.Helper()
+
+	cfg := config.ExecToolConfig{
+		Name:        "script",
+		Description: "Run a test script",
+		Command:     "sh",
+		Args:        []string{"-c", script, "sh"},
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"message": {"type": "string"},
+				"count": {"type": "integer"},
+				"verbose": {"type": "boolean"}
+			}
+		}`),
+	}
+	if modify != nil {
+		modify(&cfg)
+	}
+
+	factory, err := NewExecFactory(cfg)
+	if err != nil {
+		t.Fatalf("NewExecFactory() unexpected error: %v", err)
+	}
+	return newExecTool(factory.config, factory.command)
+}
+
+func callTool(t *testing.T, tool mcp.Tool, params string) mcp.ToolResult {
+	t.Helper()
+
+	result, err := tool.Handler().Handle(context.Background(), json.RawMessage(params))
+	if err != nil {
+		t.Fatalf("Handle() unexpected error: %v", err)
+	}
+	return result
+}
+
+func TestExecHandler_Arguments(t *testing.T) {
+	tool := createScriptTool(t, `printf '%s|' "$@"`, func(cfg *config.ExecToolConfig) {
+		cfg.Args = append(cfg.Args, "{{.message}}", "--count={{.count}}", "{{if .verbose}}--verbose{{end}}")
+	})
+
+	tests := []struct {
+		name     string
+		params   string
+		expected string
+	}{
+		{
+			name:     "all arguments",
+			params:   `{"message": "hello; rm -rf /", "count": 3, "verbose": true}`,
+			expected: "hello; rm -rf /|--count=3|--verbose|",
+		},
+		{
+			name:     "optional arguments omitted",
+			params:   `{"message": "hi"}`,
+			expected: "hi|--count=|",
+		},
+		{
+			name:     "large number keeps its form",
+			params:   `{"message": "n", "count": 12345678901234567890}`,
+			expected: "n|--count=12345678901234567890|",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callTool(t, tool, tt.params)
+			if result.IsError() {
+				t.Fatalf("unexpected error result: %v", result.GetError())
+			}
+			if text := result.GetContent()[0].GetText(); text != tt.expected {
+				t.Errorf("output = %q, expected %q", text, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExecHandler_UndeclaredArgument(t *testing.T) {
+	tool := createScriptTool(t, `echo "$1"`, func(cfg *config.ExecToolConfig) {
+		cfg.Args = append(cfg.Args, "{{.undeclared}}")
+	})
+
+	result := callTool(t, tool, `{}`)
+	if !result.IsError() || !strings.Contains(result.GetError().Error(), "undeclared") {
+		t.Errorf("expected error result naming the undeclared argument, got %v", result.GetError())
+	}
+}
+
+func TestExecHandler_Environment(t *testing.T) {
+	t.Setenv("EXEC_TEST_ALLOWED", "visible")
+	t.Setenv("EXEC_TEST_HIDDEN", "secret")
+	tool := createScriptTool(t, `printf '%s,%s' "$EXEC_TEST_ALLOWED" "$EXEC_TEST_HIDDEN"`, func(cfg *config.ExecToolConfig) {
+		cfg.Env = []string{"EXEC_TEST_ALLOWED"}
+	})
+
+	result := callTool(t, tool, `{}`)
+	if text := result.GetContent()[0].GetText(); text != "visible," {
+		t.Errorf("expected only allowlisted variables, got %q", text)
+	}
+}
+
+func TestExecHandler_WorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	tool := createScriptTool(t, `pwd`, func(cfg *config.ExecToolConfig) {
+		cfg.WorkingDir = dir
+	})
+
+	result := callTool(t, tool, `{}`)
+	if text := strings.TrimSpace(result.GetContent()[0].GetText()); !strings.HasSuffix(text, dir) {
+		t.Errorf("expected command to run in %s, got %q", dir, text)
+	}
+}
+
+func TestExecHandler_Failures(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		modify func(cfg *config.ExecToolConfig)
+		errMsg string
+	}{
+		{
+			name:   "non-zero exit",
+			script: `echo "bad input" >&2; exit 3`,
+			errMsg: "command exited with status 3: bad input",
+		},
+		{
+			name:   "timeout",
+			script: `sleep 5`,
+			modify: func(cfg *config.ExecToolConfig) { cfg.Timeout = 50 * time.Millisecond },
+			errMsg: "command timed out after 50ms",
+		},
+		{
+			name:   "output too large",
+			script: `yes`,
+			errMsg: "command output exceeds",
+		},
+		{
+			name:   "invalid json output",
+			script: `echo "not json"`,
+			modify: func(cfg *config.ExecToolConfig) { cfg.Output = config.ExecOutputJSON },
+			errMsg: "not a JSON content array",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := createScriptTool(t, tt.script, tt.modify)
+			result := callTool(t, tool, `{}`)
+			if !result.IsError() || !strings.Contains(result.GetError().Error(), tt.errMsg) {
+				t.Errorf("expected error result containing %q, got %v", tt.errMsg, result.GetError())
+			}
+		})
+	}
+}
+
+func TestExecHandler_JSONOutput(t *testing.T) {
+	tool := createScriptTool(t, `echo '[{"type": "text", "text": "summary"}, {"type": "blob", "data": "aGk="}]'`, func(cfg *config.ExecToolConfig) {
+		cfg.Output = config.ExecOutputJSON
+	})
+
+	result := callTool(t, tool, `{}`)
+	if result.IsError() {
+		t.Fatalf("unexpected error result: %v", result.GetError())
+	}
+
+	content := result.GetContent()
+	if len(content) != 2 {
+		t.Fatalf("expected 2 content items, got %d", len(content))
+	}
+	if content[0].Type() != "text" || content[0].GetText() != "summary" {
+		t.Errorf("unexpected text item: %s %q", content[0].Type(), content[0].GetText())
+	}
+	if content[1].Type() != "blob" || string(content[1].GetBlob()) != "hi" {
+		t.Errorf("unexpected blob item: %s %q", content[1].Type(), content[1].GetBlob())
+	}
+}
+
+func TestExecFactory_RelativeCommandInWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "bin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "bin", "greet")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho hello from bin\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	factory, err := NewExecFactory(config.ExecToolConfig{
+		Name:       "greet",
+		Command:    "./bin/greet",
+		WorkingDir: dir,
+	})
+	if err != nil {
+		t.Fatalf("NewExecFactory() unexpected error: %v", err)
+	}
+
+	requirements, err := tools.ParseRequirements(factory.Requirements())
+	if err != nil {
+		t.Fatalf("Requirements() are not parseable: %v", err)
+	}
+	if len(requirements) != 1 || requirements[0].Target != script {
+		t.Fatalf("expected the command to be required in the working directory, got %v", requirements)
+	}
+	if _, err := osexec.LookPath(requirements[0].Target); err != nil {
+		t.Errorf("expected the requirement check to find the command: %v", err)
+	}
+
+	result := callTool(t, newExecTool(factory.config, factory.command), `{}`)
+	if result.IsError() {
+		t.Fatalf("expected the command to run, got %v", result.GetError())
+	}
+	if text := strings.TrimSpace(result.GetContent()[0].GetText()); text != "hello from bin" {
+		t.Errorf("unexpected output %q", text)
+	}
+}
+
+func TestExecFactory_RelativeWorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "work", "bin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "work", "bin", "greet"), []byte("#!/bin/sh\necho hello from work\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(previous) })
+
+	factory, err := NewExecFactory(config.ExecToolConfig{
+		Name:       "greet",
+		Command:    "./bin/greet",
+		WorkingDir: "work",
+	})
+	if err != nil {
+		t.Fatalf("NewExecFactory() unexpected error: %v", err)
+	}
+
+	// The process starts in the working directory, so the command must not
+	// be resolved against it a second time
+	result := callTool(t, newExecTool(factory.config, factory.command), `{}`)
+	if result.IsError() {
+		t.Fatalf("expected the command to run, got %v", result.GetError())
+	}
+	if text := strings.TrimSpace(result.GetContent()[0].GetText()); text != "hello from work" {
+		t.Errorf("unexpected output %q", text)
+	}
+}
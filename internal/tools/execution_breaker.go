@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/registry"
+)
+
+// ErrToolCircuitOpen is returned when a tool call is rejected by its execution circuit breaker
+var ErrToolCircuitOpen = errors.New("tool circuit breaker open")
+
+// ErrNoCircuitBreaker is returned when resetting the breaker of a tool that
+// has none configured
+var ErrNoCircuitBreaker = errors.New("tool has no circuit breaker")
+
+// errToolResultFailure marks error results so the breaker counts them as failures
+var errToolResultFailure = errors.New("tool returned error result")
+
+// ExecutionBreaker guards the execution of a single tool
+type ExecutionBreaker = registry.CircuitBreakerFactory[mcp.ToolResult]
+
+// NewExecutionBreaker creates an execution circuit breaker from tool configuration
+func NewExecutionBreaker(name string, cfg config.ToolCircuitBreakerConfig, onStateChange func(name, from, to string)) *ExecutionBreaker {
+	return registry.NewExecutionCircuitBreaker[mcp.ToolResult](name, registry.CircuitBreakerConfig{
+		MaxRequests:   cfg.HalfOpenRequests,
+		Interval:      cfg.Interval,
+		Timeout:       cfg.OpenTimeout,
+		MinRequests:   cfg.MinRequests,
+		FailureRatio:  cfg.FailureRatio,
+		OnStateChange: onStateChange,
+	})
+}
+
+// breakerTool routes a tool's handler through its execution circuit breaker
+type breakerTool struct {
+	mcp.Tool
+	handler *breakerHandler
+}
+
+type breakerHandler struct {
+	name    string
+	breaker *ExecutionBreaker
+	next    mcp.ToolHandler
+}
+
+// WithExecutionBreaker returns a tool whose calls fail fast while the breaker is open
+func WithExecutionBreaker(tool mcp.Tool, breaker *ExecutionBreaker) mcp.Tool {
+	return &breakerTool{
+		Tool: tool,
+		handler: &breakerHandler{
+			name:    tool.Name(),
+			breaker: breaker,
+			next:    tool.Handler(),
+		},
+	}
+}
+
+func (t *breakerTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+// Unwrap returns the original tool
+func (t *breakerTool) Unwrap() mcp.Tool {
+	return t.Tool
+}
+
+func (h *breakerHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	result, err := h.breaker.Call(func() (mcp.ToolResult, error) {
+		result, err := h.next.Handle(ctx, params)
+		if err == nil && result != nil && result.IsError() {
+			return result, errToolResultFailure
+		}
+		return result, err
+	})
+
+	switch {
+	case errors.Is(err, registry.ErrCircuitOpen):
+		return &mcp.ToolResultImpl{
+			Error:       h.openError(),
+			IsErrorFlag: true,
+		}, nil
+	case errors.Is(err, errToolResultFailure):
+		return result, nil
+	default:
+		return result, err
+	}
+}
+
+func (h *breakerHandler) openError() error {
+	retryAfter := h.breaker.RetryAfter().Round(time.Second)
+	if retryAfter > 0 {
+		return fmt.Errorf("%w: tool %s is temporarily unavailable after repeated failures; retry in %v",
+			ErrToolCircuitOpen, h.name, retryAfter)
+	}
+	return fmt.Errorf("%w: tool %s is temporarily unavailable after repeated failures; retry shortly",
+		ErrToolCircuitOpen, h.name)
+}
@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/mcp"
+)
+
+func failingTool(name string, calls *int) *mockTool {
+	return &mockTool{
+		name:        name,
+		description: "Failing tool",
+		parameters:  json.RawMessage(`{"type": "object"}`),
+		handler: mcp.ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+			*calls++
+			return nil, errors.New("backend unavailable")
+		}),
+	}
+}
+
+func testBreakerConfig() config.ToolCircuitBreakerConfig {
+	return config.ToolCircuitBreakerConfig{
+		Enabled:          true,
+		MinRequests:      2,
+		FailureRatio:     0.5,
+		OpenTimeout:      time.Minute,
+		HalfOpenRequests: 1,
+	}
+}
+
+func TestExecutionBreaker_OpensAndFailsFast(t *testing.T) {
+	calls := 0
+	breaker := NewExecutionBreaker("flaky", testBreakerConfig(), nil)
+	tool := WithExecutionBreaker(failingTool("flaky", &calls), breaker)
+
+	for i := 0; i < 2; i++ {
+		if _, err := tool.Handler().Handle(context.Background(), nil); err == nil {
+			t.Fatal("expected handler error while breaker is closed")
+		}
+	}
+
+	if breaker.Status() != "open" {
+		t.Fatalf("expected breaker to be open, got %s", breaker.Status())
+	}
+
+	result, err := tool.Handler().Handle(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected fail-fast error result, got error: %v", err)
+	}
+	if !result.IsError() || !errors.Is(result.GetError(), ErrToolCircuitOpen) {
+		t.Errorf("expected ErrToolCircuitOpen result, got %v", result.GetError())
+	}
+	if calls != 2 {
+		t.Errorf("expected handler to be skipped while open, got %d calls", calls)
+	}
+
+	breaker.Reset()
+	if breaker.Status() != "closed" {
+		t.Errorf("expected breaker to be closed after reset, got %s", breaker.Status())
+	}
+}
+
+func TestExecutionBreaker_ErrorResultsCountAsFailures(t *testing.T) {
+	tool := &mockTool{
+		name:        "erroring",
+		description: "Erroring tool",
+		handler: mcp.ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+			return &mcp.ToolResultImpl{Error: errors.New("bad"), IsErrorFlag: true}, nil
+		}),
+	}
+	breaker := NewExecutionBreaker("erroring", testBreakerConfig(), nil)
+	wrapped := WithExecutionBreaker(tool, breaker)
+
+	for i := 0; i < 2; i++ {
+		result, err := wrapped.Handler().Handle(context.Background(), nil)
+		if err != nil || !result.IsError() {
+			t.Fatalf("expected original error result, got result=%v err=%v", result, err)
+		}
+	}
+
+	if breaker.Status() != "open" {
+		t.Errorf("expected breaker to open on error results, got %s", breaker.Status())
+	}
+}
+
+func TestDefaultToolRegistry_ExecutionBreakerLifecycle(t *testing.T) {
+	cfg := &config.Config{
+		MCP: config.MCPConfig{MaxTools: 100},
+		Tools: config.ToolsConfig{
+			Overrides: map[string]config.ToolOverrideConfig{
+				"flaky": {CircuitBreaker: func() *config.ToolCircuitBreakerConfig { c := testBreakerConfig(); return &c }()},
+			},
+		},
+	}
+	log, _ := logger.NewDefault()
+	registry := NewDefaultToolRegistry(cfg, log)
+
+	calls := 0
+	factory := &handlerToolFactory{mockToolFactory: *createTestFactory("flaky").(*mockToolFactory), tool: failingTool("flaky", &calls)}
+	if err := registry.Register("flaky", factory); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if err := registry.Register("steady", createTestFactory("steady")); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	health := registry.Health()
+	if health.CircuitBreakers["flaky"] != "closed" {
+		t.Errorf("expected closed breaker for flaky, got %q", health.CircuitBreakers["flaky"])
+	}
+	if _, exists := health.CircuitBreakers["steady"]; exists {
+		t.Error("expected no breaker for tool without breaker configuration")
+	}
+
+	tool, err := registry.Get("flaky")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		tool.Handler().Handle(context.Background(), json.RawMessage(`{}`))
+	}
+
+	if registry.Health().CircuitBreakers["flaky"] != "open" {
+		t.Fatalf("expected breaker to be open in health")
+	}
+
+	if err := registry.ResetCircuitBreaker("flaky"); err != nil {
+		t.Fatalf("reset failed: %v", err)
+	}
+	if registry.Health().CircuitBreakers["flaky"] != "closed" {
+		t.Error("expected breaker to be closed after reset")
+	}
+
+	if err := registry.ResetCircuitBreaker("missing"); !errors.Is(err, ErrToolNotFound) {
+		t.Errorf("expected ErrToolNotFound, got %v", err)
+	}
+	if err := registry.ResetCircuitBreaker("steady"); !errors.Is(err, ErrNoCircuitBreaker) || errors.Is(err, ErrToolNotFound) {
+		t.Errorf("expected ErrNoCircuitBreaker for a tool without breaker, got %v", err)
+	}
+}
+
+type handlerToolFactory struct {
+	mockToolFactory
+	tool mcp.Tool
+}
+
+func (f *handlerToolFactory) Create(ctx context.Context, config ToolConfig) (mcp.Tool, error) {
+	return f.tool, nil
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/tools"
+)
+
+// defaultInputSchema accepts any arguments when a declaration has no schema
+const defaultInputSchema = `{"type": "object", "properties": {}}`
+
+// HTTPFactory creates tools declared in configuration that call a REST
+// endpoint, so internal services can be exposed without a Go package per
+// tool
+type HTTPFactory struct {
+	config  config.HTTPToolConfig
+	request *request
+}
+
+func NewHTTPFactory(cfg config.HTTPToolConfig) (*HTTPFactory, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("http tool name cannot be empty")
+	}
+	if cfg.Version == "" {
+		cfg.Version = config.DefaultHTTPToolVersion
+	}
+	if cfg.Method == "" {
+		cfg.Method = config.DefaultHTTPToolMethod
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = config.DefaultHTTPToolTimeout
+	}
+	if cfg.ResponsePointer != "" && cfg.ResponsePointer[0] != '/' {
+		return nil, fmt.Errorf("http tool %s: response pointer %q must start with /", cfg.Name, cfg.ResponsePointer)
+	}
+	if len(cfg.InputSchema) == 0 {
+		cfg.InputSchema = json.RawMessage(defaultInputSchema)
+	}
+
+	schema, err := tools.CompileArgumentSchema(cfg.InputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("http tool %s: %w", cfg.Name, err)
+	}
+	req, err := newRequest(cfg, schema.Properties())
+	if err != nil {
+		return nil, fmt.Errorf("http tool %s: %w", cfg.Name, err)
+	}
+
+	return &HTTPFactory{
+		config:  cfg,
+		request: req,
+	}, nil
+}
+
+func (f *HTTPFactory) GetName() string {
+	return f.config.Name
+}
+
+func (f *HTTPFactory) GetDescription() string {
+	return f.config.Description
+}
+
+func (f *HTTPFactory) GetVersion() string {
+	return f.config.Version
+}
+
+func (f *HTTPFactory) GetCapabilities() []string {
+	return []string{"http"}
+}
+
+// Requirements declares the secrets the headers reference, so the tool is
+// refused at load time when they are not set
+func (f *HTTPFactory) Requirements() map[string]string {
+	requirements := map[string]string{
+		"runtime": "http",
+	}
+	for _, name := range secretNames(f.config.Headers) {
+		requirements[tools.RequirementEnv+":"+name] = "secret referenced by a header"
+	}
+	return requirements
+}
+
+func (f *HTTPFactory) Create(ctx context.Context, config tools.ToolConfig) (mcp.Tool, error) {
+	if !config.Enabled {
+		return nil, fmt.Errorf("http tool %s is disabled in configuration", f.config.Name)
+	}
+
+	return newHTTPTool(f.config, f.request), nil
+}
+
+func (f *HTTPFactory) Validate(config tools.ToolConfig) error {
+	if config.Timeout < 0 {
+		return fmt.Errorf("invalid timeout value: %d (must be non-negative)", config.Timeout)
+	}
+
+	if config.MaxRetries < 0 {
+		return fmt.Errorf("invalid max retries value: %d (must be non-negative)", config.MaxRetries)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/tools"
+)
+
+func TestNewHTTPFactory(t *testing.T) {
+	factory, err := NewHTTPFactory(config.HTTPToolConfig{
+		Name:        "get_user",
+		Description: "Look up a user",
+		URL:         "https://users.internal/v1/users/{{.id}}",
+		Headers: map[string]string{
+			"Authorization": `Bearer {{secret "USERS_TOKEN"}}`,
+			"X-Tenant":      "{{.tenant}}",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPFactory() unexpected error: %v", err)
+	}
+
+	if factory.GetVersion() != config.DefaultHTTPToolVersion {
+		t.Errorf("GetVersion() = %q, expected %q", factory.GetVersion(), config.DefaultHTTPToolVersion)
+	}
+	if factory.config.Method != config.DefaultHTTPToolMethod {
+		t.Errorf("expected default method %s, got %s", config.DefaultHTTPToolMethod, factory.config.Method)
+	}
+
+	requirements, err := tools.ParseRequirements(factory.Requirements())
+	if err != nil {
+		t.Fatalf("Requirements() are not parseable: %v", err)
+	}
+	if len(requirements) != 1 || requirements[0] != (tools.Requirement{Kind: tools.RequirementEnv, Target: "USERS_TOKEN"}) {
+		t.Errorf("expected the secret to be required, got %v", requirements)
+	}
+}
+
+func TestNewHTTPFactory_Invalid(t *testing.T) {
+	valid := config.HTTPToolConfig{
+		Name:        "get_user",
+		Description: "Look up a user",
+		URL:         "https://users.internal/v1/users/{{.id}}",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(cfg *config.HTTPToolConfig)
+		errMsg string
+	}{
+		{"missing name", func(cfg *config.HTTPToolConfig) { cfg.Name = "" }, "name cannot be empty"},
+		{"templated host", func(cfg *config.HTTPToolConfig) { cfg.URL = "https://{{.host}}/users" }, "fixed http:// or https:// host"},
+		{"unsupported scheme", func(cfg *config.HTTPToolConfig) { cfg.URL = "file:///etc/passwd" }, "fixed http:// or https:// host"},
+		{"templated query name", func(cfg *config.HTTPToolConfig) { cfg.URL = "https://users.internal/v1/users?{{.key}}=1" }, "fixed query parameter names"},
+		{"bad body template", func(cfg *config.HTTPToolConfig) { cfg.Body = "{{.id" }, "invalid body template"},
+		{"secret outside headers", func(cfg *config.HTTPToolConfig) { cfg.Body = `{{secret "TOKEN"}}` }, "invalid body template"},
+		{"bad pointer", func(cfg *config.HTTPToolConfig) { cfg.ResponsePointer = "data" }, "must start with /"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			_, err := NewHTTPFactory(cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("NewHTTPFactory() error = %v, expected it to contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestHTTPFactory_Create(t *testing.T) {
+	factory, err := NewHTTPFactory(config.HTTPToolConfig{
+		Name:        "get_user",
+		Description: "Look up a user",
+		URL:         "https://users.internal/v1/users",
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPFactory() unexpected error: %v", err)
+	}
+
+	tool, err := factory.Create(context.Background(), tools.ToolConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("Create() unexpected error: %v", err)
+	}
+	if tool.Name() != "get_user" || string(tool.Parameters()) != defaultInputSchema {
+		t.Errorf("unexpected tool %q with schema %s", tool.Name(), tool.Parameters())
+	}
+
+	if _, err := factory.Create(context.Background(), tools.ToolConfig{Enabled: false}); err == nil {
+		t.Error("Create() expected error for disabled tool")
+	}
+}
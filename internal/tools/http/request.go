@@ -0,0 +1,367 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"mcp-server/internal/config"
+)
+
+const (
+	// maxResponseBytes caps how much of a response body is read
+	maxResponseBytes = 10 << 20
+	// maxErrorBody caps how much of a failed response is quoted in the error
+	maxErrorBody = 1024
+)
+
+// secretPattern finds the secrets header templates reference, so they can
+// be declared as requirements
+var secretPattern = regexp.MustCompile(`secret\s+"([^"]+)"`)
+
+var templateFuncs = template.FuncMap{
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	"pathescape":  url.PathEscape,
+	"queryescape": url.QueryEscape,
+}
+
+// request builds and sends the HTTP request of one declared tool. The
+// scheme and host of the URL are fixed by the declaration; call arguments
+// can only fill in the path, query, headers and body.
+type request struct {
+	method       string
+	url          *template.Template
+	origin       string
+	query        []string
+	headers      map[string]*template.Template
+	body         *template.Template
+	pointer      string
+	statusErrors map[string]string
+	properties   []string
+	timeout      time.Duration
+	client       *nethttp.Client
+	lookupEnv    func(key string) (string, bool)
+}
+
+func newRequest(cfg config.HTTPToolConfig, properties []string) (*request, error) {
+	origin, err := urlOrigin(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	query, err := queryNames(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &request{
+		method:       cfg.Method,
+		origin:       origin,
+		query:        query,
+		headers:      make(map[string]*template.Template, len(cfg.Headers)),
+		pointer:      cfg.ResponsePointer,
+		statusErrors: cfg.StatusErrors,
+		properties:   properties,
+		timeout:      cfg.Timeout,
+		lookupEnv:    os.LookupEnv,
+	}
+	r.client = &nethttp.Client{CheckRedirect: r.checkRedirect}
+
+	if r.url, err = parseTemplate("URL", cfg.URL, nil); err != nil {
+		return nil, err
+	}
+	// Only headers may reference secrets
+	headerFuncs := template.FuncMap{"secret": r.secret}
+	for name, value := range cfg.Headers {
+		if r.headers[name], err = parseTemplate("header "+name, value, headerFuncs); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Body != "" {
+		if r.body, err = parseTemplate("body", cfg.Body, nil); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func parseTemplate(name, text string, funcs template.FuncMap) (*template.Template, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(templateFuncs).
+		Funcs(funcs).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// urlOrigin returns the scheme and host of a URL template, which must not
+// be templated themselves
+func urlOrigin(rawURL string) (string, error) {
+	static, _, _ := strings.Cut(rawURL, "{{")
+	parsed, err := url.Parse(static)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("URL %q must start with a fixed http:// or https:// host", rawURL)
+	}
+	return parsed.Scheme + "://" + parsed.Host, nil
+}
+
+// queryNames returns the sorted query parameter names of a URL template,
+// which must not be templated themselves
+func queryNames(rawURL string) ([]string, error) {
+	_, rawQuery, _ := strings.Cut(rawURL, "?")
+	rawQuery, _, _ = strings.Cut(rawQuery, "#")
+	var names []string
+	for _, part := range strings.Split(rawQuery, "&") {
+		name, _, _ := strings.Cut(part, "=")
+		if strings.Contains(name, "{{") {
+			return nil, fmt.Errorf("URL %q must use fixed query parameter names", rawURL)
+		}
+		if name != "" {
+			unescaped, err := url.QueryUnescape(name)
+			if err != nil {
+				return nil, fmt.Errorf("URL %q has an invalid query parameter name: %w", rawURL, err)
+			}
+			names = append(names, unescaped)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// checkQuery refuses a rendered URL whose query parameters differ from the
+// declared ones, as happens when an argument rendered without queryescape
+// carries its own '&', '=' or '#'
+func (r *request) checkQuery(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid rendered URL: %w", err)
+	}
+	values, err := url.ParseQuery(parsed.RawQuery)
+	if err != nil || parsed.Fragment != "" {
+		return errors.New("rendered URL query is not escaped, use queryescape for query values")
+	}
+	var names []string
+	for name, list := range values {
+		for range list {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if !slices.Equal(names, r.query) {
+		return errors.New("rendered URL query parameters differ from the declared ones, use queryescape for query values")
+	}
+	return nil
+}
+
+// secretNames returns the secrets referenced by header templates
+func secretNames(headers map[string]string) []string {
+	var names []string
+	for _, value := range headers {
+		for _, match := range secretPattern.FindAllStringSubmatch(value, -1) {
+			names = append(names, match[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// secret reads a secret from the environment at call time, so it never
+// appears in configuration files
+func (r *request) secret(name string) (string, error) {
+	value, exists := r.lookupEnv(name)
+	if !exists || value == "" {
+		return "", fmt.Errorf("secret %s is not set", name)
+	}
+	return value, nil
+}
+
+// checkRedirect refuses redirects leaving the declared host, which would
+// otherwise receive the headers and their secrets
+func (r *request) checkRedirect(req *nethttp.Request, via []*nethttp.Request) error {
+	if req.URL.Scheme+"://"+req.URL.Host != r.origin {
+		return fmt.Errorf("redirect to %s leaves %s", req.URL.Redacted(), r.origin)
+	}
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	return nil
+}
+
+// build renders the request for a call. Properties declared in the input
+// schema but absent from the call render as empty strings, and headers
+// rendering to an empty string are omitted.
+func (r *request) build(ctx context.Context, arguments map[string]interface{}) (*nethttp.Request, error) {
+	data := make(map[string]interface{}, len(arguments)+len(r.properties))
+	for _, property := range r.properties {
+		data[property] = ""
+	}
+	for name, value := range arguments {
+		data[name] = value
+	}
+
+	rawURL, err := render(r.url, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render URL: %w", err)
+	}
+	if origin, err := urlOrigin(rawURL); err != nil || origin != r.origin {
+		return nil, fmt.Errorf("rendered URL must stay on %s", r.origin)
+	}
+	if err := r.checkQuery(rawURL); err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if r.body != nil {
+		rendered, err := render(r.body, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render body: %w", err)
+		}
+		body = strings.NewReader(rendered)
+	}
+
+	req, err := nethttp.NewRequestWithContext(ctx, r.method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if r.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, tmpl := range r.headers {
+		value, err := render(tmpl, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render header %s: %w", name, err)
+		}
+		if value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+	return req, nil
+}
+
+func render(tmpl *template.Template, data map[string]interface{}) (string, error) {
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// do sends the request and returns the response body as the result text
+func (r *request) do(ctx context.Context, arguments map[string]interface{}) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	req, err := r.build(ctx, arguments)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("request timed out after %v", r.timeout)
+		}
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(body) > maxResponseBytes {
+		return "", fmt.Errorf("response exceeds %d bytes", maxResponseBytes)
+	}
+
+	if err := r.statusError(resp.StatusCode, body); err != nil {
+		return "", err
+	}
+	if r.pointer == "" {
+		return string(body), nil
+	}
+	return extract(body, r.pointer)
+}
+
+// statusError maps a response status to an error. Declared status errors
+// take precedence, exact codes before classes; any other status of 400 or
+// above fails with the start of the response body.
+func (r *request) statusError(status int, body []byte) error {
+	code := strconv.Itoa(status)
+	message, mapped := r.statusErrors[code]
+	if !mapped {
+		message, mapped = r.statusErrors[code[:1]+"xx"]
+	}
+	if mapped {
+		return fmt.Errorf("request failed with status %d: %s", status, message)
+	}
+	if status < 400 {
+		return nil
+	}
+
+	text := strings.TrimSpace(string(body))
+	if len(text) > maxErrorBody {
+		text = text[:maxErrorBody]
+	}
+	if text == "" {
+		return fmt.Errorf("request failed with status %d", status)
+	}
+	return fmt.Errorf("request failed with status %d: %s", status, text)
+}
+
+// extract resolves a JSON pointer (RFC 6901) in a JSON response. Strings are
+// returned as is and any other value as JSON.
+func extract(body []byte, pointer string) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("response is not JSON: %w", err)
+	}
+
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := value.(type) {
+		case map[string]interface{}:
+			child, exists := node[token]
+			if !exists {
+				return "", fmt.Errorf("response has no value at %s", pointer)
+			}
+			value = child
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", fmt.Errorf("response has no value at %s", pointer)
+			}
+			value = node[index]
+		default:
+			return "", fmt.Errorf("response has no value at %s", pointer)
+		}
+	}
+
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode extracted value: %w", err)
+	}
+	return string(data), nil
+}
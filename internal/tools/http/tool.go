@@ -0,0 +1,69 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+)
+
+type HTTPTool struct {
+	config  config.HTTPToolConfig
+	handler *HTTPHandler
+}
+
+func newHTTPTool(cfg config.HTTPToolConfig, req *request) *HTTPTool {
+	return &HTTPTool{
+		config:  cfg,
+		handler: newHTTPHandler(req),
+	}
+}
+
+func (t *HTTPTool) Name() string {
+	return t.config.Name
+}
+
+func (t *HTTPTool) Description() string {
+	return t.config.Description
+}
+
+func (t *HTTPTool) Parameters() json.RawMessage {
+	return t.config.InputSchema
+}
+
+func (t *HTTPTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+type HTTPHandler struct {
+	request *request
+}
+
+func newHTTPHandler(req *request) *HTTPHandler {
+	return &HTTPHandler{
+		request: req,
+	}
+}
+
+func (h *HTTPHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	arguments := make(map[string]interface{})
+	if len(bytes.TrimSpace(params)) > 0 {
+		// Numbers keep their original form when rendered into the request
+		decoder := json.NewDecoder(bytes.NewReader(params))
+		decoder.UseNumber()
+		if err := decoder.Decode(&arguments); err != nil {
+			return &mcp.ToolResultImpl{Error: fmt.Errorf("invalid parameters: %w", err), IsErrorFlag: true}, nil
+		}
+	}
+
+	text, err := h.request.do(ctx, arguments)
+	if err != nil {
+		return &mcp.ToolResultImpl{Error: err, IsErrorFlag: true}, nil
+	}
+
+	content := &mcp.TextContent{Text: text}
+	return &mcp.ToolResultImpl{Content: []mcp.Content{content}, IsErrorFlag: false}, nil
+}
@@ -0,0 +1,322 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+)
+
+// createHTTPTool declares a tool calling path on server
+func createHTTPTool(t *testing.T, server *httptest.Server, path string, modify func(cfg *config.HTTPToolConfig)) (mcp.Tool, *HTTPFactory) {
+	t.Helper()
+
+	cfg := config.HTTPToolConfig{
+		Name:        "call",
+		Description: "Call a test service",
+		URL:         server.URL + path,
+		InputSchema: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"id": {"type": "string"},
+				"limit": {"type": "integer"},
+				"tags": {"type": "array", "items": {"type": "string"}}
+			}
+		}`),
+	}
+	if modify != nil {
+		modify(&cfg)
+	}
+
+	factory, err := NewHTTPFactory(cfg)
+	if err != nil {
+		t.Fatalf("NewHTTPFactory() unexpected error: %v", err)
+	}
+	return newHTTPTool(factory.config, factory.request), factory
+}
+
+func callTool(t *testing.T, tool mcp.Tool, params string) mcp.ToolResult {
+	t.Helper()
+
+	result, err := tool.Handler().Handle(context.Background(), json.RawMessage(params))
+	if err != nil {
+		t.Fatalf("Handle() unexpected error: %v", err)
+	}
+	return result
+}
+
+func TestHTTPHandler_Request(t *testing.T) {
+	var received *nethttp.Request
+	var receivedBody string
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received, receivedBody = r, string(body)
+		w.Write([]byte("created"))
+	}))
+	defer server.Close()
+
+	tool, factory := createHTTPTool(t, server, "/users/{{pathescape .id}}/tags?limit={{.limit}}", func(cfg *config.HTTPToolConfig) {
+		cfg.Method = nethttp.MethodPost
+		cfg.Headers = map[string]string{
+			"Authorization": `Bearer {{secret "TEST_TOKEN"}}`,
+			"X-Request-Id":  "{{.id}}",
+			"X-Optional":    "{{.missing_optional}}",
+		}
+		cfg.InputSchema = json.RawMessage(`{"type": "object", "properties": {"id": {}, "limit": {}, "tags": {}, "missing_optional": {}}}`)
+		cfg.Body = `{"tags": {{json .tags}}}`
+	})
+	factory.request.lookupEnv = func(key string) (string, bool) {
+		return "s3cret", key == "TEST_TOKEN"
+	}
+
+	result := callTool(t, tool, `{"id": "a/b", "limit": 25, "tags": ["x", "y"]}`)
+	if result.IsError() {
+		t.Fatalf("unexpected error result: %v", result.GetError())
+	}
+	if text := result.GetContent()[0].GetText(); text != "created" {
+		t.Errorf("expected response body as text, got %q", text)
+	}
+
+	if received.Method != nethttp.MethodPost {
+		t.Errorf("expected POST, got %s", received.Method)
+	}
+	if received.URL.EscapedPath() != "/users/a%2Fb/tags" || received.URL.RawQuery != "limit=25" {
+		t.Errorf("unexpected URL %s", received.URL)
+	}
+	if got := received.Header.Get("Authorization"); got != "Bearer s3cret" {
+		t.Errorf("expected secret in Authorization header, got %q", got)
+	}
+	if _, exists := received.Header["X-Optional"]; exists {
+		t.Error("expected empty header to be omitted")
+	}
+	if receivedBody != `{"tags": ["x","y"]}` {
+		t.Errorf("unexpected body %q", receivedBody)
+	}
+	if got := received.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected JSON content type, got %q", got)
+	}
+}
+
+func TestHTTPHandler_MissingSecret(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		t.Error("request should not be sent without its secret")
+	}))
+	defer server.Close()
+
+	tool, factory := createHTTPTool(t, server, "/users", func(cfg *config.HTTPToolConfig) {
+		cfg.Headers = map[string]string{"Authorization": `Bearer {{secret "TEST_TOKEN"}}`}
+	})
+	factory.request.lookupEnv = func(key string) (string, bool) { return "", false }
+
+	result := callTool(t, tool, `{}`)
+	if !result.IsError() || !strings.Contains(result.GetError().Error(), "secret TEST_TOKEN is not set") {
+		t.Errorf("expected missing secret error, got %v", result.GetError())
+	}
+}
+
+func TestHTTPHandler_URLStaysOnHost(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		t.Error("request should not be sent")
+	}))
+	defer server.Close()
+
+	tool, _ := createHTTPTool(t, server, "{{.id}}", nil)
+
+	result := callTool(t, tool, `{"id": "@evil.example.com/"}`)
+	if !result.IsError() || !strings.Contains(result.GetError().Error(), "must stay on") {
+		t.Errorf("expected host change to be refused, got %v", result.GetError())
+	}
+}
+
+func TestHTTPHandler_QueryInjection(t *testing.T) {
+	var received *nethttp.Request
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		received = r
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		path string
+		id   string
+	}{
+		{name: "extra parameter", path: "/users?id={{.id}}", id: "1&admin=true"},
+		{name: "fragment", path: "/users?id={{.id}}&limit=1", id: "1#"},
+		{name: "query from path", path: "/users/{{.id}}", id: "1?admin=true"},
+		{name: "bad escape", path: "/users?id={{.id}}", id: "100%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			tool, _ := createHTTPTool(t, server, tt.path, nil)
+			params, _ := json.Marshal(map[string]string{"id": tt.id})
+
+			result := callTool(t, tool, string(params))
+			if !result.IsError() || !strings.Contains(result.GetError().Error(), "queryescape") {
+				t.Errorf("expected unescaped query to be refused, got %v", result.GetError())
+			}
+			if received != nil {
+				t.Errorf("request should not be sent, got %s", received.URL)
+			}
+		})
+	}
+
+	tool, _ := createHTTPTool(t, server, "/users?id={{queryescape .id}}", nil)
+	if result := callTool(t, tool, `{"id": "1&admin=true"}`); result.IsError() {
+		t.Fatalf("unexpected error result: %v", result.GetError())
+	}
+	if got := received.URL.Query(); got.Get("id") != "1&admin=true" || got.Has("admin") {
+		t.Errorf("expected escaped query value, got %s", received.URL.RawQuery)
+	}
+}
+
+func TestHTTPHandler_ResponsePointer(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Write([]byte(`{"data": {"users": [{"name": "ada", "a/b": 12345678901234567890}], "meta": {"total": 1}}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		pointer  string
+		expected string
+		errMsg   string
+	}{
+		{pointer: "/data/users/0/name", expected: "ada"},
+		{pointer: "/data/meta", expected: `{"total":1}`},
+		{pointer: "/data/users/0/a~1b", expected: "12345678901234567890"},
+		{pointer: "/data/users/1", errMsg: "no value at /data/users/1"},
+		{pointer: "/data/missing", errMsg: "no value at /data/missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			tool, _ := createHTTPTool(t, server, "/", func(cfg *config.HTTPToolConfig) {
+				cfg.ResponsePointer = tt.pointer
+			})
+
+			result := callTool(t, tool, `{}`)
+			if tt.errMsg != "" {
+				if !result.IsError() || !strings.Contains(result.GetError().Error(), tt.errMsg) {
+					t.Errorf("expected error containing %q, got %v", tt.errMsg, result.GetError())
+				}
+				return
+			}
+			if result.IsError() {
+				t.Fatalf("unexpected error result: %v", result.GetError())
+			}
+			if text := result.GetContent()[0].GetText(); text != tt.expected {
+				t.Errorf("extracted %q, expected %q", text, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHTTPHandler_StatusErrors(t *testing.T) {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		switch r.URL.Path {
+		case "/404":
+			w.WriteHeader(nethttp.StatusNotFound)
+		case "/503":
+			w.WriteHeader(nethttp.StatusServiceUnavailable)
+		case "/500":
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			w.Write([]byte("database unavailable"))
+		case "/202":
+			w.WriteHeader(nethttp.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	statusErrors := map[string]string{
+		"404": "user does not exist",
+		"503": "service is down for maintenance",
+		"5xx": "user service failed",
+		"202": "request was queued, not completed",
+	}
+
+	tests := []struct {
+		path   string
+		errMsg string
+	}{
+		{"/404", "request failed with status 404: user does not exist"},
+		{"/503", "request failed with status 503: service is down for maintenance"},
+		{"/500", "request failed with status 500: user service failed"},
+		{"/202", "request failed with status 202: request was queued, not completed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			tool, _ := createHTTPTool(t, server, tt.path, func(cfg *config.HTTPToolConfig) {
+				cfg.StatusErrors = statusErrors
+			})
+			result := callTool(t, tool, `{}`)
+			if !result.IsError() || result.GetError().Error() != tt.errMsg {
+				t.Errorf("expected error %q, got %v", tt.errMsg, result.GetError())
+			}
+		})
+	}
+
+	// Unmapped failures quote the response body
+	tool, _ := createHTTPTool(t, server, "/500", nil)
+	result := callTool(t, tool, `{}`)
+	if !result.IsError() || result.GetError().Error() != "request failed with status 500: database unavailable" {
+		t.Errorf("expected unmapped failure to quote the body, got %v", result.GetError())
+	}
+}
+
+func TestHTTPHandler_Redirects(t *testing.T) {
+	other := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		t.Error("redirect to another host should not be followed")
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			nethttp.Redirect(w, r, "/current", nethttp.StatusFound)
+		case "/current":
+			w.Write([]byte("here"))
+		case "/away":
+			nethttp.Redirect(w, r, other.URL, nethttp.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	tool, _ := createHTTPTool(t, server, "/moved", nil)
+	if result := callTool(t, tool, `{}`); result.IsError() || result.GetContent()[0].GetText() != "here" {
+		t.Errorf("expected same-host redirect to be followed, got %v", result.GetError())
+	}
+
+	tool, _ = createHTTPTool(t, server, "/away", nil)
+	if result := callTool(t, tool, `{}`); !result.IsError() || !strings.Contains(result.GetError().Error(), "redirect to") {
+		t.Errorf("expected cross-host redirect to be refused, got %v", result.GetError())
+	}
+}
+
+func TestHTTPHandler_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	tool, _ := createHTTPTool(t, server, "/slow", func(cfg *config.HTTPToolConfig) {
+		cfg.Timeout = 50 * time.Millisecond
+	})
+
+	result := callTool(t, tool, `{}`)
+	if !result.IsError() || result.GetError().Error() != "request timed out after 50ms" {
+		t.Errorf("expected timeout error, got %v", result.GetError())
+	}
+}
@@ -0,0 +1,66 @@
+package tools
+
+import "slices"
+
+// toolIndex is an immutable snapshot of the per-tool state that Get, List
+// and Health read, so they never take r.mu. Writers holding r.mu publish a
+// new index after changing any of it. Per-version policies are not indexed;
+// they live on the registry entries, which are read without locking too.
+type toolIndex struct {
+	keys     map[string]string   // name and name@version -> registry key
+	defaults map[string]bool     // registry key -> is its tool's default version
+	unmet    map[string][]string // registry key -> why requirements are not met
+}
+
+// keyFor resolves a tool reference like DefaultToolRegistry.keyFor
+func (x *toolIndex) keyFor(ref string) string {
+	name, version := ParseToolRef(ref)
+	lookup := name
+	if version != "" {
+		lookup = ToolRef(name, version)
+	}
+	if key, exists := x.keys[lookup]; exists {
+		return key
+	}
+	return ref
+}
+
+// publishIndex rebuilds the index from the registry's maps; the caller must
+// hold r.mu
+func (r *DefaultToolRegistry) publishIndex() {
+	index := &toolIndex{
+		keys:     make(map[string]string),
+		defaults: make(map[string]bool),
+		unmet:    make(map[string][]string, len(r.unmet)),
+	}
+	for name, set := range r.versions {
+		for version, key := range set.keys {
+			index.keys[ToolRef(name, version)] = key
+			index.defaults[key] = version == set.defaultVersion
+		}
+		if key, exists := set.keys[set.defaultVersion]; exists {
+			index.keys[name] = key
+		}
+	}
+	for key, unmet := range r.unmet {
+		index.unmet[key] = slices.Clone(unmet)
+	}
+	r.index.Store(index)
+}
+
+// setUnmet records why a tool's requirements are not met; the caller must
+// hold r.mu
+func (r *DefaultToolRegistry) setUnmet(key string, unmet []string) {
+	r.unmet[key] = unmet
+	r.publishIndex()
+}
+
+// clearUnmet forgets that a tool's requirements were not met; the caller
+// must hold r.mu
+func (r *DefaultToolRegistry) clearUnmet(key string) {
+	if _, exists := r.unmet[key]; !exists {
+		return
+	}
+	delete(r.unmet, key)
+	r.publishIndex()
+}
@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/metrics"
+	"mcp-server/internal/ratelimit"
+	"mcp-server/internal/registry"
+	"mcp-server/internal/tracing"
+)
+
+// instrumentedTool records the outcome, latency and payload sizes of every
+// call and traces it as a tool.execute span
+type instrumentedTool struct {
+	mcp.Tool
+	handler *instrumentedHandler
+}
+
+type instrumentedHandler struct {
+	name      string
+	collector *metrics.Collector
+	next      mcp.ToolHandler
+}
+
+// WithMetrics returns a tool whose calls are recorded in collector
+func WithMetrics(tool mcp.Tool, collector *metrics.Collector) mcp.Tool {
+	return &instrumentedTool{
+		Tool: tool,
+		handler: &instrumentedHandler{
+			name:      tool.Name(),
+			collector: collector,
+			next:      tool.Handler(),
+		},
+	}
+}
+
+func (t *instrumentedTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+// Unwrap returns the original tool
+func (t *instrumentedTool) Unwrap() mcp.Tool {
+	return t.Tool
+}
+
+func (h *instrumentedHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	ctx, span := tracing.Start(ctx, "tool.execute",
+		tracing.WithAttributes(tracing.String("mcp.tool.name", h.name)))
+	defer span.End()
+
+	done := h.collector.Start(h.name, len(params))
+	defer func() {
+		// Keep the in-flight gauge accurate when the handler panics
+		if recovered := recover(); recovered != nil {
+			done(metrics.OutcomeError, 0)
+			span.SetStatus(tracing.StatusError, "tool handler panicked")
+			panic(recovered)
+		}
+	}()
+
+	result, err := h.next.Handle(ctx, params)
+	outcome := classifyToolOutcome(result, err)
+	done(outcome, toolResultSize(result))
+
+	span.SetAttributes(tracing.String("mcp.tool.outcome", string(outcome)))
+	switch {
+	case err != nil:
+		span.RecordError(err)
+	case outcome != metrics.OutcomeSuccess:
+		span.RecordError(result.GetError())
+	}
+	return result, err
+}
+
+// classifyToolOutcome distinguishes calls refused before execution from
+// calls that failed while executing
+func classifyToolOutcome(result mcp.ToolResult, err error) metrics.Outcome {
+	if err != nil {
+		return metrics.OutcomeError
+	}
+	if result == nil || !result.IsError() {
+		return metrics.OutcomeSuccess
+	}
+	if resultErr := result.GetError(); errors.Is(resultErr, ErrInvalidArguments) || errors.Is(resultErr, ErrToolCircuitOpen) ||
+		errors.Is(resultErr, ratelimit.ErrRateLimited) || errors.Is(resultErr, registry.ErrBulkheadFull) ||
+		errors.Is(resultErr, registry.ErrBulkheadTimeout) {
+		return metrics.OutcomeRejected
+	}
+	return metrics.OutcomeError
+}
+
+func toolResultSize(result mcp.ToolResult) int {
+	if result == nil {
+		return 0
+	}
+	size := 0
+	for _, content := range result.GetContent() {
+		size += len(content.GetText()) + len(content.GetBlob())
+	}
+	if result.IsError() && result.GetError() != nil {
+		size += len(result.GetError().Error())
+	}
+	return size
+}
@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"mcp-server/internal/mcp"
+)
+
+func TestDefaultToolRegistry_RecordsExecutionMetrics(t *testing.T) {
+	registry := createTestRegistry()
+
+	tool := &mockTool{
+		name:        "greeter",
+		description: "Greeter tool",
+		parameters:  json.RawMessage(`{"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}`),
+		handler: mcp.ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+			var args struct{ Name string }
+			json.Unmarshal(params, &args)
+			if args.Name == "fail" {
+				return nil, errors.New("backend unavailable")
+			}
+			return &mcp.ToolResultImpl{Content: []mcp.Content{&mcp.TextContent{Text: "hello " + args.Name}}}, nil
+		}),
+	}
+	factory := &handlerToolFactory{mockToolFactory: *createTestFactory("greeter").(*mockToolFactory), tool: tool}
+	if err := registry.Register("greeter", factory); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	wrapped, err := registry.Get("greeter")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+
+	calls := []string{`{"name":"bob"}`, `{"name":"fail"}`, `{}`}
+	for _, args := range calls {
+		wrapped.Handler().Handle(context.Background(), json.RawMessage(args))
+	}
+
+	snapshot, ok := registry.Metrics().Snapshot("greeter")
+	if !ok {
+		t.Fatal("expected metrics for greeter")
+	}
+	if snapshot.Calls != 3 || snapshot.Success != 1 || snapshot.Errors != 1 || snapshot.Rejected != 1 {
+		t.Errorf("unexpected outcome counts: %+v", snapshot)
+	}
+	if snapshot.BytesIn != int64(len(calls[0])+len(calls[1])+len(calls[2])) {
+		t.Errorf("unexpected bytes in: %d", snapshot.BytesIn)
+	}
+	if snapshot.BytesOut < int64(len("hello bob")) {
+		t.Errorf("expected bytes out to include result content, got %d", snapshot.BytesOut)
+	}
+	if snapshot.InFlight != 0 || snapshot.Latency.Count != 3 {
+		t.Errorf("unexpected in-flight or latency count: %+v", snapshot)
+	}
+
+	if err := registry.Unregister("greeter"); err != nil {
+		t.Fatalf("unregister failed: %v", err)
+	}
+	if _, ok := registry.Metrics().Snapshot("greeter"); ok {
+		t.Error("expected metrics to be discarded on unregister")
+	}
+}
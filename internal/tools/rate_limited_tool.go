@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/ratelimit"
+)
+
+// ToolRateLimiter enforces the total call rate of a single tool on top of
+// the session and client budgets shared by every tool and resource. Buckets
+// outlive tool restarts.
+type ToolRateLimiter struct {
+	callers *mcp.CallerLimiter
+	tool    *ratelimit.Limiter
+}
+
+// NewToolRateLimiter creates the limiter for one tool from configuration;
+// callers may be nil when no caller budgets are enforced
+func NewToolRateLimiter(cfg config.ToolRateLimitConfig, callers *mcp.CallerLimiter) *ToolRateLimiter {
+	return &ToolRateLimiter{
+		callers: callers,
+		tool:    ratelimit.NewLimiter("tool", rateLimit(cfg.PerTool)),
+	}
+}
+
+func rateLimit(cfg config.RateLimit) ratelimit.Limit {
+	return ratelimit.Limit{Rate: cfg.RequestsPerSecond, Burst: cfg.Burst}
+}
+
+// Allow admits one call from caller when the caller's budgets and the tool
+// bucket all have a token, and takes one from each. A call rejected by any
+// of them uses up none, so a flooded tool does not drain the budget a
+// caller has left for other tools.
+func (l *ToolRateLimiter) Allow(caller mcp.Caller) error {
+	return ratelimit.AllowAll(append(l.callers.Checks(caller), ratelimit.Check{Limiter: l.tool})...)
+}
+
+// rateLimitedTool rejects calls that exceed the tool's rate limits
+type rateLimitedTool struct {
+	mcp.Tool
+	handler *rateLimitedHandler
+}
+
+type rateLimitedHandler struct {
+	name    string
+	limiter *ToolRateLimiter
+	next    mcp.ToolHandler
+}
+
+// WithRateLimit returns a tool whose calls are admitted by limiter
+func WithRateLimit(tool mcp.Tool, limiter *ToolRateLimiter) mcp.Tool {
+	return &rateLimitedTool{
+		Tool: tool,
+		handler: &rateLimitedHandler{
+			name:    tool.Name(),
+			limiter: limiter,
+			next:    tool.Handler(),
+		},
+	}
+}
+
+func (t *rateLimitedTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+// Unwrap returns the original tool
+func (t *rateLimitedTool) Unwrap() mcp.Tool {
+	return t.Tool
+}
+
+func (h *rateLimitedHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	if err := h.limiter.Allow(mcp.CallerFromContext(ctx)); err != nil {
+		return mcp.NewErrorResult(fmt.Errorf("tool %s: %w", h.name, err)), nil
+	}
+	return h.next.Handle(ctx, params)
+}
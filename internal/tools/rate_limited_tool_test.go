@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+	"mcp-server/internal/mcp"
+	"mcp-server/internal/ratelimit"
+)
+
+func countingTool(name string, calls *int) *mockTool {
+	return &mockTool{
+		name:        name,
+		description: "Counting tool",
+		parameters:  json.RawMessage(`{"type": "object"}`),
+		handler: mcp.ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+			*calls++
+			return &mcp.ToolResultImpl{}, nil
+		}),
+	}
+}
+
+func TestToolRateLimiter_Scopes(t *testing.T) {
+	callers := mcp.NewCallerLimiter(ratelimit.Limit{Rate: 1, Burst: 1})
+	limiter := NewToolRateLimiter(config.ToolRateLimitConfig{
+		Enabled: true,
+		PerTool: config.RateLimit{RequestsPerSecond: 1, Burst: 2},
+	}, callers)
+
+	first := mcp.Caller{SessionID: "s1"}
+	if err := limiter.Allow(first); err != nil {
+		t.Fatalf("first call rejected: %v", err)
+	}
+
+	err := limiter.Allow(first)
+	var limitErr *ratelimit.LimitError
+	if !errors.As(err, &limitErr) || limitErr.Scope != "session" {
+		t.Fatalf("expected session limit, got %v", err)
+	}
+
+	if err := limiter.Allow(mcp.Caller{SessionID: "s2"}); err != nil {
+		t.Fatalf("other session rejected: %v", err)
+	}
+	err = limiter.Allow(mcp.Caller{})
+	if !errors.As(err, &limitErr) || limitErr.Scope != "tool" {
+		t.Fatalf("expected tool limit, got %v", err)
+	}
+}
+
+func TestToolRateLimiter_SessionBudgetIsShared(t *testing.T) {
+	callers := mcp.NewCallerLimiter(ratelimit.Limit{Rate: 0.01, Burst: 3})
+	busy := NewToolRateLimiter(config.ToolRateLimitConfig{
+		Enabled: true,
+		PerTool: config.RateLimit{RequestsPerSecond: 0.01, Burst: 1},
+	}, callers)
+	other := NewToolRateLimiter(config.ToolRateLimitConfig{Enabled: true}, callers)
+
+	caller := mcp.Caller{SessionID: "runaway"}
+	if err := busy.Allow(caller); err != nil {
+		t.Fatalf("first call rejected: %v", err)
+	}
+	// Calls rejected by the busy tool's own bucket leave the session budget
+	// alone
+	for i := 0; i < 5; i++ {
+		if err := busy.Allow(caller); err == nil {
+			t.Fatal("expected the tool limit to reject the call")
+		}
+	}
+
+	// The session budget spans tools: two calls are left of three
+	for i := 0; i < 2; i++ {
+		if err := other.Allow(caller); err != nil {
+			t.Fatalf("call %d to another tool rejected: %v", i, err)
+		}
+	}
+	var limitErr *ratelimit.LimitError
+	if err := other.Allow(caller); !errors.As(err, &limitErr) || limitErr.Scope != "session" {
+		t.Fatalf("expected the shared session budget to be used up, got %v", err)
+	}
+}
+
+func TestDefaultToolRegistry_RateLimitsCalls(t *testing.T) {
+	cfg := &config.Config{
+		MCP: config.MCPConfig{MaxTools: 100},
+		Tools: config.ToolsConfig{
+			RateLimit: config.ToolRateLimitConfig{Enabled: true},
+		},
+	}
+	log, _ := logger.NewDefault()
+	registry := NewDefaultToolRegistry(cfg, log)
+	registry.SetCallerLimiter(mcp.NewCallerLimiter(ratelimit.Limit{Rate: 0.01, Burst: 2}))
+
+	calls := 0
+	factory := &handlerToolFactory{mockToolFactory: *createTestFactory("busy").(*mockToolFactory), tool: countingTool("busy", &calls)}
+	if err := registry.Register("busy", factory); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	tool, err := registry.Get("busy")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+
+	ctx := mcp.WithCaller(context.Background(), mcp.Caller{SessionID: "runaway"})
+	for i := 0; i < 2; i++ {
+		if result, _ := tool.Handler().Handle(ctx, json.RawMessage(`{}`)); result.IsError() {
+			t.Fatalf("call %d within burst rejected: %v", i, result.GetError())
+		}
+	}
+
+	result, err := tool.Handler().Handle(ctx, json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("expected error result, got error: %v", err)
+	}
+	if !result.IsError() || !errors.Is(result.GetError(), ratelimit.ErrRateLimited) {
+		t.Fatalf("expected rate limited result, got %v", result.GetError())
+	}
+	if retryAfter, ok := ratelimit.RetryAfter(result.GetError()); !ok || retryAfter <= 0 {
+		t.Errorf("expected retry hint, got %v", retryAfter)
+	}
+	if calls != 2 {
+		t.Errorf("expected throttled call to skip the handler, got %d calls", calls)
+	}
+
+	other := mcp.WithCaller(context.Background(), mcp.Caller{SessionID: "quiet"})
+	if result, _ := tool.Handler().Handle(other, json.RawMessage(`{}`)); result.IsError() {
+		t.Errorf("other session should not be throttled: %v", result.GetError())
+	}
+
+	snapshot, _ := registry.Metrics().Snapshot("busy")
+	if snapshot.Rejected != 1 {
+		t.Errorf("expected 1 rejected call in metrics, got %d", snapshot.Rejected)
+	}
+
+}
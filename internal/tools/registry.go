@@ -3,56 +3,84 @@ package tools
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"mcp-server/internal/cache"
 	"mcp-server/internal/config"
+	"mcp-server/internal/events"
 	"mcp-server/internal/logger"
 	"mcp-server/internal/mcp"
+	"mcp-server/internal/metrics"
+	"mcp-server/internal/registry"
 	"mcp-server/internal/tools/adapters"
+	"mcp-server/internal/tracing"
 )
 
 // DefaultToolRegistry implements ToolRegistry
 type DefaultToolRegistry struct {
-	factories        map[string]ToolFactory
-	circuitFactories map[string]*CircuitBreakerToolFactory
-	tools            map[string]mcp.Tool
-	toolInfo         map[string]ToolInfo
+	entities         *registry.Registry[ToolFactory, mcp.Tool, *toolPolicy]
+	callers          *mcp.CallerLimiter // session and client budgets; nil when disabled
+	globalBulkhead   *registry.Bulkhead // shared by all tools; nil when disabled
+	versions         map[string]*versionSet // tool name -> registered versions
+	published        map[string][]string    // tool name -> names registered with the adapter
+	unmet            map[string][]string    // registry key -> why requirements are not met
+	resourceWait     ResourceWait           // nil unless resources load alongside
+	checker          *requirementChecker
 	logger           *logger.Logger
 	config           *config.Config
 	validator        *ToolValidator
+	metrics          *metrics.Collector
 	adapter          adapters.LibraryAdapter // Library adapter for MCP implementation
+	supervisor       *registry.Supervisor
+	loader           *registry.Loader
+	index            atomic.Pointer[toolIndex] // read without r.mu
 	mu               sync.RWMutex
-	running          bool
-	lastCheck        time.Time
 }
 
 // NewDefaultToolRegistry creates a new tool registry instance
 func NewDefaultToolRegistry(cfg *config.Config, log *logger.Logger) ToolRegistry {
-	return &DefaultToolRegistry{
-		factories:        make(map[string]ToolFactory),
-		circuitFactories: make(map[string]*CircuitBreakerToolFactory),
-		tools:            make(map[string]mcp.Tool),
-		toolInfo:         make(map[string]ToolInfo),
+	r := &DefaultToolRegistry{
+		globalBulkhead:   newGlobalBulkhead(cfg),
+		versions:         make(map[string]*versionSet),
+		published:        make(map[string][]string),
+		unmet:            make(map[string][]string),
+		checker:          newRequirementChecker(cfg),
 		logger:           log,
 		config:           cfg,
 		validator:        NewToolValidator(cfg, log),
+		metrics:          metrics.NewCollector(),
 		adapter:          nil, // No adapter for backward compatibility
 	}
+	r.entities = registry.NewRegistry[ToolFactory, mcp.Tool, *toolPolicy](events.KindTool, log, registry.Hooks{Removed: r.metrics.Remove})
+	r.supervisor = r.newSupervisor()
+	r.loader = registry.NewLoader(events.KindTool, cfg, log)
+	r.publishIndex()
+	return r
 }
 
 // NewDefaultToolRegistryWithAdapter creates a new tool registry instance with a library adapter
 func NewDefaultToolRegistryWithAdapter(cfg *config.Config, log *logger.Logger, adapter adapters.LibraryAdapter) ToolRegistry {
-	return &DefaultToolRegistry{
-		factories:        make(map[string]ToolFactory),
-		circuitFactories: make(map[string]*CircuitBreakerToolFactory),
-		tools:            make(map[string]mcp.Tool),
-		toolInfo:         make(map[string]ToolInfo),
+	r := &DefaultToolRegistry{
+		globalBulkhead:   newGlobalBulkhead(cfg),
+		versions:         make(map[string]*versionSet),
+		published:        make(map[string][]string),
+		unmet:            make(map[string][]string),
+		checker:          newRequirementChecker(cfg),
 		logger:           log,
 		config:           cfg,
 		validator:        NewToolValidator(cfg, log),
+		metrics:          metrics.NewCollector(),
 		adapter:          adapter,
 	}
+	r.entities = registry.NewRegistry[ToolFactory, mcp.Tool, *toolPolicy](events.KindTool, log, registry.Hooks{Removed: r.metrics.Remove})
+	r.supervisor = r.newSupervisor()
+	r.loader = registry.NewLoader(events.KindTool, cfg, log)
+	r.publishIndex()
+	return r
 }
 
 // Register implements ToolRegistry.Register
@@ -75,12 +103,27 @@ func (r *DefaultToolRegistry) Register(name string, factory ToolFactory) error {
 		return fmt.Errorf("%w: %v", ErrInvalidToolName, err)
 	}
 
+	// Further versions of a registered tool are keyed by name@version
+	version := factory.GetVersion()
+	key := name
+	set, versioned := r.versions[name]
+	if versioned {
+		if _, exists := set.keys[version]; exists {
+			r.logger.Error("tool version already registered",
+				"name", name,
+				"version", version,
+			)
+			return fmt.Errorf("%w: %s", ErrToolAlreadyExists, ToolRef(name, version))
+		}
+		key = ToolRef(name, version)
+	}
+
 	// Check for duplicate registration
-	if _, exists := r.factories[name]; exists {
+	if r.entities.Has(key) {
 		r.logger.Error("tool already registered",
-			"name", name,
+			"name", key,
 		)
-		return fmt.Errorf("%w: %s", ErrToolAlreadyExists, name)
+		return fmt.Errorf("%w: %s", ErrToolAlreadyExists, key)
 	}
 
 	// Validate factory
@@ -92,27 +135,36 @@ func (r *DefaultToolRegistry) Register(name string, factory ToolFactory) error {
 		return fmt.Errorf("%w: %v", ErrToolValidation, err)
 	}
 
-	// Register factory
-	r.factories[name] = factory
+	// Validation above rejects malformed requirements
+	requirements, _ := ParseRequirements(factory.Requirements())
+	policy := r.newToolPolicy(key, name, version, requirements)
 
-	// Create circuit breaker wrapper
-	circuitConfig := DefaultCircuitBreakerConfig()
-	circuitFactory := NewCircuitBreakerToolFactory(factory, circuitConfig)
-	r.circuitFactories[name] = circuitFactory
+	// Register factory with its policy; the entity registry adds the
+	// creation circuit breaker and restores the tool's persisted state,
+	// which is kept under name@version whichever version took the bare name
+	if err := r.entities.RegisterWithStateKey(key, policy.ref, factory, policy); err != nil {
+		return fmt.Errorf("%w: %s", ErrToolAlreadyExists, key)
+	}
 
-	// Create tool info
-	info := ToolInfo{
-		Name:         factory.GetName(),
-		Description:  factory.GetDescription(),
-		Version:      factory.GetVersion(),
-		Capabilities: factory.GetCapabilities(),
-		Requirements: factory.Requirements(),
-		Status:       ToolStatusRegistered,
+	// Track the version and apply the configured default
+	if !versioned {
+		set = &versionSet{defaultVersion: version, keys: make(map[string]string)}
+		r.versions[name] = set
+	}
+	set.keys[version] = key
+	if r.defaultVersionConfig(name) == version {
+		set.defaultVersion = version
+	}
+	r.refreshReplacements(name)
+	r.publishIndex()
+	if versioned {
+		r.publishVersions(name)
 	}
-	r.toolInfo[name] = info
 
 	r.logger.Info("tool factory registered successfully",
 		"name", name,
+		"version", version,
+		"default_version", set.defaultVersion,
 		"capabilities", factory.GetCapabilities(),
 	)
 
@@ -126,27 +178,23 @@ func (r *DefaultToolRegistry) Unregister(name string) error {
 
 	r.logger.Info("unregistering tool", "name", name)
 
-	// Check if tool exists
-	if _, exists := r.factories[name]; !exists {
+	// Resolve name@version references to the registry key
+	name = r.keyFor(name)
+
+	// Remove the tool, its policy and its metrics
+	policy := r.policy(name)
+	if err := r.entities.Unregister(name); err != nil {
 		r.logger.Warn("attempted to unregister non-existent tool", "name", name)
 		return fmt.Errorf("%w: %s", ErrToolNotFound, name)
 	}
+	toolName, version := ParseToolRef(policy.ref)
+	delete(r.unmet, name)
 
-	// Unregister from adapter if available
-	if r.adapter != nil {
-		if err := r.adapter.UnregisterTool(name); err != nil {
-			r.logger.Error("failed to unregister tool from adapter",
-				"name", name,
-				"error", err,
-			)
-			// Continue with local unregistration even if adapter fails
-		}
-	}
-
-	// Remove from all maps
-	delete(r.factories, name)
-	delete(r.tools, name)
-	delete(r.toolInfo, name)
+	// Forget the version and withdraw it from the adapter; the adapter
+	// failing does not stop local unregistration
+	r.removeVersion(toolName, version)
+	r.publishIndex()
+	r.publishVersions(toolName)
 
 	r.logger.Info("tool unregistered successfully", "name", name)
 	return nil
@@ -154,23 +202,28 @@ func (r *DefaultToolRegistry) Unregister(name string) error {
 
 // Get implements ToolRegistry.Get
 func (r *DefaultToolRegistry) Get(name string) (mcp.Tool, error) {
-	r.mu.RLock()
+	// Lookups read the index and the entity registry without taking r.mu
+	index := r.index.Load()
+
+	// Resolve name@version references to the registry key
+	name = index.keyFor(name)
 
 	// Check if tool instance exists
-	if tool, exists := r.tools[name]; exists {
-		r.mu.RUnlock()
+	if tool, exists := r.entities.Instance(name); exists {
 		return tool, nil
 	}
 
 	// Check if factory exists
-	factory, exists := r.factories[name]
+	factory, exists := r.entities.Factory(name)
 	if !exists {
-		r.mu.RUnlock()
 		return nil, fmt.Errorf("%w: %s", ErrToolNotFound, name)
 	}
 
-	// Release read lock for tool creation (may take time)
-	r.mu.RUnlock()
+	if err := r.checkAvailable(name, index.unmet); err != nil {
+		return nil, err
+	}
+	policy := r.executionPolicy(name)
+	breaker := r.entities.Breaker(name)
 
 	// Create tool instance
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -184,7 +237,10 @@ func (r *DefaultToolRegistry) Get(name string) (mcp.Tool, error) {
 		MaxRetries: 3,
 	}
 
-	tool, err := factory.Create(ctx, toolConfig)
+	// Use circuit breaker to protect tool creation
+	tool, err := breaker.ExecuteWithContext(ctx, func(ctx context.Context) (mcp.Tool, error) {
+		return r.createTool(ctx, name, factory, toolConfig)
+	})
 	if err != nil {
 		r.logger.Error("tool creation failed",
 			"name", name,
@@ -193,8 +249,9 @@ func (r *DefaultToolRegistry) Get(name string) (mcp.Tool, error) {
 		return nil, fmt.Errorf("%w: %v", ErrToolCreation, err)
 	}
 
-	// Validate created tool
-	if err := r.validator.ValidateTool(tool); err != nil {
+	// Validate created tool and enforce its input schema on every call
+	tool, err = r.prepareTool(name, tool, policy)
+	if err != nil {
 		r.logger.Error("created tool validation failed",
 			"name", name,
 			"error", err,
@@ -202,29 +259,30 @@ func (r *DefaultToolRegistry) Get(name string) (mcp.Tool, error) {
 		return nil, fmt.Errorf("%w: %v", ErrToolValidation, err)
 	}
 
-	// Register with adapter if available
-	if r.adapter != nil {
-		if err := r.adapter.RegisterTool(tool); err != nil {
-			r.logger.Error("failed to register tool with adapter",
-				"name", name,
-				"error", err,
-			)
-			// Continue even if adapter registration fails - this is business logic
-			// We still store the tool locally for fallback
-		}
+	// Store tool instance (need write lock). The tool may have been
+	// disabled, failed its requirements or been created by a concurrent
+	// call meanwhile; the new instance is then thrown away.
+	r.mu.Lock()
+	if _, exists := r.entities.Factory(name); !exists {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrToolNotFound, name)
+	}
+	if err := r.checkAvailable(name, r.unmet); err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	if existing, exists := r.entities.Instance(name); exists {
+		r.mu.Unlock()
+		return existing, nil
 	}
+	r.entities.SetInstance(name, tool)
 
-	// Store tool instance (need write lock)
-	r.mu.Lock()
-	r.tools[name] = tool
+	// Register with adapter if available; failures are logged and the
+	// tool is still stored locally for fallback
+	r.publishVersions(r.nameOf(name))
 	
 	// Update status using transition logic
-	if info, exists := r.toolInfo[name]; exists {
-		if IsValidTransition(info.Status, ToolStatusLoaded) {
-			info.Status = ToolStatusLoaded
-			r.toolInfo[name] = info
-		}
-	}
+	r.entities.TryTransition(name, ToolStatusLoaded, registry.SystemCause("instance created"))
 	r.mu.Unlock()
 
 	r.logger.Info("tool instance created and cached",
@@ -234,12 +292,24 @@ func (r *DefaultToolRegistry) Get(name string) (mcp.Tool, error) {
 	return tool, nil
 }
 
+// checkAvailable refuses tools that failed their requirement check or were
+// disabled; both stay unavailable until the tool is restarted
+func (r *DefaultToolRegistry) checkAvailable(name string, unmet map[string][]string) error {
+	if reasons, exists := unmet[name]; exists {
+		return fmt.Errorf("%w: %s: %s", ErrRequirementsNotMet, name, strings.Join(reasons, "; "))
+	}
+	if status, _ := r.entities.Status(name); status == ToolStatusDisabled {
+		return fmt.Errorf("%w: %s", ErrToolDisabled, name)
+	}
+	return nil
+}
+
 // GetFactory implements ToolRegistry.GetFactory
 func (r *DefaultToolRegistry) GetFactory(name string) (ToolFactory, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	// Resolve name@version references to the registry key
+	name = r.index.Load().keyFor(name)
 
-	factory, exists := r.factories[name]
+	factory, exists := r.entities.Factory(name)
 	if !exists {
 		return nil, fmt.Errorf("%w: %s", ErrToolNotFound, name)
 	}
@@ -249,23 +319,63 @@ func (r *DefaultToolRegistry) GetFactory(name string) (ToolFactory, error) {
 
 // List implements ToolRegistry.List
 func (r *DefaultToolRegistry) List() []ToolInfo {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	result := make([]ToolInfo, 0, len(r.toolInfo))
-	for _, info := range r.toolInfo {
-		result = append(result, info)
+	index := r.index.Load()
+	entries := r.entities.Entries()
+	result := make([]ToolInfo, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, toolInfo(index, entry))
 	}
 
 	return result
 }
 
-// LoadTools implements ToolRegistry.LoadTools
+// toolInfo describes a registered tool
+func toolInfo(index *toolIndex, entry registry.Entry[ToolFactory, mcp.Tool, *toolPolicy]) ToolInfo {
+	factory := entry.Factory
+	info := ToolInfo{
+		ID:                    entry.Key,
+		Name:                  factory.GetName(),
+		Description:           factory.GetDescription(),
+		Version:               factory.GetVersion(),
+		Capabilities:          factory.GetCapabilities(),
+		Requirements:          factory.Requirements(),
+		Status:                entry.Status,
+		Restarts:              entry.Restarts,
+		CircuitBreakerResetAt: entry.BreakerResetAt,
+		History:               entry.History,
+	}
+
+	info.Default = index.defaults[entry.Key]
+	if notice := entry.Policy.deprecation; notice != nil {
+		info.Deprecation = notice.get()
+	}
+	return info
+}
+
+// LoadTools implements ToolRegistry.LoadTools. Tools load in parallel
+// within the startup limits, each after the tools it requires and, when a
+// resource wait is set, the resources it requires, so its requirements are
+// checked once they have loaded; tools whose requirements
+// are not met move to error along with the tools requiring them. Tools
+// still loading at the startup deadline finish in the background and are
+// left out of the returned error.
 func (r *DefaultToolRegistry) LoadTools(ctx context.Context) error {
 	r.mu.RLock()
 	factories := make(map[string]ToolFactory)
-	for name, factory := range r.factories {
-		factories[name] = factory
+	for _, entry := range r.entities.Entries() {
+		// Disabled tools stay down until an operator enables them
+		if entry.Status == ToolStatusDisabled {
+			continue
+		}
+		factories[entry.Key] = entry.Factory
+	}
+	policies := make(map[string]executionPolicy)
+	dependencies := make(map[string][]string)
+	keys := make([]string, 0, len(factories))
+	for name := range factories {
+		policies[name] = r.executionPolicy(name)
+		dependencies[name] = r.toolDependencies(name)
+		keys = append(keys, name)
 	}
 	r.mu.RUnlock()
 
@@ -273,97 +383,147 @@ func (r *DefaultToolRegistry) LoadTools(ctx context.Context) error {
 		"count", len(factories),
 	)
 
-	var errors []string
-	loaded := 0
+	order, cyclic := orderByDependencies(keys, dependencies)
+	tasks := make([]registry.LoadTask, 0, len(keys))
+	for _, name := range order {
+		tasks = append(tasks, registry.LoadTask{Key: name, After: dependencies[name]})
+	}
+	for _, name := range cyclic {
+		tasks = append(tasks, registry.LoadTask{Key: name})
+	}
+	isCyclic := make(map[string]bool, len(cyclic))
+	for _, name := range cyclic {
+		isCyclic[name] = true
+	}
 
-	for name, factory := range factories {
-		// Get tool configuration (empty for now)
-		toolConfig := ToolConfig{
-			Enabled:    true,
-			Config:     make(map[string]interface{}),
-			Timeout:    30,
-			MaxRetries: 3,
+	report := r.loader.Run(ctx, tasks, func(ctx context.Context, name string) error {
+		if isCyclic[name] {
+			return r.failLoad(name, []string{"dependency cycle between required tools"})
 		}
+		return r.loadTool(ctx, name, factories[name], policies[name])
+	})
 
-		// Create tool instance
-		tool, err := factory.Create(ctx, toolConfig)
-		if err != nil {
-			errorMsg := fmt.Sprintf("failed to create tool %s: %v", name, err)
-			errors = append(errors, errorMsg)
-			r.logger.Error("tool creation failed during load",
-				"name", name,
-				"error", err,
-			)
-
-			// Update status to error using transition logic
-			r.mu.Lock()
-			if info, exists := r.toolInfo[name]; exists {
-				if IsValidTransition(info.Status, ToolStatusError) {
-					info.Status = ToolStatusError
-					r.toolInfo[name] = info
-				}
-			}
-			r.mu.Unlock()
-			continue
+	var errors []string
+	for _, result := range report.Entities {
+		if result.Status == registry.LoadFailed {
+			errors = append(errors, result.Error)
 		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to load %d tools: %v", len(errors), errors)
+	}
 
-		// Validate tool
-		if err := r.validator.ValidateTool(tool); err != nil {
-			errorMsg := fmt.Sprintf("tool validation failed for %s: %v", name, err)
-			errors = append(errors, errorMsg)
-			r.logger.Error("tool validation failed during load",
-				"name", name,
-				"error", err,
-			)
+	return nil
+}
 
-			// Update status to error using transition logic
-			r.mu.Lock()
-			if info, exists := r.toolInfo[name]; exists {
-				if IsValidTransition(info.Status, ToolStatusError) {
-					info.Status = ToolStatusError
-					r.toolInfo[name] = info
-				}
-			}
-			r.mu.Unlock()
-			continue
-		}
+// loadTool checks the requirements of a tool, then creates, prepares and
+// stores it
+func (r *DefaultToolRegistry) loadTool(ctx context.Context, name string, factory ToolFactory, policy executionPolicy) error {
+	r.waitForResources(ctx, name)
+
+	// Check requirements
+	r.mu.Lock()
+	unmet := r.unmetRequirements(name)
+	if len(unmet) == 0 {
+		r.clearUnmet(name)
+	}
+	r.mu.Unlock()
+	if len(unmet) > 0 {
+		return r.failLoad(name, unmet)
+	}
 
-		// Store tool
+	// Get tool configuration (empty for now)
+	toolConfig := ToolConfig{
+		Enabled:    true,
+		Config:     make(map[string]interface{}),
+		Timeout:    30,
+		MaxRetries: 3,
+	}
+
+	// Create tool instance
+	tool, err := r.createTool(ctx, name, factory, toolConfig)
+	if err != nil {
+		r.logger.Error("tool creation failed during load",
+			"name", name,
+			"error", err,
+		)
+
+		// Update status to error using transition logic
 		r.mu.Lock()
-		r.tools[name] = tool
-		if info, exists := r.toolInfo[name]; exists {
-			info.Status = ToolStatusLoaded
-			r.toolInfo[name] = info
-		}
+		r.entities.TryTransition(name, ToolStatusError, registry.ErrorCause(registry.ActorSystem, "creation failed", err))
 		r.mu.Unlock()
+		return fmt.Errorf("failed to create tool %s: %v", name, err)
+	}
 
-		loaded++
-		r.logger.Debug("tool loaded successfully",
+	// Validate tool
+	tool, err = r.prepareTool(name, tool, policy)
+	if err != nil {
+		r.logger.Error("tool validation failed during load",
 			"name", name,
+			"error", err,
 		)
+
+		// Update status to error using transition logic
+		r.mu.Lock()
+		r.entities.TryTransition(name, ToolStatusError, registry.ErrorCause(registry.ActorSystem, "validation failed", err))
+		r.mu.Unlock()
+		return fmt.Errorf("tool validation failed for %s: %v", name, err)
 	}
 
-	r.logger.Info("tool loading completed",
-		"total", len(factories),
-		"loaded", loaded,
-		"errors", len(errors),
+	// Store tool
+	r.mu.Lock()
+	r.entities.SetInstance(name, tool)
+	r.entities.TryTransition(name, ToolStatusLoaded, registry.SystemCause("loaded"))
+	r.mu.Unlock()
+
+	r.logger.Debug("tool loaded successfully",
+		"name", name,
 	)
+	return nil
+}
 
-	if len(errors) > 0 {
-		return fmt.Errorf("failed to load %d tools: %v", len(errors), errors)
+// failLoad moves a tool whose requirements are not met to error
+func (r *DefaultToolRegistry) failLoad(name string, unmet []string) error {
+	r.logger.Error("tool requirements not met during load",
+		"name", name,
+		"unmet", unmet,
+	)
+
+	r.mu.Lock()
+	r.failRequirements(name, unmet)
+	r.mu.Unlock()
+	return fmt.Errorf("requirements not met for %s: %s", name, strings.Join(unmet, "; "))
+}
+
+// waitForResources waits for the resources a tool requires to finish
+// loading, within the tool's factory timeout
+func (r *DefaultToolRegistry) waitForResources(ctx context.Context, key string) {
+	r.mu.RLock()
+	wait, policy := r.resourceWait, r.policy(key)
+	r.mu.RUnlock()
+	if wait == nil || policy == nil {
+		return
 	}
 
-	return nil
+	for _, requirement := range policy.requirements {
+		if requirement.Kind != RequirementResource {
+			continue
+		}
+		if err := wait(ctx, requirement.Target); err != nil {
+			r.logger.Warn("gave up waiting for required resource", "name", key, "resource", requirement.Target, "error", err)
+			return
+		}
+	}
+}
+
+// StartupReport implements ToolRegistry.StartupReport
+func (r *DefaultToolRegistry) StartupReport() *registry.LoadReport {
+	return r.loader.Report()
 }
 
 // ValidateTools implements ToolRegistry.ValidateTools
 func (r *DefaultToolRegistry) ValidateTools(ctx context.Context) error {
-	r.mu.RLock()
-	tools := make(map[string]mcp.Tool)
-	for name, tool := range r.tools {
-		tools[name] = tool
-	}
-	r.mu.RUnlock()
+	tools := r.entities.Instances()
 
 	r.logger.Info("validating tools",
 		"count", len(tools),
@@ -382,21 +542,17 @@ func (r *DefaultToolRegistry) ValidateTools(ctx context.Context) error {
 
 			// Update status to error using transition logic
 			r.mu.Lock()
-			if info, exists := r.toolInfo[name]; exists {
-				if IsValidTransition(info.Status, ToolStatusError) {
-					info.Status = ToolStatusError
-					r.toolInfo[name] = info
-				}
-			}
+			r.entities.TryTransition(name, ToolStatusError, registry.ErrorCause(registry.ActorSystem, "validation failed", err))
 			r.mu.Unlock()
 		} else {
-			// Update status to active using transition logic
+			// Update status to active using transition logic, unless a
+			// requirement stopped being met since the tool was loaded
 			r.mu.Lock()
-			if info, exists := r.toolInfo[name]; exists {
-				if IsValidTransition(info.Status, ToolStatusActive) {
-					info.Status = ToolStatusActive
-					r.toolInfo[name] = info
-				}
+			if unmet := r.unmetRequirements(name); len(unmet) > 0 {
+				r.failRequirements(name, unmet)
+				errors = append(errors, fmt.Sprintf("requirements not met for %s: %s", name, strings.Join(unmet, "; ")))
+			} else {
+				r.entities.TryTransition(name, ToolStatusActive, registry.SystemCause("activated"))
 			}
 			r.mu.Unlock()
 		}
@@ -419,7 +575,7 @@ func (r *DefaultToolRegistry) Start(ctx context.Context) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if r.running {
+	if r.entities.Running() {
 		return fmt.Errorf("registry is already running")
 	}
 
@@ -434,8 +590,11 @@ func (r *DefaultToolRegistry) Start(ctx context.Context) error {
 		r.logger.Info("adapter started successfully")
 	}
 
-	r.running = true
-	r.lastCheck = time.Now()
+	// Tools an operator left disabled are withdrawn from the adapter
+	for _, key := range r.entities.Start() {
+		r.publishVersions(r.nameOf(key))
+	}
+	r.supervisor.Start()
 
 	r.logger.Info("tool registry started successfully")
 	return nil
@@ -443,10 +602,13 @@ func (r *DefaultToolRegistry) Start(ctx context.Context) error {
 
 // Stop implements ToolRegistry.Stop
 func (r *DefaultToolRegistry) Stop(ctx context.Context) error {
+	// The supervisor's restarts take r.mu, so it must stop first
+	r.supervisor.Stop()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if !r.running {
+	if !r.entities.Running() {
 		return nil
 	}
 
@@ -462,18 +624,8 @@ func (r *DefaultToolRegistry) Stop(ctx context.Context) error {
 		}
 	}
 
-	// Clear all tools
-	r.tools = make(map[string]mcp.Tool)
-	
-	// Update all statuses to disabled using transition logic
-	for name, info := range r.toolInfo {
-		if IsValidTransition(info.Status, ToolStatusDisabled) {
-			info.Status = ToolStatusDisabled
-			r.toolInfo[name] = info
-		}
-	}
-
-	r.running = false
+	// Clear all tools and disable them
+	r.entities.Stop(registry.SystemCause("registry stopped"))
 
 	r.logger.Info("tool registry stopped")
 	return nil
@@ -481,6 +633,11 @@ func (r *DefaultToolRegistry) Stop(ctx context.Context) error {
 
 // TransitionStatus implements ToolRegistry.TransitionStatus
 func (r *DefaultToolRegistry) TransitionStatus(name string, newStatus ToolStatus) error {
+	return r.TransitionStatusWith(name, newStatus, registry.SystemCause(""))
+}
+
+// TransitionStatusWith implements ToolRegistry.TransitionStatusWith
+func (r *DefaultToolRegistry) TransitionStatusWith(name string, newStatus ToolStatus, cause registry.TransitionCause) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -489,8 +646,11 @@ func (r *DefaultToolRegistry) TransitionStatus(name string, newStatus ToolStatus
 		"new_status", string(newStatus),
 	)
 
+	// Resolve name@version references to the registry key
+	name = r.keyFor(name)
+
 	// Check if tool exists
-	info, exists := r.toolInfo[name]
+	currentStatus, exists := r.entities.Status(name)
 	if !exists {
 		r.logger.Error("attempted to transition status of non-existent tool",
 			"name", name,
@@ -498,8 +658,6 @@ func (r *DefaultToolRegistry) TransitionStatus(name string, newStatus ToolStatus
 		)
 		return fmt.Errorf("%w: %s", ErrToolNotFound, name)
 	}
-
-	currentStatus := info.Status
 	
 	// Validate transition
 	if !IsValidTransition(currentStatus, newStatus) {
@@ -513,7 +671,7 @@ func (r *DefaultToolRegistry) TransitionStatus(name string, newStatus ToolStatus
 	}
 
 	// Check registry state for certain transitions
-	if !r.running && (newStatus == ToolStatusActive || newStatus == ToolStatusLoaded) {
+	if !r.entities.Running() && (newStatus == ToolStatusActive || newStatus == ToolStatusLoaded) {
 		r.logger.Error("cannot activate tool when registry is not running",
 			"name", name,
 			"new_status", string(newStatus),
@@ -522,23 +680,35 @@ func (r *DefaultToolRegistry) TransitionStatus(name string, newStatus ToolStatus
 			ErrRegistryNotRunning, string(newStatus))
 	}
 
-	// Update tool status
-	info.Status = newStatus
-	r.toolInfo[name] = info
+	// Refuse to activate tools whose requirements are not met
+	if newStatus == ToolStatusActive || newStatus == ToolStatusLoaded {
+		if unmet := r.unmetRequirements(name); len(unmet) > 0 {
+			r.setUnmet(name, unmet)
+			r.logger.Error("cannot activate tool with unmet requirements",
+				"name", name,
+				"unmet", unmet,
+			)
+			return fmt.Errorf("%w: %s: %s", ErrRequirementsNotMet, name, strings.Join(unmet, "; "))
+		}
+		r.clearUnmet(name)
+	}
+
+	// Update tool status; disabled and failed tools lose their instance
+	_, loaded := r.entities.Instance(name)
+	if err := r.entities.Transition(name, newStatus, cause); err != nil {
+		return err
+	}
 
-	// Handle special transitions
+	// Handle special transitions; removed instances are also withdrawn
+	// from the adapter so protocol clients stop reaching them
 	switch newStatus {
-	case ToolStatusDisabled:
-		// Remove from active tools map
-		if _, exists := r.tools[name]; exists {
-			delete(r.tools, name)
-			r.logger.Debug("removed tool instance for disabled tool", "name", name)
+	case ToolStatusDisabled, ToolStatusError:
+		if loaded {
+			r.publishVersions(r.nameOf(name))
+			r.logger.Debug("removed tool instance", "name", name, "status", string(newStatus))
 		}
-	case ToolStatusError:
-		// Remove from active tools map but keep factory
-		if _, exists := r.tools[name]; exists {
-			delete(r.tools, name)
-			r.logger.Debug("removed tool instance for error tool", "name", name)
+		if newStatus == ToolStatusError {
+			r.failDependents(name)
 		}
 	}
 
@@ -553,40 +723,39 @@ func (r *DefaultToolRegistry) TransitionStatus(name string, newStatus ToolStatus
 
 // Restart validation functions
 
-func (r *DefaultToolRegistry) validateRestartPreconditions(name string) (ToolInfo, ToolFactory, error) {
-	info, exists := r.toolInfo[name]
+func (r *DefaultToolRegistry) validateRestartPreconditions(name string) (ToolStatus, ToolFactory, error) {
+	status, exists := r.entities.Status(name)
 	if !exists {
 		r.logger.Error("attempted to restart non-existent tool", "name", name)
-		return ToolInfo{}, nil, fmt.Errorf("%w: %s", ErrToolNotFound, name)
+		return "", nil, fmt.Errorf("%w: %s", ErrToolNotFound, name)
 	}
 
-	if !IsValidTransition(info.Status, ToolStatusRegistered) {
+	if !IsValidTransition(status, ToolStatusRegistered) {
 		r.logger.Error("tool restart not allowed from current status",
-			"name", name, "current_status", string(info.Status))
-		return ToolInfo{}, nil, fmt.Errorf("%w: cannot restart tool from status %s", 
-			ErrRestartNotAllowed, string(info.Status))
+			"name", name, "current_status", string(status))
+		return "", nil, fmt.Errorf("%w: cannot restart tool from status %s", 
+			ErrRestartNotAllowed, string(status))
 	}
 
-	if !r.running {
+	if !r.entities.Running() {
 		r.logger.Error("cannot restart tool when registry is not running", "name", name)
-		return ToolInfo{}, nil, fmt.Errorf("%w: cannot restart tool when registry is stopped", 
+		return "", nil, fmt.Errorf("%w: cannot restart tool when registry is stopped", 
 			ErrRegistryNotRunning)
 	}
 
-	factory, exists := r.factories[name]
+	factory, exists := r.entities.Factory(name)
 	if !exists {
 		r.logger.Error("tool factory not found for restart", "name", name)
-		return ToolInfo{}, nil, fmt.Errorf("%w: factory not found for tool %s", ErrToolNotFound, name)
+		return "", nil, fmt.Errorf("%w: factory not found for tool %s", ErrToolNotFound, name)
 	}
 
-	return info, factory, nil
+	return status, factory, nil
 }
 
 // Tool management functions
 
 func (r *DefaultToolRegistry) cleanupExistingTool(name string) {
-	if _, exists := r.tools[name]; exists {
-		delete(r.tools, name)
+	if r.entities.DropInstance(name) {
 		r.logger.Debug("removed existing tool instance for restart", "name", name)
 	}
 }
@@ -598,71 +767,600 @@ func (r *DefaultToolRegistry) createToolInstance(ctx context.Context, factory To
 		Timeout:    30,
 		MaxRetries: 3,
 	}
-	return factory.Create(ctx, toolConfig)
+	return r.createTool(ctx, factory.GetName(), factory, toolConfig)
+}
+
+// createTool runs a factory inside a trace span
+func (r *DefaultToolRegistry) createTool(ctx context.Context, name string, factory ToolFactory, toolConfig ToolConfig) (mcp.Tool, error) {
+	ctx, span := tracing.Start(ctx, "tool.factory.create",
+		tracing.WithAttributes(tracing.String("mcp.tool.name", name)))
+	defer span.End()
+
+	tool, err := factory.Create(ctx, toolConfig)
+	span.RecordError(err)
+	return tool, err
+}
+
+// executionPolicy holds the per-tool guards that outlive tool instances.
+// Fields are nil when the guard is disabled.
+type executionPolicy struct {
+	breaker     *ExecutionBreaker
+	rateLimiter *ToolRateLimiter
+	bulkhead    *registry.Bulkhead
+	cache       *ToolResultCache
+	deprecation *deprecationNotice
+}
+
+// toolPolicy is what the registry keeps per tool version besides its
+// factory. It is the version's registry entry Policy, so it is registered
+// and unregistered along with the version.
+type toolPolicy struct {
+	ref          string // name@version
+	requirements []Requirement
+	executionPolicy
+}
+
+// newToolPolicy creates the policy of a tool version. Execution policies are
+// configured per tool name and instantiated per version so versions never
+// share breaker state or cached results.
+func (r *DefaultToolRegistry) newToolPolicy(key, name, version string, requirements []Requirement) *toolPolicy {
+	policy := &toolPolicy{ref: ToolRef(name, version), requirements: requirements}
+
+	// Create execution circuit breaker guarding tool calls
+	if breakerConfig := r.breakerConfig(name); breakerConfig.Enabled {
+		policy.breaker = NewExecutionBreaker(key, breakerConfig, r.logBreakerStateChange)
+	}
+
+	// Create rate limiter admitting tool calls
+	if rateLimitConfig := r.rateLimitConfig(name); rateLimitConfig.Enabled {
+		policy.rateLimiter = NewToolRateLimiter(rateLimitConfig, r.callers)
+	}
+
+	// Create bulkhead bounding concurrent executions
+	if bulkheadConfig := r.bulkheadConfig(name); bulkheadConfig.Enabled {
+		policy.bulkhead = NewToolBulkhead(key, bulkheadConfig)
+	}
+
+	// Create result cache for idempotent tools that opt in
+	if cacheConfig := r.cacheConfig(name); cacheConfig.Enabled {
+		policy.cache = NewToolResultCache(name, version, cacheConfig)
+	}
+
+	// Apply the configured deprecation
+	policy.deprecation = newDeprecationNotice(policy.ref)
+	if deprecation, exists := r.deprecationConfig(name, version); exists {
+		policy.deprecation.set(deprecation)
+	}
+	return policy
+}
+
+// policy returns the policy of a registered tool version, nil when the key
+// is not registered
+func (r *DefaultToolRegistry) policy(key string) *toolPolicy {
+	policy, _ := r.entities.Policy(key)
+	return policy
+}
+
+// executionPolicy returns the guards of a tool, all nil when it is not
+// registered
+func (r *DefaultToolRegistry) executionPolicy(key string) executionPolicy {
+	if policy := r.policy(key); policy != nil {
+		return policy.executionPolicy
+	}
+	return executionPolicy{}
+}
+
+// prepareTool validates a freshly created tool and wraps its handler with
+// the execution circuit breaker (if any), the tool and global bulkheads (if
+// any), the result cache (if any), argument validation against the tool's
+// input schema and the rate limiter (if any). Invalid arguments are rejected
+// before they reach the breaker so caller mistakes never trip it, and
+// bulkhead rejections happen outside the breaker for the same reason. Cache
+// hits skip the bulkheads and breaker but still count against rate limits.
+// Rate limiting runs first so throttled calls cost nothing. Deprecation
+// warnings are added to every result, rejections included, and metrics are
+// recorded outermost so rejected calls are counted too. Versions registered
+// after the first are renamed to their registry key so their metrics and
+// errors are told apart.
+func (r *DefaultToolRegistry) prepareTool(key string, tool mcp.Tool, policy executionPolicy) (mcp.Tool, error) {
+	if err := r.validator.ValidateTool(tool); err != nil {
+		return nil, err
+	}
+	if _, version := ParseToolRef(key); version != "" {
+		tool = withName(tool, key)
+	}
+	if policy.breaker != nil {
+		tool = WithExecutionBreaker(tool, policy.breaker)
+	}
+	tool = WithBulkheads(tool, policy.bulkhead, r.globalBulkhead)
+	if policy.cache != nil {
+		tool = WithResultCache(tool, policy.cache)
+	}
+	tool, err := WithArgumentValidation(tool)
+	if err != nil {
+		return nil, err
+	}
+	if policy.rateLimiter != nil {
+		tool = WithRateLimit(tool, policy.rateLimiter)
+	}
+	if policy.deprecation != nil {
+		tool = withDeprecationWarning(tool, policy.deprecation)
+	}
+	return WithMetrics(tool, r.metrics), nil
+}
+
+// Metrics implements ToolRegistry.Metrics
+func (r *DefaultToolRegistry) Metrics() *metrics.Collector {
+	return r.metrics
+}
+
+func (r *DefaultToolRegistry) breakerConfig(name string) config.ToolCircuitBreakerConfig {
+	if r.config == nil {
+		return config.ToolCircuitBreakerConfig{}
+	}
+	return r.config.Tools.CircuitBreakerFor(name)
+}
+
+func (r *DefaultToolRegistry) cacheConfig(name string) config.ToolCacheConfig {
+	if r.config == nil {
+		return config.ToolCacheConfig{}
+	}
+	return r.config.Tools.CacheFor(name)
+}
+
+func (r *DefaultToolRegistry) rateLimitConfig(name string) config.ToolRateLimitConfig {
+	if r.config == nil {
+		return config.ToolRateLimitConfig{}
+	}
+	return r.config.Tools.RateLimitFor(name)
+}
+
+func (r *DefaultToolRegistry) bulkheadConfig(name string) config.ToolBulkheadConfig {
+	if r.config == nil {
+		return config.ToolBulkheadConfig{}
+	}
+	return r.config.Tools.BulkheadFor(name)
+}
+
+func (r *DefaultToolRegistry) defaultVersionConfig(name string) string {
+	if r.config == nil {
+		return ""
+	}
+	return r.config.Tools.DefaultVersionFor(name)
+}
+
+func (r *DefaultToolRegistry) deprecationConfig(name, version string) (*Deprecation, bool) {
+	if r.config == nil {
+		return nil, false
+	}
+	deprecation, exists := r.config.Tools.DeprecationFor(name, version)
+	if !exists {
+		return nil, false
+	}
+	return &Deprecation{Sunset: deprecation.Sunset, Message: deprecation.Message}, true
 }
 
-func (r *DefaultToolRegistry) registerToolWithAdapter(tool mcp.Tool, name string) {
+func newGlobalBulkhead(cfg *config.Config) *registry.Bulkhead {
+	if cfg == nil || !cfg.Tools.GlobalBulkhead.Enabled {
+		return nil
+	}
+	return NewToolBulkhead("all tools", cfg.Tools.GlobalBulkhead)
+}
+
+func (r *DefaultToolRegistry) logBreakerStateChange(name, from, to string) {
+	r.logger.Warn("tool circuit breaker state changed",
+		"name", name,
+		"from", from,
+		"to", to,
+	)
+	r.entities.Publish(events.Event{Type: events.TypeCircuitBreaker, Name: name, From: from, To: to})
+}
+
+// ResetCircuitBreaker implements ToolRegistry.ResetCircuitBreaker
+func (r *DefaultToolRegistry) ResetCircuitBreaker(name string) error {
+	r.mu.RLock()
+	name = r.keyFor(name)
+	exists := r.entities.Has(name)
+	breaker := r.executionPolicy(name).breaker
+	r.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrToolNotFound, name)
+	}
+	if breaker == nil {
+		return fmt.Errorf("%w: %s", ErrNoCircuitBreaker, name)
+	}
+
+	previous := breaker.Status()
+	breaker.Reset()
+
+	r.entities.RecordBreakerReset(name)
+
+	r.logger.Info("tool circuit breaker reset",
+		"name", name,
+		"previous_state", previous,
+	)
+	return nil
+}
+
+// Version management functions
+
+// keyFor resolves a tool reference to its registry key, returning the
+// reference unchanged when it names no registered version; the caller must
+// hold r.mu
+func (r *DefaultToolRegistry) keyFor(ref string) string {
+	name, version := ParseToolRef(ref)
+	set, exists := r.versions[name]
+	if !exists {
+		return ref
+	}
+	if version == "" {
+		version = set.defaultVersion
+	}
+	if key, exists := set.keys[version]; exists {
+		return key
+	}
+	return ref
+}
+
+// nameOf returns the tool name a registry key belongs to; the caller must
+// hold r.mu
+func (r *DefaultToolRegistry) nameOf(key string) string {
+	policy := r.policy(key)
+	if policy == nil {
+		return ""
+	}
+	name, _ := ParseToolRef(policy.ref)
+	return name
+}
+
+// removeVersion forgets an unregistered version, moving the default to the
+// newest remaining version when needed; the caller must hold r.mu
+func (r *DefaultToolRegistry) removeVersion(name, version string) {
+	set, exists := r.versions[name]
+	if !exists {
+		return
+	}
+	delete(set.keys, version)
+	if len(set.keys) == 0 {
+		delete(r.versions, name)
+		return
+	}
+	if set.defaultVersion == version {
+		set.defaultVersion = set.versions()[0]
+		r.logger.Info("default tool version unregistered",
+			"name", name,
+			"removed_version", version,
+			"default_version", set.defaultVersion,
+		)
+	}
+	r.refreshReplacements(name)
+}
+
+// refreshReplacements points the deprecation warnings of every version at
+// the current default; the caller must hold r.mu
+func (r *DefaultToolRegistry) refreshReplacements(name string) {
+	set, exists := r.versions[name]
+	if !exists {
+		return
+	}
+	for version, key := range set.keys {
+		replacement := ""
+		if version != set.defaultVersion {
+			replacement = ToolRef(name, set.defaultVersion)
+		}
+		r.executionPolicy(key).deprecation.setReplacement(replacement)
+	}
+}
+
+// publishVersions registers the loaded versions of a tool with the adapter:
+// the default version under the bare name and every version under its
+// name@version reference, so clients can pin a version before a second one
+// is registered. Adapter failures are logged and otherwise ignored. The
+// caller must hold r.mu.
+func (r *DefaultToolRegistry) publishVersions(name string) {
+	if r.adapter == nil {
+		return
+	}
+
+	for _, alias := range r.published[name] {
+		if err := r.adapter.UnregisterTool(alias); err != nil {
+			r.logger.Error("failed to unregister tool from adapter",
+				"name", alias,
+				"error", err,
+			)
+		}
+	}
+	delete(r.published, name)
+
+	set, exists := r.versions[name]
+	if !exists {
+		return
+	}
+
+	var published []string
+	for version, key := range set.keys {
+		tool, loaded := r.entities.Instance(key)
+		if !loaded {
+			continue
+		}
+		var aliases []string
+		if version == set.defaultVersion {
+			aliases = append(aliases, name)
+		}
+		if version != "" {
+			aliases = append(aliases, ToolRef(name, version))
+		}
+		for _, alias := range aliases {
+			if err := r.adapter.RegisterTool(withName(tool, alias)); err != nil {
+				r.logger.Error("failed to register tool with adapter",
+					"name", alias,
+					"error", err,
+				)
+				continue
+			}
+			published = append(published, alias)
+		}
+	}
+	if len(published) > 0 {
+		r.published[name] = published
+	}
+}
+
+// SetDefaultVersion implements ToolRegistry.SetDefaultVersion. Protocol
+// calls by bare name reach the new default once it has been loaded.
+func (r *DefaultToolRegistry) SetDefaultVersion(name, version string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, exists := r.versions[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrToolNotFound, name)
+	}
+	if _, exists := set.keys[version]; !exists {
+		return fmt.Errorf("%w: %s", ErrToolNotFound, ToolRef(name, version))
+	}
+
+	previous := set.defaultVersion
+	set.defaultVersion = version
+	r.refreshReplacements(name)
+	r.publishIndex()
+	r.publishVersions(name)
+
+	r.logger.Info("default tool version changed",
+		"name", name,
+		"previous_version", previous,
+		"default_version", version,
+	)
+	return nil
+}
+
+// DeprecateVersion implements ToolRegistry.DeprecateVersion. A nil
+// deprecation withdraws an earlier one.
+func (r *DefaultToolRegistry) DeprecateVersion(name, version string, deprecation *Deprecation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, exists := r.versions[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrToolNotFound, name)
+	}
+	key, exists := set.keys[version]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrToolNotFound, ToolRef(name, version))
+	}
+	if deprecation != nil && deprecation.Sunset.IsZero() {
+		return fmt.Errorf("%w: deprecation of %s needs a sunset date", ErrToolValidation, ToolRef(name, version))
+	}
+
+	r.executionPolicy(key).deprecation.set(deprecation)
+
+	if deprecation == nil {
+		r.logger.Info("tool version deprecation withdrawn", "name", name, "version", version)
+		return nil
+	}
+	r.logger.Warn("tool version deprecated",
+		"name", name,
+		"version", version,
+		"sunset", deprecation.Sunset.Format(time.RFC3339),
+	)
+	return nil
+}
+
+// Requirement management functions
+
+// SetEvents implements ToolRegistry.SetEvents
+func (r *DefaultToolRegistry) SetEvents(bus *events.Bus) {
+	r.entities.SetEvents(bus)
+}
+
+// SetStateStore implements ToolRegistry.SetStateStore
+func (r *DefaultToolRegistry) SetStateStore(store registry.StateStore) {
+	r.entities.SetStateStore(store)
+}
+
+// SetResourceLookup implements ToolRegistry.SetResourceLookup
+func (r *DefaultToolRegistry) SetResourceLookup(lookup ResourceLookup) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checker.resources = lookup
+}
+
+// SetResourceWait implements ToolRegistry.SetResourceWait
+func (r *DefaultToolRegistry) SetResourceWait(wait ResourceWait) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resourceWait = wait
+}
+
+// SetCallerLimiter implements ToolRegistry.SetCallerLimiter
+func (r *DefaultToolRegistry) SetCallerLimiter(limiter *mcp.CallerLimiter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.callers = limiter
+}
+
+// SetPipeline implements ToolRegistry.SetPipeline
+func (r *DefaultToolRegistry) SetPipeline(pipeline *mcp.Pipeline) {
 	if r.adapter != nil {
-		if err := r.adapter.RegisterTool(tool); err != nil {
-			r.logger.Error("failed to register restarted tool with adapter",
-				"name", name, "error", err)
+		r.adapter.SetPipeline(pipeline)
+	}
+}
+
+// RegisterPrompt publishes a prompt through the adapter. The prompt
+// registry keeps the prompts' lifecycle and calls it for active prompts.
+func (r *DefaultToolRegistry) RegisterPrompt(prompt mcp.Prompt) error {
+	if r.adapter == nil {
+		return fmt.Errorf("%w: cannot publish prompt %s", ErrNoAdapter, prompt.Name())
+	}
+	return r.adapter.RegisterPrompt(prompt)
+}
+
+func (r *DefaultToolRegistry) UnregisterPrompt(name string) error {
+	if r.adapter == nil {
+		return fmt.Errorf("%w: cannot withdraw prompt %s", ErrNoAdapter, name)
+	}
+	return r.adapter.UnregisterPrompt(name)
+}
+
+// toolDependencies returns the registry keys of the tools a tool requires;
+// the caller must hold r.mu
+func (r *DefaultToolRegistry) toolDependencies(key string) []string {
+	policy := r.policy(key)
+	if policy == nil {
+		return nil
+	}
+	var dependencies []string
+	for _, requirement := range policy.requirements {
+		if requirement.Kind == RequirementTool {
+			dependencies = append(dependencies, r.keyFor(requirement.Target))
+		}
+	}
+	return dependencies
+}
+
+// unmetRequirements checks the requirements of a tool and returns why they
+// are not met; the caller must hold r.mu
+func (r *DefaultToolRegistry) unmetRequirements(key string) []string {
+	policy := r.policy(key)
+	if policy == nil {
+		return nil
+	}
+	var unmet []string
+	for _, requirement := range policy.requirements {
+		if requirement.Kind != RequirementTool {
+			if reason := r.checker.check(requirement); reason != "" {
+				unmet = append(unmet, reason)
+			}
+			continue
+		}
+
+		status, exists := r.entities.Status(r.keyFor(requirement.Target))
+		switch {
+		case !exists:
+			unmet = append(unmet, fmt.Sprintf("required tool %s is not registered", requirement.Target))
+		case !isAvailable(status):
+			unmet = append(unmet, fmt.Sprintf("required tool %s is %s", requirement.Target, status))
 		}
 	}
+	return unmet
+}
+
+// failRequirements records why a tool's requirements are not met and moves
+// it, and the tools requiring it, to error; the caller must hold r.mu
+func (r *DefaultToolRegistry) failRequirements(key string, unmet []string) {
+	r.setUnmet(key, unmet)
+	if r.entities.DropInstance(key) {
+		r.publishVersions(r.nameOf(key))
+	}
+	r.entities.TryTransition(key, ToolStatusError, registry.TransitionCause{
+		Actor:  registry.ActorSystem,
+		Reason: "requirements not met",
+		Error:  strings.Join(unmet, "; "),
+	})
+	r.failDependents(key)
+}
+
+// failDependents moves the tools requiring a failed tool to error, and in
+// turn the tools requiring those; the caller must hold r.mu
+func (r *DefaultToolRegistry) failDependents(key string) {
+	for _, dependent := range r.entities.Keys() {
+		if !slices.Contains(r.toolDependencies(dependent), key) {
+			continue
+		}
+		status, _ := r.entities.Status(dependent)
+		if status == ToolStatusError || !IsValidTransition(status, ToolStatusError) {
+			continue
+		}
+
+		r.logger.Warn("tool moved to error because a required tool failed",
+			"name", dependent,
+			"required", key,
+		)
+		r.failRequirements(dependent, []string{fmt.Sprintf("required tool %s failed", key)})
+	}
 }
 
 // Status management functions
 
-func (r *DefaultToolRegistry) transitionToRegistered(name string) error {
-	if info, exists := r.toolInfo[name]; exists {
-		info.Status = ToolStatusRegistered
-		r.toolInfo[name] = info
+func (r *DefaultToolRegistry) transitionToRegistered(name string, actor registry.Actor) error {
+	if r.entities.TryTransition(name, ToolStatusRegistered, registry.TransitionCause{Actor: actor, Reason: "restart"}) {
 		r.logger.Info("tool transitioned to registered status for restart", "name", name)
 	}
 	return nil
 }
 
-func (r *DefaultToolRegistry) transitionToLoaded(name string) error {
-	if info, exists := r.toolInfo[name]; exists && IsValidTransition(info.Status, ToolStatusLoaded) {
-		info.Status = ToolStatusLoaded
-		r.toolInfo[name] = info
-	}
+func (r *DefaultToolRegistry) transitionToLoaded(name string, actor registry.Actor) error {
+	r.entities.TryTransition(name, ToolStatusLoaded, registry.TransitionCause{Actor: actor, Reason: "restarted"})
 	return nil
 }
 
-func (r *DefaultToolRegistry) transitionToError(name string) error {
-	if info, exists := r.toolInfo[name]; exists && IsValidTransition(info.Status, ToolStatusError) {
-		info.Status = ToolStatusError
-		r.toolInfo[name] = info
-	}
+func (r *DefaultToolRegistry) transitionToError(name string, cause registry.TransitionCause) error {
+	r.entities.TryTransition(name, ToolStatusError, cause)
+	r.failDependents(name)
 	return nil
 }
 
 // Core restart orchestration
 
 func (r *DefaultToolRegistry) restartToolCore(ctx context.Context, name string, factory ToolFactory) error {
+	actor := registry.ActorFromContext(ctx)
 	r.cleanupExistingTool(name)
+	if resultCache := r.executionPolicy(name).cache; resultCache != nil {
+		resultCache.Purge()
+		r.entities.Publish(events.Event{Type: events.TypeCacheInvalidated, Name: name, Reason: "restart"})
+		r.logger.Debug("purged result cache for restart", "name", name)
+	}
 	
-	if err := r.transitionToRegistered(name); err != nil {
+	if unmet := r.unmetRequirements(name); len(unmet) > 0 {
+		r.logger.Error("tool requirements not met during restart", "name", name, "unmet", unmet)
+		r.failRequirements(name, unmet)
+		return fmt.Errorf("%w: %w: %s: %s", ErrToolRestart, ErrRequirementsNotMet, name, strings.Join(unmet, "; "))
+	}
+	r.clearUnmet(name)
+
+	if err := r.transitionToRegistered(name, actor); err != nil {
 		return err
 	}
 
 	tool, err := r.createToolInstance(ctx, factory)
 	if err != nil {
 		r.logger.Error("tool recreation failed during restart", "name", name, "error", err)
-		r.transitionToError(name)
+		r.transitionToError(name, registry.ErrorCause(actor, "recreation failed", err))
 		return fmt.Errorf("%w: failed to recreate tool %s: %v", ErrToolRestart, name, err)
 	}
 
-	if err := r.validator.ValidateTool(tool); err != nil {
+	tool, err = r.prepareTool(name, tool, r.executionPolicy(name))
+	if err != nil {
 		r.logger.Error("tool validation failed during restart", "name", name, "error", err)
-		r.transitionToError(name)
+		r.transitionToError(name, registry.ErrorCause(actor, "validation failed", err))
 		return fmt.Errorf("%w: tool validation failed for %s: %v", ErrToolRestart, name, err)
 	}
 
-	r.registerToolWithAdapter(tool, name)
-	r.tools[name] = tool
+	r.entities.SetInstance(name, tool)
+	r.publishVersions(r.nameOf(name))
 	
-	return r.transitionToLoaded(name)
+	return r.transitionToLoaded(name, actor)
 }
 
 // RestartTool implements ToolRegistry.RestartTool
@@ -672,7 +1370,10 @@ func (r *DefaultToolRegistry) RestartTool(ctx context.Context, name string) erro
 
 	r.logger.Info("restarting tool", "name", name)
 
-	info, factory, err := r.validateRestartPreconditions(name)
+	// Resolve name@version references to the registry key
+	name = r.keyFor(name)
+
+	status, factory, err := r.validateRestartPreconditions(name)
 	if err != nil {
 		return err
 	}
@@ -680,37 +1381,53 @@ func (r *DefaultToolRegistry) RestartTool(ctx context.Context, name string) erro
 	if err := r.restartToolCore(ctx, name, factory); err != nil {
 		return err
 	}
+	restarts := r.entities.RecordRestart(name)
 
 	r.logger.Info("tool restart completed successfully",
-		"name", name, "status", string(info.Status))
+		"name", name, "status", string(status), "restarts", restarts)
 
 	return nil
 }
 
 // Health implements ToolRegistry.Health
 func (r *DefaultToolRegistry) Health() RegistryHealth {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
+	index := r.index.Load()
+	counts := r.entities.Health()
 	health := RegistryHealth{
 		Status:       "healthy",
-		ToolCount:    len(r.toolInfo),
-		ActiveTools:  0,
-		ErrorTools:   0,
-		LastCheck:    r.lastCheck.Format(time.RFC3339),
-		Errors:       []string{},
-		ToolStatuses: make(map[string]string),
+		ToolCount:    counts.EntityCount,
+		ActiveTools:  counts.ActiveEntities,
+		ErrorTools:   counts.ErrorEntities,
+		LastCheck:    counts.LastCheck,
+		Errors:          []string{},
+		ToolStatuses:    counts.EntityStatuses,
+		CircuitBreakers: make(map[string]string),
+		Bulkheads:       make(map[string]registry.BulkheadStats),
+		ResultCaches:    make(map[string]cache.Stats),
 	}
 
-	// Count tool statuses
-	for name, info := range r.toolInfo {
-		health.ToolStatuses[name] = string(info.Status)
-		
-		switch info.Status {
-		case ToolStatusActive:
-			health.ActiveTools++
-		case ToolStatusError:
-			health.ErrorTools++
+	for _, entry := range r.entities.Entries() {
+		if policy := entry.Policy; policy.breaker != nil {
+			health.CircuitBreakers[entry.Key] = policy.breaker.Status()
+		}
+		if policy := entry.Policy; policy.bulkhead != nil {
+			health.Bulkheads[entry.Key] = policy.bulkhead.Stats()
+		}
+		if policy := entry.Policy; policy.cache != nil {
+			health.ResultCaches[entry.Key] = policy.cache.Stats()
+		}
+	}
+	if r.globalBulkhead != nil {
+		stats := r.globalBulkhead.Stats()
+		health.GlobalBulkhead = &stats
+	}
+	if attempts := r.supervisor.Attempts(); len(attempts) > 0 {
+		health.Recovering = attempts
+	}
+	if len(index.unmet) > 0 {
+		health.UnmetRequirements = make(map[string][]string, len(index.unmet))
+		for name, unmet := range index.unmet {
+			health.UnmetRequirements[name] = slices.Clone(unmet)
 		}
 	}
 
@@ -725,7 +1442,7 @@ func (r *DefaultToolRegistry) Health() RegistryHealth {
 	}
 
 	// Determine overall status
-	if !r.running {
+	if !r.entities.Running() {
 		health.Status = "stopped"
 	} else if health.ErrorTools > 0 {
 		health.Status = "degraded"
@@ -737,5 +1454,39 @@ func (r *DefaultToolRegistry) Health() RegistryHealth {
 			fmt.Sprintf("%d tools in error state", health.ErrorTools))
 	}
 
+	openBreakers := 0
+	for _, state := range health.CircuitBreakers {
+		if state == "open" {
+			openBreakers++
+		}
+	}
+	if openBreakers > 0 {
+		if health.Status == "healthy" {
+			health.Status = "degraded"
+		}
+		health.Errors = append(health.Errors,
+			fmt.Sprintf("%d tool circuit breakers open", openBreakers))
+	}
+
+	saturated := 0
+	for _, stats := range health.Bulkheads {
+		if stats.Saturated {
+			saturated++
+		}
+	}
+	if saturated > 0 {
+		if health.Status == "healthy" {
+			health.Status = "degraded"
+		}
+		health.Errors = append(health.Errors,
+			fmt.Sprintf("%d tool bulkheads saturated", saturated))
+	}
+	if health.GlobalBulkhead != nil && health.GlobalBulkhead.Saturated {
+		if health.Status == "healthy" {
+			health.Status = "degraded"
+		}
+		health.Errors = append(health.Errors, "global tool bulkhead saturated")
+	}
+
 	return health
 }
\ No newline at end of file
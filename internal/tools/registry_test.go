@@ -3,15 +3,19 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"mcp-server/internal/config"
+	"mcp-server/internal/events"
 	"mcp-server/internal/logger"
 	"mcp-server/internal/mcp"
+	"mcp-server/internal/registry"
 	"mcp-server/internal/tools/adapters"
 )
 
@@ -377,6 +381,130 @@ func TestDefaultToolRegistry_ValidateTools(t *testing.T) {
 	}
 }
 
+// slowToolFactory creates its tool once release is closed, or fails when
+// ctx ends first
+type slowToolFactory struct {
+	mockToolFactory
+	release chan struct{}
+}
+
+func (f *slowToolFactory) Create(ctx context.Context, config ToolConfig) (mcp.Tool, error) {
+	select {
+	case <-f.release:
+		return f.mockToolFactory.Create(ctx, config)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestDefaultToolRegistry_LoadToolsLeavesSlowToolsLoadingPastDeadline(t *testing.T) {
+	cfg := &config.Config{
+		MCP:     config.MCPConfig{MaxTools: 100},
+		Startup: config.StartupConfig{Workers: 4, FactoryTimeout: 5 * time.Second, Deadline: 50 * time.Millisecond},
+	}
+	log, _ := logger.NewDefault()
+	registry := NewDefaultToolRegistry(cfg, log)
+	ctx := context.Background()
+
+	slow := &slowToolFactory{mockToolFactory: *createTestFactory("slow_tool").(*mockToolFactory), release: make(chan struct{})}
+	if err := registry.Register("slow_tool", slow); err != nil {
+		t.Fatalf("failed to register slow tool: %v", err)
+	}
+	for _, name := range []string{"fast_a", "fast_b", "fast_c"} {
+		if err := registry.Register(name, createTestFactory(name)); err != nil {
+			t.Fatalf("failed to register %s: %v", name, err)
+		}
+	}
+	if err := registry.Start(ctx); err != nil {
+		t.Fatalf("failed to start registry: %v", err)
+	}
+	defer registry.Stop(ctx)
+
+	if registry.StartupReport() != nil {
+		t.Error("expected no startup report before loading")
+	}
+
+	started := time.Now()
+	if err := registry.LoadTools(ctx); err != nil {
+		t.Fatalf("expected tools still loading not to fail the load, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("expected loading to stop waiting at the deadline, took %v", elapsed)
+	}
+
+	report := registry.StartupReport()
+	if !report.DeadlineExceeded || report.Complete() || report.Loaded != 3 || report.Pending != 1 {
+		t.Fatalf("unexpected report at the deadline: %+v", report)
+	}
+	if _, err := registry.Get("fast_a"); err != nil {
+		t.Errorf("expected fast tools to be ready, got %v", err)
+	}
+
+	close(slow.release)
+	deadline := time.Now().Add(time.Second)
+	for !registry.StartupReport().Complete() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	report = registry.StartupReport()
+	if !report.Complete() || report.Loaded != 4 || report.Pending != 0 {
+		t.Fatalf("expected the slow tool to finish in background, got %+v", report)
+	}
+	for _, result := range report.Entities {
+		if background := result.Key == "slow_tool"; result.Background != background {
+			t.Errorf("unexpected background flag for %s: %+v", result.Key, result)
+		}
+	}
+	if info := findToolInfo(registry.List(), "slow_tool"); info == nil || info.Status != ToolStatusLoaded {
+		t.Errorf("expected slow tool loaded, got %+v", info)
+	}
+}
+
+func TestDefaultToolRegistry_LoadToolsBoundsEachFactory(t *testing.T) {
+	cfg := &config.Config{
+		MCP:     config.MCPConfig{MaxTools: 100},
+		Startup: config.StartupConfig{Workers: 2, FactoryTimeout: 20 * time.Millisecond, Deadline: 5 * time.Second},
+	}
+	log, _ := logger.NewDefault()
+	registry := NewDefaultToolRegistry(cfg, log)
+	ctx := context.Background()
+
+	stuck := &slowToolFactory{mockToolFactory: *createTestFactory("stuck_tool").(*mockToolFactory), release: make(chan struct{})}
+	if err := registry.Register("stuck_tool", stuck); err != nil {
+		t.Fatalf("failed to register stuck tool: %v", err)
+	}
+	if err := registry.Register("fast_tool", createTestFactory("fast_tool")); err != nil {
+		t.Fatalf("failed to register fast tool: %v", err)
+	}
+	if err := registry.Start(ctx); err != nil {
+		t.Fatalf("failed to start registry: %v", err)
+	}
+	defer registry.Stop(ctx)
+
+	err := registry.LoadTools(ctx)
+	if err == nil || !strings.Contains(err.Error(), "stuck_tool") {
+		t.Fatalf("expected the stuck tool to fail loading, got %v", err)
+	}
+
+	report := registry.StartupReport()
+	if !report.Complete() || report.DeadlineExceeded || report.Loaded != 1 || report.Failed != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if info := findToolInfo(registry.List(), "stuck_tool"); info == nil || info.Status != ToolStatusError {
+		t.Errorf("expected stuck tool in error, got %+v", info)
+	}
+}
+
+// findToolInfo returns the listed tool with the registry key id, or nil
+func findToolInfo(infos []ToolInfo, id string) *ToolInfo {
+	for i := range infos {
+		if infos[i].ID == id {
+			return &infos[i]
+		}
+	}
+	return nil
+}
+
 func TestDefaultToolRegistry_ConcurrentAccess(t *testing.T) {
 	registry := createTestRegistry()
 	ctx := context.Background()
@@ -447,6 +575,186 @@ func TestDefaultToolRegistry_ConcurrentAccess(t *testing.T) {
 	}
 }
 
+func TestDefaultToolRegistry_StatusHistoryIsBounded(t *testing.T) {
+	reg := createTestRegistry()
+	if err := reg.Register("test_tool", createTestFactory("test_tool")); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	admin := registry.TransitionCause{Actor: registry.ActorAdmin, Reason: "disable requested by oncall"}
+	if err := reg.TransitionStatusWith("test_tool", ToolStatusDisabled, admin); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	history := reg.List()[0].History
+	if len(history) != 2 || history[1].Actor != registry.ActorAdmin || history[1].Reason != admin.Reason {
+		t.Fatalf("Expected the admin transition to be recorded, got %+v", history)
+	}
+
+	// Flap between disabled and error well past the bound
+	for i := 0; i < registry.MaxTransitionHistory; i++ {
+		next := ToolStatusError
+		if i%2 == 1 {
+			next = ToolStatusDisabled
+		}
+		if err := reg.TransitionStatus("test_tool", next); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+	}
+
+	history = reg.List()[0].History
+	if len(history) != registry.MaxTransitionHistory {
+		t.Fatalf("Expected history bounded to %d entries, got %d", registry.MaxTransitionHistory, len(history))
+	}
+	if last := history[len(history)-1]; last.From != ToolStatusError || last.To != ToolStatusDisabled || last.Actor != registry.ActorSystem {
+		t.Errorf("Expected the latest transition last, got %+v", last)
+	}
+	if history[0].To == ToolStatusRegistered {
+		t.Error("Expected the oldest entries to be dropped")
+	}
+}
+
+func TestDefaultToolRegistry_RestoresPersistedState(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.json")
+	store, err := registry.NewJSONFileStore(path)
+	if err != nil {
+		t.Fatalf("Expected no error opening store, got: %v", err)
+	}
+
+	reg := createTestRegistry()
+	reg.SetStateStore(store)
+	if err := reg.Register("test_tool", createTestFactory("test_tool")); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := reg.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting registry, got: %v", err)
+	}
+
+	admin := registry.TransitionCause{Actor: registry.ActorAdmin, Reason: "disable requested by oncall"}
+	if err := reg.TransitionStatusWith("test_tool", ToolStatusDisabled, admin); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := reg.RestartTool(registry.WithActor(ctx, registry.ActorAdmin), "test_tool"); err != nil {
+		t.Fatalf("Expected no error restarting tool, got: %v", err)
+	}
+	if err := reg.TransitionStatusWith("test_tool", ToolStatusDisabled, admin); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	reg.Stop(ctx)
+
+	// A new process reads the same file
+	store, err = registry.NewJSONFileStore(path)
+	if err != nil {
+		t.Fatalf("Expected no error reopening store, got: %v", err)
+	}
+	restored := createTestRegistry()
+	restored.SetStateStore(store)
+	if err := restored.Register("test_tool", createTestFactory("test_tool")); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := restored.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting registry, got: %v", err)
+	}
+	defer restored.Stop(ctx)
+
+	info := restored.List()[0]
+	if info.Status != ToolStatusDisabled {
+		t.Fatalf("Expected the operator's disable to be restored, got %s", info.Status)
+	}
+	if info.Restarts != 1 {
+		t.Errorf("Expected 1 restart restored, got %d", info.Restarts)
+	}
+	if last := info.History[len(info.History)-1]; last.Reason != "restored: "+admin.Reason {
+		t.Errorf("Expected the restore to be recorded, got %+v", last)
+	}
+	if _, err := restored.Get("test_tool"); !errors.Is(err, ErrToolDisabled) {
+		t.Errorf("Expected ErrToolDisabled, got: %v", err)
+	}
+
+	// Enabling it again lifts the persisted decision
+	if err := restored.RestartTool(registry.WithActor(ctx, registry.ActorAdmin), "test_tool"); err != nil {
+		t.Fatalf("Expected no error restarting tool, got: %v", err)
+	}
+	states, _ := store.Load(events.KindTool)
+	if state := states["test_tool@1.0.0"]; state.Status != "" || state.Restarts != 2 {
+		t.Errorf("Expected the disable lifted and 2 restarts, got %+v", state)
+	}
+}
+
+func TestDefaultToolRegistry_PersistedStateFollowsVersion(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.json")
+	store, err := registry.NewJSONFileStore(path)
+	if err != nil {
+		t.Fatalf("Expected no error opening store, got: %v", err)
+	}
+
+	reg := createTestRegistry()
+	reg.SetStateStore(store)
+	reg.Register("echo", createVersionedFactory("echo", "1.0.0"))
+	reg.Register("echo", createVersionedFactory("echo", "2.0.0"))
+	if err := reg.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting registry, got: %v", err)
+	}
+	admin := registry.TransitionCause{Actor: registry.ActorAdmin, Reason: "broken release"}
+	if err := reg.TransitionStatusWith("echo@2.0.0", ToolStatusDisabled, admin); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	reg.Stop(ctx)
+
+	// The next process loads the versions the other way round, so 2.0.0
+	// now holds the bare registry key
+	store, err = registry.NewJSONFileStore(path)
+	if err != nil {
+		t.Fatalf("Expected no error reopening store, got: %v", err)
+	}
+	restored := createTestRegistry()
+	restored.SetStateStore(store)
+	restored.Register("echo", createVersionedFactory("echo", "2.0.0"))
+	restored.Register("echo", createVersionedFactory("echo", "1.0.0"))
+	if err := restored.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting registry, got: %v", err)
+	}
+	defer restored.Stop(ctx)
+
+	for _, info := range restored.List() {
+		disabled := info.Status == ToolStatusDisabled
+		if disabled != (info.Version == "2.0.0") {
+			t.Errorf("Expected only 2.0.0 to be disabled, got %s %s", info.Version, info.Status)
+		}
+	}
+}
+
+func TestDefaultToolRegistry_PublishesEvents(t *testing.T) {
+	reg := createTestRegistry().(*DefaultToolRegistry)
+	bus := events.NewBus(events.DefaultHistory)
+	reg.SetEvents(bus)
+	_, feed, cancel := bus.Subscribe(0, 16)
+	defer cancel()
+
+	if err := reg.Register("test_tool", createTestFactory("test_tool")); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := reg.TransitionStatusWith("test_tool", ToolStatusError, registry.SystemCause("probe failed")); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := reg.Unregister("test_tool"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := []events.Event{
+		{Type: events.TypeRegistered, Kind: events.KindTool, Name: "test_tool", To: "registered"},
+		{Type: events.TypeStatusChanged, Kind: events.KindTool, Name: "test_tool", From: "registered", To: "error", Reason: "probe failed"},
+		{Type: events.TypeUnregistered, Kind: events.KindTool, Name: "test_tool"},
+	}
+	for _, want := range expected {
+		got := <-feed
+		if got.Type != want.Type || got.Name != want.Name || got.From != want.From || got.To != want.To || got.Reason != want.Reason {
+			t.Errorf("Expected event %+v, got %+v", want, got)
+		}
+	}
+}
+
 func TestDefaultToolRegistry_Health(t *testing.T) {
 	registry := createTestRegistry()
 	ctx := context.Background()
@@ -570,6 +878,10 @@ func (m *mockLibraryAdapter) RegisterResource(resource mcp.Resource) error   { r
 func (m *mockLibraryAdapter) UnregisterResource(uri string) error            { return nil }
 func (m *mockLibraryAdapter) GetResource(uri string) (mcp.Resource, error)   { return nil, nil }
 func (m *mockLibraryAdapter) ListResources() []string                        { return nil }
+func (m *mockLibraryAdapter) SetPipeline(pipeline *mcp.Pipeline)             {}
+func (m *mockLibraryAdapter) RegisterPrompt(prompt mcp.Prompt) error         { return nil }
+func (m *mockLibraryAdapter) UnregisterPrompt(name string) error             { return nil }
+func (m *mockLibraryAdapter) ListPrompts() []string                          { return nil }
 
 func (m *mockLibraryAdapter) Start(ctx context.Context) error {
 	m.mu.Lock()
@@ -651,10 +963,10 @@ func TestDefaultToolRegistry_AdapterIntegration(t *testing.T) {
 		t.Errorf("Expected tool name 'test_tool', got '%s'", tool.Name())
 	}
 
-	// Check tool was registered with adapter
+	// Check tool was registered with adapter, by name and by name@version
 	adapterTools := adapter.ListTools()
-	if len(adapterTools) != 1 {
-		t.Errorf("Expected 1 tool in adapter, got %d", len(adapterTools))
+	if len(adapterTools) != 2 {
+		t.Errorf("Expected 2 tool names in adapter, got %v", adapterTools)
 	}
 
 	// Unregister should remove from adapter
@@ -763,4 +1075,180 @@ func TestDefaultToolRegistry_HealthWithAdapter(t *testing.T) {
 	if len(health.Errors) == 0 {
 		t.Error("Expected errors in health when adapter is degraded")
 	}
-}
\ No newline at end of file
+}
+// Benchmark tests
+
+// newBenchmarkRegistry returns a started registry holding count loaded
+// tools. It logs only errors so logging does not dominate the timings.
+func newBenchmarkRegistry(b *testing.B, count int) (*DefaultToolRegistry, []string) {
+	b.Helper()
+	log, _ := logger.New(logger.Config{Level: "error", Format: "text", Service: "bench", Version: "bench"})
+	r := NewDefaultToolRegistry(&config.Config{MCP: config.MCPConfig{MaxTools: count}}, log).(*DefaultToolRegistry)
+
+	ctx := context.Background()
+	names := make([]string, count)
+	for i := range names {
+		names[i] = fmt.Sprintf("bench_tool_%d", i)
+		if err := r.Register(names[i], createTestFactory(names[i])); err != nil {
+			b.Fatalf("failed to register %s: %v", names[i], err)
+		}
+	}
+	if err := r.Start(ctx); err != nil {
+		b.Fatalf("failed to start registry: %v", err)
+	}
+	if err := r.LoadTools(ctx); err != nil {
+		b.Fatalf("failed to load tools: %v", err)
+	}
+	b.Cleanup(func() { r.Stop(ctx) })
+	return r, names
+}
+
+// churnStatuses moves tools between active and loaded until the benchmark
+// ends, like the status transitions of a busy server
+func churnStatuses(b *testing.B, r *DefaultToolRegistry, names []string) {
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			status := ToolStatusActive
+			if i/len(names)%2 == 1 {
+				status = ToolStatusLoaded
+			}
+			r.TransitionStatus(names[i%len(names)], status)
+		}
+	}()
+	b.Cleanup(func() {
+		close(stop)
+		<-done
+	})
+}
+
+// benchmarkReaders runs read in parallel, alone and alongside a goroutine
+// churning tool statuses
+func benchmarkReaders(b *testing.B, read func(r *DefaultToolRegistry, name string)) {
+	for _, churn := range []bool{false, true} {
+		name := "readers"
+		if churn {
+			name = "readers_and_writer"
+		}
+		b.Run(name, func(b *testing.B) {
+			r, names := newBenchmarkRegistry(b, 100)
+			if churn {
+				churnStatuses(b, r, names)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					read(r, names[i%len(names)])
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDefaultToolRegistry_Get(b *testing.B) {
+	benchmarkReaders(b, func(r *DefaultToolRegistry, name string) {
+		if _, err := r.Get(name); err != nil {
+			b.Error(err)
+		}
+	})
+}
+
+func BenchmarkDefaultToolRegistry_List(b *testing.B) {
+	benchmarkReaders(b, func(r *DefaultToolRegistry, _ string) {
+		r.List()
+	})
+}
+
+func BenchmarkDefaultToolRegistry_Health(b *testing.B) {
+	benchmarkReaders(b, func(r *DefaultToolRegistry, _ string) {
+		r.Health()
+	})
+}
+
+// blockingToolFactory holds every Create until release is closed
+type blockingToolFactory struct {
+	*mockToolFactory
+	creating chan struct{}
+	release  chan struct{}
+}
+
+func (f *blockingToolFactory) Create(ctx context.Context, config ToolConfig) (mcp.Tool, error) {
+	f.creating <- struct{}{}
+	<-f.release
+	return f.mockToolFactory.Create(ctx, config)
+}
+
+func TestDefaultToolRegistry_GetDuringDisable(t *testing.T) {
+	r := createTestRegistry()
+	factory := &blockingToolFactory{
+		mockToolFactory: createTestFactory("slow").(*mockToolFactory),
+		creating:        make(chan struct{}, 1),
+		release:         make(chan struct{}),
+	}
+	if err := r.Register("slow", factory); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := r.Get("slow")
+		errs <- err
+	}()
+
+	// Disable the tool while its instance is being created
+	<-factory.creating
+	if err := r.TransitionStatus("slow", ToolStatusDisabled); err != nil {
+		t.Fatalf("failed to disable tool: %v", err)
+	}
+	close(factory.release)
+
+	if err := <-errs; !errors.Is(err, ErrToolDisabled) {
+		t.Errorf("expected ErrToolDisabled, got %v", err)
+	}
+	if _, exists := r.(*DefaultToolRegistry).entities.Instance("slow"); exists {
+		t.Error("expected the instance of the disabled tool to be thrown away")
+	}
+}
+
+func TestDefaultToolRegistry_ConcurrentGet(t *testing.T) {
+	r := createTestRegistry()
+	factory := &blockingToolFactory{
+		mockToolFactory: createTestFactory("shared").(*mockToolFactory),
+		creating:        make(chan struct{}, 2),
+		release:         make(chan struct{}),
+	}
+	if err := r.Register("shared", factory); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	results := make(chan mcp.Tool, 2)
+	for range 2 {
+		go func() {
+			tool, err := r.Get("shared")
+			if err != nil {
+				t.Errorf("Get failed: %v", err)
+			}
+			results <- tool
+		}()
+	}
+
+	// Both calls create an instance before either stores one
+	<-factory.creating
+	<-factory.creating
+	close(factory.release)
+
+	first, second := <-results, <-results
+	if first == nil || first != second {
+		t.Error("expected concurrent calls to return the same instance")
+	}
+	if stored, _ := r.Get("shared"); stored != first {
+		t.Error("expected the returned instance to be the stored one")
+	}
+}
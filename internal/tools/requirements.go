@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/registry"
+)
+
+// Requirement kinds checked by the registry. ToolFactory.Requirements keys
+// take the form kind:target, except server_version whose value is the
+// minimum version. Keys of any other form, such as "runtime", are
+// informational and never checked; values of the other kinds are free-form
+// notes.
+const (
+	RequirementTool          = "tool"           // tool:<name>[@<version>] must be loaded or active
+	RequirementResource      = "resource"       // resource:<uri> must be loaded or active
+	RequirementEnv           = "env"            // env:<VAR> must be set and non-empty
+	RequirementExecutable    = "exec"           // exec:<program> must be found on PATH
+	RequirementServerVersion = "server_version" // the server must be at least this version
+)
+
+// Requirement is one checked entry of ToolFactory.Requirements
+type Requirement struct {
+	Kind   string
+	Target string
+}
+
+func (req Requirement) String() string {
+	if req.Kind == RequirementServerVersion {
+		return req.Kind + " >= " + req.Target
+	}
+	return req.Kind + ":" + req.Target
+}
+
+// ParseRequirements extracts the checked requirements from the requirements
+// a factory declares, sorted for stable reporting
+func ParseRequirements(declared map[string]string) ([]Requirement, error) {
+	var requirements []Requirement
+	var errors ToolValidationErrors
+
+	for key, value := range declared {
+		if key == RequirementServerVersion {
+			minimum := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), ">="))
+			if !isReleaseVersion(minimum) {
+				errors.Add("requirements", key, "minimum server version must be a dotted version such as 1.2.0")
+				continue
+			}
+			requirements = append(requirements, Requirement{Kind: key, Target: minimum})
+			continue
+		}
+
+		kind, target, found := strings.Cut(key, ":")
+		if !found {
+			continue
+		}
+		switch kind {
+		case RequirementTool, RequirementResource, RequirementEnv, RequirementExecutable:
+			if target == "" {
+				errors.Add("requirements", key, "requirement target cannot be empty")
+				continue
+			}
+			requirements = append(requirements, Requirement{Kind: kind, Target: target})
+		}
+	}
+
+	if errors.HasErrors() {
+		return nil, errors
+	}
+	sort.Slice(requirements, func(i, j int) bool {
+		return requirements[i].String() < requirements[j].String()
+	})
+	return requirements, nil
+}
+
+// isReleaseVersion reports whether version starts with a numeric segment;
+// development builds such as "dev" are not release versions
+func isReleaseVersion(version string) bool {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	_, err := strconv.Atoi(major)
+	return err == nil
+}
+
+// isAvailable reports whether a tool or resource in status can serve
+// requests, which is what requiring it means
+func isAvailable(status registry.LifecycleStatus) bool {
+	return status == registry.StatusLoaded || status == registry.StatusActive
+}
+
+// ResourceLookup reports the lifecycle status of a registered resource, so
+// tools can require resources without the registries depending on each other
+type ResourceLookup func(uri string) (status registry.LifecycleStatus, exists bool)
+
+// ResourceWait blocks until a resource loading at startup finished, so
+// tools requiring it are checked once it loaded
+type ResourceWait func(ctx context.Context, uri string) error
+
+// requirementChecker checks the requirements that do not depend on other
+// tools. The lookups are fields so tests can replace them.
+type requirementChecker struct {
+	serverVersion string
+	resources     ResourceLookup
+	lookupEnv     func(key string) (string, bool)
+	lookPath      func(file string) (string, error)
+}
+
+func newRequirementChecker(cfg *config.Config) *requirementChecker {
+	checker := &requirementChecker{
+		lookupEnv: os.LookupEnv,
+		lookPath:  exec.LookPath,
+	}
+	if cfg != nil {
+		checker.serverVersion = cfg.Logger.Version
+	}
+	return checker
+}
+
+// check returns why req is not met, or "" when it is. Development builds
+// satisfy any minimum server version.
+func (c *requirementChecker) check(req Requirement) string {
+	switch req.Kind {
+	case RequirementEnv:
+		if value, exists := c.lookupEnv(req.Target); !exists || value == "" {
+			return fmt.Sprintf("environment variable %s is not set", req.Target)
+		}
+	case RequirementExecutable:
+		if _, err := c.lookPath(req.Target); err != nil {
+			return fmt.Sprintf("executable %s not found on PATH", req.Target)
+		}
+	case RequirementServerVersion:
+		if isReleaseVersion(c.serverVersion) && compareVersions(c.serverVersion, req.Target) < 0 {
+			return fmt.Sprintf("server version %s is older than the required %s", c.serverVersion, req.Target)
+		}
+	case RequirementResource:
+		if c.resources == nil {
+			return fmt.Sprintf("required resource %s cannot be checked without a resource registry", req.Target)
+		}
+		status, exists := c.resources(req.Target)
+		if !exists {
+			return fmt.Sprintf("required resource %s is not registered", req.Target)
+		}
+		if !isAvailable(status) {
+			return fmt.Sprintf("required resource %s is %s", req.Target, status)
+		}
+	}
+	return ""
+}
+
+// orderByDependencies sorts keys so every tool follows the tools it
+// requires; deps maps a key to the keys it requires. Tools on or behind a
+// dependency cycle cannot be ordered and are returned separately.
+func orderByDependencies(keys []string, deps map[string][]string) (ordered, cyclic []string) {
+	pending := make(map[string]int, len(keys))
+	dependents := make(map[string][]string)
+	for _, key := range keys {
+		pending[key] = 0
+	}
+	for _, key := range keys {
+		for _, dep := range deps[key] {
+			// Unregistered dependencies are reported by the requirement check
+			if _, known := pending[dep]; !known {
+				continue
+			}
+			pending[key]++
+			dependents[dep] = append(dependents[dep], key)
+		}
+	}
+
+	var ready []string
+	for _, key := range keys {
+		if pending[key] == 0 {
+			ready = append(ready, key)
+		}
+	}
+	sort.Strings(ready)
+
+	for len(ready) > 0 {
+		key := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, key)
+
+		var unblocked []string
+		for _, dependent := range dependents[key] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				unblocked = append(unblocked, dependent)
+			}
+		}
+		sort.Strings(unblocked)
+		ready = append(ready, unblocked...)
+	}
+
+	for _, key := range keys {
+		if pending[key] > 0 {
+			cyclic = append(cyclic, key)
+		}
+	}
+	sort.Strings(cyclic)
+	return ordered, cyclic
+}
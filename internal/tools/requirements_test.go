@@ -0,0 +1,224 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"mcp-server/internal/registry"
+)
+
+func createRequiringFactory(name string, requirements map[string]string) ToolFactory {
+	factory := createTestFactory(name).(*mockToolFactory)
+	factory.requirements = requirements
+	return factory
+}
+
+func TestParseRequirements(t *testing.T) {
+	requirements, err := ParseRequirements(map[string]string{
+		"runtime":          "go",
+		"tool:lookup":      "results are enriched from lookup",
+		"env:API_TOKEN":    "",
+		"exec:git":         "",
+		"resource:file://": "",
+		"server_version":   ">= 1.4.0",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Requirement{
+		{Kind: RequirementEnv, Target: "API_TOKEN"},
+		{Kind: RequirementExecutable, Target: "git"},
+		{Kind: RequirementResource, Target: "file://"},
+		{Kind: RequirementServerVersion, Target: "1.4.0"},
+		{Kind: RequirementTool, Target: "lookup"},
+	}
+	if !reflect.DeepEqual(requirements, want) {
+		t.Errorf("unexpected requirements:\n got %v\nwant %v", requirements, want)
+	}
+
+	for _, declared := range []map[string]string{
+		{"tool:": ""},
+		{"server_version": "latest"},
+	} {
+		if _, err := ParseRequirements(declared); err == nil {
+			t.Errorf("expected %v to be rejected", declared)
+		}
+	}
+}
+
+func TestOrderByDependencies(t *testing.T) {
+	keys := []string{"report", "fetch", "parse", "loop_a", "loop_b", "after_loop"}
+	deps := map[string][]string{
+		"report":     {"parse", "fetch"},
+		"parse":      {"fetch", "missing"},
+		"loop_a":     {"loop_b"},
+		"loop_b":     {"loop_a"},
+		"after_loop": {"loop_a"},
+	}
+
+	ordered, cyclic := orderByDependencies(keys, deps)
+	if want := []string{"fetch", "parse", "report"}; !reflect.DeepEqual(ordered, want) {
+		t.Errorf("ordered = %v, want %v", ordered, want)
+	}
+	if want := []string{"after_loop", "loop_a", "loop_b"}; !reflect.DeepEqual(cyclic, want) {
+		t.Errorf("cyclic = %v, want %v", cyclic, want)
+	}
+}
+
+func TestRequirementChecker_Check(t *testing.T) {
+	checker := &requirementChecker{
+		serverVersion: "1.4.2",
+		resources: func(uri string) (registry.LifecycleStatus, bool) {
+			if uri == "custom://disabled" {
+				return registry.StatusDisabled, true
+			}
+			return registry.StatusActive, uri == "custom://data"
+		},
+		lookupEnv: func(key string) (string, bool) {
+			return "secret", key == "API_TOKEN"
+		},
+		lookPath: func(file string) (string, error) {
+			if file == "git" {
+				return "/usr/bin/git", nil
+			}
+			return "", errors.New("not found")
+		},
+	}
+
+	tests := []struct {
+		req   Requirement
+		unmet bool
+	}{
+		{Requirement{RequirementEnv, "API_TOKEN"}, false},
+		{Requirement{RequirementEnv, "MISSING"}, true},
+		{Requirement{RequirementExecutable, "git"}, false},
+		{Requirement{RequirementExecutable, "hg"}, true},
+		{Requirement{RequirementServerVersion, "1.4.0"}, false},
+		{Requirement{RequirementServerVersion, "1.10.0"}, true},
+		{Requirement{RequirementResource, "custom://data"}, false},
+		{Requirement{RequirementResource, "custom://disabled"}, true},
+		{Requirement{RequirementResource, "custom://missing"}, true},
+	}
+	for _, tt := range tests {
+		if reason := checker.check(tt.req); (reason != "") != tt.unmet {
+			t.Errorf("check(%s) = %q, want unmet=%v", tt.req, reason, tt.unmet)
+		}
+	}
+
+	// Development builds satisfy any minimum server version
+	checker.serverVersion = "dev"
+	if reason := checker.check(Requirement{RequirementServerVersion, "99.0.0"}); reason != "" {
+		t.Errorf("expected dev build to satisfy minimum version, got %q", reason)
+	}
+}
+
+func TestDefaultToolRegistry_LoadToolsRequirements(t *testing.T) {
+	reg := createTestRegistry().(*DefaultToolRegistry)
+	reg.checker.lookupEnv = func(key string) (string, bool) { return "", false }
+	ctx := context.Background()
+	if err := reg.Start(ctx); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	factories := map[string]ToolFactory{
+		"fetch":   createTestFactory("fetch"),
+		"parse":   createRequiringFactory("parse", map[string]string{"tool:fetch": ""}),
+		"report":  createRequiringFactory("report", map[string]string{"tool:parse": ""}),
+		"upload":  createRequiringFactory("upload", map[string]string{"env:UPLOAD_TOKEN": ""}),
+		"publish": createRequiringFactory("publish", map[string]string{"tool:upload": ""}),
+	}
+	for name, factory := range factories {
+		if err := reg.Register(name, factory); err != nil {
+			t.Fatalf("register %s failed: %v", name, err)
+		}
+	}
+
+	err := reg.LoadTools(ctx)
+	if err == nil || !strings.Contains(err.Error(), "UPLOAD_TOKEN") {
+		t.Fatalf("expected load to report the missing variable, got %v", err)
+	}
+
+	for _, name := range []string{"fetch", "parse", "report"} {
+		if _, err := reg.Get(name); err != nil {
+			t.Errorf("expected %s to load, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"upload", "publish"} {
+		if _, err := reg.Get(name); !errors.Is(err, ErrRequirementsNotMet) {
+			t.Errorf("expected %s to be refused, got %v", name, err)
+		}
+		if err := reg.TransitionStatus(name, ToolStatusRegistered); err != nil {
+			t.Fatalf("transition %s to registered failed: %v", name, err)
+		}
+		if err := reg.TransitionStatus(name, ToolStatusLoaded); !errors.Is(err, ErrRequirementsNotMet) {
+			t.Errorf("expected activating %s to be refused, got %v", name, err)
+		}
+	}
+
+	health := reg.Health()
+	if unmet := health.UnmetRequirements["upload"]; len(unmet) != 1 || !strings.Contains(unmet[0], "UPLOAD_TOKEN") {
+		t.Errorf("expected health to show the missing variable, got %v", unmet)
+	}
+	if unmet := health.UnmetRequirements["publish"]; len(unmet) != 1 || !strings.Contains(unmet[0], "upload") {
+		t.Errorf("expected health to show the failed required tool, got %v", unmet)
+	}
+
+	// A failing tool takes the tools requiring it down with it
+	if err := reg.TransitionStatus("fetch", ToolStatusError); err != nil {
+		t.Fatalf("transition fetch to error failed: %v", err)
+	}
+	for _, info := range reg.List() {
+		if (info.Name == "parse" || info.Name == "report") && info.Status != ToolStatusError {
+			t.Errorf("expected %s to move to error, got %s", info.Name, info.Status)
+		}
+	}
+}
+
+func TestDefaultToolRegistry_LoadToolsWaitsForRequiredResources(t *testing.T) {
+	reg := createTestRegistry().(*DefaultToolRegistry)
+	ctx := context.Background()
+	if err := reg.Start(ctx); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	// The resource loads alongside the tools, slower than they do
+	var mu sync.Mutex
+	status := registry.StatusRegistered
+	reg.SetResourceLookup(func(uri string) (registry.LifecycleStatus, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		return status, uri == "file:///data"
+	})
+	resources := registry.NewLoader("resource", nil, reg.logger)
+	reg.SetResourceWait(resources.Wait)
+
+	if err := reg.Register("reader", createRequiringFactory("reader", map[string]string{"resource:file:///data": ""})); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+		resources.Run(ctx, []registry.LoadTask{{Key: "file:///data"}}, func(ctx context.Context, uri string) error {
+			time.Sleep(50 * time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			status = registry.StatusLoaded
+			return nil
+		})
+	}()
+	defer func() { <-loaded }()
+
+	if err := reg.LoadTools(ctx); err != nil {
+		t.Fatalf("expected reader to load once its resource did, got %v", err)
+	}
+	if _, err := reg.Get("reader"); err != nil {
+		t.Errorf("expected reader to be available, got %v", err)
+	}
+}
@@ -0,0 +1,506 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidArguments is returned when tool arguments do not satisfy the tool's input schema
+var ErrInvalidArguments = fmt.Errorf("invalid tool arguments")
+
+// ArgumentSchema validates tool arguments against a JSON Schema (draft 2020-12 subset).
+// Supported keywords: type, enum, const, required, properties, additionalProperties,
+// minProperties, maxProperties, items, prefixItems, minItems, maxItems, uniqueItems,
+// minLength, maxLength, pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum
+// and multipleOf.
+type ArgumentSchema struct {
+	root *schemaNode
+}
+
+type schemaNode struct {
+	never                bool
+	types                []string
+	enum                 []interface{}
+	constValue           interface{}
+	hasConst             bool
+	required             []string
+	properties           map[string]*schemaNode
+	additionalProperties *schemaNode
+	noAdditional         bool
+	minProperties        *int
+	maxProperties        *int
+	items                *schemaNode
+	prefixItems          []*schemaNode
+	minItems             *int
+	maxItems             *int
+	uniqueItems          bool
+	minLength            *int
+	maxLength            *int
+	pattern              *regexp.Regexp
+	minimum              *float64
+	maximum              *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+	multipleOf           *float64
+}
+
+// CompileArgumentSchema parses a JSON schema into a reusable argument validator.
+// An empty schema accepts any arguments.
+func CompileArgumentSchema(schema json.RawMessage) (*ArgumentSchema, error) {
+	if len(bytes.TrimSpace(schema)) == 0 {
+		return &ArgumentSchema{root: &schemaNode{}}, nil
+	}
+
+	var raw interface{}
+	if err := json.Unmarshal(schema, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	root, err := compileSchemaNode(raw, "$")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArgumentSchema{root: root}, nil
+}
+
+// Validate checks arguments against the schema and returns ToolValidationErrors
+// whose Field holds the path of each offending value (e.g. $.items[2].name)
+func (s *ArgumentSchema) Validate(arguments json.RawMessage) error {
+	var value interface{}
+	if len(bytes.TrimSpace(arguments)) == 0 {
+		value = map[string]interface{}{}
+	} else if err := json.Unmarshal(arguments, &value); err != nil {
+		var errors ToolValidationErrors
+		errors.Add("$", "", fmt.Sprintf("arguments are not valid JSON: %v", err))
+		return errors
+	}
+
+	var errors ToolValidationErrors
+	s.root.validate(value, "$", &errors)
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// Properties returns the names of the top-level properties the schema
+// declares, sorted
+func (s *ArgumentSchema) Properties() []string {
+	properties := make([]string, 0, len(s.root.properties))
+	for name := range s.root.properties {
+		properties = append(properties, name)
+	}
+	sort.Strings(properties)
+	return properties
+}
+
+// FormatArgumentErrors renders every validation error as "path: message", joined by semicolons
+func FormatArgumentErrors(err error) string {
+	validationErrors, ok := err.(ToolValidationErrors)
+	if !ok {
+		return err.Error()
+	}
+
+	messages := make([]string, 0, len(validationErrors))
+	for _, e := range validationErrors {
+		messages = append(messages, fmt.Sprintf("%s: %s", e.Field, e.Message))
+	}
+	return strings.Join(messages, "; ")
+}
+
+func compileSchemaNode(raw interface{}, path string) (*schemaNode, error) {
+	switch v := raw.(type) {
+	case bool:
+		// true accepts everything, false accepts nothing
+		if v {
+			return &schemaNode{}, nil
+		}
+		return &schemaNode{never: true}, nil
+	case map[string]interface{}:
+		return compileSchemaObject(v, path)
+	default:
+		return nil, fmt.Errorf("schema at %s must be an object or boolean", path)
+	}
+}
+
+func compileSchemaObject(schema map[string]interface{}, path string) (*schemaNode, error) {
+	node := &schemaNode{}
+	var err error
+
+	if node.types, err = compileTypes(schema["type"], path); err != nil {
+		return nil, err
+	}
+
+	if enum, exists := schema["enum"]; exists {
+		values, ok := enum.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("enum at %s must be an array", path)
+		}
+		node.enum = values
+	}
+
+	if constValue, exists := schema["const"]; exists {
+		node.constValue = constValue
+		node.hasConst = true
+	}
+
+	if required, exists := schema["required"]; exists {
+		values, ok := required.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("required at %s must be an array", path)
+		}
+		for _, value := range values {
+			name, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("required at %s must contain only strings", path)
+			}
+			node.required = append(node.required, name)
+		}
+	}
+
+	if properties, exists := schema["properties"]; exists {
+		propertyMap, ok := properties.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("properties at %s must be an object", path)
+		}
+		node.properties = make(map[string]*schemaNode, len(propertyMap))
+		for name, propertySchema := range propertyMap {
+			child, err := compileSchemaNode(propertySchema, childPath(path, name))
+			if err != nil {
+				return nil, err
+			}
+			node.properties[name] = child
+		}
+	}
+
+	if additional, exists := schema["additionalProperties"]; exists {
+		if allowed, ok := additional.(bool); ok {
+			node.noAdditional = !allowed
+		} else if node.additionalProperties, err = compileSchemaNode(additional, path+".additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+
+	if items, exists := schema["items"]; exists {
+		if node.items, err = compileSchemaNode(items, path+"[*]"); err != nil {
+			return nil, err
+		}
+	}
+
+	if prefixItems, exists := schema["prefixItems"]; exists {
+		values, ok := prefixItems.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("prefixItems at %s must be an array", path)
+		}
+		for i, itemSchema := range values {
+			child, err := compileSchemaNode(itemSchema, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			node.prefixItems = append(node.prefixItems, child)
+		}
+	}
+
+	if uniqueItems, ok := schema["uniqueItems"].(bool); ok {
+		node.uniqueItems = uniqueItems
+	}
+
+	if pattern, exists := schema["pattern"]; exists {
+		patternStr, ok := pattern.(string)
+		if !ok {
+			return nil, fmt.Errorf("pattern at %s must be a string", path)
+		}
+		if node.pattern, err = regexp.Compile(patternStr); err != nil {
+			return nil, fmt.Errorf("pattern at %s is not a valid regular expression: %w", path, err)
+		}
+	}
+
+	integerKeywords := map[string]**int{
+		"minLength":     &node.minLength,
+		"maxLength":     &node.maxLength,
+		"minItems":      &node.minItems,
+		"maxItems":      &node.maxItems,
+		"minProperties": &node.minProperties,
+		"maxProperties": &node.maxProperties,
+	}
+	for keyword, target := range integerKeywords {
+		if value, exists := schema[keyword]; exists {
+			number, ok := value.(float64)
+			if !ok || number < 0 || number != math.Trunc(number) {
+				return nil, fmt.Errorf("%s at %s must be a non-negative integer", keyword, path)
+			}
+			n := int(number)
+			*target = &n
+		}
+	}
+
+	numberKeywords := map[string]**float64{
+		"minimum":          &node.minimum,
+		"maximum":          &node.maximum,
+		"exclusiveMinimum": &node.exclusiveMinimum,
+		"exclusiveMaximum": &node.exclusiveMaximum,
+		"multipleOf":       &node.multipleOf,
+	}
+	for keyword, target := range numberKeywords {
+		if value, exists := schema[keyword]; exists {
+			number, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s at %s must be a number", keyword, path)
+			}
+			*target = &number
+		}
+	}
+
+	if node.multipleOf != nil && *node.multipleOf <= 0 {
+		return nil, fmt.Errorf("multipleOf at %s must be greater than zero", path)
+	}
+
+	return node, nil
+}
+
+var schemaTypes = map[string]bool{
+	"object":  true,
+	"array":   true,
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"null":    true,
+}
+
+func compileTypes(raw interface{}, path string) ([]string, error) {
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if !schemaTypes[v] {
+			return nil, fmt.Errorf("invalid type %q at %s", v, path)
+		}
+		return []string{v}, nil
+	case []interface{}:
+		types := make([]string, 0, len(v))
+		for _, t := range v {
+			typeStr, ok := t.(string)
+			if !ok || !schemaTypes[typeStr] {
+				return nil, fmt.Errorf("invalid type %v at %s", t, path)
+			}
+			types = append(types, typeStr)
+		}
+		return types, nil
+	default:
+		return nil, fmt.Errorf("type at %s must be a string or array of strings", path)
+	}
+}
+
+func (n *schemaNode) validate(value interface{}, path string, errors *ToolValidationErrors) {
+	if n.never {
+		errors.Add(path, describeValue(value), "no value is allowed here")
+		return
+	}
+
+	if len(n.types) > 0 && !n.matchesType(value) {
+		errors.Add(path, describeValue(value), fmt.Sprintf("expected %s, got %s", strings.Join(n.types, " or "), jsonType(value)))
+		return
+	}
+
+	if n.hasConst && !jsonEqual(value, n.constValue) {
+		errors.Add(path, describeValue(value), fmt.Sprintf("must be equal to %s", describeValue(n.constValue)))
+	}
+
+	if n.enum != nil && !n.inEnum(value) {
+		allowed := make([]string, 0, len(n.enum))
+		for _, e := range n.enum {
+			allowed = append(allowed, describeValue(e))
+		}
+		errors.Add(path, describeValue(value), fmt.Sprintf("must be one of [%s]", strings.Join(allowed, ", ")))
+	}
+
+	switch v := value.(type) {
+	case string:
+		n.validateString(v, path, errors)
+	case float64:
+		n.validateNumber(v, path, errors)
+	case []interface{}:
+		n.validateArray(v, path, errors)
+	case map[string]interface{}:
+		n.validateObject(v, path, errors)
+	}
+}
+
+func (n *schemaNode) validateString(value, path string, errors *ToolValidationErrors) {
+	length := utf8.RuneCountInString(value)
+	if n.minLength != nil && length < *n.minLength {
+		errors.Add(path, value, fmt.Sprintf("must be at least %d characters long, got %d", *n.minLength, length))
+	}
+	if n.maxLength != nil && length > *n.maxLength {
+		errors.Add(path, truncateValue(value), fmt.Sprintf("must be at most %d characters long, got %d", *n.maxLength, length))
+	}
+	if n.pattern != nil && !n.pattern.MatchString(value) {
+		errors.Add(path, truncateValue(value), fmt.Sprintf("must match pattern %q", n.pattern.String()))
+	}
+}
+
+func (n *schemaNode) validateNumber(value float64, path string, errors *ToolValidationErrors) {
+	display := describeValue(value)
+	if n.minimum != nil && value < *n.minimum {
+		errors.Add(path, display, fmt.Sprintf("must be >= %v", *n.minimum))
+	}
+	if n.maximum != nil && value > *n.maximum {
+		errors.Add(path, display, fmt.Sprintf("must be <= %v", *n.maximum))
+	}
+	if n.exclusiveMinimum != nil && value <= *n.exclusiveMinimum {
+		errors.Add(path, display, fmt.Sprintf("must be > %v", *n.exclusiveMinimum))
+	}
+	if n.exclusiveMaximum != nil && value >= *n.exclusiveMaximum {
+		errors.Add(path, display, fmt.Sprintf("must be < %v", *n.exclusiveMaximum))
+	}
+	if n.multipleOf != nil {
+		quotient := value / *n.multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			errors.Add(path, display, fmt.Sprintf("must be a multiple of %v", *n.multipleOf))
+		}
+	}
+}
+
+func (n *schemaNode) validateArray(value []interface{}, path string, errors *ToolValidationErrors) {
+	if n.minItems != nil && len(value) < *n.minItems {
+		errors.Add(path, fmt.Sprintf("%d items", len(value)), fmt.Sprintf("must contain at least %d items", *n.minItems))
+	}
+	if n.maxItems != nil && len(value) > *n.maxItems {
+		errors.Add(path, fmt.Sprintf("%d items", len(value)), fmt.Sprintf("must contain at most %d items", *n.maxItems))
+	}
+
+	for i, item := range value {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if i < len(n.prefixItems) {
+			n.prefixItems[i].validate(item, itemPath, errors)
+		} else if n.items != nil {
+			n.items.validate(item, itemPath, errors)
+		}
+	}
+
+	if n.uniqueItems {
+		for i := 0; i < len(value); i++ {
+			for j := i + 1; j < len(value); j++ {
+				if jsonEqual(value[i], value[j]) {
+					errors.Add(fmt.Sprintf("%s[%d]", path, j), describeValue(value[j]), fmt.Sprintf("duplicates item at index %d", i))
+				}
+			}
+		}
+	}
+}
+
+func (n *schemaNode) validateObject(value map[string]interface{}, path string, errors *ToolValidationErrors) {
+	if n.minProperties != nil && len(value) < *n.minProperties {
+		errors.Add(path, fmt.Sprintf("%d properties", len(value)), fmt.Sprintf("must have at least %d properties", *n.minProperties))
+	}
+	if n.maxProperties != nil && len(value) > *n.maxProperties {
+		errors.Add(path, fmt.Sprintf("%d properties", len(value)), fmt.Sprintf("must have at most %d properties", *n.maxProperties))
+	}
+
+	for _, name := range n.required {
+		if _, exists := value[name]; !exists {
+			errors.Add(childPath(path, name), "", "is required")
+		}
+	}
+
+	// Iterate in sorted order so error messages are deterministic
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property := value[name]
+		propertyPath := childPath(path, name)
+		if propertySchema, known := n.properties[name]; known {
+			propertySchema.validate(property, propertyPath, errors)
+		} else if n.additionalProperties != nil {
+			n.additionalProperties.validate(property, propertyPath, errors)
+		} else if n.noAdditional {
+			errors.Add(propertyPath, describeValue(property), "additional property is not allowed")
+		}
+	}
+}
+
+func (n *schemaNode) matchesType(value interface{}) bool {
+	actual := jsonType(value)
+	for _, t := range n.types {
+		if t == actual {
+			return true
+		}
+		if t == "number" && actual == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *schemaNode) inEnum(value interface{}) bool {
+	for _, allowed := range n.enum {
+		if jsonEqual(value, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func jsonEqual(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
+
+func describeValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return truncateValue(string(data))
+}
+
+func truncateValue(value string) string {
+	const maxDisplay = 64
+	if len(value) > maxDisplay {
+		return value[:maxDisplay] + "..."
+	}
+	return value
+}
+
+func childPath(path, name string) string {
+	if toolNameRegex.MatchString(name) {
+		return path + "." + name
+	}
+	return fmt.Sprintf("%s[%q]", path, name)
+}
@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"mcp-server/internal/mcp"
+)
+
+const testArgumentSchema = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string", "minLength": 2, "maxLength": 8, "pattern": "^[a-z]+$"},
+		"mode": {"type": "string", "enum": ["fast", "slow"]},
+		"count": {"type": "integer", "minimum": 1, "maximum": 10},
+		"ratio": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 1},
+		"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2, "uniqueItems": true},
+		"owner": {
+			"type": "object",
+			"properties": {"email": {"type": "string", "pattern": "@"}},
+			"required": ["email"],
+			"additionalProperties": false
+		}
+	},
+	"required": ["name"]
+}`
+
+func compileTestSchema(t *testing.T) *ArgumentSchema {
+	t.Helper()
+	schema, err := CompileArgumentSchema(json.RawMessage(testArgumentSchema))
+	if err != nil {
+		t.Fatalf("failed to compile schema: %v", err)
+	}
+	return schema
+}
+
+func TestArgumentSchema_ValidArguments(t *testing.T) {
+	schema := compileTestSchema(t)
+
+	args := `{"name":"abc","mode":"fast","count":3,"ratio":0.5,"tags":["a","b"],"owner":{"email":"a@b"}}`
+	if err := schema.Validate(json.RawMessage(args)); err != nil {
+		t.Errorf("expected valid arguments, got: %v", err)
+	}
+}
+
+func TestArgumentSchema_InvalidArguments(t *testing.T) {
+	schema := compileTestSchema(t)
+
+	tests := []struct {
+		name         string
+		args         string
+		expectedPath string
+		expectedMsg  string
+	}{
+		{"missing required", `{}`, "$.name", "is required"},
+		{"wrong type", `{"name": 5}`, "$.name", "expected string, got integer"},
+		{"too short", `{"name": "a"}`, "$.name", "at least 2 characters"},
+		{"too long", `{"name": "abcdefghij"}`, "$.name", "at most 8 characters"},
+		{"pattern mismatch", `{"name": "ABC"}`, "$.name", "must match pattern"},
+		{"enum mismatch", `{"name": "abc", "mode": "medium"}`, "$.mode", "must be one of"},
+		{"integer expected", `{"name": "abc", "count": 1.5}`, "$.count", "expected integer, got number"},
+		{"below minimum", `{"name": "abc", "count": 0}`, "$.count", "must be >= 1"},
+		{"above maximum", `{"name": "abc", "count": 11}`, "$.count", "must be <= 10"},
+		{"exclusive bound", `{"name": "abc", "ratio": 1}`, "$.ratio", "must be < 1"},
+		{"item type", `{"name": "abc", "tags": ["a", 2]}`, "$.tags[1]", "expected string"},
+		{"too many items", `{"name": "abc", "tags": ["a", "b", "c"]}`, "$.tags", "at most 2 items"},
+		{"duplicate items", `{"name": "abc", "tags": ["a", "a"]}`, "$.tags[1]", "duplicates item at index 0"},
+		{"nested required", `{"name": "abc", "owner": {}}`, "$.owner.email", "is required"},
+		{"nested pattern", `{"name": "abc", "owner": {"email": "nope"}}`, "$.owner.email", "must match pattern"},
+		{"additional property", `{"name": "abc", "owner": {"email": "a@b", "extra": 1}}`, "$.owner.extra", "not allowed"},
+		{"not an object", `[]`, "$", "expected object, got array"},
+		{"invalid JSON", `{`, "$", "not valid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(json.RawMessage(tt.args))
+			if err == nil {
+				t.Fatal("expected validation error")
+			}
+
+			var validationErrors ToolValidationErrors
+			if !errors.As(err, &validationErrors) {
+				t.Fatalf("expected ToolValidationErrors, got %T", err)
+			}
+
+			found := false
+			for _, e := range validationErrors {
+				if e.Field == tt.expectedPath && strings.Contains(e.Message, tt.expectedMsg) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("expected error at %s containing %q, got: %s", tt.expectedPath, tt.expectedMsg, FormatArgumentErrors(err))
+			}
+		})
+	}
+}
+
+func TestArgumentSchema_EmptyArgumentsTreatedAsObject(t *testing.T) {
+	schema, err := CompileArgumentSchema(json.RawMessage(`{"type": "object"}`))
+	if err != nil {
+		t.Fatalf("failed to compile schema: %v", err)
+	}
+	if err := schema.Validate(nil); err != nil {
+		t.Errorf("expected nil arguments to validate as empty object, got: %v", err)
+	}
+}
+
+func TestCompileArgumentSchema_Malformed(t *testing.T) {
+	tests := []string{
+		`{"type": "text"}`,
+		`{"required": "name"}`,
+		`{"pattern": "("}`,
+		`{"minLength": -1}`,
+		`{"multipleOf": 0}`,
+		`{"properties": {"a": 5}}`,
+	}
+
+	for _, schema := range tests {
+		if _, err := CompileArgumentSchema(json.RawMessage(schema)); err == nil {
+			t.Errorf("expected compile error for schema %s", schema)
+		}
+	}
+}
+
+func TestWithArgumentValidation_RejectsBeforeDispatch(t *testing.T) {
+	called := false
+	tool := &mockTool{
+		name:        "guarded",
+		description: "Guarded tool",
+		parameters:  json.RawMessage(`{"type": "object", "properties": {"message": {"type": "string"}}, "required": ["message"]}`),
+		handler: mcp.ToolHandlerFunc(func(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+			called = true
+			return &mcp.ToolResultImpl{}, nil
+		}),
+	}
+
+	wrapped, err := WithArgumentValidation(tool)
+	if err != nil {
+		t.Fatalf("failed to wrap tool: %v", err)
+	}
+
+	result, err := wrapped.Handler().Handle(context.Background(), json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if called {
+		t.Error("handler should not run when arguments are invalid")
+	}
+	if !result.IsError() || !errors.Is(result.GetError(), ErrInvalidArguments) {
+		t.Errorf("expected ErrInvalidArguments result, got %v", result.GetError())
+	}
+	if !strings.Contains(result.GetError().Error(), "$.message: is required") {
+		t.Errorf("expected path-qualified message, got %v", result.GetError())
+	}
+
+	if _, err := wrapped.Handler().Handle(context.Background(), json.RawMessage(`{"message": "hi"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("handler should run when arguments are valid")
+	}
+}
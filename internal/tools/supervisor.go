@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+
+	"mcp-server/internal/events"
+	"mcp-server/internal/registry"
+)
+
+// newSupervisor connects a supervisor to the tools of the registry. Tools
+// whose factories implement HealthChecker are probed while loaded or
+// active; tools in error are restarted and activated.
+func (r *DefaultToolRegistry) newSupervisor() *registry.Supervisor {
+	return registry.NewSupervisor("tool", r.config, registry.SupervisorHooks{
+		Entities: r.supervisedTools,
+		Fail: func(key string, cause error) error {
+			r.logger.Warn("tool failed its health check", "name", key, "error", cause)
+			return r.TransitionStatusWith(key, ToolStatusError, registry.ErrorCause(registry.ActorSupervisor, "health check failed", cause))
+		},
+		Restart: func(ctx context.Context, key string) error {
+			if err := r.RestartTool(ctx, key); err != nil {
+				return err
+			}
+			return r.TransitionStatusWith(key, ToolStatusActive, registry.TransitionCause{Actor: registry.ActorSupervisor, Reason: "restarted"})
+		},
+		Disable: func(key string) error {
+			return r.TransitionStatusWith(key, ToolStatusDisabled, registry.TransitionCause{Actor: registry.ActorSupervisor, Reason: "restart attempts exhausted"})
+		},
+		Attempted: r.publishRestartAttempt,
+	}, r.logger)
+}
+
+func (r *DefaultToolRegistry) publishRestartAttempt(key string, attempt int, err error) {
+	event := events.Event{Type: events.TypeRestartAttempt, Name: key, Attempt: attempt, Actor: string(registry.ActorSupervisor)}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	r.entities.Publish(event)
+}
+
+// supervisedTools lists the tools for the supervisor. Tools in error whose
+// requirements are still unmet are blocked: restarting them cannot help
+// until what they require recovers.
+func (r *DefaultToolRegistry) supervisedTools() []registry.SupervisedEntity {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := r.entities.Entries()
+	entities := make([]registry.SupervisedEntity, 0, len(entries))
+	for _, entry := range entries {
+		key := entry.Key
+		entity := registry.SupervisedEntity{Key: key, Status: entry.Status}
+		if checker, ok := entry.Factory.(HealthChecker); ok {
+			entity.Checker = checker
+		}
+		if entry.Status == ToolStatusError && len(r.unmet[key]) > 0 {
+			entity.Blocked = len(r.unmetRequirements(key)) > 0
+		}
+		entities = append(entities, entity)
+	}
+	return entities
+}
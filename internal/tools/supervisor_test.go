@@ -0,0 +1,205 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/logger"
+)
+
+type probedToolFactory struct {
+	*mockToolFactory
+	mu        sync.Mutex
+	healthErr error
+}
+
+func (f *probedToolFactory) HealthCheck(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.healthErr
+}
+
+func (f *probedToolFactory) setHealth(err error) {
+	f.mu.Lock()
+	f.healthErr = err
+	f.mu.Unlock()
+}
+
+func createSupervisedRegistry(t *testing.T, factory ToolFactory, backoff time.Duration) *DefaultToolRegistry {
+	cfg := &config.Config{
+		MCP: config.MCPConfig{MaxTools: 100},
+		Supervisor: config.SupervisorConfig{
+			Interval:       time.Hour,
+			ProbeTimeout:   time.Second,
+			InitialBackoff: backoff,
+			MaxBackoff:     backoff,
+			MaxAttempts:    2,
+		},
+	}
+	log, _ := logger.NewDefault()
+	r := NewDefaultToolRegistry(cfg, log).(*DefaultToolRegistry)
+
+	ctx := context.Background()
+	if err := r.Start(ctx); err != nil {
+		t.Fatalf("failed to start registry: %v", err)
+	}
+	t.Cleanup(func() { r.Stop(ctx) })
+
+	if err := r.Register(factory.GetName(), factory); err != nil {
+		t.Fatalf("failed to register tool: %v", err)
+	}
+	if err := r.LoadTools(ctx); err != nil {
+		t.Fatalf("failed to load tools: %v", err)
+	}
+	if err := r.TransitionStatus(factory.GetName(), ToolStatusActive); err != nil {
+		t.Fatalf("failed to activate tool: %v", err)
+	}
+	return r
+}
+
+func assertToolStatus(t *testing.T, r *DefaultToolRegistry, name string, expected ToolStatus) {
+	t.Helper()
+	for _, info := range r.List() {
+		if info.Name == name {
+			if info.Status != expected {
+				t.Errorf("expected %s to be %s, got %s", name, expected, info.Status)
+			}
+			return
+		}
+	}
+	t.Errorf("tool %s not found", name)
+}
+
+func TestSupervisor_RestartsToolFailingProbe(t *testing.T) {
+	factory := &probedToolFactory{mockToolFactory: createTestFactory("flaky").(*mockToolFactory)}
+	r := createSupervisedRegistry(t, factory, time.Nanosecond)
+	ctx := context.Background()
+
+	factory.setHealth(errors.New("backend unreachable"))
+	r.supervisor.Check(ctx)
+
+	// The probe failure moved the tool to error and the restart brought it back
+	assertToolStatus(t, r, "flaky", ToolStatusActive)
+	if attempts := r.Health().Recovering["flaky"]; attempts != 1 {
+		t.Errorf("expected 1 restart attempt, got %d", attempts)
+	}
+
+	factory.setHealth(nil)
+	r.supervisor.Check(ctx)
+
+	assertToolStatus(t, r, "flaky", ToolStatusActive)
+	if recovering := r.Health().Recovering; len(recovering) != 0 {
+		t.Errorf("expected attempts to reset once healthy, got %v", recovering)
+	}
+	if _, err := r.Get("flaky"); err != nil {
+		t.Errorf("expected restarted tool to be available: %v", err)
+	}
+}
+
+func TestSupervisor_DisablesToolAfterMaxAttempts(t *testing.T) {
+	factory := &probedToolFactory{mockToolFactory: createTestFactory("broken").(*mockToolFactory)}
+	r := createSupervisedRegistry(t, factory, time.Nanosecond)
+	ctx := context.Background()
+
+	factory.setHealth(errors.New("backend unreachable"))
+	factory.createError = errors.New("cannot connect")
+
+	r.supervisor.Check(ctx)
+	assertToolStatus(t, r, "broken", ToolStatusError)
+	if attempts := r.Health().Recovering["broken"]; attempts != 1 {
+		t.Errorf("expected 1 restart attempt, got %d", attempts)
+	}
+
+	time.Sleep(time.Millisecond)
+	r.supervisor.Check(ctx)
+	assertToolStatus(t, r, "broken", ToolStatusDisabled)
+	if recovering := r.Health().Recovering; len(recovering) != 0 {
+		t.Errorf("expected no recovery in progress once disabled, got %v", recovering)
+	}
+
+	// Disabled tools are left for an operator
+	factory.createError = nil
+	r.supervisor.Check(ctx)
+	assertToolStatus(t, r, "broken", ToolStatusDisabled)
+}
+
+func TestSupervisor_DisablesToolFailingProbeAfterMaxAttempts(t *testing.T) {
+	factory := &probedToolFactory{mockToolFactory: createTestFactory("sickly").(*mockToolFactory)}
+	r := createSupervisedRegistry(t, factory, time.Nanosecond)
+	ctx := context.Background()
+
+	// Every restart succeeds, but the probe never passes
+	factory.setHealth(errors.New("backend unreachable"))
+	for attempt := 1; attempt <= 2; attempt++ {
+		time.Sleep(time.Millisecond)
+		r.supervisor.Check(ctx)
+		assertToolStatus(t, r, "sickly", ToolStatusActive)
+		if attempts := r.Health().Recovering["sickly"]; attempts != attempt {
+			t.Errorf("expected %d restart attempts, got %d", attempt, attempts)
+		}
+	}
+
+	time.Sleep(time.Millisecond)
+	r.supervisor.Check(ctx)
+	assertToolStatus(t, r, "sickly", ToolStatusDisabled)
+	if recovering := r.Health().Recovering; len(recovering) != 0 {
+		t.Errorf("expected no recovery in progress once disabled, got %v", recovering)
+	}
+}
+
+func TestSupervisor_BackoffDelaysRestart(t *testing.T) {
+	factory := createTestFactory("slow").(*mockToolFactory)
+	r := createSupervisedRegistry(t, factory, time.Hour)
+	ctx := context.Background()
+
+	factory.createError = errors.New("cannot connect")
+	if err := r.TransitionStatus("slow", ToolStatusError); err != nil {
+		t.Fatalf("failed to move tool to error: %v", err)
+	}
+
+	if next := r.supervisor.Check(ctx); next > time.Hour || next < 59*time.Minute {
+		t.Errorf("expected next pass when the backoff elapses, got %v", next)
+	}
+	r.supervisor.Check(ctx)
+
+	assertToolStatus(t, r, "slow", ToolStatusError)
+	if attempts := r.Health().Recovering["slow"]; attempts != 1 {
+		t.Errorf("expected the second pass to wait for the backoff, got %d attempts", attempts)
+	}
+}
+
+func TestSupervisor_SkipsToolsWithUnmetRequirements(t *testing.T) {
+	factory := createTestFactory("needs_env").(*mockToolFactory)
+	factory.requirements = map[string]string{"env:MCP_SUPERVISOR_TEST_UNSET": ""}
+	cfg := &config.Config{
+		MCP: config.MCPConfig{MaxTools: 100},
+		Supervisor: config.SupervisorConfig{
+			Interval:       time.Hour,
+			ProbeTimeout:   time.Second,
+			InitialBackoff: time.Nanosecond,
+			MaxBackoff:     time.Nanosecond,
+			MaxAttempts:    1,
+		},
+	}
+	log, _ := logger.NewDefault()
+	r := NewDefaultToolRegistry(cfg, log).(*DefaultToolRegistry)
+	ctx := context.Background()
+	r.Start(ctx)
+	defer r.Stop(ctx)
+
+	if err := r.Register("needs_env", factory); err != nil {
+		t.Fatalf("failed to register tool: %v", err)
+	}
+	r.LoadTools(ctx)
+	assertToolStatus(t, r, "needs_env", ToolStatusError)
+
+	r.supervisor.Check(ctx)
+	assertToolStatus(t, r, "needs_env", ToolStatusError)
+	if recovering := r.Health().Recovering; len(recovering) != 0 {
+		t.Errorf("expected blocked tool not to be restarted, got %v", recovering)
+	}
+}
@@ -3,13 +3,21 @@ package tools
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"mcp-server/internal/cache"
+	"mcp-server/internal/events"
 	"mcp-server/internal/mcp"
+	"mcp-server/internal/metrics"
 	"mcp-server/internal/registry"
 )
 
 type ToolStatus = registry.LifecycleStatus
 
+// HealthChecker may be implemented by tool factories so the supervisor can
+// probe whether their tools still work
+type HealthChecker = registry.HealthChecker
+
 const (
 	ToolStatusUnknown    = registry.StatusUnknown
 	ToolStatusRegistered = registry.StatusRegistered
@@ -20,12 +28,33 @@ const (
 )
 
 type ToolInfo struct {
+	ID           string            `json:"id"`
 	Name         string            `json:"name"`
 	Description  string            `json:"description"`
 	Version      string            `json:"version"`
+	Default      bool              `json:"default"`
+	Deprecation  *Deprecation      `json:"deprecation,omitempty"`
 	Capabilities []string          `json:"capabilities"`
 	Requirements map[string]string `json:"requirements"`
 	Status       ToolStatus        `json:"status"`
+
+	// Restarts counts successful restarts, across process restarts when a
+	// state store is configured
+	Restarts              int        `json:"restarts,omitempty"`
+	CircuitBreakerResetAt *time.Time `json:"circuit_breaker_reset_at,omitempty"`
+
+	// History holds the most recent status transitions, oldest first
+	History []registry.TransitionRecord `json:"history,omitempty"`
+}
+
+// Key returns the registry key that health and metrics are reported under:
+// the bare name for the first version registered under a name and
+// name@version for the others
+func (i ToolInfo) Key() string {
+	if i.ID != "" {
+		return i.ID
+	}
+	return i.Name
 }
 
 type ToolConfig struct {
@@ -43,13 +72,19 @@ type ToolFactory interface {
 }
 
 type RegistryHealth struct {
-	Status       string              `json:"status"`
-	ToolCount    int                 `json:"tool_count"`
-	ActiveTools  int                 `json:"active_tools"`
-	ErrorTools   int                 `json:"error_tools"`
-	LastCheck    string              `json:"last_check"`
-	Errors       []string            `json:"errors,omitempty"`
-	ToolStatuses map[string]string   `json:"tool_statuses"`
+	Status            string                            `json:"status"`
+	ToolCount         int                               `json:"tool_count"`
+	ActiveTools       int                               `json:"active_tools"`
+	ErrorTools        int                               `json:"error_tools"`
+	LastCheck         string                            `json:"last_check"`
+	Errors            []string                          `json:"errors,omitempty"`
+	ToolStatuses      map[string]string                 `json:"tool_statuses"`
+	CircuitBreakers   map[string]string                 `json:"circuit_breakers"`
+	Bulkheads         map[string]registry.BulkheadStats `json:"bulkheads,omitempty"`
+	GlobalBulkhead    *registry.BulkheadStats           `json:"global_bulkhead,omitempty"`
+	ResultCaches      map[string]cache.Stats            `json:"result_caches,omitempty"`
+	UnmetRequirements map[string][]string               `json:"unmet_requirements,omitempty"`
+	Recovering        map[string]int                    `json:"recovering,omitempty"` // supervisor restart attempts
 }
 
 type ToolRegistry interface {
@@ -60,9 +95,43 @@ type ToolRegistry interface {
 	List() []ToolInfo
 
 	LoadTools(ctx context.Context) error
+	// StartupReport describes the latest LoadTools, including the tools it
+	// left loading in the background; nil before the first
+	StartupReport() *registry.LoadReport
 	ValidateTools(ctx context.Context) error
 	TransitionStatus(name string, newStatus ToolStatus) error
+	// TransitionStatusWith is TransitionStatus recording who made the
+	// transition and why in the tool's history
+	TransitionStatusWith(name string, newStatus ToolStatus, cause registry.TransitionCause) error
 	RestartTool(ctx context.Context, name string) error
+	ResetCircuitBreaker(name string) error
+	Metrics() *metrics.Collector
+
+	// Versions are addressed as name@version; a bare name resolves to the
+	// default version
+	SetDefaultVersion(name, version string) error
+	DeprecateVersion(name, version string, deprecation *Deprecation) error
+
+	// SetResourceLookup lets tools require resources from another registry
+	SetResourceLookup(lookup ResourceLookup)
+	// SetResourceWait makes tools loading at startup wait for the resources
+	// they require to finish loading alongside them
+	SetResourceWait(wait ResourceWait)
+	SetEvents(bus *events.Bus)
+	// SetStateStore persists operator decisions; they are re-applied on Start
+	SetStateStore(store registry.StateStore)
+	// SetPipeline serves the calls of published tools through the server's
+	// pipeline; it is set before Start
+	SetPipeline(pipeline *mcp.Pipeline)
+	// SetCallerLimiter makes rate limited tools draw from the session and
+	// client budgets shared with resource reads; it is set before tools are
+	// registered
+	SetCallerLimiter(limiter *mcp.CallerLimiter)
+
+	// Prompts are published through the registry's library adapter; the
+	// prompt registry decides which are
+	RegisterPrompt(prompt mcp.Prompt) error
+	UnregisterPrompt(name string) error
 
 	Start(ctx context.Context) error
 	Stop(ctx context.Context) error
@@ -70,16 +139,19 @@ type ToolRegistry interface {
 }
 
 var (
-	ErrToolNotFound        = registry.ErrEntityNotFound
-	ErrToolAlreadyExists   = registry.ErrEntityAlreadyExists
-	ErrInvalidToolName     = fmt.Errorf("invalid tool name")
-	ErrToolValidation      = registry.ErrEntityValidation
-	ErrRegistryNotRunning  = registry.ErrRegistryNotRunning
-	ErrToolCreation        = registry.ErrEntityCreation
-	ErrInvalidTransition   = registry.ErrInvalidTransition
+	ErrToolNotFound         = registry.ErrEntityNotFound
+	ErrToolAlreadyExists    = registry.ErrEntityAlreadyExists
+	ErrInvalidToolName      = fmt.Errorf("invalid tool name")
+	ErrToolValidation       = registry.ErrEntityValidation
+	ErrRegistryNotRunning   = registry.ErrRegistryNotRunning
+	ErrToolCreation         = registry.ErrEntityCreation
+	ErrInvalidTransition    = registry.ErrInvalidTransition
 	ErrTransitionNotAllowed = registry.ErrTransitionNotAllowed
-	ErrToolRestart         = fmt.Errorf("tool restart failed")
-	ErrRestartNotAllowed   = fmt.Errorf("tool restart not allowed")
+	ErrToolRestart          = fmt.Errorf("tool restart failed")
+	ErrRestartNotAllowed    = fmt.Errorf("tool restart not allowed")
+	ErrRequirementsNotMet   = fmt.Errorf("tool requirements not met")
+	ErrToolDisabled         = fmt.Errorf("tool disabled")
+	ErrNoAdapter            = fmt.Errorf("no library adapter")
 )
 
 type ToolValidationError = registry.ValidationError
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"mcp-server/internal/mcp"
+)
+
+// validatingTool wraps a tool so that arguments are checked against its
+// input schema before the underlying handler runs
+type validatingTool struct {
+	mcp.Tool
+	handler *validatingHandler
+}
+
+type validatingHandler struct {
+	name   string
+	schema *ArgumentSchema
+	next   mcp.ToolHandler
+}
+
+// WithArgumentValidation returns a tool whose handler rejects arguments that
+// do not satisfy the tool's JSON schema
+func WithArgumentValidation(tool mcp.Tool) (mcp.Tool, error) {
+	if existing, ok := tool.(*validatingTool); ok {
+		return existing, nil
+	}
+
+	schema, err := CompileArgumentSchema(tool.Parameters())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile input schema for tool %s: %w", tool.Name(), err)
+	}
+
+	return &validatingTool{
+		Tool: tool,
+		handler: &validatingHandler{
+			name:   tool.Name(),
+			schema: schema,
+			next:   tool.Handler(),
+		},
+	}, nil
+}
+
+func (t *validatingTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+// Unwrap returns the original tool
+func (t *validatingTool) Unwrap() mcp.Tool {
+	return t.Tool
+}
+
+func (h *validatingHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	if err := h.schema.Validate(params); err != nil {
+		return &mcp.ToolResultImpl{
+			Error:       fmt.Errorf("%w for %s: %s", ErrInvalidArguments, h.name, FormatArgumentErrors(err)),
+			IsErrorFlag: true,
+		}, nil
+	}
+
+	return h.next.Handle(ctx, params)
+}
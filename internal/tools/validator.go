@@ -22,6 +22,9 @@ func NewToolValidator(cfg *config.Config, log *logger.Logger) *ToolValidator {
 	}
 }
 
+// MaxDescriptionLength is the longest tool description the registry accepts
+const MaxDescriptionLength = 500
+
 var (
 	toolNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,63}$`)
 	reservedNames = map[string]bool{
@@ -60,7 +63,7 @@ func (v *ToolValidator) ValidateDescription(description string) error {
 	var errors ToolValidationErrors
 
 	v.ValidateRequiredString(description, "description", &errors)
-	v.ValidateStringLength(description, "description", 500, &errors)
+	v.ValidateStringLength(description, "description", MaxDescriptionLength, &errors)
 
 	if errors.HasErrors() {
 		return errors
@@ -99,6 +102,14 @@ func (v *ToolValidator) ValidateFactory(factory ToolFactory) error {
 	
 	v.ValidateCapabilities(capabilities, &errors)
 
+	if _, err := ParseRequirements(factory.Requirements()); err != nil {
+		if validationErrors, ok := err.(ToolValidationErrors); ok {
+			errors = append(errors, validationErrors...)
+		} else {
+			errors.Add("requirements", "", err.Error())
+		}
+	}
+
 	config := ToolConfig{
 		Enabled: true,
 		Config:  make(map[string]interface{}),
@@ -117,7 +128,9 @@ func (v *ToolValidator) ValidateFactory(factory ToolFactory) error {
 func (v *ToolValidator) ValidateTool(tool mcp.Tool) error {
 	var errors ToolValidationErrors
 
-	if err := v.ValidateName(tool.Name()); err != nil {
+	// Registered versions may carry an @version suffix
+	name, _ := ParseToolRef(tool.Name())
+	if err := v.ValidateName(name); err != nil {
 		if validationErrors, ok := err.(ToolValidationErrors); ok {
 			errors = append(errors, validationErrors...)
 		} else {
@@ -188,7 +201,9 @@ func (v *ToolValidator) validateSchemaType(schema map[string]interface{}) error
 		"array":   true,
 		"string":  true,
 		"number":  true,
+		"integer": true,
 		"boolean": true,
+		"null":    true,
 	}
 	if !validTypes[typeStr] {
 		return fmt.Errorf("invalid schema type: %s", typeStr)
@@ -0,0 +1,279 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mcp-server/internal/mcp"
+)
+
+// ToolVersionSeparator separates the tool name from the version in a tool
+// reference such as echo@1.2.0
+const ToolVersionSeparator = "@"
+
+// ParseToolRef splits a tool reference into its name and version. The
+// version is empty for a bare name, which addresses the default version.
+func ParseToolRef(ref string) (name, version string) {
+	name, version, _ = strings.Cut(ref, ToolVersionSeparator)
+	return name, version
+}
+
+// ToolRef formats the reference addressing one version of a tool
+func ToolRef(name, version string) string {
+	return name + ToolVersionSeparator + version
+}
+
+// Deprecation marks a tool version for removal
+type Deprecation struct {
+	Sunset  time.Time `json:"sunset"`
+	Message string    `json:"message,omitempty"`
+}
+
+// versionSet tracks the versions registered under one tool name. The first
+// version keeps the bare name as its registry key so single-version tools
+// are keyed exactly as before; later versions are keyed name@version.
+type versionSet struct {
+	defaultVersion string
+	keys           map[string]string // version -> registry key
+}
+
+// versions returns the registered versions, newest first
+func (s *versionSet) versions() []string {
+	versions := make([]string, 0, len(s.keys))
+	for version := range s.keys {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) > 0
+	})
+	return versions
+}
+
+// compareVersions orders versions by semver precedence: the dotted release
+// numerically segment by segment, with missing segments counting as zero,
+// then a pre-release such as 1.0.0-rc1 below the release itself. Build
+// metadata after a + is ignored.
+func compareVersions(a, b string) int {
+	aRelease, aPre := splitVersion(a)
+	bRelease, bPre := splitVersion(b)
+
+	as := strings.Split(aRelease, ".")
+	bs := strings.Split(bRelease, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if order := compareIdentifiers(x, y); order != 0 {
+			return order
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+
+	// Pre-releases compare field by field; a shorter one that matches the
+	// start of a longer one sorts first
+	ap := strings.Split(aPre, ".")
+	bp := strings.Split(bPre, ".")
+	for i := 0; i < len(ap) && i < len(bp); i++ {
+		if order := compareIdentifiers(ap[i], bp[i]); order != 0 {
+			return order
+		}
+	}
+	return cmp.Compare(len(ap), len(bp))
+}
+
+// splitVersion separates the release from the pre-release of a version,
+// dropping a leading v and any build metadata
+func splitVersion(version string) (release, preRelease string) {
+	version, _, _ = strings.Cut(strings.TrimPrefix(version, "v"), "+")
+	release, preRelease, _ = strings.Cut(version, "-")
+	return release, preRelease
+}
+
+// compareIdentifiers orders two version fields. Numeric fields compare
+// numerically and sort below alphanumeric ones. Alphanumeric fields with
+// the same prefix compare their trailing numbers numerically, so rc10
+// follows rc2; others compare as strings.
+func compareIdentifiers(x, y string) int {
+	if x == y {
+		return 0
+	}
+	xn, xErr := strconv.Atoi(x)
+	yn, yErr := strconv.Atoi(y)
+	switch {
+	case xErr == nil && yErr == nil:
+		return cmp.Compare(xn, yn)
+	case xErr == nil:
+		return -1
+	case yErr == nil:
+		return 1
+	}
+
+	xPrefix, xNumber := splitTrailingNumber(x)
+	yPrefix, yNumber := splitTrailingNumber(y)
+	if xPrefix == yPrefix && xNumber >= 0 && yNumber >= 0 && xNumber != yNumber {
+		return cmp.Compare(xNumber, yNumber)
+	}
+	return strings.Compare(x, y)
+}
+
+// splitTrailingNumber splits an identifier such as rc10 into its prefix and
+// trailing number, which is -1 when there is none
+func splitTrailingNumber(identifier string) (string, int) {
+	prefix := strings.TrimRight(identifier, "0123456789")
+	number, err := strconv.Atoi(identifier[len(prefix):])
+	if err != nil {
+		return identifier, -1
+	}
+	return prefix, number
+}
+
+// deprecationNotice holds the deprecation of one registered version. It is
+// shared with the tool instance so deprecating a version takes effect on
+// the next call without recreating the tool.
+type deprecationNotice struct {
+	mu          sync.RWMutex
+	ref         string
+	deprecation *Deprecation
+	replacement string // ref of the default version, if different
+	now         func() time.Time
+}
+
+func newDeprecationNotice(ref string) *deprecationNotice {
+	return &deprecationNotice{ref: ref, now: time.Now}
+}
+
+func (n *deprecationNotice) set(deprecation *Deprecation) {
+	if deprecation != nil {
+		copied := *deprecation
+		deprecation = &copied
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.deprecation = deprecation
+}
+
+func (n *deprecationNotice) setReplacement(replacement string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.replacement = replacement
+}
+
+// get returns a copy of the deprecation, or nil when the version is current
+func (n *deprecationNotice) get() *Deprecation {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.deprecation == nil {
+		return nil
+	}
+	deprecation := *n.deprecation
+	return &deprecation
+}
+
+// warning returns the notice attached to results, or "" when not deprecated
+func (n *deprecationNotice) warning() string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if n.deprecation == nil {
+		return ""
+	}
+
+	sunset := n.deprecation.Sunset.UTC().Format(time.DateOnly)
+	warning := fmt.Sprintf("tool %s is deprecated and will be removed on %s", n.ref, sunset)
+	if !n.now().Before(n.deprecation.Sunset) {
+		warning = fmt.Sprintf("tool %s is deprecated and was due for removal on %s", n.ref, sunset)
+	}
+	if n.replacement != "" {
+		warning += "; use " + n.replacement + " instead"
+	}
+	if n.deprecation.Message != "" {
+		warning += ": " + n.deprecation.Message
+	}
+	return warning
+}
+
+// deprecatedTool adds a warning to the results of a deprecated version
+type deprecatedTool struct {
+	mcp.Tool
+	handler *deprecatedHandler
+}
+
+type deprecatedHandler struct {
+	notice *deprecationNotice
+	next   mcp.ToolHandler
+}
+
+// withDeprecationWarning returns a tool whose results carry the notice's
+// warning while the version is deprecated
+func withDeprecationWarning(tool mcp.Tool, notice *deprecationNotice) mcp.Tool {
+	return &deprecatedTool{
+		Tool: tool,
+		handler: &deprecatedHandler{
+			notice: notice,
+			next:   tool.Handler(),
+		},
+	}
+}
+
+func (t *deprecatedTool) Handler() mcp.ToolHandler {
+	return t.handler
+}
+
+// Unwrap returns the original tool
+func (t *deprecatedTool) Unwrap() mcp.Tool {
+	return t.Tool
+}
+
+func (h *deprecatedHandler) Handle(ctx context.Context, params json.RawMessage) (mcp.ToolResult, error) {
+	result, err := h.next.Handle(ctx, params)
+	if err != nil {
+		return result, err
+	}
+	if warning := h.notice.warning(); warning != "" {
+		result = mcp.WithWarnings(result, warning)
+	}
+	return result, nil
+}
+
+// aliasTool exposes a tool under another name, such as its name@version
+// reference
+type aliasTool struct {
+	mcp.Tool
+	name string
+}
+
+// withName returns tool renamed to name
+func withName(tool mcp.Tool, name string) mcp.Tool {
+	if tool.Name() == name {
+		return tool
+	}
+	return &aliasTool{Tool: tool, name: name}
+}
+
+func (t *aliasTool) Name() string {
+	return t.name
+}
+
+// Unwrap returns the original tool
+func (t *aliasTool) Unwrap() mcp.Tool {
+	return t.Tool
+}
@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"mcp-server/internal/mcp"
+)
+
+// countingToolFactory creates tools that return a non-nil result, so result
+// wrappers can be observed
+type countingToolFactory struct {
+	*mockToolFactory
+}
+
+func (f countingToolFactory) Create(ctx context.Context, config ToolConfig) (mcp.Tool, error) {
+	calls := 0
+	return countingTool(f.name, &calls), nil
+}
+
+func createVersionedFactory(name, version string) ToolFactory {
+	factory := createTestFactory(name).(*mockToolFactory)
+	factory.version = version
+	return countingToolFactory{factory}
+}
+
+func TestParseToolRef(t *testing.T) {
+	tests := []struct {
+		ref, name, version string
+	}{
+		{"echo", "echo", ""},
+		{"echo@1.2.0", "echo", "1.2.0"},
+		{ToolRef("echo", "2.0.0"), "echo", "2.0.0"},
+	}
+	for _, tt := range tests {
+		name, version := ParseToolRef(tt.ref)
+		if name != tt.name || version != tt.version {
+			t.Errorf("ParseToolRef(%q) = %q, %q; want %q, %q", tt.ref, name, version, tt.name, tt.version)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"2.0.0", "10.0.0", -1},
+		{"v1.2", "1.2.0", 0},
+		{"1.0.0-rc1", "1.0.0-rc2", -1},
+		{"1.0.0", "1.0.0-rc1", 1},
+		{"1.0.0-rc1", "1.0.0", -1},
+		{"1.0.0-rc2", "1.0.0-rc10", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.2", "1.0.0-alpha.10", -1},
+		{"1.0.0-1", "1.0.0-alpha", -1},
+		{"1.1.0-rc1", "1.0.0", 1},
+		{"1.0.0+build.5", "1.0.0", 0},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDeprecationNotice_Warning(t *testing.T) {
+	notice := newDeprecationNotice("lookup@1.0.0")
+	if warning := notice.warning(); warning != "" {
+		t.Errorf("expected no warning for a current version, got %q", warning)
+	}
+
+	sunset := time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)
+	notice.set(&Deprecation{Sunset: sunset, Message: "results are now paginated"})
+	notice.setReplacement("lookup@2.0.0")
+	notice.now = func() time.Time { return sunset.Add(-time.Hour) }
+
+	want := "tool lookup@1.0.0 is deprecated and will be removed on 2027-01-31; use lookup@2.0.0 instead: results are now paginated"
+	if warning := notice.warning(); warning != want {
+		t.Errorf("unexpected warning:\n got %q\nwant %q", warning, want)
+	}
+
+	notice.now = func() time.Time { return sunset }
+	want = "tool lookup@1.0.0 is deprecated and was due for removal on 2027-01-31; use lookup@2.0.0 instead: results are now paginated"
+	if warning := notice.warning(); warning != want {
+		t.Errorf("unexpected warning past sunset:\n got %q\nwant %q", warning, want)
+	}
+}
+
+func TestDefaultToolRegistry_Versions(t *testing.T) {
+	adapter := newMockAdapter()
+	reg := createTestRegistryWithAdapter(adapter).(*DefaultToolRegistry)
+	ctx := context.Background()
+	if err := reg.Start(ctx); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	if err := reg.Register("lookup", createVersionedFactory("lookup", "1.0.0")); err != nil {
+		t.Fatalf("register 1.0.0 failed: %v", err)
+	}
+	if err := reg.Register("lookup", createVersionedFactory("lookup", "2.0.0")); err != nil {
+		t.Fatalf("register 2.0.0 failed: %v", err)
+	}
+	if err := reg.Register("lookup", createVersionedFactory("lookup", "2.0.0")); !errors.Is(err, ErrToolAlreadyExists) {
+		t.Errorf("expected duplicate version to be rejected, got %v", err)
+	}
+
+	// The first version keeps the bare key and stays the default
+	v1, err := reg.Get("lookup")
+	if err != nil || v1.Name() != "lookup" {
+		t.Fatalf("expected bare name to resolve to the first version, got %v, %v", v1, err)
+	}
+	v2, err := reg.Get("lookup@2.0.0")
+	if err != nil || v2.Name() != "lookup@2.0.0" {
+		t.Fatalf("expected lookup@2.0.0, got %v, %v", v2, err)
+	}
+	if _, err := reg.Get("lookup@3.0.0"); !errors.Is(err, ErrToolNotFound) {
+		t.Errorf("expected unknown version to be not found, got %v", err)
+	}
+	assertAdapterTools(t, adapter, "lookup", "lookup@1.0.0", "lookup@2.0.0")
+
+	if err := reg.SetDefaultVersion("lookup", "3.0.0"); !errors.Is(err, ErrToolNotFound) {
+		t.Errorf("expected unknown default version to be rejected, got %v", err)
+	}
+	if err := reg.SetDefaultVersion("lookup", "2.0.0"); err != nil {
+		t.Fatalf("set default failed: %v", err)
+	}
+	if tool, _ := reg.Get("lookup"); tool != v2 {
+		t.Error("expected bare name to resolve to the new default")
+	}
+	if tool, _ := adapter.GetTool("lookup"); tool.(*aliasTool).Unwrap() != v2 {
+		t.Error("expected the adapter to serve the new default under the bare name")
+	}
+	for _, info := range reg.List() {
+		if info.Default != (info.Version == "2.0.0") {
+			t.Errorf("unexpected default flag for %s: %v", info.ID, info.Default)
+		}
+	}
+
+	// Deprecation warnings reach results of the deprecated version only
+	if err := reg.DeprecateVersion("lookup", "1.0.0", &Deprecation{}); !errors.Is(err, ErrToolValidation) {
+		t.Errorf("expected deprecation without sunset to be rejected, got %v", err)
+	}
+	sunset := time.Date(2099, 1, 31, 0, 0, 0, 0, time.UTC)
+	if err := reg.DeprecateVersion("lookup", "1.0.0", &Deprecation{Sunset: sunset}); err != nil {
+		t.Fatalf("deprecate failed: %v", err)
+	}
+	result, _ := v1.Handler().Handle(ctx, nil)
+	want := []string{"tool lookup@1.0.0 is deprecated and will be removed on 2099-01-31; use lookup@2.0.0 instead"}
+	if got := mcp.ResultWarnings(result); len(got) != 1 || got[0] != want[0] {
+		t.Errorf("unexpected warnings %q", got)
+	}
+	result, _ = v2.Handler().Handle(ctx, nil)
+	if got := mcp.ResultWarnings(result); len(got) != 0 {
+		t.Errorf("expected no warnings for the current version, got %q", got)
+	}
+
+	// Removing the default falls back to the newest remaining version
+	if err := reg.Unregister("lookup"); err != nil {
+		t.Fatalf("unregister failed: %v", err)
+	}
+	if tool, _ := reg.Get("lookup"); tool != v1 {
+		t.Error("expected bare name to fall back to 1.0.0")
+	}
+	// A lone version stays reachable by its pinned reference
+	assertAdapterTools(t, adapter, "lookup", "lookup@1.0.0")
+}
+
+func assertAdapterTools(t *testing.T, adapter *mockLibraryAdapter, want ...string) {
+	t.Helper()
+	got := adapter.ListTools()
+	sort.Strings(got)
+	if len(got) != len(want) {
+		t.Fatalf("expected adapter tools %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected adapter tools %v, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mcp-server/internal/config"
+)
+
+func testSpanData() SpanData {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sc, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	parent := sc.SpanID
+	sc.SpanID = SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+	return SpanData{
+		Name:          "tools/call",
+		Kind:          SpanKindServer,
+		SpanContext:   sc,
+		ParentSpanID:  parent,
+		StartTime:     start,
+		EndTime:       start.Add(250 * time.Millisecond),
+		Attributes:    []Attribute{String("mcp.tool.name", "echo"), Int("attempt", 2), Bool("cached", false)},
+		Status:        StatusError,
+		StatusMessage: "failed",
+	}
+}
+
+func TestJSONLExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	exporter, err := NewJSONLExporter(path, "mcp-test")
+	if err != nil {
+		t.Fatalf("NewJSONLExporter() error = %v", err)
+	}
+
+	spans := []SpanData{testSpanData(), testSpanData()}
+	if err := exporter.ExportSpans(context.Background(), spans); err != nil {
+		t.Fatalf("ExportSpans() error = %v", err)
+	}
+	if err := exporter.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line is not valid JSON: %v", err)
+		}
+		lines = append(lines, record)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	record := lines[0]
+	expected := map[string]interface{}{
+		"trace_id":       "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":        "0102030405060708",
+		"parent_span_id": "00f067aa0ba902b7",
+		"name":           "tools/call",
+		"kind":           "server",
+		"service":        "mcp-test",
+		"duration_ms":    250.0,
+		"status":         "error",
+		"status_message": "failed",
+	}
+	for key, want := range expected {
+		if record[key] != want {
+			t.Errorf("%s = %v, want %v", key, record[key], want)
+		}
+	}
+	attributes, _ := record["attributes"].(map[string]interface{})
+	if attributes["mcp.tool.name"] != "echo" {
+		t.Errorf("attributes = %v", attributes)
+	}
+}
+
+func TestOTLPHTTPExporter(t *testing.T) {
+	var received otlpRequest
+	var authorization string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	exporter := NewOTLPHTTPExporter(collector.URL, "mcp-test", map[string]string{"Authorization": "Bearer token"})
+	if err := exporter.ExportSpans(context.Background(), []SpanData{testSpanData()}); err != nil {
+		t.Fatalf("ExportSpans() error = %v", err)
+	}
+
+	if authorization != "Bearer token" {
+		t.Errorf("Authorization = %q, want configured header", authorization)
+	}
+	if len(received.ResourceSpans) != 1 || len(received.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected request shape: %+v", received)
+	}
+	service := received.ResourceSpans[0].Resource.Attributes[0]
+	if service.Key != "service.name" || service.Value.StringValue == nil || *service.Value.StringValue != "mcp-test" {
+		t.Errorf("resource attributes = %+v", received.ResourceSpans[0].Resource.Attributes)
+	}
+
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || span.ParentSpanID != "00f067aa0ba902b7" {
+		t.Errorf("span ids = %s/%s", span.TraceID, span.ParentSpanID)
+	}
+	if span.Kind != 2 || span.Status.Code != 2 {
+		t.Errorf("kind = %d, status = %d, want 2 and 2", span.Kind, span.Status.Code)
+	}
+	if span.EndTimeUnixNano != "1704067200250000000" {
+		t.Errorf("EndTimeUnixNano = %s", span.EndTimeUnixNano)
+	}
+	for _, attr := range span.Attributes {
+		if attr.Key == "attempt" && (attr.Value.IntValue == nil || *attr.Value.IntValue != "2") {
+			t.Errorf("attempt attribute = %+v, want intValue 2", attr.Value)
+		}
+	}
+}
+
+func TestOTLPHTTPExporter_CollectorError(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer collector.Close()
+
+	exporter := NewOTLPHTTPExporter(collector.URL, "mcp-test", nil)
+	err := exporter.ExportSpans(context.Background(), []SpanData{testSpanData()})
+	if !errors.Is(err, ErrExport) {
+		t.Errorf("ExportSpans() error = %v, want ErrExport", err)
+	}
+}
+
+func TestNewExporter(t *testing.T) {
+	cfg := config.TracingConfig{Exporter: config.TracingExporterJSONL, FilePath: filepath.Join(t.TempDir(), "t.jsonl")}
+	exporter, err := NewExporter(cfg, "mcp-test")
+	if err != nil {
+		t.Fatalf("NewExporter(jsonl) error = %v", err)
+	}
+	if _, ok := exporter.(*JSONLExporter); !ok {
+		t.Errorf("NewExporter(jsonl) = %T", exporter)
+	}
+	exporter.Shutdown(context.Background())
+
+	cfg = config.TracingConfig{Exporter: config.TracingExporterOTLP, OTLPEndpoint: "http://localhost:4318/v1/traces"}
+	exporter, err = NewExporter(cfg, "mcp-test")
+	if err != nil {
+		t.Fatalf("NewExporter(otlp) error = %v", err)
+	}
+	if _, ok := exporter.(*OTLPHTTPExporter); !ok {
+		t.Errorf("NewExporter(otlp) = %T", exporter)
+	}
+
+	if _, err := NewExporter(config.TracingConfig{Exporter: "zipkin"}, "mcp-test"); err == nil {
+		t.Error("NewExporter should reject unknown exporters")
+	}
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+)
+
+// ExtractTraceparent returns ctx continuing the trace described by a
+// traceparent value; invalid or empty values leave ctx unchanged
+func ExtractTraceparent(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	sc, err := ParseTraceparent(traceparent)
+	if err != nil {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// InjectHTTP sets the traceparent header for the current span in ctx
+func InjectHTTP(ctx context.Context, header http.Header) {
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		header.Set(TraceparentHeader, sc.Traceparent())
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush keeps streaming responses working through the recorder
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// HTTPMiddleware starts a server span for every request, continuing the
+// caller's trace when a traceparent header is present
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tracer := GetTracer()
+		if tracer == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := ExtractTraceparent(r.Context(), r.Header.Get(TraceparentHeader))
+		ctx, span := tracer.Start(ctx, r.Method+" "+r.URL.Path,
+			WithSpanKind(SpanKindServer),
+			WithAttributes(
+				String("http.request.method", r.Method),
+				String("url.path", r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		span.SetAttributes(Int("http.response.status_code", recorder.status))
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(StatusError, http.StatusText(recorder.status))
+		}
+	})
+}
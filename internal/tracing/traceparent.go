@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// TraceparentHeader is the W3C trace context header and _meta field name
+const TraceparentHeader = "traceparent"
+
+// ErrInvalidTraceparent is returned for malformed traceparent values
+var ErrInvalidTraceparent = fmt.Errorf("invalid traceparent")
+
+// TraceID identifies a trace
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace
+type SpanID [8]byte
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+func (s SpanID) String() string  { return hex.EncodeToString(s[:]) }
+
+// IsValid reports whether the trace ID is non-zero
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// IsValid reports whether the span ID is non-zero
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// SpanContext identifies a span and carries the sampling decision
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+	Remote  bool
+}
+
+// IsValid reports whether both identifiers are set
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent formats the span context as a W3C traceparent value
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceparent parses a W3C traceparent value, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func ParseTraceparent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, fmt.Errorf("%w: expected 4 fields, got %d", ErrInvalidTraceparent, len(parts))
+	}
+
+	version, err := decodeHex(parts[0], 1)
+	if err != nil || version[0] == 0xff {
+		return SpanContext{}, fmt.Errorf("%w: bad version %q", ErrInvalidTraceparent, parts[0])
+	}
+	// Version 00 has exactly four fields; later versions may append more
+	if version[0] == 0 && len(parts) != 4 {
+		return SpanContext{}, fmt.Errorf("%w: version 00 must have 4 fields", ErrInvalidTraceparent)
+	}
+
+	var sc SpanContext
+	traceID, err := decodeHex(parts[1], len(sc.TraceID))
+	if err != nil {
+		return SpanContext{}, fmt.Errorf("%w: bad trace id %q", ErrInvalidTraceparent, parts[1])
+	}
+	spanID, err := decodeHex(parts[2], len(sc.SpanID))
+	if err != nil {
+		return SpanContext{}, fmt.Errorf("%w: bad parent id %q", ErrInvalidTraceparent, parts[2])
+	}
+	flags, err := decodeHex(parts[3], 1)
+	if err != nil {
+		return SpanContext{}, fmt.Errorf("%w: bad flags %q", ErrInvalidTraceparent, parts[3])
+	}
+
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Sampled = flags[0]&0x01 == 0x01
+	sc.Remote = true
+
+	if !sc.IsValid() {
+		return SpanContext{}, fmt.Errorf("%w: all-zero trace or parent id", ErrInvalidTraceparent)
+	}
+	return sc, nil
+}
+
+func decodeHex(value string, size int) ([]byte, error) {
+	if len(value) != size*2 || strings.ToLower(value) != value {
+		return nil, ErrInvalidTraceparent
+	}
+	return hex.DecodeString(value)
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,180 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingExporter struct {
+	mu       sync.Mutex
+	spans    []SpanData
+	shutdown bool
+}
+
+func (e *recordingExporter) ExportSpans(ctx context.Context, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+func (e *recordingExporter) byName(name string) (SpanData, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, span := range e.spans {
+		if span.Name == name {
+			return span, true
+		}
+	}
+	return SpanData{}, false
+}
+
+func newTestTracer(t *testing.T) (*Tracer, *recordingExporter) {
+	t.Helper()
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter, Options{ServiceName: "test", FlushInterval: time.Hour})
+	t.Cleanup(func() { tracer.Shutdown(context.Background()) })
+	return tracer, exporter
+}
+
+func TestTracer_ParentChild(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+
+	ctx, parent := tracer.Start(context.Background(), "parent", WithSpanKind(SpanKindServer))
+	_, child := tracer.Start(ctx, "child", WithAttributes(String("key", "value")))
+	child.RecordError(errors.New("boom"))
+	child.End()
+	parent.End()
+
+	if err := tracer.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+
+	parentData, ok := exporter.byName("parent")
+	if !ok {
+		t.Fatal("parent span was not exported")
+	}
+	childData, ok := exporter.byName("child")
+	if !ok {
+		t.Fatal("child span was not exported")
+	}
+
+	if childData.SpanContext.TraceID != parentData.SpanContext.TraceID {
+		t.Error("child span should share the parent's trace id")
+	}
+	if childData.ParentSpanID != parentData.SpanContext.SpanID {
+		t.Error("child span should reference the parent span id")
+	}
+	if parentData.ParentSpanID.IsValid() {
+		t.Error("root span should have no parent")
+	}
+	if parentData.Kind != SpanKindServer {
+		t.Errorf("parent kind = %v, want server", parentData.Kind)
+	}
+	if childData.Status != StatusError || childData.StatusMessage != "boom" {
+		t.Errorf("child status = %v %q, want error boom", childData.Status, childData.StatusMessage)
+	}
+	if len(childData.Attributes) != 1 || childData.Attributes[0].Value != "value" {
+		t.Errorf("child attributes = %v", childData.Attributes)
+	}
+}
+
+func TestTracer_RemoteParentSampling(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+
+	ctx := ExtractTraceparent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	_, span := tracer.Start(ctx, "unsampled")
+	if span.IsRecording() {
+		t.Error("span with an unsampled remote parent should not record")
+	}
+	if got := span.SpanContext().TraceID.String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("TraceID = %s, want remote trace id", got)
+	}
+	span.End()
+
+	tracer.ForceFlush(context.Background())
+	if _, ok := exporter.byName("unsampled"); ok {
+		t.Error("unsampled span should not be exported")
+	}
+}
+
+func TestTracer_ShutdownFlushes(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter, Options{FlushInterval: time.Hour})
+
+	_, span := tracer.Start(context.Background(), "pending")
+	span.End()
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if _, ok := exporter.byName("pending"); !ok {
+		t.Error("Shutdown should export queued spans")
+	}
+	if !exporter.shutdown {
+		t.Error("Shutdown should shut the exporter down")
+	}
+}
+
+func TestNilTracer(t *testing.T) {
+	var tracer *Tracer
+	ctx, span := tracer.Start(context.Background(), "noop")
+	if span != nil || SpanFromContext(ctx) != nil {
+		t.Fatal("nil tracer should not create spans")
+	}
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("ignored"))
+	span.End()
+}
+
+func TestHTTPMiddleware_PropagatesTraceparent(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+	SetTracer(tracer)
+	t.Cleanup(func() { SetTracer(nil) })
+
+	var inner SpanContext
+	handler := HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner = SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	tracer.ForceFlush(context.Background())
+
+	span, ok := exporter.byName("GET /health")
+	if !ok {
+		t.Fatal("server span was not exported")
+	}
+	if got := span.SpanContext.TraceID.String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("TraceID = %s, want caller's trace id", got)
+	}
+	if got := span.ParentSpanID.String(); got != "00f067aa0ba902b7" {
+		t.Errorf("ParentSpanID = %s, want caller's span id", got)
+	}
+	if inner.SpanID != span.SpanContext.SpanID {
+		t.Error("handler context should carry the server span")
+	}
+	if span.Status != StatusError {
+		t.Errorf("status = %v, want error for 503", span.Status)
+	}
+
+	header := http.Header{}
+	InjectHTTP(ContextWithRemoteSpanContext(context.Background(), span.SpanContext), header)
+	if got := header.Get(TraceparentHeader); got != span.SpanContext.Traceparent() {
+		t.Errorf("injected traceparent = %q", got)
+	}
+}